@@ -0,0 +1,292 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/volcano-sh/kthena/client-go/clientset/versioned"
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	diffFilename  string
+	diffNamespace string
+	diffOutput    string
+)
+
+// diffCmd represents the diff command
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the changes a resource would make to a cluster",
+	Long: `Show the changes applying a resource would make to the resources derived from it in the cluster.
+
+Examples:
+  kthena diff model-booster -f model.yaml
+  kthena diff model-booster -f model.yaml -o yaml`,
+}
+
+// diffModelBoosterCmd represents the diff model-booster command
+var diffModelBoosterCmd = &cobra.Command{
+	Use:     "model-booster",
+	Aliases: []string{"model-boosters"},
+	Short:   "Show the changes a ModelBooster would make to its derived resources",
+	Long: `Compare the resources the ModelBooster controller would derive from a ModelBooster with the
+ones of the ModelBooster in the cluster, without applying anything.
+
+The ModelBooster is defaulted and validated by a server-side dry run. Like the controller, a
+resource is only updated if its revision label changes, and a ModelServing whose revision
+changes rolls out its serving groups. The fields the API server defaulted on the existing
+resources are reported as removed.`,
+	Args: cobra.NoArgs,
+	RunE: runDiffModelBooster,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.AddCommand(diffModelBoosterCmd)
+
+	diffCmd.PersistentFlags().StringVarP(&diffFilename, "filename", "f", "", "ModelBooster YAML or JSON file, or - for stdin (required)")
+	diffCmd.PersistentFlags().StringVarP(&diffNamespace, "namespace", "n", "", "Namespace of the ModelBooster, if not set in the file (default: default)")
+	diffCmd.PersistentFlags().StringVarP(&diffOutput, "output", "o", "", "Output format (yaml|json), a readable diff by default")
+	_ = diffCmd.MarkPersistentFlagRequired("filename")
+}
+
+func runDiffModelBooster(cmd *cobra.Command, args []string) error {
+	model, err := readModelBooster(diffFilename, diffNamespace)
+	if err != nil {
+		return err
+	}
+	client, err := getKthenaClient()
+	if err != nil {
+		return err
+	}
+	kubeClient, err := getKubeClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	admitted, live, err := dryRunModelBooster(ctx, client, model)
+	if err != nil {
+		return err
+	}
+	desired, err := convert.Render(admitted)
+	if err != nil {
+		return fmt.Errorf("failed to render ModelBooster %s: %v", model.Name, err)
+	}
+	existing := &convert.RenderedObjects{}
+	if live != nil {
+		if existing, err = listModelBoosterObjects(ctx, client, kubeClient, live, admitted); err != nil {
+			return err
+		}
+	}
+	diffs, err := convert.Diff(desired, existing)
+	if err != nil {
+		return err
+	}
+
+	switch diffOutput {
+	case "":
+		return printDiffs(os.Stdout, diffs)
+	case "yaml":
+		data, err := yaml.Marshal(diffs)
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %v", err)
+		}
+		fmt.Print(string(data))
+		return nil
+	case "json":
+		data, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal diff: %v", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", diffOutput)
+	}
+}
+
+func getKubeClient() (*kubernetes.Clientset, error) {
+	config, err := clientcmd.BuildConfigFromFlags("", clientcmd.RecommendedHomeFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+
+	client, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %v", err)
+	}
+
+	return client, nil
+}
+
+// dryRunModelBooster creates or updates the ModelBooster with a server-side dry run, so that it is defaulted
+// and validated like the applied one. It returns the admitted ModelBooster and the live one, or nil if the
+// ModelBooster does not exist yet.
+func dryRunModelBooster(ctx context.Context, client versioned.Interface,
+	model *workloadv1alpha1.ModelBooster) (*workloadv1alpha1.ModelBooster, *workloadv1alpha1.ModelBooster, error) {
+	models := client.WorkloadV1alpha1().ModelBoosters(model.Namespace)
+	dryRun := []string{metav1.DryRunAll}
+	live, err := models.Get(ctx, model.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get ModelBooster %s: %v", model.Name, err)
+		}
+		admitted, err := models.Create(ctx, model, metav1.CreateOptions{DryRun: dryRun})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dry run ModelBooster %s: %v", model.Name, err)
+		}
+		return admitted, nil, nil
+	}
+	model.ResourceVersion = live.ResourceVersion
+	admitted, err := models.Update(ctx, model, metav1.UpdateOptions{DryRun: dryRun})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to dry run ModelBooster %s: %v", model.Name, err)
+	}
+	admitted.Status = live.Status
+	return admitted, live, nil
+}
+
+// listModelBoosterObjects returns the objects derived from the live ModelBooster, including the cache warmers
+// of the backends of the admitted one.
+func listModelBoosterObjects(ctx context.Context, client versioned.Interface, kubeClient kubernetes.Interface,
+	live, admitted *workloadv1alpha1.ModelBooster) (*convert.RenderedObjects, error) {
+	existing := &convert.RenderedObjects{}
+	namespace := live.Namespace
+	opts := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(map[string]string{
+		utils.OwnerUIDKey: string(live.UID),
+	}).String()}
+
+	modelServings, err := client.WorkloadV1alpha1().ModelServings(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ModelServings: %v", err)
+	}
+	for i := range modelServings.Items {
+		existing.ModelServings = append(existing.ModelServings, &modelServings.Items[i])
+	}
+	modelServers, err := client.NetworkingV1alpha1().ModelServers(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ModelServers: %v", err)
+	}
+	for i := range modelServers.Items {
+		existing.ModelServers = append(existing.ModelServers, &modelServers.Items[i])
+	}
+	modelRoute, err := client.NetworkingV1alpha1().ModelRoutes(namespace).Get(ctx, live.Name, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get ModelRoute %s: %v", live.Name, err)
+	}
+	if err == nil {
+		existing.ModelRoutes = append(existing.ModelRoutes, modelRoute)
+	}
+	policies, err := client.WorkloadV1alpha1().AutoscalingPolicies(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AutoscalingPolicies: %v", err)
+	}
+	for i := range policies.Items {
+		existing.AutoscalingPolicies = append(existing.AutoscalingPolicies, &policies.Items[i])
+	}
+	bindings, err := client.WorkloadV1alpha1().AutoscalingPolicyBindings(namespace).List(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list AutoscalingPolicyBindings: %v", err)
+	}
+	for i := range bindings.Items {
+		existing.AutoscalingPolicyBindings = append(existing.AutoscalingPolicyBindings, &bindings.Items[i])
+	}
+
+	backendNames := map[string]struct{}{}
+	for _, backend := range utils.GetModelBackends(admitted) {
+		backendNames[backend.Name] = struct{}{}
+	}
+	for _, status := range live.Status.CacheWarming {
+		backendNames[status.BackendName] = struct{}{}
+	}
+	for backendName := range backendNames {
+		name := convert.GetCacheWarmerName(live, &workloadv1alpha1.ModelBackend{Name: backendName})
+		daemonSet, err := kubeClient.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get DaemonSet %s: %v", name, err)
+		}
+		if err == nil {
+			existing.CacheWarmerDaemonSets = append(existing.CacheWarmerDaemonSets, daemonSet)
+		}
+		job, err := kubeClient.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get Job %s: %v", name, err)
+		}
+		if err == nil {
+			existing.CacheWarmerJobs = append(existing.CacheWarmerJobs, job)
+		}
+	}
+	return existing, nil
+}
+
+// printDiffs prints the diffs with one line per object, followed by its changed fields.
+func printDiffs(w io.Writer, diffs []convert.ObjectDiff) error {
+	for _, diff := range diffs {
+		line := fmt.Sprintf("%s %s: %s", diff.Kind, diff.Name, diff.Action)
+		switch diff.Action {
+		case convert.DiffActionUpdate:
+			line += fmt.Sprintf(", revision %s -> %s", diff.OldRevision, diff.NewRevision)
+			if diff.Rollout {
+				line += ", rolls out the serving groups"
+			}
+		case convert.DiffActionNone:
+			line += fmt.Sprintf(", revision %s", diff.NewRevision)
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		for _, change := range diff.Changes {
+			var err error
+			switch {
+			case change.Old == nil:
+				_, err = fmt.Fprintf(w, "  + %s: %s\n", change.Path, formatDiffValue(change.New))
+			case change.New == nil:
+				_, err = fmt.Fprintf(w, "  - %s: %s\n", change.Path, formatDiffValue(change.Old))
+			default:
+				_, err = fmt.Fprintf(w, "  ~ %s: %s -> %s\n", change.Path, formatDiffValue(change.Old), formatDiffValue(change.New))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatDiffValue(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,153 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	renderFilename  string
+	renderNamespace string
+	renderOutput    string
+)
+
+// renderCmd represents the render command
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Render the resources derived from a resource",
+	Long: `Render the resources derived from a resource offline, without a cluster.
+
+Examples:
+  kthena render model-booster -f model.yaml
+  kthena render model-booster -f model.yaml -o json`,
+}
+
+// renderModelBoosterCmd represents the render model-booster command
+var renderModelBoosterCmd = &cobra.Command{
+	Use:     "model-booster",
+	Aliases: []string{"model-boosters"},
+	Short:   "Render the resources derived from a ModelBooster",
+	Long: `Render the ModelServings, ModelServers, ModelRoute, autoscaling policy and binding, and cache
+warmers the ModelBooster controller derives from a ModelBooster, without a cluster.
+
+The defaults the API server sets on the ModelBooster are not applied, except for the ones the
+conversion depends on, so the revision labels may differ from the ones of the cluster. Use
+'kthena diff model-booster' to compare a ModelBooster with a cluster.`,
+	Args: cobra.NoArgs,
+	RunE: runRenderModelBooster,
+}
+
+func init() {
+	rootCmd.AddCommand(renderCmd)
+	renderCmd.AddCommand(renderModelBoosterCmd)
+
+	renderCmd.PersistentFlags().StringVarP(&renderFilename, "filename", "f", "", "ModelBooster YAML or JSON file, or - for stdin (required)")
+	renderCmd.PersistentFlags().StringVarP(&renderNamespace, "namespace", "n", "", "Namespace of the ModelBooster, if not set in the file (default: default)")
+	renderCmd.PersistentFlags().StringVarP(&renderOutput, "output", "o", "yaml", "Output format (yaml|json)")
+	_ = renderCmd.MarkPersistentFlagRequired("filename")
+}
+
+func runRenderModelBooster(cmd *cobra.Command, args []string) error {
+	model, err := readModelBooster(renderFilename, renderNamespace)
+	if err != nil {
+		return err
+	}
+	convert.SetDefaults(model)
+	rendered, err := convert.Render(model)
+	if err != nil {
+		return fmt.Errorf("failed to render ModelBooster %s: %v", model.Name, err)
+	}
+	objects := rendered.List()
+
+	switch renderOutput {
+	case "yaml":
+		for i, obj := range objects {
+			data, err := yaml.Marshal(obj)
+			if err != nil {
+				return fmt.Errorf("failed to marshal %s: %v", obj.GetName(), err)
+			}
+			if i > 0 {
+				fmt.Println("---")
+			}
+			fmt.Print(string(data))
+		}
+		return nil
+	case "json":
+		data, err := json.MarshalIndent(map[string]any{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      objects,
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal objects: %v", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format: %s", renderOutput)
+	}
+}
+
+// readModelBooster reads a ModelBooster from a YAML or JSON file, or stdin if the filename is -. The namespace
+// is set if the ModelBooster has none.
+func readModelBooster(filename, namespace string) (*workloadv1alpha1.ModelBooster, error) {
+	data, err := readFileOrStdin(filename)
+	if err != nil {
+		return nil, err
+	}
+	model := &workloadv1alpha1.ModelBooster{}
+	if err := yaml.UnmarshalStrict(data, model); err != nil {
+		return nil, fmt.Errorf("failed to decode ModelBooster from %s: %v", filename, err)
+	}
+	if model.Kind != workloadv1alpha1.ModelKind.Kind {
+		return nil, fmt.Errorf("%s is a %q, not a ModelBooster", filename, model.Kind)
+	}
+	if model.Name == "" {
+		return nil, fmt.Errorf("ModelBooster in %s has no name", filename)
+	}
+	if model.Namespace == "" {
+		model.Namespace = namespace
+	}
+	if model.Namespace == "" {
+		model.Namespace = "default"
+	}
+	return model, nil
+}
+
+// readFileOrStdin reads a file, or stdin if the filename is -.
+func readFileOrStdin(filename string) ([]byte, error) {
+	var data []byte
+	var err error
+	if filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filename)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", filename, err)
+	}
+	return data, nil
+}
@@ -34,6 +34,8 @@ It allows you to:
 - Create manifests from predefined templates with custom values
 - List and view Kthena resources in Kubernetes clusters
 - Manage inference workloads, models, and autoscaling policies
+- Preview the resources a ModelBooster derives, and the changes it would apply
+- Simulate autoscaling policies offline before rolling them out
 
 Examples:
   kthena get templates
@@ -41,7 +43,10 @@ Examples:
   kthena get template DeepSeek-R1-Distill-Qwen-32B -o yaml
   kthena create manifest --name my-model --template DeepSeek-R1-Distill-Qwen-32B
   kthena get model-boosters
-  kthena get model-servings --all-namespaces`,
+  kthena get model-servings --all-namespaces
+  kthena render model-booster -f model.yaml
+  kthena diff model-booster -f model.yaml
+  kthena simulate -f scenario.yaml`,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -0,0 +1,281 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/simulator"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+)
+
+var (
+	simulateFilename string
+	simulateSeries   string
+	simulatePolicies []string
+	simulateOutput   string
+	simulateSummary  bool
+)
+
+// simulateCmd represents the simulate command
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate an autoscaling policy offline",
+	Long: `Simulate an autoscaling policy and binding offline, without a cluster, by replaying the load of a
+scenario through the autoscaler with a simulated clock and simulated pods.
+
+The scenario is a YAML or JSON file with the policy, the binding, and a recorded or synthetic series of
+the metrics of the policy. The series may also be read from a CSV file with a "time" column followed by
+a column per metric. The output is the timeline of the replicas of the targets, with their cost and the
+SLO violations, the evaluations at which fewer replicas than needed were ready.
+
+With several --policy files, the policies are simulated on the same scenario and their summaries compared.
+
+Examples:
+  kthena simulate -f scenario.yaml
+  kthena simulate -f scenario.yaml --series load.csv -o csv
+  kthena simulate -f scenario.yaml --policy current.yaml --policy tuned.yaml`,
+	Args: cobra.NoArgs,
+	RunE: runSimulate,
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+
+	simulateCmd.Flags().StringVarP(&simulateFilename, "filename", "f", "", "Scenario YAML or JSON file, or - for stdin (required)")
+	simulateCmd.Flags().StringVar(&simulateSeries, "series", "", "CSV file of the series, added to the series of the scenario")
+	simulateCmd.Flags().StringArrayVar(&simulatePolicies, "policy", nil, "AutoscalingPolicy file to simulate instead of the policy of the scenario, may be repeated to compare policies")
+	simulateCmd.Flags().StringVarP(&simulateOutput, "output", "o", "table", "Output format (table|json|csv)")
+	simulateCmd.Flags().BoolVar(&simulateSummary, "summary", false, "Only output the summary of the simulation")
+	_ = simulateCmd.MarkFlagRequired("filename")
+}
+
+// simulation is the result of the simulation of a policy.
+type simulation struct {
+	Policy string            `json:"policy"`
+	Result *simulator.Result `json:"result"`
+}
+
+func runSimulate(cmd *cobra.Command, args []string) error {
+	switch simulateOutput {
+	case "table", "json", "csv":
+	default:
+		return fmt.Errorf("unsupported output format: %s", simulateOutput)
+	}
+	// The autoscaler logs each evaluation.
+	klog.LogToStderr(false)
+	klog.SetOutput(io.Discard)
+
+	data, err := readFileOrStdin(simulateFilename)
+	if err != nil {
+		return err
+	}
+	scenario, err := simulator.ReadScenario(data)
+	if err != nil {
+		return fmt.Errorf("failed to read scenario from %s: %v", simulateFilename, err)
+	}
+	if simulateSeries != "" {
+		file, err := os.Open(simulateSeries)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %v", simulateSeries, err)
+		}
+		defer file.Close()
+		points, start, err := simulator.ReadSeriesCSV(file)
+		if err != nil {
+			return fmt.Errorf("failed to read series from %s: %v", simulateSeries, err)
+		}
+		scenario.Series = append(scenario.Series, points...)
+		if scenario.Start == nil && start != nil {
+			scenario.Start = &metav1.Time{Time: *start}
+		}
+	}
+
+	var simulations []simulation
+	if len(simulatePolicies) == 0 {
+		result, err := simulator.Simulate(cmd.Context(), scenario)
+		if err != nil {
+			return fmt.Errorf("failed to simulate policy %s: %v", scenario.Policy.Name, err)
+		}
+		simulations = append(simulations, simulation{Policy: scenario.Policy.Name, Result: result})
+	}
+	for _, filename := range simulatePolicies {
+		data, err := readFileOrStdin(filename)
+		if err != nil {
+			return err
+		}
+		policy, err := simulator.ReadPolicy(data)
+		if err != nil {
+			return fmt.Errorf("failed to read policy from %s: %v", filename, err)
+		}
+		if policy.Name == "" {
+			policy.Name = filename
+		}
+		policyScenario := *scenario
+		policyScenario.Policy = policy
+		result, err := simulator.Simulate(cmd.Context(), &policyScenario)
+		if err != nil {
+			return fmt.Errorf("failed to simulate policy %s: %v", policy.Name, err)
+		}
+		simulations = append(simulations, simulation{Policy: policy.Name, Result: result})
+	}
+
+	switch {
+	case simulateOutput == "json":
+		var value any = simulations
+		if len(simulations) == 1 {
+			value = simulations[0].Result
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal result: %v", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	case len(simulations) > 1 || simulateSummary:
+		return printSimulationSummaries(simulations)
+	case simulateOutput == "csv":
+		return printSimulationCSV(simulations[0].Result)
+	default:
+		return printSimulationTable(simulations[0].Result)
+	}
+}
+
+// simulationColumns returns the header of the timeline of a simulation, and the names of its metrics.
+func simulationColumns(result *simulator.Result) ([]string, []string) {
+	metricSet := map[string]struct{}{}
+	for _, step := range result.Steps {
+		for name := range step.Metrics {
+			metricSet[name] = struct{}{}
+		}
+	}
+	metrics := make([]string, 0, len(metricSet))
+	for name := range metricSet {
+		metrics = append(metrics, name)
+	}
+	sort.Strings(metrics)
+	header := append([]string{"TIME"}, metrics...)
+	header = append(header, "NEEDED", "READY")
+	if len(result.Steps) > 0 {
+		for _, target := range result.Steps[0].Targets {
+			header = append(header, target.Name)
+		}
+	}
+	return append(header, "REASON"), metrics
+}
+
+// simulationRow returns the row of a step in the timeline, with the desired replicas of each target.
+func simulationRow(step *simulator.Step, metrics []string) []string {
+	row := []string{step.Time.Duration.String()}
+	for _, name := range metrics {
+		value, ok := step.Metrics[name]
+		if !ok {
+			row = append(row, "")
+			continue
+		}
+		row = append(row, strconv.FormatFloat(value, 'g', 6, 64))
+	}
+	row = append(row, strconv.Itoa(int(step.NeededReplicas)), strconv.Itoa(int(step.ReadyReplicas)))
+	for _, target := range step.Targets {
+		row = append(row, strconv.Itoa(int(target.DesiredReplicas)))
+	}
+	return append(row, string(step.Reason))
+}
+
+func printSimulationTable(result *simulator.Result) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	header, metrics := simulationColumns(result)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for i := range result.Steps {
+		fmt.Fprintln(w, strings.Join(simulationRow(&result.Steps[i], metrics), "\t"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	summary := &result.Summary
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintf(w, "Duration:\t%s\n", summary.Duration.Duration)
+	fmt.Fprintf(w, "Evaluations:\t%d\n", summary.Evaluations)
+	fmt.Fprintf(w, "Replica hours:\t%.2f\n", summary.ReplicaHours)
+	fmt.Fprintf(w, "Cost:\t%.2f\n", summary.Cost)
+	fmt.Fprintf(w, "Peak replicas:\t%d\n", summary.PeakReplicas)
+	fmt.Fprintf(w, "Scale ups:\t%d\n", summary.ScaleUps)
+	fmt.Fprintf(w, "Scale downs:\t%d\n", summary.ScaleDowns)
+	fmt.Fprintf(w, "SLO violations:\t%d (%s, %.2f%%)\n", summary.SLOViolations, summary.SLOViolationDuration.Duration, summary.SLOViolationPercent)
+	fmt.Fprintf(w, "Metrics unavailable:\t%d\n", summary.MetricsUnavailable)
+	return w.Flush()
+}
+
+func printSimulationCSV(result *simulator.Result) error {
+	w := csv.NewWriter(os.Stdout)
+	header, metrics := simulationColumns(result)
+	if err := w.Write(header); err != nil {
+		return err
+	}
+	for i := range result.Steps {
+		if err := w.Write(simulationRow(&result.Steps[i], metrics)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func printSimulationSummaries(simulations []simulation) error {
+	header := []string{"POLICY", "REPLICA-HOURS", "COST", "PEAK", "SCALE-UPS", "SCALE-DOWNS", "SLO-VIOLATIONS", "SLO-VIOLATION-%", "METRICS-UNAVAILABLE"}
+	rows := make([][]string, 0, len(simulations))
+	for _, s := range simulations {
+		summary := &s.Result.Summary
+		rows = append(rows, []string{
+			s.Policy,
+			strconv.FormatFloat(summary.ReplicaHours, 'f', 2, 64),
+			strconv.FormatFloat(summary.Cost, 'f', 2, 64),
+			strconv.Itoa(int(summary.PeakReplicas)),
+			strconv.Itoa(summary.ScaleUps),
+			strconv.Itoa(summary.ScaleDowns),
+			strconv.Itoa(summary.SLOViolations),
+			strconv.FormatFloat(summary.SLOViolationPercent, 'f', 2, 64),
+			strconv.Itoa(summary.MetricsUnavailable),
+		})
+	}
+	if simulateOutput == "csv" {
+		w := csv.NewWriter(os.Stdout)
+		if err := w.Write(header); err != nil {
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		return w.Error()
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
@@ -0,0 +1,84 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LoraPlacementApplyConfiguration represents a declarative configuration of the LoraPlacement type for use
+// with apply.
+type LoraPlacementApplyConfiguration struct {
+	MaxLoras     *int32                                `json:"maxLoras,omitempty"`
+	RuntimePort  *int32                                `json:"runtimePort,omitempty"`
+	DownloadPath *string                               `json:"downloadPath,omitempty"`
+	LoadTimeout  *v1.Duration                          `json:"loadTimeout,omitempty"`
+	Adapters     []LoraAdapterSourceApplyConfiguration `json:"adapters,omitempty"`
+}
+
+// LoraPlacementApplyConfiguration constructs a declarative configuration of the LoraPlacement type for use with
+// apply.
+func LoraPlacement() *LoraPlacementApplyConfiguration {
+	return &LoraPlacementApplyConfiguration{}
+}
+
+// WithMaxLoras sets the MaxLoras field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxLoras field is set to the value of the last call.
+func (b *LoraPlacementApplyConfiguration) WithMaxLoras(value int32) *LoraPlacementApplyConfiguration {
+	b.MaxLoras = &value
+	return b
+}
+
+// WithRuntimePort sets the RuntimePort field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RuntimePort field is set to the value of the last call.
+func (b *LoraPlacementApplyConfiguration) WithRuntimePort(value int32) *LoraPlacementApplyConfiguration {
+	b.RuntimePort = &value
+	return b
+}
+
+// WithDownloadPath sets the DownloadPath field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DownloadPath field is set to the value of the last call.
+func (b *LoraPlacementApplyConfiguration) WithDownloadPath(value string) *LoraPlacementApplyConfiguration {
+	b.DownloadPath = &value
+	return b
+}
+
+// WithLoadTimeout sets the LoadTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LoadTimeout field is set to the value of the last call.
+func (b *LoraPlacementApplyConfiguration) WithLoadTimeout(value v1.Duration) *LoraPlacementApplyConfiguration {
+	b.LoadTimeout = &value
+	return b
+}
+
+// WithAdapters adds the given value to the Adapters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Adapters field.
+func (b *LoraPlacementApplyConfiguration) WithAdapters(values ...*LoraAdapterSourceApplyConfiguration) *LoraPlacementApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAdapters")
+		}
+		b.Adapters = append(b.Adapters, *values[i])
+	}
+	return b
+}
@@ -26,11 +26,12 @@ import (
 // ModelRouteSpecApplyConfiguration represents a declarative configuration of the ModelRouteSpec type for use
 // with apply.
 type ModelRouteSpecApplyConfiguration struct {
-	ModelName    *string                      `json:"modelName,omitempty"`
-	LoraAdapters []string                     `json:"loraAdapters,omitempty"`
-	ParentRefs   []v1.ParentReference         `json:"parentRefs,omitempty"`
-	Rules        []*networkingv1alpha1.Rule   `json:"rules,omitempty"`
-	RateLimit    *RateLimitApplyConfiguration `json:"rateLimit,omitempty"`
+	ModelName     *string                          `json:"modelName,omitempty"`
+	LoraAdapters  []string                         `json:"loraAdapters,omitempty"`
+	ParentRefs    []v1.ParentReference             `json:"parentRefs,omitempty"`
+	Rules         []*networkingv1alpha1.Rule       `json:"rules,omitempty"`
+	RateLimit     *RateLimitApplyConfiguration     `json:"rateLimit,omitempty"`
+	ScaleFromZero *ScaleFromZeroApplyConfiguration `json:"scaleFromZero,omitempty"`
 }
 
 // ModelRouteSpecApplyConfiguration constructs a declarative configuration of the ModelRouteSpec type for use with
@@ -87,3 +88,11 @@ func (b *ModelRouteSpecApplyConfiguration) WithRateLimit(value *RateLimitApplyCo
 	b.RateLimit = value
 	return b
 }
+
+// WithScaleFromZero sets the ScaleFromZero field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleFromZero field is set to the value of the last call.
+func (b *ModelRouteSpecApplyConfiguration) WithScaleFromZero(value *ScaleFromZeroApplyConfiguration) *ModelRouteSpecApplyConfiguration {
+	b.ScaleFromZero = value
+	return b
+}
@@ -31,6 +31,7 @@ type ModelServerSpecApplyConfiguration struct {
 	WorkloadPort     *WorkloadPortApplyConfiguration     `json:"workloadPort,omitempty"`
 	TrafficPolicy    *TrafficPolicyApplyConfiguration    `json:"trafficPolicy,omitempty"`
 	KVConnector      *KVConnectorSpecApplyConfiguration  `json:"kvConnector,omitempty"`
+	LoraPlacement    *LoraPlacementApplyConfiguration    `json:"loraPlacement,omitempty"`
 }
 
 // ModelServerSpecApplyConfiguration constructs a declarative configuration of the ModelServerSpec type for use with
@@ -86,3 +87,11 @@ func (b *ModelServerSpecApplyConfiguration) WithKVConnector(value *KVConnectorSp
 	b.KVConnector = value
 	return b
 }
+
+// WithLoraPlacement sets the LoraPlacement field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LoraPlacement field is set to the value of the last call.
+func (b *ModelServerSpecApplyConfiguration) WithLoraPlacement(value *LoraPlacementApplyConfiguration) *ModelServerSpecApplyConfiguration {
+	b.LoraPlacement = value
+	return b
+}
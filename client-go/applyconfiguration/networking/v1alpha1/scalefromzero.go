@@ -0,0 +1,52 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ScaleFromZeroApplyConfiguration represents a declarative configuration of the ScaleFromZero type for use
+// with apply.
+type ScaleFromZeroApplyConfiguration struct {
+	Timeout             *v1.Duration `json:"timeout,omitempty"`
+	MaxBufferedRequests *int32       `json:"maxBufferedRequests,omitempty"`
+}
+
+// ScaleFromZeroApplyConfiguration constructs a declarative configuration of the ScaleFromZero type for use with
+// apply.
+func ScaleFromZero() *ScaleFromZeroApplyConfiguration {
+	return &ScaleFromZeroApplyConfiguration{}
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *ScaleFromZeroApplyConfiguration) WithTimeout(value v1.Duration) *ScaleFromZeroApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithMaxBufferedRequests sets the MaxBufferedRequests field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxBufferedRequests field is set to the value of the last call.
+func (b *ScaleFromZeroApplyConfiguration) WithMaxBufferedRequests(value int32) *ScaleFromZeroApplyConfiguration {
+	b.MaxBufferedRequests = &value
+	return b
+}
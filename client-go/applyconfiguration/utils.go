@@ -40,6 +40,10 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &networkingv1alpha1.GlobalRateLimitApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("KVConnectorSpec"):
 		return &networkingv1alpha1.KVConnectorSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LoraAdapterSource"):
+		return &networkingv1alpha1.LoraAdapterSourceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("LoraPlacement"):
+		return &networkingv1alpha1.LoraPlacementApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModelMatch"):
 		return &networkingv1alpha1.ModelMatchApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("ModelRoute"):
@@ -60,6 +64,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &networkingv1alpha1.RetryApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("Rule"):
 		return &networkingv1alpha1.RuleApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ScaleFromZero"):
+		return &networkingv1alpha1.ScaleFromZeroApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("StringMatch"):
 		return &networkingv1alpha1.StringMatchApplyConfiguration{}
 	case v1alpha1.SchemeGroupVersion.WithKind("TargetModel"):
@@ -72,6 +78,12 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &networkingv1alpha1.WorkloadSelectorApplyConfiguration{}
 
 		// Group=workload.serving.volcano.sh, Version=v1alpha1
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ArtifactVerification"):
+		return &applyconfigurationworkloadv1alpha1.ArtifactVerificationApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingDecision"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingDecisionApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingMetricStatus"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingMetricStatusApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicy"):
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyBehavior"):
@@ -80,6 +92,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyBindingApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyBindingSpec"):
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyBindingSpecApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyBindingStatus"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyBindingStatusApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyMetric"):
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyMetricApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyPanicPolicy"):
@@ -90,6 +104,24 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicySpecApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyStablePolicy"):
 		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyStablePolicyApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingPolicyStatus"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingPolicyStatusApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingSchedule"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingScheduleApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("AutoscalingTargetStatus"):
+		return &applyconfigurationworkloadv1alpha1.AutoscalingTargetStatusApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("CacheWarming"):
+		return &applyconfigurationworkloadv1alpha1.CacheWarmingApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("CacheWarmingStatus"):
+		return &applyconfigurationworkloadv1alpha1.CacheWarmingStatusApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("CoupledRole"):
+		return &applyconfigurationworkloadv1alpha1.CoupledRoleApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("CoupledTarget"):
+		return &applyconfigurationworkloadv1alpha1.CoupledTargetApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ExistingPVCArtifactSource"):
+		return &applyconfigurationworkloadv1alpha1.ExistingPVCArtifactSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ExternalMetricSource"):
+		return &applyconfigurationworkloadv1alpha1.ExternalMetricSourceApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("GangPolicy"):
 		return &applyconfigurationworkloadv1alpha1.GangPolicyApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("HeterogeneousTarget"):
@@ -98,10 +130,22 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationworkloadv1alpha1.HeterogeneousTargetParamApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("HomogeneousTarget"):
 		return &applyconfigurationworkloadv1alpha1.HomogeneousTargetApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("HuggingFaceArtifactSource"):
+		return &applyconfigurationworkloadv1alpha1.HuggingFaceArtifactSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("LoraAdapter"):
+		return &applyconfigurationworkloadv1alpha1.LoraAdapterApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("LoraAdapterPodStatus"):
+		return &applyconfigurationworkloadv1alpha1.LoraAdapterPodStatusApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("LoraAdapterStatus"):
+		return &applyconfigurationworkloadv1alpha1.LoraAdapterStatusApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("Metadata"):
 		return &applyconfigurationworkloadv1alpha1.MetadataApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("MetricEndpoint"):
 		return &applyconfigurationworkloadv1alpha1.MetricEndpointApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("MetricSource"):
+		return &applyconfigurationworkloadv1alpha1.MetricSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ModelArtifact"):
+		return &applyconfigurationworkloadv1alpha1.ModelArtifactApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("ModelBackend"):
 		return &applyconfigurationworkloadv1alpha1.ModelBackendApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("ModelBooster"):
@@ -120,24 +164,50 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &applyconfigurationworkloadv1alpha1.ModelWorkerApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("NetworkTopology"):
 		return &applyconfigurationworkloadv1alpha1.NetworkTopologyApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("OCIArtifactSource"):
+		return &applyconfigurationworkloadv1alpha1.OCIArtifactSourceApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("PluginScope"):
 		return &applyconfigurationworkloadv1alpha1.PluginScopeApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("PluginSpec"):
 		return &applyconfigurationworkloadv1alpha1.PluginSpecApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("PodMetricSource"):
+		return &applyconfigurationworkloadv1alpha1.PodMetricSourceApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("PodTemplateSpec"):
 		return &applyconfigurationworkloadv1alpha1.PodTemplateSpecApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("PredictivePolicy"):
+		return &applyconfigurationworkloadv1alpha1.PredictivePolicyApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("PrometheusMetricSource"):
+		return &applyconfigurationworkloadv1alpha1.PrometheusMetricSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("RestartBudget"):
+		return &applyconfigurationworkloadv1alpha1.RestartBudgetApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("Role"):
 		return &applyconfigurationworkloadv1alpha1.RoleApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("RollbackPolicy"):
+		return &applyconfigurationworkloadv1alpha1.RollbackPolicyApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("RollingUpdateConfiguration"):
 		return &applyconfigurationworkloadv1alpha1.RollingUpdateConfigurationApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("RolloutStrategy"):
 		return &applyconfigurationworkloadv1alpha1.RolloutStrategyApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("RouterAggregateMetricSource"):
+		return &applyconfigurationworkloadv1alpha1.RouterAggregateMetricSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("RouterDemandMetricSource"):
+		return &applyconfigurationworkloadv1alpha1.RouterDemandMetricSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("S3ArtifactSource"):
+		return &applyconfigurationworkloadv1alpha1.S3ArtifactSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ScaleToZero"):
+		return &applyconfigurationworkloadv1alpha1.ScaleToZeroApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("ServingGroup"):
 		return &applyconfigurationworkloadv1alpha1.ServingGroupApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("ServingGroupRecoveryStatus"):
+		return &applyconfigurationworkloadv1alpha1.ServingGroupRecoveryStatusApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("SubTarget"):
 		return &applyconfigurationworkloadv1alpha1.SubTargetApplyConfiguration{}
 	case workloadv1alpha1.SchemeGroupVersion.WithKind("Target"):
 		return &applyconfigurationworkloadv1alpha1.TargetApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("VolumeSnapshotArtifactSource"):
+		return &applyconfigurationworkloadv1alpha1.VolumeSnapshotArtifactSourceApplyConfiguration{}
+	case workloadv1alpha1.SchemeGroupVersion.WithKind("WebhookPluginConfig"):
+		return &applyconfigurationworkloadv1alpha1.WebhookPluginConfigApplyConfiguration{}
 
 	}
 	return nil
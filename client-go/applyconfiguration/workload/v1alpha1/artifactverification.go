@@ -0,0 +1,43 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// ArtifactVerificationApplyConfiguration represents a declarative configuration of the ArtifactVerification type for use
+// with apply.
+type ArtifactVerificationApplyConfiguration struct {
+	SHA256Manifest *v1.ConfigMapKeySelector `json:"sha256Manifest,omitempty"`
+}
+
+// ArtifactVerificationApplyConfiguration constructs a declarative configuration of the ArtifactVerification type for use with
+// apply.
+func ArtifactVerification() *ArtifactVerificationApplyConfiguration {
+	return &ArtifactVerificationApplyConfiguration{}
+}
+
+// WithSHA256Manifest sets the SHA256Manifest field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SHA256Manifest field is set to the value of the last call.
+func (b *ArtifactVerificationApplyConfiguration) WithSHA256Manifest(value v1.ConfigMapKeySelector) *ArtifactVerificationApplyConfiguration {
+	b.SHA256Manifest = &value
+	return b
+}
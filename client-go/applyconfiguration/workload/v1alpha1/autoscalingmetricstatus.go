@@ -0,0 +1,61 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// AutoscalingMetricStatusApplyConfiguration represents a declarative configuration of the AutoscalingMetricStatus type for use
+// with apply.
+type AutoscalingMetricStatusApplyConfiguration struct {
+	MetricName   *string            `json:"metricName,omitempty"`
+	CurrentValue *resource.Quantity `json:"currentValue,omitempty"`
+	TargetValue  *resource.Quantity `json:"targetValue,omitempty"`
+}
+
+// AutoscalingMetricStatusApplyConfiguration constructs a declarative configuration of the AutoscalingMetricStatus type for use with
+// apply.
+func AutoscalingMetricStatus() *AutoscalingMetricStatusApplyConfiguration {
+	return &AutoscalingMetricStatusApplyConfiguration{}
+}
+
+// WithMetricName sets the MetricName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MetricName field is set to the value of the last call.
+func (b *AutoscalingMetricStatusApplyConfiguration) WithMetricName(value string) *AutoscalingMetricStatusApplyConfiguration {
+	b.MetricName = &value
+	return b
+}
+
+// WithCurrentValue sets the CurrentValue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CurrentValue field is set to the value of the last call.
+func (b *AutoscalingMetricStatusApplyConfiguration) WithCurrentValue(value resource.Quantity) *AutoscalingMetricStatusApplyConfiguration {
+	b.CurrentValue = &value
+	return b
+}
+
+// WithTargetValue sets the TargetValue field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TargetValue field is set to the value of the last call.
+func (b *AutoscalingMetricStatusApplyConfiguration) WithTargetValue(value resource.Quantity) *AutoscalingMetricStatusApplyConfiguration {
+	b.TargetValue = &value
+	return b
+}
@@ -19,7 +19,6 @@ limitations under the License.
 package v1alpha1
 
 import (
-	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
@@ -30,8 +29,8 @@ import (
 type AutoscalingPolicyBindingApplyConfiguration struct {
 	v1.TypeMetaApplyConfiguration    `json:",inline"`
 	*v1.ObjectMetaApplyConfiguration `json:"metadata,omitempty"`
-	Spec                             *AutoscalingPolicyBindingSpecApplyConfiguration  `json:"spec,omitempty"`
-	Status                           *workloadv1alpha1.AutoscalingPolicyBindingStatus `json:"status,omitempty"`
+	Spec                             *AutoscalingPolicyBindingSpecApplyConfiguration   `json:"spec,omitempty"`
+	Status                           *AutoscalingPolicyBindingStatusApplyConfiguration `json:"status,omitempty"`
 }
 
 // AutoscalingPolicyBinding constructs a declarative configuration of the AutoscalingPolicyBinding type for use with
@@ -215,8 +214,8 @@ func (b *AutoscalingPolicyBindingApplyConfiguration) WithSpec(value *Autoscaling
 // WithStatus sets the Status field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Status field is set to the value of the last call.
-func (b *AutoscalingPolicyBindingApplyConfiguration) WithStatus(value workloadv1alpha1.AutoscalingPolicyBindingStatus) *AutoscalingPolicyBindingApplyConfiguration {
-	b.Status = &value
+func (b *AutoscalingPolicyBindingApplyConfiguration) WithStatus(value *AutoscalingPolicyBindingStatusApplyConfiguration) *AutoscalingPolicyBindingApplyConfiguration {
+	b.Status = value
 	return b
 }
 
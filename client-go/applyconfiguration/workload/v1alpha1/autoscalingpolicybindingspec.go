@@ -20,6 +20,7 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AutoscalingPolicyBindingSpecApplyConfiguration represents a declarative configuration of the AutoscalingPolicyBindingSpec type for use
@@ -28,6 +29,8 @@ type AutoscalingPolicyBindingSpecApplyConfiguration struct {
 	PolicyRef           *v1.LocalObjectReference               `json:"policyRef,omitempty"`
 	HeterogeneousTarget *HeterogeneousTargetApplyConfiguration `json:"heterogeneousTarget,omitempty"`
 	HomogeneousTarget   *HomogeneousTargetApplyConfiguration   `json:"homogeneousTarget,omitempty"`
+	CoupledTarget       *CoupledTargetApplyConfiguration       `json:"coupledTarget,omitempty"`
+	SyncPeriod          *metav1.Duration                       `json:"syncPeriod,omitempty"`
 }
 
 // AutoscalingPolicyBindingSpecApplyConfiguration constructs a declarative configuration of the AutoscalingPolicyBindingSpec type for use with
@@ -59,3 +62,19 @@ func (b *AutoscalingPolicyBindingSpecApplyConfiguration) WithHomogeneousTarget(v
 	b.HomogeneousTarget = value
 	return b
 }
+
+// WithCoupledTarget sets the CoupledTarget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CoupledTarget field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingSpecApplyConfiguration) WithCoupledTarget(value *CoupledTargetApplyConfiguration) *AutoscalingPolicyBindingSpecApplyConfiguration {
+	b.CoupledTarget = value
+	return b
+}
+
+// WithSyncPeriod sets the SyncPeriod field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SyncPeriod field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingSpecApplyConfiguration) WithSyncPeriod(value metav1.Duration) *AutoscalingPolicyBindingSpecApplyConfiguration {
+	b.SyncPeriod = &value
+	return b
+}
@@ -0,0 +1,131 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalingPolicyBindingStatusApplyConfiguration represents a declarative configuration of the AutoscalingPolicyBindingStatus type for use
+// with apply.
+type AutoscalingPolicyBindingStatusApplyConfiguration struct {
+	ObservedGeneration   *int64                                      `json:"observedGeneration,omitempty"`
+	LastScaleTime        *v1.Time                                    `json:"lastScaleTime,omitempty"`
+	Reason               *workloadv1alpha1.AutoscalingReason         `json:"reason,omitempty"`
+	PanicMode            *bool                                       `json:"panicMode,omitempty"`
+	ScheduledMinReplicas *int32                                      `json:"scheduledMinReplicas,omitempty"`
+	PredictedReplicas    *int32                                      `json:"predictedReplicas,omitempty"`
+	Targets              []AutoscalingTargetStatusApplyConfiguration `json:"targets,omitempty"`
+	Metrics              []AutoscalingMetricStatusApplyConfiguration `json:"metrics,omitempty"`
+	History              []AutoscalingDecisionApplyConfiguration     `json:"history,omitempty"`
+}
+
+// AutoscalingPolicyBindingStatusApplyConfiguration constructs a declarative configuration of the AutoscalingPolicyBindingStatus type for use with
+// apply.
+func AutoscalingPolicyBindingStatus() *AutoscalingPolicyBindingStatusApplyConfiguration {
+	return &AutoscalingPolicyBindingStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithObservedGeneration(value int64) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithLastScaleTime sets the LastScaleTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastScaleTime field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithLastScaleTime(value v1.Time) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.LastScaleTime = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithReason(value workloadv1alpha1.AutoscalingReason) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.Reason = &value
+	return b
+}
+
+// WithPanicMode sets the PanicMode field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PanicMode field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithPanicMode(value bool) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.PanicMode = &value
+	return b
+}
+
+// WithScheduledMinReplicas sets the ScheduledMinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScheduledMinReplicas field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithScheduledMinReplicas(value int32) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.ScheduledMinReplicas = &value
+	return b
+}
+
+// WithPredictedReplicas sets the PredictedReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PredictedReplicas field is set to the value of the last call.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithPredictedReplicas(value int32) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	b.PredictedReplicas = &value
+	return b
+}
+
+// WithTargets adds the given value to the Targets field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Targets field.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithTargets(values ...*AutoscalingTargetStatusApplyConfiguration) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithTargets")
+		}
+		b.Targets = append(b.Targets, *values[i])
+	}
+	return b
+}
+
+// WithMetrics adds the given value to the Metrics field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Metrics field.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithMetrics(values ...*AutoscalingMetricStatusApplyConfiguration) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithMetrics")
+		}
+		b.Metrics = append(b.Metrics, *values[i])
+	}
+	return b
+}
+
+// WithHistory adds the given value to the History field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the History field.
+func (b *AutoscalingPolicyBindingStatusApplyConfiguration) WithHistory(values ...*AutoscalingDecisionApplyConfiguration) *AutoscalingPolicyBindingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithHistory")
+		}
+		b.History = append(b.History, *values[i])
+	}
+	return b
+}
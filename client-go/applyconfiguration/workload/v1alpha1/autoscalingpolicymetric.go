@@ -25,8 +25,9 @@ import (
 // AutoscalingPolicyMetricApplyConfiguration represents a declarative configuration of the AutoscalingPolicyMetric type for use
 // with apply.
 type AutoscalingPolicyMetricApplyConfiguration struct {
-	MetricName  *string            `json:"metricName,omitempty"`
-	TargetValue *resource.Quantity `json:"targetValue,omitempty"`
+	MetricName  *string                         `json:"metricName,omitempty"`
+	TargetValue *resource.Quantity              `json:"targetValue,omitempty"`
+	Source      *MetricSourceApplyConfiguration `json:"source,omitempty"`
 }
 
 // AutoscalingPolicyMetricApplyConfiguration constructs a declarative configuration of the AutoscalingPolicyMetric type for use with
@@ -50,3 +51,11 @@ func (b *AutoscalingPolicyMetricApplyConfiguration) WithTargetValue(value resour
 	b.TargetValue = &value
 	return b
 }
+
+// WithSource sets the Source field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Source field is set to the value of the last call.
+func (b *AutoscalingPolicyMetricApplyConfiguration) WithSource(value *MetricSourceApplyConfiguration) *AutoscalingPolicyMetricApplyConfiguration {
+	b.Source = value
+	return b
+}
@@ -24,6 +24,8 @@ type AutoscalingPolicySpecApplyConfiguration struct {
 	TolerancePercent *int32                                       `json:"tolerancePercent,omitempty"`
 	Metrics          []AutoscalingPolicyMetricApplyConfiguration  `json:"metrics,omitempty"`
 	Behavior         *AutoscalingPolicyBehaviorApplyConfiguration `json:"behavior,omitempty"`
+	Schedules        []AutoscalingScheduleApplyConfiguration      `json:"schedules,omitempty"`
+	Predictive       *PredictivePolicyApplyConfiguration          `json:"predictive,omitempty"`
 }
 
 // AutoscalingPolicySpecApplyConfiguration constructs a declarative configuration of the AutoscalingPolicySpec type for use with
@@ -60,3 +62,24 @@ func (b *AutoscalingPolicySpecApplyConfiguration) WithBehavior(value *Autoscalin
 	b.Behavior = value
 	return b
 }
+
+// WithSchedules adds the given value to the Schedules field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Schedules field.
+func (b *AutoscalingPolicySpecApplyConfiguration) WithSchedules(values ...*AutoscalingScheduleApplyConfiguration) *AutoscalingPolicySpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSchedules")
+		}
+		b.Schedules = append(b.Schedules, *values[i])
+	}
+	return b
+}
+
+// WithPredictive sets the Predictive field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Predictive field is set to the value of the last call.
+func (b *AutoscalingPolicySpecApplyConfiguration) WithPredictive(value *PredictivePolicyApplyConfiguration) *AutoscalingPolicySpecApplyConfiguration {
+	b.Predictive = value
+	return b
+}
@@ -0,0 +1,50 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// AutoscalingPolicyStatusApplyConfiguration represents a declarative configuration of the AutoscalingPolicyStatus type for use
+// with apply.
+type AutoscalingPolicyStatusApplyConfiguration struct {
+	ObservedGeneration *int64   `json:"observedGeneration,omitempty"`
+	Bindings           []string `json:"bindings,omitempty"`
+}
+
+// AutoscalingPolicyStatusApplyConfiguration constructs a declarative configuration of the AutoscalingPolicyStatus type for use with
+// apply.
+func AutoscalingPolicyStatus() *AutoscalingPolicyStatusApplyConfiguration {
+	return &AutoscalingPolicyStatusApplyConfiguration{}
+}
+
+// WithObservedGeneration sets the ObservedGeneration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ObservedGeneration field is set to the value of the last call.
+func (b *AutoscalingPolicyStatusApplyConfiguration) WithObservedGeneration(value int64) *AutoscalingPolicyStatusApplyConfiguration {
+	b.ObservedGeneration = &value
+	return b
+}
+
+// WithBindings adds the given value to the Bindings field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Bindings field.
+func (b *AutoscalingPolicyStatusApplyConfiguration) WithBindings(values ...string) *AutoscalingPolicyStatusApplyConfiguration {
+	for i := range values {
+		b.Bindings = append(b.Bindings, values[i])
+	}
+	return b
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AutoscalingScheduleApplyConfiguration represents a declarative configuration of the AutoscalingSchedule type for use
+// with apply.
+type AutoscalingScheduleApplyConfiguration struct {
+	Name        *string      `json:"name,omitempty"`
+	Schedule    *string      `json:"schedule,omitempty"`
+	Duration    *v1.Duration `json:"duration,omitempty"`
+	TimeZone    *string      `json:"timeZone,omitempty"`
+	MinReplicas *int32       `json:"minReplicas,omitempty"`
+}
+
+// AutoscalingScheduleApplyConfiguration constructs a declarative configuration of the AutoscalingSchedule type for use with
+// apply.
+func AutoscalingSchedule() *AutoscalingScheduleApplyConfiguration {
+	return &AutoscalingScheduleApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *AutoscalingScheduleApplyConfiguration) WithName(value string) *AutoscalingScheduleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSchedule sets the Schedule field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Schedule field is set to the value of the last call.
+func (b *AutoscalingScheduleApplyConfiguration) WithSchedule(value string) *AutoscalingScheduleApplyConfiguration {
+	b.Schedule = &value
+	return b
+}
+
+// WithDuration sets the Duration field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Duration field is set to the value of the last call.
+func (b *AutoscalingScheduleApplyConfiguration) WithDuration(value v1.Duration) *AutoscalingScheduleApplyConfiguration {
+	b.Duration = &value
+	return b
+}
+
+// WithTimeZone sets the TimeZone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeZone field is set to the value of the last call.
+func (b *AutoscalingScheduleApplyConfiguration) WithTimeZone(value string) *AutoscalingScheduleApplyConfiguration {
+	b.TimeZone = &value
+	return b
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *AutoscalingScheduleApplyConfiguration) WithMinReplicas(value int32) *AutoscalingScheduleApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// CacheWarmingStatusApplyConfiguration represents a declarative configuration of the CacheWarmingStatus type for use
+// with apply.
+type CacheWarmingStatusApplyConfiguration struct {
+	BackendName  *string `json:"backendName,omitempty"`
+	CacheKey     *string `json:"cacheKey,omitempty"`
+	DesiredNodes *int32  `json:"desiredNodes,omitempty"`
+	WarmNodes    *int32  `json:"warmNodes,omitempty"`
+}
+
+// CacheWarmingStatusApplyConfiguration constructs a declarative configuration of the CacheWarmingStatus type for use with
+// apply.
+func CacheWarmingStatus() *CacheWarmingStatusApplyConfiguration {
+	return &CacheWarmingStatusApplyConfiguration{}
+}
+
+// WithBackendName sets the BackendName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendName field is set to the value of the last call.
+func (b *CacheWarmingStatusApplyConfiguration) WithBackendName(value string) *CacheWarmingStatusApplyConfiguration {
+	b.BackendName = &value
+	return b
+}
+
+// WithCacheKey sets the CacheKey field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CacheKey field is set to the value of the last call.
+func (b *CacheWarmingStatusApplyConfiguration) WithCacheKey(value string) *CacheWarmingStatusApplyConfiguration {
+	b.CacheKey = &value
+	return b
+}
+
+// WithDesiredNodes sets the DesiredNodes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DesiredNodes field is set to the value of the last call.
+func (b *CacheWarmingStatusApplyConfiguration) WithDesiredNodes(value int32) *CacheWarmingStatusApplyConfiguration {
+	b.DesiredNodes = &value
+	return b
+}
+
+// WithWarmNodes sets the WarmNodes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WarmNodes field is set to the value of the last call.
+func (b *CacheWarmingStatusApplyConfiguration) WithWarmNodes(value int32) *CacheWarmingStatusApplyConfiguration {
+	b.WarmNodes = &value
+	return b
+}
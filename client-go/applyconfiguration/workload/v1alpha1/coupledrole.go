@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// CoupledRoleApplyConfiguration represents a declarative configuration of the CoupledRole type for use
+// with apply.
+type CoupledRoleApplyConfiguration struct {
+	Name        *string                            `json:"name,omitempty"`
+	Weight      *int32                             `json:"weight,omitempty"`
+	TokenType   *workloadv1alpha1.CoupledTokenType `json:"tokenType,omitempty"`
+	MinReplicas *int32                             `json:"minReplicas,omitempty"`
+	MaxReplicas *int32                             `json:"maxReplicas,omitempty"`
+}
+
+// CoupledRoleApplyConfiguration constructs a declarative configuration of the CoupledRole type for use with
+// apply.
+func CoupledRole() *CoupledRoleApplyConfiguration {
+	return &CoupledRoleApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *CoupledRoleApplyConfiguration) WithName(value string) *CoupledRoleApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *CoupledRoleApplyConfiguration) WithWeight(value int32) *CoupledRoleApplyConfiguration {
+	b.Weight = &value
+	return b
+}
+
+// WithTokenType sets the TokenType field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenType field is set to the value of the last call.
+func (b *CoupledRoleApplyConfiguration) WithTokenType(value workloadv1alpha1.CoupledTokenType) *CoupledRoleApplyConfiguration {
+	b.TokenType = &value
+	return b
+}
+
+// WithMinReplicas sets the MinReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MinReplicas field is set to the value of the last call.
+func (b *CoupledRoleApplyConfiguration) WithMinReplicas(value int32) *CoupledRoleApplyConfiguration {
+	b.MinReplicas = &value
+	return b
+}
+
+// WithMaxReplicas sets the MaxReplicas field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxReplicas field is set to the value of the last call.
+func (b *CoupledRoleApplyConfiguration) WithMaxReplicas(value int32) *CoupledRoleApplyConfiguration {
+	b.MaxReplicas = &value
+	return b
+}
@@ -0,0 +1,66 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// CoupledTargetApplyConfiguration represents a declarative configuration of the CoupledTarget type for use
+// with apply.
+type CoupledTargetApplyConfiguration struct {
+	Target *TargetApplyConfiguration       `json:"target,omitempty"`
+	Roles  []CoupledRoleApplyConfiguration `json:"roles,omitempty"`
+	Ratio  *workloadv1alpha1.CoupledRatio  `json:"ratio,omitempty"`
+}
+
+// CoupledTargetApplyConfiguration constructs a declarative configuration of the CoupledTarget type for use with
+// apply.
+func CoupledTarget() *CoupledTargetApplyConfiguration {
+	return &CoupledTargetApplyConfiguration{}
+}
+
+// WithTarget sets the Target field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Target field is set to the value of the last call.
+func (b *CoupledTargetApplyConfiguration) WithTarget(value *TargetApplyConfiguration) *CoupledTargetApplyConfiguration {
+	b.Target = value
+	return b
+}
+
+// WithRoles adds the given value to the Roles field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Roles field.
+func (b *CoupledTargetApplyConfiguration) WithRoles(values ...*CoupledRoleApplyConfiguration) *CoupledTargetApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithRoles")
+		}
+		b.Roles = append(b.Roles, *values[i])
+	}
+	return b
+}
+
+// WithRatio sets the Ratio field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Ratio field is set to the value of the last call.
+func (b *CoupledTargetApplyConfiguration) WithRatio(value workloadv1alpha1.CoupledRatio) *CoupledTargetApplyConfiguration {
+	b.Ratio = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ExistingPVCArtifactSourceApplyConfiguration represents a declarative configuration of the ExistingPVCArtifactSource type for use
+// with apply.
+type ExistingPVCArtifactSourceApplyConfiguration struct {
+	ClaimName *string `json:"claimName,omitempty"`
+	Path      *string `json:"path,omitempty"`
+}
+
+// ExistingPVCArtifactSourceApplyConfiguration constructs a declarative configuration of the ExistingPVCArtifactSource type for use with
+// apply.
+func ExistingPVCArtifactSource() *ExistingPVCArtifactSourceApplyConfiguration {
+	return &ExistingPVCArtifactSourceApplyConfiguration{}
+}
+
+// WithClaimName sets the ClaimName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClaimName field is set to the value of the last call.
+func (b *ExistingPVCArtifactSourceApplyConfiguration) WithClaimName(value string) *ExistingPVCArtifactSourceApplyConfiguration {
+	b.ClaimName = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *ExistingPVCArtifactSourceApplyConfiguration) WithPath(value string) *ExistingPVCArtifactSourceApplyConfiguration {
+	b.Path = &value
+	return b
+}
@@ -18,11 +18,16 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // HeterogeneousTargetApplyConfiguration represents a declarative configuration of the HeterogeneousTarget type for use
 // with apply.
 type HeterogeneousTargetApplyConfiguration struct {
 	Params                   []HeterogeneousTargetParamApplyConfiguration `json:"params,omitempty"`
 	CostExpansionRatePercent *int32                                       `json:"costExpansionRatePercent,omitempty"`
+	CapacityBackoff          *v1.Duration                                 `json:"capacityBackoff,omitempty"`
 }
 
 // HeterogeneousTargetApplyConfiguration constructs a declarative configuration of the HeterogeneousTarget type for use with
@@ -51,3 +56,11 @@ func (b *HeterogeneousTargetApplyConfiguration) WithCostExpansionRatePercent(val
 	b.CostExpansionRatePercent = &value
 	return b
 }
+
+// WithCapacityBackoff sets the CapacityBackoff field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CapacityBackoff field is set to the value of the last call.
+func (b *HeterogeneousTargetApplyConfiguration) WithCapacityBackoff(value v1.Duration) *HeterogeneousTargetApplyConfiguration {
+	b.CapacityBackoff = &value
+	return b
+}
@@ -21,9 +21,10 @@ package v1alpha1
 // HomogeneousTargetApplyConfiguration represents a declarative configuration of the HomogeneousTarget type for use
 // with apply.
 type HomogeneousTargetApplyConfiguration struct {
-	Target      *TargetApplyConfiguration `json:"target,omitempty"`
-	MinReplicas *int32                    `json:"minReplicas,omitempty"`
-	MaxReplicas *int32                    `json:"maxReplicas,omitempty"`
+	Target      *TargetApplyConfiguration      `json:"target,omitempty"`
+	MinReplicas *int32                         `json:"minReplicas,omitempty"`
+	MaxReplicas *int32                         `json:"maxReplicas,omitempty"`
+	ScaleToZero *ScaleToZeroApplyConfiguration `json:"scaleToZero,omitempty"`
 }
 
 // HomogeneousTargetApplyConfiguration constructs a declarative configuration of the HomogeneousTarget type for use with
@@ -55,3 +56,11 @@ func (b *HomogeneousTargetApplyConfiguration) WithMaxReplicas(value int32) *Homo
 	b.MaxReplicas = &value
 	return b
 }
+
+// WithScaleToZero sets the ScaleToZero field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ScaleToZero field is set to the value of the last call.
+func (b *HomogeneousTargetApplyConfiguration) WithScaleToZero(value *ScaleToZeroApplyConfiguration) *HomogeneousTargetApplyConfiguration {
+	b.ScaleToZero = value
+	return b
+}
@@ -0,0 +1,70 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// HuggingFaceArtifactSourceApplyConfiguration represents a declarative configuration of the HuggingFaceArtifactSource type for use
+// with apply.
+type HuggingFaceArtifactSourceApplyConfiguration struct {
+	Repository     *string               `json:"repository,omitempty"`
+	Revision       *string               `json:"revision,omitempty"`
+	Endpoint       *string               `json:"endpoint,omitempty"`
+	TokenSecretRef *v1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// HuggingFaceArtifactSourceApplyConfiguration constructs a declarative configuration of the HuggingFaceArtifactSource type for use with
+// apply.
+func HuggingFaceArtifactSource() *HuggingFaceArtifactSourceApplyConfiguration {
+	return &HuggingFaceArtifactSourceApplyConfiguration{}
+}
+
+// WithRepository sets the Repository field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Repository field is set to the value of the last call.
+func (b *HuggingFaceArtifactSourceApplyConfiguration) WithRepository(value string) *HuggingFaceArtifactSourceApplyConfiguration {
+	b.Repository = &value
+	return b
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *HuggingFaceArtifactSourceApplyConfiguration) WithRevision(value string) *HuggingFaceArtifactSourceApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithEndpoint sets the Endpoint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Endpoint field is set to the value of the last call.
+func (b *HuggingFaceArtifactSourceApplyConfiguration) WithEndpoint(value string) *HuggingFaceArtifactSourceApplyConfiguration {
+	b.Endpoint = &value
+	return b
+}
+
+// WithTokenSecretRef sets the TokenSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TokenSecretRef field is set to the value of the last call.
+func (b *HuggingFaceArtifactSourceApplyConfiguration) WithTokenSecretRef(value v1.SecretKeySelector) *HuggingFaceArtifactSourceApplyConfiguration {
+	b.TokenSecretRef = &value
+	return b
+}
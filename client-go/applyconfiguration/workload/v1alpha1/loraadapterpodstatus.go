@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// LoraAdapterPodStatusApplyConfiguration represents a declarative configuration of the LoraAdapterPodStatus type for use
+// with apply.
+type LoraAdapterPodStatusApplyConfiguration struct {
+	Name         *string                            `json:"name,omitempty"`
+	UID          *string                            `json:"uid,omitempty"`
+	RestartCount *int32                             `json:"restartCount,omitempty"`
+	State        *workloadv1alpha1.LoraAdapterState `json:"state,omitempty"`
+	Message      *string                            `json:"message,omitempty"`
+}
+
+// LoraAdapterPodStatusApplyConfiguration constructs a declarative configuration of the LoraAdapterPodStatus type for use with
+// apply.
+func LoraAdapterPodStatus() *LoraAdapterPodStatusApplyConfiguration {
+	return &LoraAdapterPodStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *LoraAdapterPodStatusApplyConfiguration) WithName(value string) *LoraAdapterPodStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithUID sets the UID field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UID field is set to the value of the last call.
+func (b *LoraAdapterPodStatusApplyConfiguration) WithUID(value string) *LoraAdapterPodStatusApplyConfiguration {
+	b.UID = &value
+	return b
+}
+
+// WithRestartCount sets the RestartCount field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartCount field is set to the value of the last call.
+func (b *LoraAdapterPodStatusApplyConfiguration) WithRestartCount(value int32) *LoraAdapterPodStatusApplyConfiguration {
+	b.RestartCount = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *LoraAdapterPodStatusApplyConfiguration) WithState(value workloadv1alpha1.LoraAdapterState) *LoraAdapterPodStatusApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithMessage sets the Message field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Message field is set to the value of the last call.
+func (b *LoraAdapterPodStatusApplyConfiguration) WithMessage(value string) *LoraAdapterPodStatusApplyConfiguration {
+	b.Message = &value
+	return b
+}
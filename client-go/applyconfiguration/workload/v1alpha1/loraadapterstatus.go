@@ -0,0 +1,71 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// LoraAdapterStatusApplyConfiguration represents a declarative configuration of the LoraAdapterStatus type for use
+// with apply.
+type LoraAdapterStatusApplyConfiguration struct {
+	Name        *string                                  `json:"name,omitempty"`
+	BackendName *string                                  `json:"backendName,omitempty"`
+	ArtifactURL *string                                  `json:"artifactURL,omitempty"`
+	Pods        []LoraAdapterPodStatusApplyConfiguration `json:"pods,omitempty"`
+}
+
+// LoraAdapterStatusApplyConfiguration constructs a declarative configuration of the LoraAdapterStatus type for use with
+// apply.
+func LoraAdapterStatus() *LoraAdapterStatusApplyConfiguration {
+	return &LoraAdapterStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *LoraAdapterStatusApplyConfiguration) WithName(value string) *LoraAdapterStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithBackendName sets the BackendName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackendName field is set to the value of the last call.
+func (b *LoraAdapterStatusApplyConfiguration) WithBackendName(value string) *LoraAdapterStatusApplyConfiguration {
+	b.BackendName = &value
+	return b
+}
+
+// WithArtifactURL sets the ArtifactURL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ArtifactURL field is set to the value of the last call.
+func (b *LoraAdapterStatusApplyConfiguration) WithArtifactURL(value string) *LoraAdapterStatusApplyConfiguration {
+	b.ArtifactURL = &value
+	return b
+}
+
+// WithPods adds the given value to the Pods field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Pods field.
+func (b *LoraAdapterStatusApplyConfiguration) WithPods(values ...*LoraAdapterPodStatusApplyConfiguration) *LoraAdapterStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithPods")
+		}
+		b.Pods = append(b.Pods, *values[i])
+	}
+	return b
+}
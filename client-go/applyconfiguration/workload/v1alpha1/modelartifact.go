@@ -0,0 +1,84 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ModelArtifactApplyConfiguration represents a declarative configuration of the ModelArtifact type for use
+// with apply.
+type ModelArtifactApplyConfiguration struct {
+	HuggingFace    *HuggingFaceArtifactSourceApplyConfiguration    `json:"huggingFace,omitempty"`
+	S3             *S3ArtifactSourceApplyConfiguration             `json:"s3,omitempty"`
+	OCI            *OCIArtifactSourceApplyConfiguration            `json:"oci,omitempty"`
+	ExistingPVC    *ExistingPVCArtifactSourceApplyConfiguration    `json:"existingPVC,omitempty"`
+	VolumeSnapshot *VolumeSnapshotArtifactSourceApplyConfiguration `json:"volumeSnapshot,omitempty"`
+	Verification   *ArtifactVerificationApplyConfiguration         `json:"verification,omitempty"`
+}
+
+// ModelArtifactApplyConfiguration constructs a declarative configuration of the ModelArtifact type for use with
+// apply.
+func ModelArtifact() *ModelArtifactApplyConfiguration {
+	return &ModelArtifactApplyConfiguration{}
+}
+
+// WithHuggingFace sets the HuggingFace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the HuggingFace field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithHuggingFace(value *HuggingFaceArtifactSourceApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.HuggingFace = value
+	return b
+}
+
+// WithS3 sets the S3 field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the S3 field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithS3(value *S3ArtifactSourceApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.S3 = value
+	return b
+}
+
+// WithOCI sets the OCI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the OCI field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithOCI(value *OCIArtifactSourceApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.OCI = value
+	return b
+}
+
+// WithExistingPVC sets the ExistingPVC field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ExistingPVC field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithExistingPVC(value *ExistingPVCArtifactSourceApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.ExistingPVC = value
+	return b
+}
+
+// WithVolumeSnapshot sets the VolumeSnapshot field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the VolumeSnapshot field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithVolumeSnapshot(value *VolumeSnapshotArtifactSourceApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.VolumeSnapshot = value
+	return b
+}
+
+// WithVerification sets the Verification field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Verification field is set to the value of the last call.
+func (b *ModelArtifactApplyConfiguration) WithVerification(value *ArtifactVerificationApplyConfiguration) *ModelArtifactApplyConfiguration {
+	b.Verification = value
+	return b
+}
@@ -29,6 +29,7 @@ type ModelBackendApplyConfiguration struct {
 	Name          *string                            `json:"name,omitempty"`
 	Type          *workloadv1alpha1.ModelBackendType `json:"type,omitempty"`
 	ModelURI      *string                            `json:"modelURI,omitempty"`
+	Artifact      *ModelArtifactApplyConfiguration   `json:"artifact,omitempty"`
 	CacheURI      *string                            `json:"cacheURI,omitempty"`
 	EnvFrom       []v1.EnvFromSource                 `json:"envFrom,omitempty"`
 	Env           []v1.EnvVar                        `json:"env,omitempty"`
@@ -36,6 +37,10 @@ type ModelBackendApplyConfiguration struct {
 	MaxReplicas   *int32                             `json:"maxReplicas,omitempty"`
 	Workers       []ModelWorkerApplyConfiguration    `json:"workers,omitempty"`
 	SchedulerName *string                            `json:"schedulerName,omitempty"`
+	Cost          *int32                             `json:"cost,omitempty"`
+	Weight        *uint32                            `json:"weight,omitempty"`
+	LoraAdapters  []LoraAdapterApplyConfiguration    `json:"loraAdapters,omitempty"`
+	CacheWarming  *CacheWarmingApplyConfiguration    `json:"cacheWarming,omitempty"`
 }
 
 // ModelBackendApplyConfiguration constructs a declarative configuration of the ModelBackend type for use with
@@ -68,6 +73,14 @@ func (b *ModelBackendApplyConfiguration) WithModelURI(value string) *ModelBacken
 	return b
 }
 
+// WithArtifact sets the Artifact field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Artifact field is set to the value of the last call.
+func (b *ModelBackendApplyConfiguration) WithArtifact(value *ModelArtifactApplyConfiguration) *ModelBackendApplyConfiguration {
+	b.Artifact = value
+	return b
+}
+
 // WithCacheURI sets the CacheURI field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the CacheURI field is set to the value of the last call.
@@ -132,3 +145,40 @@ func (b *ModelBackendApplyConfiguration) WithSchedulerName(value string) *ModelB
 	b.SchedulerName = &value
 	return b
 }
+
+// WithCost sets the Cost field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Cost field is set to the value of the last call.
+func (b *ModelBackendApplyConfiguration) WithCost(value int32) *ModelBackendApplyConfiguration {
+	b.Cost = &value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *ModelBackendApplyConfiguration) WithWeight(value uint32) *ModelBackendApplyConfiguration {
+	b.Weight = &value
+	return b
+}
+
+// WithLoraAdapters adds the given value to the LoraAdapters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the LoraAdapters field.
+func (b *ModelBackendApplyConfiguration) WithLoraAdapters(values ...*LoraAdapterApplyConfiguration) *ModelBackendApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithLoraAdapters")
+		}
+		b.LoraAdapters = append(b.LoraAdapters, *values[i])
+	}
+	return b
+}
+
+// WithCacheWarming sets the CacheWarming field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CacheWarming field is set to the value of the last call.
+func (b *ModelBackendApplyConfiguration) WithCacheWarming(value *CacheWarmingApplyConfiguration) *ModelBackendApplyConfiguration {
+	b.CacheWarming = value
+	return b
+}
@@ -28,6 +28,7 @@ type ModelBoosterSpecApplyConfiguration struct {
 	Name              *string                                          `json:"name,omitempty"`
 	Owner             *string                                          `json:"owner,omitempty"`
 	Backend           *ModelBackendApplyConfiguration                  `json:"backend,omitempty"`
+	Backends          []ModelBackendApplyConfiguration                 `json:"backends,omitempty"`
 	AutoscalingPolicy *AutoscalingPolicySpecApplyConfiguration         `json:"autoscalingPolicy,omitempty"`
 	ModelMatch        *networkingv1alpha1.ModelMatchApplyConfiguration `json:"modelMatch,omitempty"`
 }
@@ -62,6 +63,19 @@ func (b *ModelBoosterSpecApplyConfiguration) WithBackend(value *ModelBackendAppl
 	return b
 }
 
+// WithBackends adds the given value to the Backends field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Backends field.
+func (b *ModelBoosterSpecApplyConfiguration) WithBackends(values ...*ModelBackendApplyConfiguration) *ModelBoosterSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithBackends")
+		}
+		b.Backends = append(b.Backends, *values[i])
+	}
+	return b
+}
+
 // WithAutoscalingPolicy sets the AutoscalingPolicy field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the AutoscalingPolicy field is set to the value of the last call.
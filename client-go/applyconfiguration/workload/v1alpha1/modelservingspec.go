@@ -25,12 +25,14 @@ import (
 // ModelServingSpecApplyConfiguration represents a declarative configuration of the ModelServingSpec type for use
 // with apply.
 type ModelServingSpecApplyConfiguration struct {
-	Replicas        *int32                             `json:"replicas,omitempty"`
-	SchedulerName   *string                            `json:"schedulerName,omitempty"`
-	Plugins         []PluginSpecApplyConfiguration     `json:"plugins,omitempty"`
-	Template        *ServingGroupApplyConfiguration    `json:"template,omitempty"`
-	RolloutStrategy *RolloutStrategyApplyConfiguration `json:"rolloutStrategy,omitempty"`
-	RecoveryPolicy  *workloadv1alpha1.RecoveryPolicy   `json:"recoveryPolicy,omitempty"`
+	Replicas                *int32                             `json:"replicas,omitempty"`
+	SchedulerName           *string                            `json:"schedulerName,omitempty"`
+	Plugins                 []PluginSpecApplyConfiguration     `json:"plugins,omitempty"`
+	Template                *ServingGroupApplyConfiguration    `json:"template,omitempty"`
+	RolloutStrategy         *RolloutStrategyApplyConfiguration `json:"rolloutStrategy,omitempty"`
+	RecoveryPolicy          *workloadv1alpha1.RecoveryPolicy   `json:"recoveryPolicy,omitempty"`
+	RestartBudget           *RestartBudgetApplyConfiguration   `json:"restartBudget,omitempty"`
+	ProgressDeadlineSeconds *int32                             `json:"progressDeadlineSeconds,omitempty"`
 }
 
 // ModelServingSpecApplyConfiguration constructs a declarative configuration of the ModelServingSpec type for use with
@@ -91,3 +93,19 @@ func (b *ModelServingSpecApplyConfiguration) WithRecoveryPolicy(value workloadv1
 	b.RecoveryPolicy = &value
 	return b
 }
+
+// WithRestartBudget sets the RestartBudget field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RestartBudget field is set to the value of the last call.
+func (b *ModelServingSpecApplyConfiguration) WithRestartBudget(value *RestartBudgetApplyConfiguration) *ModelServingSpecApplyConfiguration {
+	b.RestartBudget = value
+	return b
+}
+
+// WithProgressDeadlineSeconds sets the ProgressDeadlineSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ProgressDeadlineSeconds field is set to the value of the last call.
+func (b *ModelServingSpecApplyConfiguration) WithProgressDeadlineSeconds(value int32) *ModelServingSpecApplyConfiguration {
+	b.ProgressDeadlineSeconds = &value
+	return b
+}
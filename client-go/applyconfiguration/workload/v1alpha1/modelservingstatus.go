@@ -19,21 +19,25 @@ limitations under the License.
 package v1alpha1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	v1 "k8s.io/client-go/applyconfigurations/meta/v1"
 )
 
 // ModelServingStatusApplyConfiguration represents a declarative configuration of the ModelServingStatus type for use
 // with apply.
 type ModelServingStatusApplyConfiguration struct {
-	ObservedGeneration *int64                           `json:"observedGeneration,omitempty"`
-	Replicas           *int32                           `json:"replicas,omitempty"`
-	CurrentReplicas    *int32                           `json:"currentReplicas,omitempty"`
-	UpdatedReplicas    *int32                           `json:"updatedReplicas,omitempty"`
-	AvailableReplicas  *int32                           `json:"availableReplicas,omitempty"`
-	CurrentRevision    *string                          `json:"currentRevision,omitempty"`
-	UpdateRevision     *string                          `json:"updateRevision,omitempty"`
-	Conditions         []v1.ConditionApplyConfiguration `json:"conditions,omitempty"`
-	LabelSelector      *string                          `json:"labelSelector,omitempty"`
+	ObservedGeneration     *int64                                         `json:"observedGeneration,omitempty"`
+	Replicas               *int32                                         `json:"replicas,omitempty"`
+	CurrentReplicas        *int32                                         `json:"currentReplicas,omitempty"`
+	UpdatedReplicas        *int32                                         `json:"updatedReplicas,omitempty"`
+	AvailableReplicas      *int32                                         `json:"availableReplicas,omitempty"`
+	CurrentRevision        *string                                        `json:"currentRevision,omitempty"`
+	UpdateRevision         *string                                        `json:"updateRevision,omitempty"`
+	Conditions             []v1.ConditionApplyConfiguration               `json:"conditions,omitempty"`
+	LabelSelector          *string                                        `json:"labelSelector,omitempty"`
+	LastProgressTime       *metav1.Time                                   `json:"lastProgressTime,omitempty"`
+	UpdateFailures         *int32                                         `json:"updateFailures,omitempty"`
+	ServingGroupRecoveries []ServingGroupRecoveryStatusApplyConfiguration `json:"servingGroupRecoveries,omitempty"`
 }
 
 // ModelServingStatusApplyConfiguration constructs a declarative configuration of the ModelServingStatus type for use with
@@ -118,3 +122,32 @@ func (b *ModelServingStatusApplyConfiguration) WithLabelSelector(value string) *
 	b.LabelSelector = &value
 	return b
 }
+
+// WithLastProgressTime sets the LastProgressTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastProgressTime field is set to the value of the last call.
+func (b *ModelServingStatusApplyConfiguration) WithLastProgressTime(value metav1.Time) *ModelServingStatusApplyConfiguration {
+	b.LastProgressTime = &value
+	return b
+}
+
+// WithUpdateFailures sets the UpdateFailures field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateFailures field is set to the value of the last call.
+func (b *ModelServingStatusApplyConfiguration) WithUpdateFailures(value int32) *ModelServingStatusApplyConfiguration {
+	b.UpdateFailures = &value
+	return b
+}
+
+// WithServingGroupRecoveries adds the given value to the ServingGroupRecoveries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ServingGroupRecoveries field.
+func (b *ModelServingStatusApplyConfiguration) WithServingGroupRecoveries(values ...*ServingGroupRecoveryStatusApplyConfiguration) *ModelServingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithServingGroupRecoveries")
+		}
+		b.ServingGroupRecoveries = append(b.ServingGroupRecoveries, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,61 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// OCIArtifactSourceApplyConfiguration represents a declarative configuration of the OCIArtifactSource type for use
+// with apply.
+type OCIArtifactSourceApplyConfiguration struct {
+	Image      *string        `json:"image,omitempty"`
+	PullPolicy *v1.PullPolicy `json:"pullPolicy,omitempty"`
+	Path       *string        `json:"path,omitempty"`
+}
+
+// OCIArtifactSourceApplyConfiguration constructs a declarative configuration of the OCIArtifactSource type for use with
+// apply.
+func OCIArtifactSource() *OCIArtifactSourceApplyConfiguration {
+	return &OCIArtifactSourceApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *OCIArtifactSourceApplyConfiguration) WithImage(value string) *OCIArtifactSourceApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithPullPolicy sets the PullPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullPolicy field is set to the value of the last call.
+func (b *OCIArtifactSourceApplyConfiguration) WithPullPolicy(value v1.PullPolicy) *OCIArtifactSourceApplyConfiguration {
+	b.PullPolicy = &value
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *OCIArtifactSourceApplyConfiguration) WithPath(value string) *OCIArtifactSourceApplyConfiguration {
+	b.Path = &value
+	return b
+}
@@ -26,10 +26,11 @@ import (
 // PluginSpecApplyConfiguration represents a declarative configuration of the PluginSpec type for use
 // with apply.
 type PluginSpecApplyConfiguration struct {
-	Name   *string                        `json:"name,omitempty"`
-	Type   *workloadv1alpha1.PluginType   `json:"type,omitempty"`
-	Config *v1.JSON                       `json:"config,omitempty"`
-	Scope  *PluginScopeApplyConfiguration `json:"scope,omitempty"`
+	Name    *string                                `json:"name,omitempty"`
+	Type    *workloadv1alpha1.PluginType           `json:"type,omitempty"`
+	Config  *v1.JSON                               `json:"config,omitempty"`
+	Webhook *WebhookPluginConfigApplyConfiguration `json:"webhook,omitempty"`
+	Scope   *PluginScopeApplyConfiguration         `json:"scope,omitempty"`
 }
 
 // PluginSpecApplyConfiguration constructs a declarative configuration of the PluginSpec type for use with
@@ -62,6 +63,14 @@ func (b *PluginSpecApplyConfiguration) WithConfig(value v1.JSON) *PluginSpecAppl
 	return b
 }
 
+// WithWebhook sets the Webhook field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Webhook field is set to the value of the last call.
+func (b *PluginSpecApplyConfiguration) WithWebhook(value *WebhookPluginConfigApplyConfiguration) *PluginSpecApplyConfiguration {
+	b.Webhook = value
+	return b
+}
+
 // WithScope sets the Scope field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Scope field is set to the value of the last call.
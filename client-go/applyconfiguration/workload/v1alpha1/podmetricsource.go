@@ -0,0 +1,86 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PodMetricSourceApplyConfiguration represents a declarative configuration of the PodMetricSource type for use
+// with apply.
+type PodMetricSourceApplyConfiguration struct {
+	MatchLabels        map[string]string                   `json:"matchLabels,omitempty"`
+	Aggregation        *workloadv1alpha1.MetricAggregation `json:"aggregation,omitempty"`
+	QuantilePercentile *int32                              `json:"quantilePercentile,omitempty"`
+	Rate               *bool                               `json:"rate,omitempty"`
+	Window             *v1.Duration                        `json:"window,omitempty"`
+}
+
+// PodMetricSourceApplyConfiguration constructs a declarative configuration of the PodMetricSource type for use with
+// apply.
+func PodMetricSource() *PodMetricSourceApplyConfiguration {
+	return &PodMetricSourceApplyConfiguration{}
+}
+
+// WithMatchLabels puts the entries into the MatchLabels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the MatchLabels field,
+// overwriting an existing map entries in MatchLabels field with the same key.
+func (b *PodMetricSourceApplyConfiguration) WithMatchLabels(entries map[string]string) *PodMetricSourceApplyConfiguration {
+	if b.MatchLabels == nil && len(entries) > 0 {
+		b.MatchLabels = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.MatchLabels[k] = v
+	}
+	return b
+}
+
+// WithAggregation sets the Aggregation field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Aggregation field is set to the value of the last call.
+func (b *PodMetricSourceApplyConfiguration) WithAggregation(value workloadv1alpha1.MetricAggregation) *PodMetricSourceApplyConfiguration {
+	b.Aggregation = &value
+	return b
+}
+
+// WithQuantilePercentile sets the QuantilePercentile field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the QuantilePercentile field is set to the value of the last call.
+func (b *PodMetricSourceApplyConfiguration) WithQuantilePercentile(value int32) *PodMetricSourceApplyConfiguration {
+	b.QuantilePercentile = &value
+	return b
+}
+
+// WithRate sets the Rate field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Rate field is set to the value of the last call.
+func (b *PodMetricSourceApplyConfiguration) WithRate(value bool) *PodMetricSourceApplyConfiguration {
+	b.Rate = &value
+	return b
+}
+
+// WithWindow sets the Window field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Window field is set to the value of the last call.
+func (b *PodMetricSourceApplyConfiguration) WithWindow(value v1.Duration) *PodMetricSourceApplyConfiguration {
+	b.Window = &value
+	return b
+}
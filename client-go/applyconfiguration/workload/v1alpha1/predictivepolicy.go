@@ -0,0 +1,88 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PredictivePolicyApplyConfiguration represents a declarative configuration of the PredictivePolicy type for use
+// with apply.
+type PredictivePolicyApplyConfiguration struct {
+	Season                   *v1.Duration `json:"season,omitempty"`
+	Interval                 *v1.Duration `json:"interval,omitempty"`
+	LeadTime                 *v1.Duration `json:"leadTime,omitempty"`
+	LevelSmoothingPercent    *int32       `json:"levelSmoothingPercent,omitempty"`
+	TrendSmoothingPercent    *int32       `json:"trendSmoothingPercent,omitempty"`
+	SeasonalSmoothingPercent *int32       `json:"seasonalSmoothingPercent,omitempty"`
+}
+
+// PredictivePolicyApplyConfiguration constructs a declarative configuration of the PredictivePolicy type for use with
+// apply.
+func PredictivePolicy() *PredictivePolicyApplyConfiguration {
+	return &PredictivePolicyApplyConfiguration{}
+}
+
+// WithSeason sets the Season field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Season field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithSeason(value v1.Duration) *PredictivePolicyApplyConfiguration {
+	b.Season = &value
+	return b
+}
+
+// WithInterval sets the Interval field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Interval field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithInterval(value v1.Duration) *PredictivePolicyApplyConfiguration {
+	b.Interval = &value
+	return b
+}
+
+// WithLeadTime sets the LeadTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LeadTime field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithLeadTime(value v1.Duration) *PredictivePolicyApplyConfiguration {
+	b.LeadTime = &value
+	return b
+}
+
+// WithLevelSmoothingPercent sets the LevelSmoothingPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LevelSmoothingPercent field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithLevelSmoothingPercent(value int32) *PredictivePolicyApplyConfiguration {
+	b.LevelSmoothingPercent = &value
+	return b
+}
+
+// WithTrendSmoothingPercent sets the TrendSmoothingPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TrendSmoothingPercent field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithTrendSmoothingPercent(value int32) *PredictivePolicyApplyConfiguration {
+	b.TrendSmoothingPercent = &value
+	return b
+}
+
+// WithSeasonalSmoothingPercent sets the SeasonalSmoothingPercent field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SeasonalSmoothingPercent field is set to the value of the last call.
+func (b *PredictivePolicyApplyConfiguration) WithSeasonalSmoothingPercent(value int32) *PredictivePolicyApplyConfiguration {
+	b.SeasonalSmoothingPercent = &value
+	return b
+}
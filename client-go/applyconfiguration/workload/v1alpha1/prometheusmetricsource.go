@@ -0,0 +1,48 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// PrometheusMetricSourceApplyConfiguration represents a declarative configuration of the PrometheusMetricSource type for use
+// with apply.
+type PrometheusMetricSourceApplyConfiguration struct {
+	Address *string `json:"address,omitempty"`
+	Query   *string `json:"query,omitempty"`
+}
+
+// PrometheusMetricSourceApplyConfiguration constructs a declarative configuration of the PrometheusMetricSource type for use with
+// apply.
+func PrometheusMetricSource() *PrometheusMetricSourceApplyConfiguration {
+	return &PrometheusMetricSourceApplyConfiguration{}
+}
+
+// WithAddress sets the Address field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Address field is set to the value of the last call.
+func (b *PrometheusMetricSourceApplyConfiguration) WithAddress(value string) *PrometheusMetricSourceApplyConfiguration {
+	b.Address = &value
+	return b
+}
+
+// WithQuery sets the Query field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Query field is set to the value of the last call.
+func (b *PrometheusMetricSourceApplyConfiguration) WithQuery(value string) *PrometheusMetricSourceApplyConfiguration {
+	b.Query = &value
+	return b
+}
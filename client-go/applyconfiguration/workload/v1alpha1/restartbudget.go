@@ -0,0 +1,48 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RestartBudgetApplyConfiguration represents a declarative configuration of the RestartBudget type for use
+// with apply.
+type RestartBudgetApplyConfiguration struct {
+	MaxRecreations *int32 `json:"maxRecreations,omitempty"`
+	WindowSeconds  *int32 `json:"windowSeconds,omitempty"`
+}
+
+// RestartBudgetApplyConfiguration constructs a declarative configuration of the RestartBudget type for use with
+// apply.
+func RestartBudget() *RestartBudgetApplyConfiguration {
+	return &RestartBudgetApplyConfiguration{}
+}
+
+// WithMaxRecreations sets the MaxRecreations field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxRecreations field is set to the value of the last call.
+func (b *RestartBudgetApplyConfiguration) WithMaxRecreations(value int32) *RestartBudgetApplyConfiguration {
+	b.MaxRecreations = &value
+	return b
+}
+
+// WithWindowSeconds sets the WindowSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the WindowSeconds field is set to the value of the last call.
+func (b *RestartBudgetApplyConfiguration) WithWindowSeconds(value int32) *RestartBudgetApplyConfiguration {
+	b.WindowSeconds = &value
+	return b
+}
@@ -18,6 +18,10 @@ limitations under the License.
 
 package v1alpha1
 
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
 // RoleApplyConfiguration represents a declarative configuration of the Role type for use
 // with apply.
 type RoleApplyConfiguration struct {
@@ -26,6 +30,7 @@ type RoleApplyConfiguration struct {
 	EntryTemplate  *PodTemplateSpecApplyConfiguration `json:"entryTemplate,omitempty"`
 	WorkerReplicas *int32                             `json:"workerReplicas,omitempty"`
 	WorkerTemplate *PodTemplateSpecApplyConfiguration `json:"workerTemplate,omitempty"`
+	RecoveryPolicy *workloadv1alpha1.RecoveryPolicy   `json:"recoveryPolicy,omitempty"`
 }
 
 // RoleApplyConfiguration constructs a declarative configuration of the Role type for use with
@@ -73,3 +78,11 @@ func (b *RoleApplyConfiguration) WithWorkerTemplate(value *PodTemplateSpecApplyC
 	b.WorkerTemplate = value
 	return b
 }
+
+// WithRecoveryPolicy sets the RecoveryPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecoveryPolicy field is set to the value of the last call.
+func (b *RoleApplyConfiguration) WithRecoveryPolicy(value workloadv1alpha1.RecoveryPolicy) *RoleApplyConfiguration {
+	b.RecoveryPolicy = &value
+	return b
+}
@@ -0,0 +1,48 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RollbackPolicyApplyConfiguration represents a declarative configuration of the RollbackPolicy type for use
+// with apply.
+type RollbackPolicyApplyConfiguration struct {
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+	AutoRollback     *bool  `json:"autoRollback,omitempty"`
+}
+
+// RollbackPolicyApplyConfiguration constructs a declarative configuration of the RollbackPolicy type for use with
+// apply.
+func RollbackPolicy() *RollbackPolicyApplyConfiguration {
+	return &RollbackPolicyApplyConfiguration{}
+}
+
+// WithFailureThreshold sets the FailureThreshold field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailureThreshold field is set to the value of the last call.
+func (b *RollbackPolicyApplyConfiguration) WithFailureThreshold(value int32) *RollbackPolicyApplyConfiguration {
+	b.FailureThreshold = &value
+	return b
+}
+
+// WithAutoRollback sets the AutoRollback field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AutoRollback field is set to the value of the last call.
+func (b *RollbackPolicyApplyConfiguration) WithAutoRollback(value bool) *RollbackPolicyApplyConfiguration {
+	b.AutoRollback = &value
+	return b
+}
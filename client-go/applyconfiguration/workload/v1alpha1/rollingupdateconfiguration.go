@@ -26,6 +26,7 @@ import (
 // with apply.
 type RollingUpdateConfigurationApplyConfiguration struct {
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
 	Partition      *int32              `json:"partition,omitempty"`
 }
 
@@ -43,6 +44,14 @@ func (b *RollingUpdateConfigurationApplyConfiguration) WithMaxUnavailable(value
 	return b
 }
 
+// WithMaxSurge sets the MaxSurge field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxSurge field is set to the value of the last call.
+func (b *RollingUpdateConfigurationApplyConfiguration) WithMaxSurge(value intstr.IntOrString) *RollingUpdateConfigurationApplyConfiguration {
+	b.MaxSurge = &value
+	return b
+}
+
 // WithPartition sets the Partition field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the Partition field is set to the value of the last call.
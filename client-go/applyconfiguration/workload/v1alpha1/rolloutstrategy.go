@@ -26,7 +26,9 @@ import (
 // with apply.
 type RolloutStrategyApplyConfiguration struct {
 	Type                       *workloadv1alpha1.RolloutStrategyType         `json:"type,omitempty"`
+	PodUpdatePolicy            *workloadv1alpha1.PodUpdatePolicyType         `json:"podUpdatePolicy,omitempty"`
 	RollingUpdateConfiguration *RollingUpdateConfigurationApplyConfiguration `json:"rollingUpdateConfiguration,omitempty"`
+	RollbackPolicy             *RollbackPolicyApplyConfiguration             `json:"rollbackPolicy,omitempty"`
 }
 
 // RolloutStrategyApplyConfiguration constructs a declarative configuration of the RolloutStrategy type for use with
@@ -43,6 +45,14 @@ func (b *RolloutStrategyApplyConfiguration) WithType(value workloadv1alpha1.Roll
 	return b
 }
 
+// WithPodUpdatePolicy sets the PodUpdatePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodUpdatePolicy field is set to the value of the last call.
+func (b *RolloutStrategyApplyConfiguration) WithPodUpdatePolicy(value workloadv1alpha1.PodUpdatePolicyType) *RolloutStrategyApplyConfiguration {
+	b.PodUpdatePolicy = &value
+	return b
+}
+
 // WithRollingUpdateConfiguration sets the RollingUpdateConfiguration field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the RollingUpdateConfiguration field is set to the value of the last call.
@@ -50,3 +60,11 @@ func (b *RolloutStrategyApplyConfiguration) WithRollingUpdateConfiguration(value
 	b.RollingUpdateConfiguration = value
 	return b
 }
+
+// WithRollbackPolicy sets the RollbackPolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RollbackPolicy field is set to the value of the last call.
+func (b *RolloutStrategyApplyConfiguration) WithRollbackPolicy(value *RollbackPolicyApplyConfiguration) *RolloutStrategyApplyConfiguration {
+	b.RollbackPolicy = value
+	return b
+}
@@ -0,0 +1,43 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// RouterDemandMetricSourceApplyConfiguration represents a declarative configuration of the RouterDemandMetricSource type for use
+// with apply.
+type RouterDemandMetricSourceApplyConfiguration struct {
+	Signal *workloadv1alpha1.RouterDemandSignal `json:"signal,omitempty"`
+}
+
+// RouterDemandMetricSourceApplyConfiguration constructs a declarative configuration of the RouterDemandMetricSource type for use with
+// apply.
+func RouterDemandMetricSource() *RouterDemandMetricSourceApplyConfiguration {
+	return &RouterDemandMetricSourceApplyConfiguration{}
+}
+
+// WithSignal sets the Signal field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Signal field is set to the value of the last call.
+func (b *RouterDemandMetricSourceApplyConfiguration) WithSignal(value workloadv1alpha1.RouterDemandSignal) *RouterDemandMetricSourceApplyConfiguration {
+	b.Signal = &value
+	return b
+}
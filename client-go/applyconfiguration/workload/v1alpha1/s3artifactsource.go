@@ -0,0 +1,61 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// S3ArtifactSourceApplyConfiguration represents a declarative configuration of the S3ArtifactSource type for use
+// with apply.
+type S3ArtifactSourceApplyConfiguration struct {
+	URI                  *string                  `json:"uri,omitempty"`
+	Endpoint             *string                  `json:"endpoint,omitempty"`
+	CredentialsSecretRef *v1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// S3ArtifactSourceApplyConfiguration constructs a declarative configuration of the S3ArtifactSource type for use with
+// apply.
+func S3ArtifactSource() *S3ArtifactSourceApplyConfiguration {
+	return &S3ArtifactSourceApplyConfiguration{}
+}
+
+// WithURI sets the URI field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URI field is set to the value of the last call.
+func (b *S3ArtifactSourceApplyConfiguration) WithURI(value string) *S3ArtifactSourceApplyConfiguration {
+	b.URI = &value
+	return b
+}
+
+// WithEndpoint sets the Endpoint field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Endpoint field is set to the value of the last call.
+func (b *S3ArtifactSourceApplyConfiguration) WithEndpoint(value string) *S3ArtifactSourceApplyConfiguration {
+	b.Endpoint = &value
+	return b
+}
+
+// WithCredentialsSecretRef sets the CredentialsSecretRef field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CredentialsSecretRef field is set to the value of the last call.
+func (b *S3ArtifactSourceApplyConfiguration) WithCredentialsSecretRef(value v1.LocalObjectReference) *S3ArtifactSourceApplyConfiguration {
+	b.CredentialsSecretRef = &value
+	return b
+}
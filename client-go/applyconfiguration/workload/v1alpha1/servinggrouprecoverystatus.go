@@ -0,0 +1,72 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ServingGroupRecoveryStatusApplyConfiguration represents a declarative configuration of the ServingGroupRecoveryStatus type for use
+// with apply.
+type ServingGroupRecoveryStatusApplyConfiguration struct {
+	Name            *string   `json:"name,omitempty"`
+	Revision        *string   `json:"revision,omitempty"`
+	RecreationTimes []v1.Time `json:"recreationTimes,omitempty"`
+	Failed          *bool     `json:"failed,omitempty"`
+}
+
+// ServingGroupRecoveryStatusApplyConfiguration constructs a declarative configuration of the ServingGroupRecoveryStatus type for use with
+// apply.
+func ServingGroupRecoveryStatus() *ServingGroupRecoveryStatusApplyConfiguration {
+	return &ServingGroupRecoveryStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ServingGroupRecoveryStatusApplyConfiguration) WithName(value string) *ServingGroupRecoveryStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithRevision sets the Revision field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Revision field is set to the value of the last call.
+func (b *ServingGroupRecoveryStatusApplyConfiguration) WithRevision(value string) *ServingGroupRecoveryStatusApplyConfiguration {
+	b.Revision = &value
+	return b
+}
+
+// WithRecreationTimes adds the given value to the RecreationTimes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the RecreationTimes field.
+func (b *ServingGroupRecoveryStatusApplyConfiguration) WithRecreationTimes(values ...v1.Time) *ServingGroupRecoveryStatusApplyConfiguration {
+	for i := range values {
+		b.RecreationTimes = append(b.RecreationTimes, values[i])
+	}
+	return b
+}
+
+// WithFailed sets the Failed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Failed field is set to the value of the last call.
+func (b *ServingGroupRecoveryStatusApplyConfiguration) WithFailed(value bool) *ServingGroupRecoveryStatusApplyConfiguration {
+	b.Failed = &value
+	return b
+}
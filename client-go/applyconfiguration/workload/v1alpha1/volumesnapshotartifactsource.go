@@ -0,0 +1,82 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
+)
+
+// VolumeSnapshotArtifactSourceApplyConfiguration represents a declarative configuration of the VolumeSnapshotArtifactSource type for use
+// with apply.
+type VolumeSnapshotArtifactSourceApplyConfiguration struct {
+	SnapshotName     *string                         `json:"snapshotName,omitempty"`
+	Size             *resource.Quantity              `json:"size,omitempty"`
+	StorageClassName *string                         `json:"storageClassName,omitempty"`
+	AccessModes      []v1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	Path             *string                         `json:"path,omitempty"`
+}
+
+// VolumeSnapshotArtifactSourceApplyConfiguration constructs a declarative configuration of the VolumeSnapshotArtifactSource type for use with
+// apply.
+func VolumeSnapshotArtifactSource() *VolumeSnapshotArtifactSourceApplyConfiguration {
+	return &VolumeSnapshotArtifactSourceApplyConfiguration{}
+}
+
+// WithSnapshotName sets the SnapshotName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SnapshotName field is set to the value of the last call.
+func (b *VolumeSnapshotArtifactSourceApplyConfiguration) WithSnapshotName(value string) *VolumeSnapshotArtifactSourceApplyConfiguration {
+	b.SnapshotName = &value
+	return b
+}
+
+// WithSize sets the Size field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Size field is set to the value of the last call.
+func (b *VolumeSnapshotArtifactSourceApplyConfiguration) WithSize(value resource.Quantity) *VolumeSnapshotArtifactSourceApplyConfiguration {
+	b.Size = &value
+	return b
+}
+
+// WithStorageClassName sets the StorageClassName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the StorageClassName field is set to the value of the last call.
+func (b *VolumeSnapshotArtifactSourceApplyConfiguration) WithStorageClassName(value string) *VolumeSnapshotArtifactSourceApplyConfiguration {
+	b.StorageClassName = &value
+	return b
+}
+
+// WithAccessModes adds the given value to the AccessModes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AccessModes field.
+func (b *VolumeSnapshotArtifactSourceApplyConfiguration) WithAccessModes(values ...v1.PersistentVolumeAccessMode) *VolumeSnapshotArtifactSourceApplyConfiguration {
+	for i := range values {
+		b.AccessModes = append(b.AccessModes, values[i])
+	}
+	return b
+}
+
+// WithPath sets the Path field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Path field is set to the value of the last call.
+func (b *VolumeSnapshotArtifactSourceApplyConfiguration) WithPath(value string) *VolumeSnapshotArtifactSourceApplyConfiguration {
+	b.Path = &value
+	return b
+}
@@ -0,0 +1,72 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// WebhookPluginConfigApplyConfiguration represents a declarative configuration of the WebhookPluginConfig type for use
+// with apply.
+type WebhookPluginConfigApplyConfiguration struct {
+	URL            *string                                `json:"url,omitempty"`
+	CABundle       []byte                                 `json:"caBundle,omitempty"`
+	TimeoutSeconds *int32                                 `json:"timeoutSeconds,omitempty"`
+	FailurePolicy  *workloadv1alpha1.WebhookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// WebhookPluginConfigApplyConfiguration constructs a declarative configuration of the WebhookPluginConfig type for use with
+// apply.
+func WebhookPluginConfig() *WebhookPluginConfigApplyConfiguration {
+	return &WebhookPluginConfigApplyConfiguration{}
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *WebhookPluginConfigApplyConfiguration) WithURL(value string) *WebhookPluginConfigApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithCABundle adds the given value to the CABundle field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the CABundle field.
+func (b *WebhookPluginConfigApplyConfiguration) WithCABundle(values ...byte) *WebhookPluginConfigApplyConfiguration {
+	for i := range values {
+		b.CABundle = append(b.CABundle, values[i])
+	}
+	return b
+}
+
+// WithTimeoutSeconds sets the TimeoutSeconds field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the TimeoutSeconds field is set to the value of the last call.
+func (b *WebhookPluginConfigApplyConfiguration) WithTimeoutSeconds(value int32) *WebhookPluginConfigApplyConfiguration {
+	b.TimeoutSeconds = &value
+	return b
+}
+
+// WithFailurePolicy sets the FailurePolicy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the FailurePolicy field is set to the value of the last call.
+func (b *WebhookPluginConfigApplyConfiguration) WithFailurePolicy(value workloadv1alpha1.WebhookFailurePolicy) *WebhookPluginConfigApplyConfiguration {
+	b.FailurePolicy = &value
+	return b
+}
@@ -29,6 +29,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/spf13/pflag"
 	clientset "github.com/volcano-sh/kthena/client-go/clientset/versioned"
 	"github.com/volcano-sh/kthena/pkg/controller"
@@ -53,6 +54,7 @@ type webhookConfig struct {
 
 func main() {
 	var enableWebhook bool
+	var metricsBindAddress string
 	var wc webhookConfig
 	var cc controller.Config
 	var controllers []string
@@ -75,6 +77,7 @@ func main() {
 		"named 'foo', '-foo' disables the controller named 'foo'.\nIf both '+foo' and '-foo' are set simultaneously, then controller named 'foo' will be enabled.\nAll controllers: 'modelserving', 'modelbooster', 'autoscaler'")
 	pflag.Float32Var(&cc.KubeAPIQPS, "kube-api-qps", 0, "QPS to use while talking with kubernetes apiserver. If 0, use default value.")
 	pflag.IntVar(&cc.KubeAPIBurst, "kube-api-burst", 0, "Burst to use while talking with kubernetes apiserver. If 0, use default value.")
+	pflag.StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the Prometheus metrics endpoint binds to. Set it to empty to disable the metrics endpoint.")
 	pflag.Parse()
 
 	cc.Controllers = parseControllers(controllers)
@@ -100,9 +103,34 @@ func main() {
 			}
 		}()
 	}
+	if metricsBindAddress != "" {
+		go setupMetricsServer(ctx, metricsBindAddress)
+	}
 	controller.SetupController(ctx, cc)
 }
 
+// setupMetricsServer serves the Prometheus metrics of the controllers until ctx is done.
+func setupMetricsServer(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		klog.Infof("Starting metrics server on %s", server.Addr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			klog.Errorf("failed to start metrics server: %v", err)
+		}
+	}()
+	<-ctx.Done()
+	ctxTimeout, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_ = server.Shutdown(ctxTimeout)
+}
+
 const validatingWebhookName = "kthena-controller-manager-validating-webhook"
 const mutatingWebhookName = "kthena-controller-manager-mutating-webhook"
 
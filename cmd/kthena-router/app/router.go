@@ -33,6 +33,7 @@ import (
 	"github.com/volcano-sh/kthena/pkg/kthena-router/datastore"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/debug"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/router"
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
 )
 
 const (
@@ -50,6 +51,7 @@ func (s *Server) startRouter(ctx context.Context, router *router.Router, store d
 
 	// Start debug server on localhost
 	s.startDebugServer(ctx, store)
+	s.startManagementServer(ctx, router)
 
 	// Gateway API features are optional
 	if s.EnableGatewayAPI {
@@ -137,6 +139,39 @@ func (s *Server) startDebugServer(ctx context.Context, store datastore.Store) {
 	}()
 }
 
+// startManagementServer starts the management server on all the interfaces
+// This server only handles the demand pulled by the autoscaler, and is not exposed by the Service of the router
+func (s *Server) startManagementServer(ctx context.Context, router *router.Router) {
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+
+	// Demand of the model servers, pulled by the autoscaler
+	engine.GET(routerdemand.Path, router.DemandHandler())
+
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", s.ManagementPort),
+		Handler: engine.Handler(),
+	}
+	go func() {
+		klog.Infof("Starting management server on port %d", s.ManagementPort)
+		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			klog.Fatalf("Management server listen failed: %v", err)
+		}
+	}()
+
+	go func() {
+		<-ctx.Done()
+		// graceful shutdown
+		klog.Info("Shutting down management HTTP server ...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), gracefulShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			klog.Errorf("Management server shutdown failed: %v", err)
+		}
+		klog.Info("Management HTTP server exited")
+	}()
+}
+
 // startDefaultServer starts the default HTTP server on fixed port
 // This server handles healthz, readyz, metrics, and /v1/*path
 func (s *Server) startDefaultServer(ctx context.Context, router *router.Router, store datastore.Store) {
@@ -36,11 +36,12 @@ type Server struct {
 	EnableGatewayAPI                   bool
 	EnableGatewayAPIInferenceExtension bool
 	DebugPort                          int
+	ManagementPort                     int
 	KubeAPIQPS                         float32
 	KubeAPIBurst                       int
 }
 
-func NewServer(port string, enableTLS bool, cert, key string, enableGatewayAPI bool, enableGatewayAPIInferenceExtension bool, debugPort int, kubeAPIQPS float32, kubeAPIBurst int) *Server {
+func NewServer(port string, enableTLS bool, cert, key string, enableGatewayAPI bool, enableGatewayAPIInferenceExtension bool, debugPort int, managementPort int, kubeAPIQPS float32, kubeAPIBurst int) *Server {
 	return &Server{
 		store:                              nil,
 		EnableTLS:                          enableTLS,
@@ -50,6 +51,7 @@ func NewServer(port string, enableTLS bool, cert, key string, enableGatewayAPI b
 		EnableGatewayAPI:                   enableGatewayAPI,
 		EnableGatewayAPIInferenceExtension: enableGatewayAPIInferenceExtension,
 		DebugPort:                          debugPort,
+		ManagementPort:                     managementPort,
 		KubeAPIQPS:                         kubeAPIQPS,
 		KubeAPIBurst:                       kubeAPIBurst,
 	}
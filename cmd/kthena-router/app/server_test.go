@@ -35,7 +35,7 @@ func TestNewServerDebugPortDefault(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			server := NewServer("8080", false, "", "", false, false, tc.debugPort, 0, 0)
+			server := NewServer("8080", false, "", "", false, false, tc.debugPort, 15001, 0, 0)
 			assert.Equal(t, tc.debugPort, server.DebugPort, "DebugPort should match the provided value")
 		})
 	}
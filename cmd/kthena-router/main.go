@@ -32,6 +32,7 @@ import (
 
 	"github.com/volcano-sh/kthena/cmd/kthena-router/app"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/webhook"
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
 	webhookcert "github.com/volcano-sh/kthena/pkg/webhook/cert"
 )
 
@@ -51,6 +52,7 @@ func main() {
 		certSecretName                     string
 		serviceName                        string
 		debugPort                          int
+		managementPort                     int
 		kubeAPIQPS                         float32
 		kubeAPIBurst                       int
 	)
@@ -69,6 +71,7 @@ func main() {
 	pflag.StringVar(&certSecretName, "cert-secret-name", "kthena-router-webhook-certs", "Name of the secret to store auto-generated webhook certificates")
 	pflag.StringVar(&serviceName, "webhook-service-name", "kthena-router-webhook", "Service name for the webhook server")
 	pflag.IntVar(&debugPort, "debug-port", 15000, "The port for the debug server (localhost only)")
+	pflag.IntVar(&managementPort, "management-port", routerdemand.DefaultPort, "The port for the management server serving the demand pulled by the autoscaler")
 	pflag.Float32Var(&kubeAPIQPS, "kube-api-qps", 0, "QPS to use while talking with kubernetes apiserver. If 0, use default value.")
 	pflag.IntVar(&kubeAPIBurst, "kube-api-burst", 0, "Burst to use while talking with kubernetes apiserver. If 0, use default value.")
 	defer klog.Flush()
@@ -90,6 +93,10 @@ func main() {
 		klog.Fatalf("invalid debug port: %d", debugPort)
 	}
 
+	if managementPort <= 0 || managementPort > 65535 {
+		klog.Fatalf("invalid management port: %d", managementPort)
+	}
+
 	pflag.CommandLine.VisitAll(func(f *pflag.Flag) {
 		klog.Infof("Flag: %s, Value: %s", f.Name, f.Value.String())
 	})
@@ -111,7 +118,7 @@ func main() {
 		klog.Info("Webhook server is disabled")
 	}
 
-	app.NewServer(routerPort, tlsCert != "" && tlsKey != "", tlsCert, tlsKey, enableGatewayAPI, enableGatewayAPIInferenceExtension, debugPort, kubeAPIQPS, kubeAPIBurst).Run(ctx)
+	app.NewServer(routerPort, tlsCert != "" && tlsKey != "", tlsCert, tlsKey, enableGatewayAPI, enableGatewayAPIInferenceExtension, debugPort, managementPort, kubeAPIQPS, kubeAPIBurst).Run(ctx)
 }
 
 // ensureWebhookCertificate generates a certificate secret if needed and returns the CA bundle.
@@ -69,6 +69,7 @@ const sidebars: SidebarsConfig = {
             'user-guide/binpack-scale-down',
             'user-guide/gang-scheduling',
             'user-guide/network-topology',
+            'user-guide/modelserving-plugins',
           ],
         },
         {
@@ -200,6 +201,29 @@ const sidebars: SidebarsConfig = {
                 { type: 'doc', id: 'reference/kthena-cli/kthena_describe_template', label: 'Describe template' },
               ],
             },
+            {
+              type: 'category',
+              label: 'Render',
+              link: {
+                type: 'doc',
+                id: 'reference/kthena-cli/kthena_render',
+              },
+              items: [
+                { type: 'doc', id: 'reference/kthena-cli/kthena_render_model-booster', label: 'Render model-booster' },
+              ],
+            },
+            {
+              type: 'category',
+              label: 'Diff',
+              link: {
+                type: 'doc',
+                id: 'reference/kthena-cli/kthena_diff',
+              },
+              items: [
+                { type: 'doc', id: 'reference/kthena-cli/kthena_diff_model-booster', label: 'Diff model-booster' },
+              ],
+            },
+            { type: 'doc', id: 'reference/kthena-cli/kthena_simulate', label: 'Simulate' },
           ],
         },
         {
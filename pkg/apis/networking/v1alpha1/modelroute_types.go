@@ -53,6 +53,12 @@ type ModelRouteSpec struct {
 	// There is no limitation if this field is not set.
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+
+	// ScaleFromZero holds the requests for a ModelServer without ready pods, e.g. scaled to zero by the
+	// autoscaler, until one of its pods is ready, instead of rejecting them.
+	// The held requests signal the autoscaler to scale the ModelServer from zero.
+	// +optional
+	ScaleFromZero *ScaleFromZero `json:"scaleFromZero,omitempty"`
 }
 
 type Rule struct {
@@ -153,6 +159,21 @@ type RedisConfig struct {
 	Address string `json:"address"`
 }
 
+// ScaleFromZero defines how the requests for a ModelServer without ready pods are held.
+type ScaleFromZero struct {
+	// Timeout is the maximum time a request is held until a pod of the ModelServer is ready.
+	// The request is rejected with HTTP 503 once it expires.
+	// +optional
+	// +kubebuilder:default="5m"
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// MaxBufferedRequests is the maximum number of requests held for a ModelServer by each router replica.
+	// The requests beyond it are rejected with HTTP 503.
+	// +optional
+	// +kubebuilder:default=100
+	// +kubebuilder:validation:Minimum=1
+	MaxBufferedRequests int32 `json:"maxBufferedRequests,omitempty"`
+}
+
 // +kubebuilder:validation:Enum=second;minute;hour;day;month
 type RateLimitUnit string
 
@@ -47,11 +47,17 @@ type ModelServerSpec struct {
 	// KVConnector specifies the KV connector configuration for PD disaggregated routing
 	// +optional
 	KVConnector *KVConnectorSpec `json:"kvConnector,omitempty"`
+
+	// LoraPlacement enables loading LoRA adapters on demand. When a request asks for an adapter which is not loaded
+	// on any pod, the router loads it on a pod with a free adapter slot, and unloads the least recently used
+	// adapter when all slots are taken. Only supported by vLLM without PD disaggregation.
+	// +optional
+	LoraPlacement *LoraPlacement `json:"loraPlacement,omitempty"`
 }
 
 // InferenceEngine defines the inference framework used by the modelServer to serve LLM requests.
 //
-// +kubebuilder:validation:Enum=vLLM;SGLang
+// +kubebuilder:validation:Enum=vLLM;SGLang;MindIE
 type InferenceEngine string
 
 const (
@@ -59,6 +65,8 @@ const (
 	VLLM InferenceEngine = "vLLM"
 	// https://github.com/sgl-project/sglang
 	SGLang InferenceEngine = "SGLang"
+	// https://www.hiascend.com/software/mindie
+	MindIE InferenceEngine = "MindIE"
 )
 
 // WorkloadSelector is used to match the model serving instances.
@@ -119,6 +127,45 @@ type KVConnectorSpec struct {
 	Type KVConnectorType `json:"type,omitempty"`
 }
 
+// LoraPlacement defines the LoRA adapters which are loaded on the pods on demand.
+type LoraPlacement struct {
+	// MaxLoras is the maximum number of LoRA adapters loaded on a pod at the same time.
+	// It should be equal to the max_loras of vLLM, or max_cpu_loras if it is set.
+	// +kubebuilder:validation:Minimum=1
+	MaxLoras int32 `json:"maxLoras"`
+	// RuntimePort is the port of the runtime sidecar, which downloads and loads the adapters.
+	// +optional
+	// +kubebuilder:default=8100
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	RuntimePort int32 `json:"runtimePort,omitempty"`
+	// DownloadPath is the directory in the pod where the adapters are downloaded to, as <downloadPath>/<adapter name>.
+	// It must be on a volume shared by the runtime sidecar and the inference engine.
+	// +kubebuilder:validation:Pattern=`^/.*`
+	DownloadPath string `json:"downloadPath"`
+	// LoadTimeout is the timeout of loading an adapter on a pod, including the download.
+	// +optional
+	// +kubebuilder:default="5m"
+	LoadTimeout *metav1.Duration `json:"loadTimeout,omitempty"`
+	// Adapters is the list of LoRA adapters which can be loaded on demand.
+	// Only these adapters are unloaded when the adapter slots of a pod run out.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=1024
+	Adapters []LoraAdapterSource `json:"adapters"`
+}
+
+// LoraAdapterSource defines where a LoRA adapter is downloaded from.
+type LoraAdapterSource struct {
+	// Name is the name of the adapter, which is the model name in the inference requests.
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+	// ArtifactURL is the URL where the adapter is downloaded from. Support hf://, s3://, pvc://.
+	// +kubebuilder:validation:Pattern=`^(hf://|s3://|pvc://).+`
+	ArtifactURL string `json:"artifactURL"`
+}
+
 type TrafficPolicy struct {
 	// The request timeout for the inference request.
 	// By default, there is no timeout.
@@ -81,6 +81,46 @@ func (in *KVConnectorSpec) DeepCopy() *KVConnectorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoraAdapterSource) DeepCopyInto(out *LoraAdapterSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoraAdapterSource.
+func (in *LoraAdapterSource) DeepCopy() *LoraAdapterSource {
+	if in == nil {
+		return nil
+	}
+	out := new(LoraAdapterSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoraPlacement) DeepCopyInto(out *LoraPlacement) {
+	*out = *in
+	if in.LoadTimeout != nil {
+		in, out := &in.LoadTimeout, &out.LoadTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]LoraAdapterSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoraPlacement.
+func (in *LoraPlacement) DeepCopy() *LoraPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(LoraPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelMatch) DeepCopyInto(out *ModelMatch) {
 	*out = *in
@@ -212,6 +252,11 @@ func (in *ModelRouteSpec) DeepCopyInto(out *ModelRouteSpec) {
 		*out = new(RateLimit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ScaleFromZero != nil {
+		in, out := &in.ScaleFromZero, &out.ScaleFromZero
+		*out = new(ScaleFromZero)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelRouteSpec.
@@ -322,6 +367,11 @@ func (in *ModelServerSpec) DeepCopyInto(out *ModelServerSpec) {
 		*out = new(KVConnectorSpec)
 		**out = **in
 	}
+	if in.LoraPlacement != nil {
+		in, out := &in.LoraPlacement, &out.LoraPlacement
+		*out = new(LoraPlacement)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServerSpec.
@@ -474,6 +524,26 @@ func (in *Rule) DeepCopy() *Rule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleFromZero) DeepCopyInto(out *ScaleFromZero) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleFromZero.
+func (in *ScaleFromZero) DeepCopy() *ScaleFromZero {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleFromZero)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StringMatch) DeepCopyInto(out *StringMatch) {
 	*out = *in
@@ -37,6 +37,78 @@ type AutoscalingPolicySpec struct {
 	// Behavior defines the scaling behavior configuration for both scale up and scale down operations.
 	// +optional
 	Behavior AutoscalingPolicyBehavior `json:"behavior"`
+	// Schedules raise the minimum replicas of the targets during recurring time windows, e.g. business hours.
+	// The highest minimum replicas of the active schedules applies.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Schedules []AutoscalingSchedule `json:"schedules,omitempty"`
+	// Predictive forecasts the replicas needed by the targets from the history of their metrics, and raises the
+	// minimum replicas ahead of the forecast demand.
+	// +optional
+	Predictive *PredictivePolicy `json:"predictive,omitempty"`
+}
+
+// AutoscalingSchedule defines the minimum replicas of the targets during a recurring time window.
+// For a heterogeneous or coupled target, the minimum replicas are the total replicas of its targets or roles.
+type AutoscalingSchedule struct {
+	// Name is the name of the schedule.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Schedule is the cron expression of the starts of the window, with the five fields minute, hour, day of month,
+	// month and day of week, e.g. "0 8 * * 1-5" for 8:00 from Monday to Friday.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration is how long the window lasts after each start, at most 7 days.
+	Duration metav1.Duration `json:"duration"`
+	// TimeZone is the IANA time zone of the schedule, e.g. Europe/Paris.
+	// +kubebuilder:default=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// MinReplicas is the minimum replicas during the window. It is bounded by the maximum replicas of the binding.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	MinReplicas int32 `json:"minReplicas"`
+}
+
+// PredictivePolicy defines the forecast of the replicas needed by the targets. The replicas the metrics ask for are
+// recorded by the autoscaler, and forecast with Holt-Winters seasonal smoothing, which learns the level, the trend
+// and the seasonal pattern of the series, e.g. the daily pattern of the traffic.
+// The forecast is available once the autoscaler recorded a whole season, and the history is lost when the
+// autoscaler restarts.
+type PredictivePolicy struct {
+	// Season is the period of the seasonal pattern. It must be a multiple of Interval.
+	// +kubebuilder:default="24h"
+	// +optional
+	Season *metav1.Duration `json:"season,omitempty"`
+	// Interval is the length of the points of the series, each being the peak of the replicas the metrics asked
+	// for during the interval. A season has at most 10080 intervals.
+	// +kubebuilder:default="5m"
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+	// LeadTime is how long ahead of the forecast demand the targets are scaled, which should cover the time the
+	// pods take to become ready. The minimum replicas are raised to the peak of the forecast until then.
+	// +kubebuilder:default="10m"
+	// +optional
+	LeadTime *metav1.Duration `json:"leadTime,omitempty"`
+	// LevelSmoothingPercent is the weight of the last point in the level of the series.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=50
+	// +optional
+	LevelSmoothingPercent *int32 `json:"levelSmoothingPercent,omitempty"`
+	// TrendSmoothingPercent is the weight of the last change of the level in the trend of the series.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=10
+	// +optional
+	TrendSmoothingPercent *int32 `json:"trendSmoothingPercent,omitempty"`
+	// SeasonalSmoothingPercent is the weight of the last season in the seasonal pattern of the series.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=30
+	// +optional
+	SeasonalSmoothingPercent *int32 `json:"seasonalSmoothingPercent,omitempty"`
 }
 
 // AutoscalingPolicyMetric defines a metric and its target value for scaling decisions.
@@ -44,7 +116,155 @@ type AutoscalingPolicyMetric struct {
 	// MetricName defines the name of the metric to monitor for scaling decisions.
 	MetricName string `json:"metricName"`
 	// TargetValue defines the target value for the metric that triggers scaling operations.
+	// For a metric scraped from the pods, it is the target of the average value per pod, or of the value of the
+	// busiest pod with the Max aggregation.
+	// For the other sources, it is the target value per instance, the desired instances being the value of the metric divided by TargetValue.
 	TargetValue resource.Quantity `json:"targetValue"`
+	// Source defines where the value of the metric comes from.
+	// By default, the metric is scraped from the metric endpoint of the pods of the target.
+	// +optional
+	Source *MetricSource `json:"source,omitempty"`
+}
+
+// MetricSourceType defines the type of the source of a metric.
+// +kubebuilder:validation:Enum=Pod;Prometheus;External;RouterAggregate;RouterDemand
+type MetricSourceType string
+
+const (
+	// MetricSourcePod scrapes the metric from the metric endpoint of the pods of the target.
+	MetricSourcePod MetricSourceType = "Pod"
+	// MetricSourcePrometheus evaluates a PromQL query against a Prometheus-compatible endpoint.
+	MetricSourcePrometheus MetricSourceType = "Prometheus"
+	// MetricSourceExternal gets the metric from the Kubernetes external metrics API.
+	MetricSourceExternal MetricSourceType = "External"
+	// MetricSourceRouterAggregate sums the metric scraped from the metric endpoint of the routers.
+	MetricSourceRouterAggregate MetricSourceType = "RouterAggregate"
+	// MetricSourceRouterDemand sums a demand signal the routers publish for the models and the ModelServers
+	// serving the target.
+	MetricSourceRouterDemand MetricSourceType = "RouterDemand"
+)
+
+// MetricSource defines where the value of a metric comes from.
+// +kubebuilder:validation:XValidation:rule="self.type == 'Pod' || !has(self.pod)",message="pod can only be set if type is Pod"
+// +kubebuilder:validation:XValidation:rule="(self.type == 'Prometheus') == has(self.prometheus)",message="prometheus must be set if and only if type is Prometheus"
+// +kubebuilder:validation:XValidation:rule="self.type == 'External' || !has(self.external)",message="external can only be set if type is External"
+// +kubebuilder:validation:XValidation:rule="self.type == 'RouterAggregate' || !has(self.routerAggregate)",message="routerAggregate can only be set if type is RouterAggregate"
+// +kubebuilder:validation:XValidation:rule="(self.type == 'RouterDemand') == has(self.routerDemand)",message="routerDemand must be set if and only if type is RouterDemand"
+type MetricSource struct {
+	// Type defines the type of the source.
+	// +kubebuilder:default=Pod
+	Type MetricSourceType `json:"type"`
+	// Pod defines how the metric scraped from the pods is computed.
+	// +optional
+	Pod *PodMetricSource `json:"pod,omitempty"`
+	// Prometheus defines the query of a Prometheus source.
+	// +optional
+	Prometheus *PrometheusMetricSource `json:"prometheus,omitempty"`
+	// External defines the series of the metric to get from the external metrics API.
+	// +optional
+	External *ExternalMetricSource `json:"external,omitempty"`
+	// RouterAggregate defines the series of the router metric to sum.
+	// +optional
+	RouterAggregate *RouterAggregateMetricSource `json:"routerAggregate,omitempty"`
+	// RouterDemand defines the demand signal of the routers.
+	// +optional
+	RouterDemand *RouterDemandMetricSource `json:"routerDemand,omitempty"`
+}
+
+// MetricAggregation defines how the values of several series or pods are combined.
+// +kubebuilder:validation:Enum=Sum;Avg;Max
+type MetricAggregation string
+
+const (
+	// MetricAggregationSum adds the values.
+	MetricAggregationSum MetricAggregation = "Sum"
+	// MetricAggregationAvg averages the values.
+	MetricAggregationAvg MetricAggregation = "Avg"
+	// MetricAggregationMax takes the maximum of the values.
+	MetricAggregationMax MetricAggregation = "Max"
+)
+
+// PodMetricSource defines how a metric scraped from the metric endpoint of the pods of the target is computed.
+type PodMetricSource struct {
+	// MatchLabels selects the series of the metric by their labels, e.g. model_name: llama.
+	// All the series are selected by default.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+	// Aggregation combines the selected series of each pod, then the pods of the target.
+	// Across the pods, Sum and Avg both compare the average value per pod to TargetValue,
+	// while Max compares the value of the busiest pod.
+	// For histogram metrics, Sum and Avg take the quantile of the observations of all the selected series,
+	// while Max takes the highest quantile of the series.
+	// +kubebuilder:default=Sum
+	// +optional
+	Aggregation MetricAggregation `json:"aggregation,omitempty"`
+	// QuantilePercentile is the quantile of histogram metrics, computed over the observations of Window.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=95
+	// +optional
+	QuantilePercentile *int32 `json:"quantilePercentile,omitempty"`
+	// Rate uses the per-second rate of counter metrics over Window rather than their value.
+	// The rate is available once the autoscaler observed the counter for a whole window.
+	// +optional
+	Rate bool `json:"rate,omitempty"`
+	// Window is the window over which the quantiles of histogram metrics and the rates of counter metrics are computed.
+	// +kubebuilder:default="60s"
+	// +optional
+	Window *metav1.Duration `json:"window,omitempty"`
+}
+
+// PrometheusMetricSource defines a PromQL query against a Prometheus-compatible endpoint.
+type PrometheusMetricSource struct {
+	// Address is the URL of the Prometheus-compatible HTTP API, e.g. http://prometheus.monitoring:9090.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+	// Query is the PromQL instant query, e.g. sum(kthena_router_fairness_queue_size{model="llama"}).
+	// The samples of the result are summed.
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+// ExternalMetricSource defines the series of a metric of the Kubernetes external metrics API.
+// The name of the metric is MetricName, and it is queried in the namespace of the binding.
+type ExternalMetricSource struct {
+	// Selector selects the series of the metric. The values of the series are summed.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// RouterAggregateMetricSource defines the series of a router metric, e.g. kthena_router_active_downstream_requests.
+// The name of the metric is MetricName, and the values of its series are summed across the routers.
+type RouterAggregateMetricSource struct {
+	// MatchLabels selects the series of the metric by their labels, e.g. model: llama.
+	// +optional
+	MatchLabels map[string]string `json:"matchLabels,omitempty"`
+}
+
+// RouterDemandSignal defines a demand signal the routers publish.
+// +kubebuilder:validation:Enum=QueueLength;Waiting;ActiveRequests;RateLimited;NotFound
+type RouterDemandSignal string
+
+const (
+	// RouterDemandQueueLength is the number of requests waiting in the fairness queues of the models.
+	RouterDemandQueueLength RouterDemandSignal = "QueueLength"
+	// RouterDemandWaiting is the number of requests held until a pod of the ModelServers is ready, see ScaleFromZero.
+	RouterDemandWaiting RouterDemandSignal = "Waiting"
+	// RouterDemandActiveRequests is the number of requests being proxied to the pods of the ModelServers.
+	RouterDemandActiveRequests RouterDemandSignal = "ActiveRequests"
+	// RouterDemandRateLimited is the rate per second of the requests rejected by the rate limits of the models.
+	RouterDemandRateLimited RouterDemandSignal = "RateLimited"
+	// RouterDemandNotFound is the rate per second of the requests rejected because the ModelServers had no ready pods.
+	RouterDemandNotFound RouterDemandSignal = "NotFound"
+)
+
+// RouterDemandMetricSource defines a demand signal the routers publish, summed across the routers.
+// The signals of the ModelServers are summed for the ModelServers selecting the pods of the target, and the signals
+// of the models for the models of the ModelRoutes routing to these ModelServers. The signals respond faster than
+// the metrics of the pods after a burst of requests.
+type RouterDemandMetricSource struct {
+	// Signal defines the demand signal.
+	Signal RouterDemandSignal `json:"signal"`
 }
 
 // AutoscalingPolicyBehavior defines the scaling behavior configuration for both scale up and scale down operations.
@@ -125,6 +345,12 @@ type AutoscalingPolicyPanicPolicy struct {
 
 // AutoscalingPolicyStatus defines the observed state of AutoscalingPolicy.
 type AutoscalingPolicyStatus struct {
+	// ObservedGeneration is the generation of the policy last processed by the autoscaler.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Bindings are the names of the AutoscalingPolicyBindings referencing the policy.
+	// +optional
+	Bindings []string `json:"bindings,omitempty"`
 }
 
 // +kubebuilder:object:root=true
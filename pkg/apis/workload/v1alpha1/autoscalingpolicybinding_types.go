@@ -18,11 +18,12 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // AutoscalingPolicyBindingSpec defines the desired state of AutoscalingPolicyBinding.
-// +kubebuilder:validation:XValidation:rule="has(self.heterogeneousTarget) != has(self.homogeneousTarget)",message="Either heterogeneousTarget or homogeneousTarget must be set, but not both."
+// +kubebuilder:validation:XValidation:rule="[has(self.heterogeneousTarget), has(self.homogeneousTarget), has(self.coupledTarget)].filter(x, x).size() == 1",message="Exactly one of heterogeneousTarget, homogeneousTarget or coupledTarget must be set."
 type AutoscalingPolicyBindingSpec struct {
 	// PolicyRef references the AutoscalingPolicy that defines the scaling rules and metrics.
 	PolicyRef corev1.LocalObjectReference `json:"policyRef"`
@@ -36,6 +37,17 @@ type AutoscalingPolicyBindingSpec struct {
 	// This approach adjusts replica count based on monitoring metrics and their target values.
 	// +optional
 	HomogeneousTarget *HomogeneousTarget `json:"homogeneousTarget,omitempty"`
+
+	// CoupledTarget enables metric-based scaling of several roles of a single ModelServing together, e.g. the prefill
+	// and decode roles of a PD disaggregated ModelServing, so that the ratio of their replicas is kept.
+	// +optional
+	CoupledTarget *CoupledTarget `json:"coupledTarget,omitempty"`
+
+	// SyncPeriod is the period at which the autoscaler evaluates the binding. An evaluation which takes longer
+	// than the period is cancelled.
+	// +kubebuilder:default="15s"
+	// +optional
+	SyncPeriod *metav1.Duration `json:"syncPeriod,omitempty"`
 }
 
 // AutoscalingTargetType defines the type of target for autoscaling operations.
@@ -70,6 +82,20 @@ type HomogeneousTarget struct {
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=1000000
 	MaxReplicas int32 `json:"maxReplicas"`
+	// ScaleToZero scales the target to zero replicas once the routers received no request for its ModelServers
+	// during the idle window, and back to MinReplicas, at least one, once they receive a request again.
+	// The ModelRoutes of the ModelServers should set scaleFromZero, so that the requests are held until the
+	// target is ready.
+	// +optional
+	ScaleToZero *ScaleToZero `json:"scaleToZero,omitempty"`
+}
+
+// ScaleToZero defines when a target without requests is scaled to zero replicas.
+type ScaleToZero struct {
+	// IdleWindow is the duration without requests after which the target is scaled to zero.
+	// +optional
+	// +kubebuilder:default="5m"
+	IdleWindow *metav1.Duration `json:"idleWindow,omitempty"`
 }
 
 // HeterogeneousTarget defines the configuration for optimization-based autoscaling across multiple deployments.
@@ -82,12 +108,21 @@ type HeterogeneousTarget struct {
 	// +kubebuilder:default=200
 	// +optional
 	CostExpansionRatePercent int32 `json:"costExpansionRatePercent,omitempty"`
+	// CapacityBackoff is how long a target is capped at the replicas the cluster could schedule after some of its
+	// replicas could not be scheduled, e.g. because its GPU pool is full or the PodGroup of a ServingGroup could not
+	// be scheduled. While a target is capped, the replicas beyond the cap spill over to the next-cost targets. The
+	// cap is lifted once no replica was unschedulable for CapacityBackoff, and the replicas move back to the target.
+	// Defaults to 5m. Zero disables the caps.
+	// +optional
+	CapacityBackoff *metav1.Duration `json:"capacityBackoff,omitempty"`
 }
 
-// Target defines a ModelServing deployment that can be monitored and scaled.
+// Target defines a ModelServing, or another workload with the scale subresource, that can be monitored and scaled.
 type Target struct {
 	// TargetRef references the target object to be monitored and scaled.
-	// Default target GVK is ModelServing. Currently supported kinds: ModelServing.
+	// Default target GVK is ModelServing. Other kinds, e.g. Deployment, StatefulSet or LeaderWorkerSet, are scaled
+	// through their scale subresource, their apiVersion is required and their metric pods are selected by the
+	// selector of their scale status.
 	TargetRef corev1.ObjectReference `json:"targetRef"`
 	// SubTarget defines the sub-target object to be monitored and scaled.
 	// Currently supported kinds: `Role` when TargetRef kind is ModelServing.
@@ -122,6 +157,72 @@ type HeterogeneousTargetParam struct {
 	MaxReplicas int32 `json:"maxReplicas"`
 }
 
+// CoupledTarget defines the configuration for metric-based autoscaling of several roles of a ModelServing together.
+// The total replicas of the roles are recommended from the metrics of the pods of all the roles, then split across
+// the roles by their ratio, and the replicas of all the roles are updated in one write of the ModelServing.
+type CoupledTarget struct {
+	// Target references the ModelServing whose roles are scaled. SubTarget must not be set.
+	Target Target `json:"target"`
+	// Roles defines the roles scaled together.
+	// +kubebuilder:validation:MinItems=2
+	// +listType=map
+	// +listMapKey=name
+	Roles []CoupledRole `json:"roles"`
+	// Ratio defines how the replicas are split across the roles.
+	// +kubebuilder:default=Fixed
+	// +optional
+	Ratio CoupledRatio `json:"ratio,omitempty"`
+}
+
+// CoupledRole defines a role scaled with the other roles of a CoupledTarget.
+type CoupledRole struct {
+	// Name is the name of the role, one of `ModelServing.spec.template.roles[].name`.
+	Name string `json:"name"`
+	// Weight is the share of the replicas of the role with a Fixed ratio, e.g. 1 for prefill and 3 for decode.
+	// With a Learned ratio, it is the replicas of the role needed per token of its token type, relative to the
+	// other roles.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+	// TokenType is the type of the tokens processed by the role with a Learned ratio, Input for a prefill role
+	// and Output for a decode role.
+	// +optional
+	TokenType CoupledTokenType `json:"tokenType,omitempty"`
+	// MinReplicas defines the minimum number of replicas of the role.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=1000000
+	MinReplicas int32 `json:"minReplicas"`
+	// MaxReplicas defines the maximum number of replicas of the role.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000
+	MaxReplicas int32 `json:"maxReplicas"`
+}
+
+// CoupledRatio defines how the replicas of a CoupledTarget are split across its roles.
+// +kubebuilder:validation:Enum=Fixed;Learned
+type CoupledRatio string
+
+const (
+	// CoupledRatioFixed splits the replicas by the weights of the roles.
+	CoupledRatioFixed CoupledRatio = "Fixed"
+	// CoupledRatioLearned splits the replicas by the weights of the roles times the rates of the tokens of their
+	// token types, which the routers observe for the ModelServers selecting the pods of the ModelServing. The
+	// replicas are split by the weights alone until the routers observed tokens.
+	CoupledRatioLearned CoupledRatio = "Learned"
+)
+
+// CoupledTokenType defines the type of the tokens processed by a role.
+// +kubebuilder:validation:Enum=Input;Output
+type CoupledTokenType string
+
+const (
+	// CoupledTokenInput is the input tokens of the requests, processed by the prefill roles.
+	CoupledTokenInput CoupledTokenType = "Input"
+	// CoupledTokenOutput is the output tokens of the responses, processed by the decode roles.
+	CoupledTokenOutput CoupledTokenType = "Output"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
@@ -139,7 +240,105 @@ type AutoscalingPolicyBinding struct {
 
 // AutoscalingPolicyBindingStatus defines the observed state of AutoscalingPolicyBinding.
 type AutoscalingPolicyBindingStatus struct {
-	// Placeholder for future status fields
+	// ObservedGeneration is the generation of the binding last processed by the autoscaler.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LastScaleTime is the last time the autoscaler changed the replicas of a target.
+	// +optional
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	// Reason is the reason of the last scaling decision.
+	// +optional
+	Reason AutoscalingReason `json:"reason,omitempty"`
+	// PanicMode indicates whether the panic policy of the scale up behavior is active.
+	// +optional
+	PanicMode bool `json:"panicMode,omitempty"`
+	// ScheduledMinReplicas is the minimum replicas of the schedules of the policy active at the last evaluation.
+	// +optional
+	ScheduledMinReplicas *int32 `json:"scheduledMinReplicas,omitempty"`
+	// PredictedReplicas is the peak of the replicas forecast by the predictive policy for its lead time at the last
+	// evaluation.
+	// +optional
+	PredictedReplicas *int32 `json:"predictedReplicas,omitempty"`
+	// Targets are the current and desired replicas of each target.
+	// +optional
+	Targets []AutoscalingTargetStatus `json:"targets,omitempty"`
+	// Metrics are the last observed values of the metrics of the policy, compared to their targets.
+	// +optional
+	Metrics []AutoscalingMetricStatus `json:"metrics,omitempty"`
+	// History holds the last scale actions, the most recent first.
+	// +optional
+	// +kubebuilder:validation:MaxItems=10
+	History []AutoscalingDecision `json:"history,omitempty"`
+}
+
+// AutoscalingReason is the reason of a scaling decision.
+// +kubebuilder:validation:Enum={Recommended,WithinTolerance,Stabilized,BoundedByMinReplicas,BoundedByMaxReplicas,MetricsUnavailable,ScaledToZero,ScaledFromZero,Scheduled,Predicted,CapacityCapped}
+type AutoscalingReason string
+
+const (
+	// AutoscalingReasonRecommended means the replicas follow the recommendation of the metrics.
+	AutoscalingReasonRecommended AutoscalingReason = "Recommended"
+	// AutoscalingReasonWithinTolerance means the metrics are within the tolerance of their targets.
+	AutoscalingReasonWithinTolerance AutoscalingReason = "WithinTolerance"
+	// AutoscalingReasonStabilized means the recommendation was limited by the stabilization windows
+	// or the scaling rates of the behavior of the policy.
+	AutoscalingReasonStabilized AutoscalingReason = "Stabilized"
+	// AutoscalingReasonBoundedByMinReplicas means the recommendation was raised to the minimum replicas.
+	AutoscalingReasonBoundedByMinReplicas AutoscalingReason = "BoundedByMinReplicas"
+	// AutoscalingReasonBoundedByMaxReplicas means the recommendation was lowered to the maximum replicas.
+	AutoscalingReasonBoundedByMaxReplicas AutoscalingReason = "BoundedByMaxReplicas"
+	// AutoscalingReasonMetricsUnavailable means no metric was available, the replicas are left unchanged.
+	AutoscalingReasonMetricsUnavailable AutoscalingReason = "MetricsUnavailable"
+	// AutoscalingReasonScaledToZero means the target was idle and scaled to zero.
+	AutoscalingReasonScaledToZero AutoscalingReason = "ScaledToZero"
+	// AutoscalingReasonScaledFromZero means requests arrived for the target while it was scaled to zero.
+	AutoscalingReasonScaledFromZero AutoscalingReason = "ScaledFromZero"
+	// AutoscalingReasonScheduled means the recommendation was raised to the minimum replicas of an active schedule.
+	AutoscalingReasonScheduled AutoscalingReason = "Scheduled"
+	// AutoscalingReasonPredicted means the recommendation was raised to the replicas forecast by the predictive policy.
+	AutoscalingReasonPredicted AutoscalingReason = "Predicted"
+	// AutoscalingReasonCapacityCapped means replicas of a target of a heterogeneous target could not be scheduled,
+	// and were moved to the next-cost targets.
+	AutoscalingReasonCapacityCapped AutoscalingReason = "CapacityCapped"
+)
+
+// AutoscalingTargetStatus is the observed state of a target of a binding.
+type AutoscalingTargetStatus struct {
+	// Name is the name of the target, followed by the name of its role for role targets, e.g. my-serving/prefill.
+	Name string `json:"name"`
+	// CurrentReplicas is the number of replicas of the target.
+	CurrentReplicas int32 `json:"currentReplicas"`
+	// DesiredReplicas is the number of replicas the autoscaler wants for the target.
+	DesiredReplicas int32 `json:"desiredReplicas"`
+	// CappedReplicas is the maximum replicas of a target of a heterogeneous target while it is capped because some
+	// of its replicas could not be scheduled.
+	// +optional
+	CappedReplicas *int32 `json:"cappedReplicas,omitempty"`
+}
+
+// AutoscalingMetricStatus is the last observed value of a metric of the policy.
+type AutoscalingMetricStatus struct {
+	// MetricName is the name of the metric.
+	MetricName string `json:"metricName"`
+	// CurrentValue is the value of the metric for all the targets. For the metrics scraped from the pods,
+	// it is the sum over the ready pods, or the value of the busiest pod times the number of pods with the
+	// Max aggregation. The desired replicas are CurrentValue divided by TargetValue.
+	// It is unset when the metric could not be collected.
+	// +optional
+	CurrentValue *resource.Quantity `json:"currentValue,omitempty"`
+	// TargetValue is the target value of the metric for each replica.
+	TargetValue resource.Quantity `json:"targetValue"`
+}
+
+// AutoscalingDecision is a scale action of the autoscaler.
+type AutoscalingDecision struct {
+	// Time is the time of the scale action.
+	Time metav1.Time `json:"time"`
+	// Reason is the reason of the scaling decision.
+	Reason AutoscalingReason `json:"reason"`
+	// Message describes the scale action, e.g. the replicas of the targets before and after it.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -30,4 +30,20 @@ const (
 
 	// RevisionLabelKey is the revision label for the model serving.
 	RevisionLabelKey = "modelserving.volcano.sh/revision"
+	// RoleRevisionLabelKey is the pod label key for the revision of the role template the pod is created from.
+	RoleRevisionLabelKey = "modelserving.volcano.sh/role-revision"
+
+	// RollbackToAnnotationKey is the model serving annotation key requesting a rollback of the
+	// roles template to the given revision, which must still have a ControllerRevision. Only the ControllerRevisions
+	// of the current and the update revisions are kept, so older revisions can't be rolled back to.
+	// The annotation is removed once the rollback is applied, or once it failed with the RollbackFailed condition.
+	RollbackToAnnotationKey = "modelserving.volcano.sh/rollback-to"
+
+	// RestartGraceDeadlineAnnotationKey is the pod annotation key recording, in RFC3339 format, the end of
+	// the restart grace period of a failed pod. The pod is rebuilt if it has not recovered by then.
+	RestartGraceDeadlineAnnotationKey = "modelserving.volcano.sh/restart-grace-deadline"
+
+	// InPlaceUpdateStateAnnotationKey is the pod annotation key recording the state of the last in-place update
+	// of the pod, which is used to tell the container restarts caused by the update apart from failures.
+	InPlaceUpdateStateAnnotationKey = "modelserving.volcano.sh/in-place-update-state"
 )
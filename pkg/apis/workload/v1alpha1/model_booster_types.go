@@ -20,10 +20,12 @@ import (
 	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ModelBoosterSpec defines the desired state of ModelBooster.
+// +kubebuilder:validation:XValidation:rule="has(self.backend) != has(self.backends)",message="Either backend or backends must be set, but not both."
 type ModelBoosterSpec struct {
 	// Name is the name of the model. ModelBooster CR name is restricted by kubernetes, for example, can't contain uppercase letters.
 	// So we use this field to specify the ModelBooster name.
@@ -36,7 +38,17 @@ type ModelBoosterSpec struct {
 	Owner string `json:"owner,omitempty"`
 	// Backend is the model backend associated with this model.
 	// ModelBackend is the minimum unit of inference instance. It can be vLLM or vLLMDisaggregated.
-	Backend ModelBackend `json:"backend"`
+	// +optional
+	Backend *ModelBackend `json:"backend,omitempty"`
+	// Backends is the list of model backends serving this model, e.g. on different hardware.
+	// Each backend gets its own ModelServing and ModelServer, and requests are split across them by weight.
+	// With AutoscalingPolicy set, the backends are scaled together, preferring the backends with lower cost.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Backends []ModelBackend `json:"backends,omitempty"`
 	// AutoscalingPolicy references the autoscaling policy to be used for this model.
 	// +optional
 	AutoscalingPolicy *AutoscalingPolicySpec `json:"autoscalingPolicy,omitempty"`
@@ -48,6 +60,7 @@ type ModelBoosterSpec struct {
 }
 
 // ModelBackend defines the configuration for a model backend.
+// +kubebuilder:validation:XValidation:rule="has(self.modelURI) != has(self.artifact)",message="Either modelURI or artifact must be set, but not both."
 type ModelBackend struct {
 	// Name is the name of the backend. Can't duplicate with other ModelBackend name in the same ModelBooster CR.
 	// Note: update name will cause the old modelInfer deletion and a new modelInfer creation.
@@ -56,8 +69,13 @@ type ModelBackend struct {
 	// Type is the type of the backend.
 	Type ModelBackendType `json:"type"`
 	// ModelURI is the URI where you download the model. Support hf://, s3://, pvc://.
+	// Use Artifact instead to pin the revision, take credentials from a Secret or verify the model files.
+	// +optional
 	// +kubebuilder:validation:Pattern=`^(hf://|s3://|pvc://).+`
-	ModelURI string `json:"modelURI"`
+	ModelURI string `json:"modelURI,omitempty"`
+	// Artifact is the typed source of the model files, with optional integrity verification.
+	// +optional
+	Artifact *ModelArtifact `json:"artifact,omitempty"`
 	// CacheURI is the URI where the downloaded model stored. Support hostpath://, pvc://.
 	// +kubebuilder:validation:Pattern=`^(hostpath://|pvc://).+`
 	CacheURI string `json:"cacheURI,omitempty"`
@@ -100,10 +118,176 @@ type ModelBackend struct {
 	// SchedulerName defines the name of the scheduler used by ModelServing for this backend.
 	// +optional
 	SchedulerName string `json:"schedulerName,omitempty"`
+	// Cost is the relative cost of one replica of this backend. Only used when the ModelBooster has multiple backends
+	// and AutoscalingPolicy is set, the autoscaler then prefers to scale the backends with lower cost.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Cost int32 `json:"cost,omitempty"`
+	// Weight is the relative share of requests routed to this backend. Only used when the ModelBooster has multiple backends.
+	// It must be set on either all or none of the backends, and must not be zero on all of them. If it is unset, requests
+	// are split evenly. The weights are static, so with a heterogeneous autoscaling policy they should follow the share
+	// of the replicas the backends are expected to run, which the autoscaler splits by their Cost.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *uint32 `json:"weight,omitempty"`
+	// LoraAdapters is the list of LoRA adapters loaded dynamically on the serving pods of this backend.
+	// Adapters are loaded through the runtime sidecar and reloaded when a pod restarts. Only supported by vLLM backends,
+	// which must set VLLM_ALLOW_RUNTIME_LORA_UPDATING=True and enable LoRA in the worker config.
+	// Updating the adapters doesn't restart the serving pods, except declaring the first adapter, which mounts the cache
+	// into the runtime sidecar. Requests for an adapter are routed to the backends declaring it.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=64
+	LoraAdapters []LoraAdapter `json:"loraAdapters,omitempty"`
+	// CacheWarming pre-downloads the model into the cache before the serving pods need it, so that cold starts
+	// and scale-ups don't wait for the download. Requires CacheURI, and a model downloaded into the cache.
+	// +optional
+	CacheWarming *CacheWarming `json:"cacheWarming,omitempty"`
+}
+
+// CacheWarming defines how the model cache of a backend is pre-populated.
+// With a hostpath:// cache, a DaemonSet downloads the model on every node of the pool, the nodes holding the model
+// are labelled, and the serving pods prefer these nodes. With a pvc:// cache, a Job downloads the model once.
+type CacheWarming struct {
+	// NodeSelector selects the node pool to warm. Only used with a hostpath:// cache.
+	// Defaults to all the nodes the warming pods tolerate.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the warming pods.
+	// +optional
+	// +listType=atomic
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// AffinityWeight is the weight of the preferred node affinity of the serving pods to the warm nodes.
+	// Only used with a hostpath:// cache.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=100
+	// +optional
+	AffinityWeight int32 `json:"affinityWeight,omitempty"`
+}
+
+// LoraAdapter defines a LoRA adapter served on top of the base model.
+type LoraAdapter struct {
+	// Name is the name of the adapter. Requests with this model name are served by the adapter.
+	// +kubebuilder:validation:MaxLength=256
+	Name string `json:"name"`
+	// ArtifactURL is the URL where the adapter is downloaded from. Support hf://, s3://, pvc://.
+	// The adapter is downloaded into the cache of the backend.
+	// +kubebuilder:validation:Pattern=`^(hf://|s3://|pvc://).+`
+	ArtifactURL string `json:"artifactURL"`
+}
+
+// ModelArtifact defines where the model files come from. Exactly one source must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.huggingFace), has(self.s3), has(self.oci), has(self.existingPVC), has(self.volumeSnapshot)].filter(x, x).size() == 1",message="Exactly one of huggingFace, s3, oci, existingPVC and volumeSnapshot must be set."
+type ModelArtifact struct {
+	// HuggingFace downloads the model from a Hugging Face repository into the cache.
+	// +optional
+	HuggingFace *HuggingFaceArtifactSource `json:"huggingFace,omitempty"`
+	// S3 downloads the model from an S3 compatible object storage, e.g. OBS, into the cache.
+	// +optional
+	S3 *S3ArtifactSource `json:"s3,omitempty"`
+	// OCI mounts the model from an OCI image or artifact as a read-only image volume.
+	// The ImageVolume feature must be enabled in the cluster.
+	// +optional
+	OCI *OCIArtifactSource `json:"oci,omitempty"`
+	// ExistingPVC mounts the model read-only from an existing PersistentVolumeClaim which already holds the model.
+	// The claim is neither created nor populated by the controller.
+	// +optional
+	ExistingPVC *ExistingPVCArtifactSource `json:"existingPVC,omitempty"`
+	// VolumeSnapshot mounts the model read-only from a PersistentVolumeClaim which the controller creates from
+	// a pre-populated VolumeSnapshot. The CSI driver of the storage class must support restoring snapshots.
+	// +optional
+	VolumeSnapshot *VolumeSnapshotArtifactSource `json:"volumeSnapshot,omitempty"`
+	// Verification verifies the model files before the inference engine starts.
+	// +optional
+	Verification *ArtifactVerification `json:"verification,omitempty"`
+}
+
+// HuggingFaceArtifactSource defines a model hosted in a Hugging Face repository.
+type HuggingFaceArtifactSource struct {
+	// Repository is the repository id, in the format of <namespace>/<repo_name>.
+	// +kubebuilder:validation:Pattern=`^[^/]+/[^/]+$`
+	Repository string `json:"repository"`
+	// Revision is the branch, tag or commit hash to download. Pin it to a commit hash for reproducible deployments.
+	// Defaults to the main branch.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+	// Endpoint is the url of Hugging Face. Defaults to https://huggingface.co/.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// TokenSecretRef selects the key of a Secret holding the token to access private repositories.
+	// +optional
+	TokenSecretRef *corev1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+}
+
+// S3ArtifactSource defines a model stored in an S3 compatible object storage.
+type S3ArtifactSource struct {
+	// URI is the location of the model, in the format of s3://<bucket>/<path> or obs://<bucket>/<path>.
+	// +kubebuilder:validation:Pattern=`^(s3://|obs://).+`
+	URI string `json:"uri"`
+	// Endpoint is the endpoint of the object storage service.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialsSecretRef references the Secret holding the ACCESS_KEY and SECRET_KEY of the object storage.
+	// +optional
+	CredentialsSecretRef *corev1.LocalObjectReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// OCIArtifactSource defines a model packaged as an OCI image or artifact.
+type OCIArtifactSource struct {
+	// Image is the image reference. Pin it to a digest for reproducible deployments.
+	// +kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// PullPolicy is the policy for pulling the image. Defaults to Always for the latest tag, IfNotPresent otherwise.
+	// +optional
+	PullPolicy corev1.PullPolicy `json:"pullPolicy,omitempty"`
+	// Path is the directory of the model files in the image. Defaults to the root of the image.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// ExistingPVCArtifactSource defines a model stored in an existing PersistentVolumeClaim.
+type ExistingPVCArtifactSource struct {
+	// ClaimName is the name of the existing PersistentVolumeClaim in the namespace of the ModelBooster.
+	// +kubebuilder:validation:MinLength=1
+	ClaimName string `json:"claimName"`
+	// Path is the directory of the model files in the volume. Defaults to the root of the volume.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// VolumeSnapshotArtifactSource defines a model stored in a VolumeSnapshot, restored into a new PersistentVolumeClaim
+// owned by the ModelBooster. The claim is replaced when the source changes.
+type VolumeSnapshotArtifactSource struct {
+	// SnapshotName is the name of the VolumeSnapshot in the namespace of the ModelBooster.
+	// +kubebuilder:validation:MinLength=1
+	SnapshotName string `json:"snapshotName"`
+	// Size is the requested size of the PersistentVolumeClaim, at least the restore size of the snapshot.
+	Size resource.Quantity `json:"size"`
+	// StorageClassName is the storage class of the PersistentVolumeClaim. Defaults to the default storage class.
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// AccessModes are the access modes of the PersistentVolumeClaim. Defaults to ReadOnlyMany, so that the
+	// serving pods on different nodes share it.
+	// +optional
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// Path is the directory of the model files in the volume. Defaults to the root of the volume.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// ArtifactVerification defines how the model files are verified.
+type ArtifactVerification struct {
+	// SHA256Manifest selects the key of a ConfigMap holding the expected SHA256 checksums of the model files,
+	// in the output format of sha256sum: "<sha256>  <path relative to the model directory>" per line.
+	// The serving pods fail to start if any listed file is missing or has a different checksum.
+	SHA256Manifest corev1.ConfigMapKeySelector `json:"sha256Manifest"`
 }
 
 // ModelBackendType defines the type of model backend.
-// +kubebuilder:validation:Enum=vLLM;vLLMDisaggregated
+// +kubebuilder:validation:Enum=vLLM;vLLMDisaggregated;SGLang;MindIE
 type ModelBackendType string
 
 const (
@@ -172,14 +356,82 @@ type ModelStatus struct {
 	// ObservedGeneration track of generation
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// LoraAdapters is the load status of the LoRA adapters on the serving pods.
+	// +optional
+	LoraAdapters []LoraAdapterStatus `json:"loraAdapters,omitempty"`
+	// CacheWarming is the status of the model cache warming of the backends.
+	// +optional
+	// +listType=map
+	// +listMapKey=backendName
+	CacheWarming []CacheWarmingStatus `json:"cacheWarming,omitempty"`
+}
+
+// CacheWarmingStatus is the status of the model cache warming of a backend.
+type CacheWarmingStatus struct {
+	// BackendName is the name of the backend.
+	BackendName string `json:"backendName"`
+	// CacheKey identifies the model revision in the cache. Nodes holding it are labelled with
+	// workload.serving.volcano.sh/model-cache-<cacheKey>.
+	CacheKey string `json:"cacheKey"`
+	// DesiredNodes is the number of nodes to warm. It is 1 for a pvc:// cache, which is shared by all the nodes.
+	DesiredNodes int32 `json:"desiredNodes"`
+	// WarmNodes is the number of nodes holding the model.
+	WarmNodes int32 `json:"warmNodes"`
+}
+
+// LoraAdapterStatus is the load status of a LoRA adapter of a backend.
+type LoraAdapterStatus struct {
+	// Name is the name of the adapter.
+	Name string `json:"name"`
+	// BackendName is the name of the backend the adapter belongs to.
+	BackendName string `json:"backendName"`
+	// ArtifactURL is the URL the adapter was loaded from.
+	ArtifactURL string `json:"artifactURL"`
+	// Pods is the load status of the adapter on each ready serving pod.
+	// +optional
+	Pods []LoraAdapterPodStatus `json:"pods,omitempty"`
+}
+
+// LoraAdapterPodStatus is the load status of a LoRA adapter on a pod.
+type LoraAdapterPodStatus struct {
+	// Name is the name of the pod.
+	Name string `json:"name"`
+	// UID is the UID of the pod.
+	UID string `json:"uid"`
+	// RestartCount is the total restart count of the pod containers when the adapter was loaded.
+	// The adapter is loaded again when the pod restarts.
+	RestartCount int32 `json:"restartCount"`
+	// State is the load state of the adapter on the pod.
+	State LoraAdapterState `json:"state"`
+	// Message is a human-readable message about the last load or unload failure.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
+// LoraAdapterState is the load state of a LoRA adapter on a pod.
+type LoraAdapterState string
+
+const (
+	// LoraAdapterLoaded means the adapter is loaded on the pod.
+	LoraAdapterLoaded LoraAdapterState = "Loaded"
+	// LoraAdapterFailed means the adapter failed to load on the pod.
+	LoraAdapterFailed LoraAdapterState = "Failed"
+	// LoraAdapterUnloadFailed means the adapter, removed or replaced, failed to unload from the pod. The unload is
+	// retried, and a replaced adapter is only loaded again once the old one is unloaded.
+	LoraAdapterUnloadFailed LoraAdapterState = "UnloadFailed"
+)
+
 type ModelStatusConditionType string
 
 const (
 	ModelStatusConditionTypeInitialized ModelStatusConditionType = "Initialized"
 	ModelStatusConditionTypeActive      ModelStatusConditionType = "Active"
 	ModelStatusConditionTypeFailed      ModelStatusConditionType = "Failed"
+	// ModelStatusConditionTypeArtifactReady reports on how many serving pods the model files are downloaded and
+	// verified, and why it failed. The progress of a download is in the logs of the downloader init container.
+	ModelStatusConditionTypeArtifactReady ModelStatusConditionType = "ModelArtifactReady"
+	// ModelStatusConditionTypeDryRun reports the changes a dry run of the model would apply.
+	ModelStatusConditionTypeDryRun ModelStatusConditionType = "DryRun"
 )
 
 // +kubebuilder:object:root=true
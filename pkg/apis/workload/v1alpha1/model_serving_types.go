@@ -63,17 +63,81 @@ type ModelServingSpec struct {
 	// +kubebuilder:validation:Enum={ServingGroupRecreate,RoleRecreate,None}
 	// +optional
 	RecoveryPolicy RecoveryPolicy `json:"recoveryPolicy,omitempty"`
+
+	// RestartBudget limits how often a ServingGroup is recovered. A ServingGroup that would exceed it
+	// is marked Failed and is no longer recovered, so that it can be inspected.
+	// By default, ServingGroups are recovered without limit.
+	// +optional
+	RestartBudget *RestartBudget `json:"restartBudget,omitempty"`
+
+	// ProgressDeadlineSeconds is the maximum time in seconds for a rolling update to make progress
+	// before it is considered failed. Progress means that a ServingGroup of the update revision is
+	// created or that a ServingGroup becomes available. When the deadline is exceeded, the Progressing
+	// condition is set to False with reason ProgressDeadlineExceeded.
+	// By default, no deadline is applied.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
 }
 
 type RecoveryPolicy string
 
+// RestartBudget defines the maximum number of recreations of a ServingGroup within a time window.
+type RestartBudget struct {
+	// MaxRecreations is the maximum number of times a ServingGroup, or any of its roles or pods,
+	// is recreated by the RecoveryPolicy within WindowSeconds.
+	// +kubebuilder:validation:Minimum=1
+	MaxRecreations int32 `json:"maxRecreations"`
+
+	// WindowSeconds is the length in seconds of the sliding window the recreations are counted in.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=3600
+	// +optional
+	WindowSeconds int32 `json:"windowSeconds,omitempty"`
+}
+
 // PluginType represents the implementation category of a plugin.
 type PluginType string
 
 const (
+	// PluginTypeBuiltIn plugins are compiled into the controller and looked up by name.
 	PluginTypeBuiltIn PluginType = "BuiltIn"
+	// PluginTypeWebhook plugins call an HTTP endpoint with the hook request, and apply the JSON patch it returns.
+	PluginTypeWebhook PluginType = "Webhook"
 )
 
+// WebhookFailurePolicy defines how the errors of a webhook plugin are handled.
+type WebhookFailurePolicy string
+
+const (
+	// WebhookFailurePolicyFail fails the hook, so the pod is not created.
+	WebhookFailurePolicyFail WebhookFailurePolicy = "Fail"
+	// WebhookFailurePolicyIgnore ignores the error, so the pod is created without the patch.
+	WebhookFailurePolicyIgnore WebhookFailurePolicy = "Ignore"
+)
+
+// WebhookPluginConfig defines the endpoint of a webhook plugin.
+type WebhookPluginConfig struct {
+	// URL is the endpoint receiving the hook requests, e.g. https://pod-tuner.kthena-system.svc/hooks.
+	// +kubebuilder:validation:Pattern=`^https?://.+`
+	URL string `json:"url"`
+	// CABundle is the PEM encoded CA bundle used to verify the certificate of an https endpoint.
+	// Defaults to the system trust roots.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+	// TimeoutSeconds is the timeout of a hook request.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=30
+	// +kubebuilder:default=10
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// FailurePolicy defines how the errors of the endpoint are handled.
+	// +kubebuilder:validation:Enum={Fail,Ignore}
+	// +kubebuilder:default=Fail
+	// +optional
+	FailurePolicy WebhookFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
 // PluginTarget specifies which pod kinds a plugin applies to.
 // If empty, it defaults to All.
 type PluginTarget string
@@ -98,16 +162,23 @@ type PluginScope struct {
 }
 
 // PluginSpec declares a plugin instance attached to a ModelServing.
+// +kubebuilder:validation:XValidation:rule="(self.type == 'Webhook') == has(self.webhook)",message="webhook must be set if and only if type is Webhook"
 type PluginSpec struct {
 	// Name uniquely identifies the plugin instance within the ModelServing.
+	// For BuiltIn plugins, it is the name of the plugin in the registry.
 	Name string `json:"name"`
-	// Type indicates plugin category. For now, only BuiltIn is supported.
+	// Type indicates plugin category.
+	// BuiltIn plugins are compiled into the controller, Webhook plugins call the endpoint in Webhook.
 	// +kubebuilder:default=BuiltIn
-	// +kubebuilder:validation:Enum={BuiltIn}
+	// +kubebuilder:validation:Enum={BuiltIn,Webhook}
 	Type PluginType `json:"type"`
 	// Config is an opaque JSON blob interpreted by the plugin implementation.
+	// It is passed as is to the endpoint of Webhook plugins.
 	// +optional
 	Config *apiextensionsv1.JSON `json:"config,omitempty"`
+	// Webhook is the endpoint of a Webhook plugin.
+	// +optional
+	Webhook *WebhookPluginConfig `json:"webhook,omitempty"`
 	// Scope optionally narrows where this plugin runs.
 	// By default, it runs on all pods.
 	// +optional
@@ -133,15 +204,43 @@ const (
 // RolloutStrategy defines the strategy that the ModelServing controller
 // will use to perform replica updates.
 type RolloutStrategy struct {
-	// Type defines the rollout strategy, it can only be “ServingGroupRollingUpdate” for now.
+	// Type defines the rollout strategy, it can be “ServingGroupRollingUpdate” or “RoleRollingUpdate”.
 	//
-	// +kubebuilder:validation:Enum={ServingGroupRollingUpdate}
+	// +kubebuilder:validation:Enum={ServingGroupRollingUpdate,RoleRollingUpdate}
 	// +kubebuilder:default=ServingGroupRollingUpdate
 	Type RolloutStrategyType `json:"type"`
 
+	// PodUpdatePolicy defines how the pods of the roles whose template changed are updated.
+	// With InPlaceIfPossible, the pods are patched in place when only the container images or the labels
+	// and annotations of the pod templates changed, and are recreated otherwise.
+	// +kubebuilder:validation:Enum={Recreate,InPlaceIfPossible}
+	// +kubebuilder:default=Recreate
+	// +optional
+	PodUpdatePolicy PodUpdatePolicyType `json:"podUpdatePolicy,omitempty"`
+
 	// RollingUpdateConfiguration defines the parameters to be used when type is RollingUpdateStrategyType.
 	// optional
 	RollingUpdateConfiguration *RollingUpdateConfiguration `json:"rollingUpdateConfiguration,omitempty"`
+
+	// RollbackPolicy defines when a rolling update is considered failed and whether it is rolled back automatically.
+	// +optional
+	RollbackPolicy *RollbackPolicy `json:"rollbackPolicy,omitempty"`
+}
+
+// RollbackPolicy defines the failure detection and rollback behavior of a rolling update.
+type RollbackPolicy struct {
+	// FailureThreshold is the number of pod failures of the update revision, counted as failed pods
+	// and restarted containers, after which the rolling update is considered failed. The Progressing
+	// condition is then set to False with reason FailureThresholdExceeded.
+	// By default, pod failures do not fail the rolling update.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+
+	// AutoRollback indicates whether a failed rolling update, either because the progress deadline
+	// or the failure threshold is exceeded, is rolled back to the CurrentRevision automatically.
+	// +optional
+	AutoRollback bool `json:"autoRollback,omitempty"`
 }
 
 type RolloutStrategyType string
@@ -149,6 +248,22 @@ type RolloutStrategyType string
 const (
 	// ServingGroupRollingUpdate indicates that ServingGroup replicas will be updated one by one.
 	ServingGroupRollingUpdate RolloutStrategyType = "ServingGroupRollingUpdate"
+
+	// RoleRollingUpdate indicates that ServingGroup replicas will be updated one by one, but only the roles
+	// whose template changed are replaced. The other roles of the ServingGroup keep running.
+	RoleRollingUpdate RolloutStrategyType = "RoleRollingUpdate"
+)
+
+// PodUpdatePolicyType defines how the pods of an updated role are updated.
+type PodUpdatePolicyType string
+
+const (
+	// RecreatePodUpdatePolicy indicates that the pods of an updated role are deleted and created again.
+	RecreatePodUpdatePolicy PodUpdatePolicyType = "Recreate"
+
+	// InPlaceIfPossiblePodUpdatePolicy indicates that the pods of an updated role are patched in place when
+	// only the container images or the labels and annotations of the pod templates changed.
+	InPlaceIfPossiblePodUpdatePolicy PodUpdatePolicyType = "InPlaceIfPossible"
 )
 
 // RollingUpdateConfiguration defines the parameters to be used for RollingUpdateStrategyType.
@@ -156,12 +271,24 @@ type RollingUpdateConfiguration struct {
 	// The maximum number of replicas that can be unavailable during the update.
 	// Value can be an absolute number (ex: 5) or a percentage of total replicas at the start of update (ex: 10%).
 	// Absolute number is calculated from percentage by rounding down.
-	// This can not be 0.
+	// This can not be 0 if MaxSurge is 0.
 	// By default, a fixed value of 1 is used.
 	// +kubebuilder:validation:XIntOrString
 	// +kubebuilder:default=1
 	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 
+	// The maximum number of ServingGroups that can be created above the desired replicas during the update.
+	// Value can be an absolute number (ex: 5) or a percentage of total replicas at the start of update (ex: 10%).
+	// Absolute number is calculated from percentage by rounding up.
+	// Surge ServingGroups are created with the new revision at ordinals outside [0, replicas),
+	// outdated ServingGroups are deleted once enough new ServingGroups are running, and the ordinals
+	// are compacted back into [0, replicas) after the update completes.
+	// Combined with MaxUnavailable=0, outdated ServingGroups are only deleted after their replacements are running.
+	// By default, a fixed value of 0 is used.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
 	// Partition indicates the ordinal at which the ModelServing should be partitioned
 	// for updates. During a rolling update, all ServingGroups from ordinal Replicas-1 to
 	// Partition are updated. All ServingGroups from ordinal Partition-1 to 0 remain untouched.
@@ -188,6 +315,22 @@ const (
 	// When the entry or worker template is updated, modelServing controller enters the upgrade process and
 	// UpdateInProgress is set to true.
 	ModelServingUpdateInProgress ModelServingConditionType = "UpdateInProgress"
+
+	// ModelServingRollbackFailed indicates that the roles template could not be rolled back, because the
+	// ControllerRevision of the revision to roll back to is not found. It is cleared once the spec changes.
+	ModelServingRollbackFailed ModelServingConditionType = "RollbackFailed"
+)
+
+const (
+	// ProgressDeadlineExceededReason is the reason of the Progressing condition when the rolling update
+	// did not make progress within ProgressDeadlineSeconds.
+	ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+	// FailureThresholdExceededReason is the reason of the Progressing condition when the pods of the
+	// update revision failed more often than RollbackPolicy.FailureThreshold.
+	FailureThresholdExceededReason = "FailureThresholdExceeded"
+	// RollbackRevisionNotFoundReason is the reason of the RollbackFailed condition when the ControllerRevision
+	// of the revision to roll back to is not found.
+	RollbackRevisionNotFoundReason = "RollbackRevisionNotFound"
 )
 
 // ModelServingStatus defines the observed state of ModelServing
@@ -224,6 +367,43 @@ type ModelServingStatus struct {
 
 	// LabelSelector is a label query over pods that should match the replica count.
 	LabelSelector string `json:"labelSelector,omitempty"`
+
+	// LastProgressTime is the last time the rolling update to UpdateRevision made progress.
+	// It is only set while a rolling update is in progress.
+	// +optional
+	LastProgressTime *metav1.Time `json:"lastProgressTime,omitempty"`
+
+	// UpdateFailures is the number of pod failures observed for UpdateRevision during the rolling update.
+	// +optional
+	UpdateFailures int32 `json:"updateFailures,omitempty"`
+
+	// ServingGroupRecoveries track the restart budget of the ServingGroups that have been recreated
+	// within the RestartBudget window or that have exhausted it.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	ServingGroupRecoveries []ServingGroupRecoveryStatus `json:"servingGroupRecoveries,omitempty"`
+}
+
+// ServingGroupRecoveryStatus is the restart budget state of a ServingGroup.
+type ServingGroupRecoveryStatus struct {
+	// Name is the name of the ServingGroup.
+	Name string `json:"name"`
+
+	// Revision is the revision of the ServingGroup the recreations are counted for.
+	// The budget is reset when the ServingGroup is updated to another revision.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// RecreationTimes are the times the ServingGroup, or one of its roles or pods, was recreated
+	// within the RestartBudget window.
+	// +optional
+	RecreationTimes []metav1.Time `json:"recreationTimes,omitempty"`
+
+	// Failed indicates that the ServingGroup exhausted its restart budget. It is no longer recovered
+	// until it becomes ready again, is updated, or all its pods are deleted.
+	// +optional
+	Failed bool `json:"failed,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -77,6 +77,13 @@ type Role struct {
 	// WorkerTemplate defines the template for the worker pod of a role.
 	// +optional
 	WorkerTemplate *PodTemplateSpec `json:"workerTemplate,omitempty"`
+
+	// RecoveryPolicy overrides the RecoveryPolicy of the ModelServing for the pods of this role.
+	// For example, a coordinator role can recreate the whole ServingGroup on failure while
+	// the other roles are recreated alone. Changing it does not update the pods.
+	// +kubebuilder:validation:Enum={ServingGroupRecreate,RoleRecreate,None}
+	// +optional
+	RecoveryPolicy *RecoveryPolicy `json:"recoveryPolicy,omitempty"`
 }
 
 // PodTemplateSpec describes the data a pod should have when created from a template
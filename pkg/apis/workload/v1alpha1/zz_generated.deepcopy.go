@@ -30,13 +30,66 @@ import (
 	"volcano.sh/apis/pkg/apis/scheduling/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactVerification) DeepCopyInto(out *ArtifactVerification) {
+	*out = *in
+	in.SHA256Manifest.DeepCopyInto(&out.SHA256Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactVerification.
+func (in *ArtifactVerification) DeepCopy() *ArtifactVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingDecision) DeepCopyInto(out *AutoscalingDecision) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingDecision.
+func (in *AutoscalingDecision) DeepCopy() *AutoscalingDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingMetricStatus) DeepCopyInto(out *AutoscalingMetricStatus) {
+	*out = *in
+	if in.CurrentValue != nil {
+		in, out := &in.CurrentValue, &out.CurrentValue
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	out.TargetValue = in.TargetValue.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingMetricStatus.
+func (in *AutoscalingMetricStatus) DeepCopy() *AutoscalingMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalingPolicy) DeepCopyInto(out *AutoscalingPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicy.
@@ -80,7 +133,7 @@ func (in *AutoscalingPolicyBinding) DeepCopyInto(out *AutoscalingPolicyBinding)
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyBinding.
@@ -147,6 +200,16 @@ func (in *AutoscalingPolicyBindingSpec) DeepCopyInto(out *AutoscalingPolicyBindi
 		*out = new(HomogeneousTarget)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.CoupledTarget != nil {
+		in, out := &in.CoupledTarget, &out.CoupledTarget
+		*out = new(CoupledTarget)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyBindingSpec.
@@ -162,6 +225,41 @@ func (in *AutoscalingPolicyBindingSpec) DeepCopy() *AutoscalingPolicyBindingSpec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalingPolicyBindingStatus) DeepCopyInto(out *AutoscalingPolicyBindingStatus) {
 	*out = *in
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ScheduledMinReplicas != nil {
+		in, out := &in.ScheduledMinReplicas, &out.ScheduledMinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PredictedReplicas != nil {
+		in, out := &in.PredictedReplicas, &out.PredictedReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]AutoscalingTargetStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]AutoscalingMetricStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AutoscalingDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyBindingStatus.
@@ -210,6 +308,11 @@ func (in *AutoscalingPolicyList) DeepCopyObject() runtime.Object {
 func (in *AutoscalingPolicyMetric) DeepCopyInto(out *AutoscalingPolicyMetric) {
 	*out = *in
 	out.TargetValue = in.TargetValue.DeepCopy()
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(MetricSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyMetric.
@@ -281,6 +384,16 @@ func (in *AutoscalingPolicySpec) DeepCopyInto(out *AutoscalingPolicySpec) {
 		}
 	}
 	in.Behavior.DeepCopyInto(&out.Behavior)
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = make([]AutoscalingSchedule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Predictive != nil {
+		in, out := &in.Predictive, &out.Predictive
+		*out = new(PredictivePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicySpec.
@@ -331,6 +444,11 @@ func (in *AutoscalingPolicyStablePolicy) DeepCopy() *AutoscalingPolicyStablePoli
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AutoscalingPolicyStatus) DeepCopyInto(out *AutoscalingPolicyStatus) {
 	*out = *in
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingPolicyStatus.
@@ -343,6 +461,157 @@ func (in *AutoscalingPolicyStatus) DeepCopy() *AutoscalingPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSchedule) DeepCopyInto(out *AutoscalingSchedule) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSchedule.
+func (in *AutoscalingSchedule) DeepCopy() *AutoscalingSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingTargetStatus) DeepCopyInto(out *AutoscalingTargetStatus) {
+	*out = *in
+	if in.CappedReplicas != nil {
+		in, out := &in.CappedReplicas, &out.CappedReplicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingTargetStatus.
+func (in *AutoscalingTargetStatus) DeepCopy() *AutoscalingTargetStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingTargetStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheWarming) DeepCopyInto(out *CacheWarming) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheWarming.
+func (in *CacheWarming) DeepCopy() *CacheWarming {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheWarming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CacheWarmingStatus) DeepCopyInto(out *CacheWarmingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CacheWarmingStatus.
+func (in *CacheWarmingStatus) DeepCopy() *CacheWarmingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CacheWarmingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoupledRole) DeepCopyInto(out *CoupledRole) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoupledRole.
+func (in *CoupledRole) DeepCopy() *CoupledRole {
+	if in == nil {
+		return nil
+	}
+	out := new(CoupledRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CoupledTarget) DeepCopyInto(out *CoupledTarget) {
+	*out = *in
+	in.Target.DeepCopyInto(&out.Target)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]CoupledRole, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoupledTarget.
+func (in *CoupledTarget) DeepCopy() *CoupledTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(CoupledTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingPVCArtifactSource) DeepCopyInto(out *ExistingPVCArtifactSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingPVCArtifactSource.
+func (in *ExistingPVCArtifactSource) DeepCopy() *ExistingPVCArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingPVCArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalMetricSource) DeepCopyInto(out *ExternalMetricSource) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalMetricSource.
+func (in *ExternalMetricSource) DeepCopy() *ExternalMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GangPolicy) DeepCopyInto(out *GangPolicy) {
 	*out = *in
@@ -375,6 +644,11 @@ func (in *HeterogeneousTarget) DeepCopyInto(out *HeterogeneousTarget) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.CapacityBackoff != nil {
+		in, out := &in.CapacityBackoff, &out.CapacityBackoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeterogeneousTarget.
@@ -407,6 +681,11 @@ func (in *HeterogeneousTargetParam) DeepCopy() *HeterogeneousTargetParam {
 func (in *HomogeneousTarget) DeepCopyInto(out *HomogeneousTarget) {
 	*out = *in
 	in.Target.DeepCopyInto(&out.Target)
+	if in.ScaleToZero != nil {
+		in, out := &in.ScaleToZero, &out.ScaleToZero
+		*out = new(ScaleToZero)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HomogeneousTarget.
@@ -419,6 +698,76 @@ func (in *HomogeneousTarget) DeepCopy() *HomogeneousTarget {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HuggingFaceArtifactSource) DeepCopyInto(out *HuggingFaceArtifactSource) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HuggingFaceArtifactSource.
+func (in *HuggingFaceArtifactSource) DeepCopy() *HuggingFaceArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(HuggingFaceArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoraAdapter) DeepCopyInto(out *LoraAdapter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoraAdapter.
+func (in *LoraAdapter) DeepCopy() *LoraAdapter {
+	if in == nil {
+		return nil
+	}
+	out := new(LoraAdapter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoraAdapterPodStatus) DeepCopyInto(out *LoraAdapterPodStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoraAdapterPodStatus.
+func (in *LoraAdapterPodStatus) DeepCopy() *LoraAdapterPodStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoraAdapterPodStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoraAdapterStatus) DeepCopyInto(out *LoraAdapterStatus) {
+	*out = *in
+	if in.Pods != nil {
+		in, out := &in.Pods, &out.Pods
+		*out = make([]LoraAdapterPodStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoraAdapterStatus.
+func (in *LoraAdapterStatus) DeepCopy() *LoraAdapterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(LoraAdapterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metadata) DeepCopyInto(out *Metadata) {
 	*out = *in
@@ -468,9 +817,99 @@ func (in *MetricEndpoint) DeepCopy() *MetricEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricSource) DeepCopyInto(out *MetricSource) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(PodMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Prometheus != nil {
+		in, out := &in.Prometheus, &out.Prometheus
+		*out = new(PrometheusMetricSource)
+		**out = **in
+	}
+	if in.External != nil {
+		in, out := &in.External, &out.External
+		*out = new(ExternalMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouterAggregate != nil {
+		in, out := &in.RouterAggregate, &out.RouterAggregate
+		*out = new(RouterAggregateMetricSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouterDemand != nil {
+		in, out := &in.RouterDemand, &out.RouterDemand
+		*out = new(RouterDemandMetricSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricSource.
+func (in *MetricSource) DeepCopy() *MetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelArtifact) DeepCopyInto(out *ModelArtifact) {
+	*out = *in
+	if in.HuggingFace != nil {
+		in, out := &in.HuggingFace, &out.HuggingFace
+		*out = new(HuggingFaceArtifactSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(S3ArtifactSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(OCIArtifactSource)
+		**out = **in
+	}
+	if in.ExistingPVC != nil {
+		in, out := &in.ExistingPVC, &out.ExistingPVC
+		*out = new(ExistingPVCArtifactSource)
+		**out = **in
+	}
+	if in.VolumeSnapshot != nil {
+		in, out := &in.VolumeSnapshot, &out.VolumeSnapshot
+		*out = new(VolumeSnapshotArtifactSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ArtifactVerification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelArtifact.
+func (in *ModelArtifact) DeepCopy() *ModelArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelBackend) DeepCopyInto(out *ModelBackend) {
 	*out = *in
+	if in.Artifact != nil {
+		in, out := &in.Artifact, &out.Artifact
+		*out = new(ModelArtifact)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.EnvFrom != nil {
 		in, out := &in.EnvFrom, &out.EnvFrom
 		*out = make([]corev1.EnvFromSource, len(*in))
@@ -492,6 +931,21 @@ func (in *ModelBackend) DeepCopyInto(out *ModelBackend) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.LoraAdapters != nil {
+		in, out := &in.LoraAdapters, &out.LoraAdapters
+		*out = make([]LoraAdapter, len(*in))
+		copy(*out, *in)
+	}
+	if in.CacheWarming != nil {
+		in, out := &in.CacheWarming, &out.CacheWarming
+		*out = new(CacheWarming)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelBackend.
@@ -566,7 +1020,18 @@ func (in *ModelBoosterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelBoosterSpec) DeepCopyInto(out *ModelBoosterSpec) {
 	*out = *in
-	in.Backend.DeepCopyInto(&out.Backend)
+	if in.Backend != nil {
+		in, out := &in.Backend, &out.Backend
+		*out = new(ModelBackend)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backends != nil {
+		in, out := &in.Backends, &out.Backends
+		*out = make([]ModelBackend, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AutoscalingPolicy != nil {
 		in, out := &in.AutoscalingPolicy, &out.AutoscalingPolicy
 		*out = new(AutoscalingPolicySpec)
@@ -669,6 +1134,16 @@ func (in *ModelServingSpec) DeepCopyInto(out *ModelServingSpec) {
 		*out = new(RolloutStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RestartBudget != nil {
+		in, out := &in.RestartBudget, &out.RestartBudget
+		*out = new(RestartBudget)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServingSpec.
@@ -691,6 +1166,17 @@ func (in *ModelServingStatus) DeepCopyInto(out *ModelServingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastProgressTime != nil {
+		in, out := &in.LastProgressTime, &out.LastProgressTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ServingGroupRecoveries != nil {
+		in, out := &in.ServingGroupRecoveries, &out.ServingGroupRecoveries
+		*out = make([]ServingGroupRecoveryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelServingStatus.
@@ -713,6 +1199,18 @@ func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LoraAdapters != nil {
+		in, out := &in.LoraAdapters, &out.LoraAdapters
+		*out = make([]LoraAdapterStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CacheWarming != nil {
+		in, out := &in.CacheWarming, &out.CacheWarming
+		*out = make([]CacheWarmingStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
@@ -768,6 +1266,21 @@ func (in *NetworkTopology) DeepCopy() *NetworkTopology {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OCIArtifactSource) DeepCopyInto(out *OCIArtifactSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OCIArtifactSource.
+func (in *OCIArtifactSource) DeepCopy() *OCIArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(OCIArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PluginScope) DeepCopyInto(out *PluginScope) {
 	*out = *in
@@ -796,6 +1309,11 @@ func (in *PluginSpec) DeepCopyInto(out *PluginSpec) {
 		*out = new(apiextensionsv1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookPluginConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Scope != nil {
 		in, out := &in.Scope, &out.Scope
 		*out = new(PluginScope)
@@ -813,6 +1331,38 @@ func (in *PluginSpec) DeepCopy() *PluginSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMetricSource) DeepCopyInto(out *PodMetricSource) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.QuantilePercentile != nil {
+		in, out := &in.QuantilePercentile, &out.QuantilePercentile
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMetricSource.
+func (in *PodMetricSource) DeepCopy() *PodMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodTemplateSpec) DeepCopyInto(out *PodTemplateSpec) {
 	*out = *in
@@ -834,6 +1384,81 @@ func (in *PodTemplateSpec) DeepCopy() *PodTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PredictivePolicy) DeepCopyInto(out *PredictivePolicy) {
+	*out = *in
+	if in.Season != nil {
+		in, out := &in.Season, &out.Season
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LeadTime != nil {
+		in, out := &in.LeadTime, &out.LeadTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.LevelSmoothingPercent != nil {
+		in, out := &in.LevelSmoothingPercent, &out.LevelSmoothingPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TrendSmoothingPercent != nil {
+		in, out := &in.TrendSmoothingPercent, &out.TrendSmoothingPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SeasonalSmoothingPercent != nil {
+		in, out := &in.SeasonalSmoothingPercent, &out.SeasonalSmoothingPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PredictivePolicy.
+func (in *PredictivePolicy) DeepCopy() *PredictivePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PredictivePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusMetricSource) DeepCopyInto(out *PrometheusMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusMetricSource.
+func (in *PrometheusMetricSource) DeepCopy() *PrometheusMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RestartBudget) DeepCopyInto(out *RestartBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RestartBudget.
+func (in *RestartBudget) DeepCopy() *RestartBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RestartBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Role) DeepCopyInto(out *Role) {
 	*out = *in
@@ -848,6 +1473,11 @@ func (in *Role) DeepCopyInto(out *Role) {
 		*out = new(PodTemplateSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RecoveryPolicy != nil {
+		in, out := &in.RecoveryPolicy, &out.RecoveryPolicy
+		*out = new(RecoveryPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
@@ -860,6 +1490,26 @@ func (in *Role) DeepCopy() *Role {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RollbackPolicy) DeepCopyInto(out *RollbackPolicy) {
+	*out = *in
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollbackPolicy.
+func (in *RollbackPolicy) DeepCopy() *RollbackPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RollbackPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RollingUpdateConfiguration) DeepCopyInto(out *RollingUpdateConfiguration) {
 	*out = *in
@@ -868,6 +1518,11 @@ func (in *RollingUpdateConfiguration) DeepCopyInto(out *RollingUpdateConfigurati
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
 	if in.Partition != nil {
 		in, out := &in.Partition, &out.Partition
 		*out = new(int32)
@@ -893,6 +1548,11 @@ func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
 		*out = new(RollingUpdateConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RollbackPolicy != nil {
+		in, out := &in.RollbackPolicy, &out.RollbackPolicy
+		*out = new(RollbackPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
@@ -905,6 +1565,83 @@ func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterAggregateMetricSource) DeepCopyInto(out *RouterAggregateMetricSource) {
+	*out = *in
+	if in.MatchLabels != nil {
+		in, out := &in.MatchLabels, &out.MatchLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAggregateMetricSource.
+func (in *RouterAggregateMetricSource) DeepCopy() *RouterAggregateMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterAggregateMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouterDemandMetricSource) DeepCopyInto(out *RouterDemandMetricSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterDemandMetricSource.
+func (in *RouterDemandMetricSource) DeepCopy() *RouterDemandMetricSource {
+	if in == nil {
+		return nil
+	}
+	out := new(RouterDemandMetricSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *S3ArtifactSource) DeepCopyInto(out *S3ArtifactSource) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new S3ArtifactSource.
+func (in *S3ArtifactSource) DeepCopy() *S3ArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(S3ArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScaleToZero) DeepCopyInto(out *ScaleToZero) {
+	*out = *in
+	if in.IdleWindow != nil {
+		in, out := &in.IdleWindow, &out.IdleWindow
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScaleToZero.
+func (in *ScaleToZero) DeepCopy() *ScaleToZero {
+	if in == nil {
+		return nil
+	}
+	out := new(ScaleToZero)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingGroup) DeepCopyInto(out *ServingGroup) {
 	*out = *in
@@ -942,6 +1679,28 @@ func (in *ServingGroup) DeepCopy() *ServingGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServingGroupRecoveryStatus) DeepCopyInto(out *ServingGroupRecoveryStatus) {
+	*out = *in
+	if in.RecreationTimes != nil {
+		in, out := &in.RecreationTimes, &out.RecreationTimes
+		*out = make([]v1.Time, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServingGroupRecoveryStatus.
+func (in *ServingGroupRecoveryStatus) DeepCopy() *ServingGroupRecoveryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServingGroupRecoveryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SubTarget) DeepCopyInto(out *SubTarget) {
 	*out = *in
@@ -978,3 +1737,49 @@ func (in *Target) DeepCopy() *Target {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSnapshotArtifactSource) DeepCopyInto(out *VolumeSnapshotArtifactSource) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]corev1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSnapshotArtifactSource.
+func (in *VolumeSnapshotArtifactSource) DeepCopy() *VolumeSnapshotArtifactSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSnapshotArtifactSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookPluginConfig) DeepCopyInto(out *WebhookPluginConfig) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookPluginConfig.
+func (in *WebhookPluginConfig) DeepCopy() *WebhookPluginConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookPluginConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,169 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+// SeasonalForecaster forecasts a series with additive Holt-Winters smoothing: each point of the series is the sum of
+// a level, a trend and a seasonal component which repeats every SeasonLength points, e.g. the daily pattern of
+// the traffic. The points are the peaks of the observations of consecutive intervals, aligned on the epoch so that
+// the seasons follow the clock.
+type SeasonalForecaster struct {
+	IntervalMilliseconds int64
+	SeasonLength         int64
+	// Alpha, Beta and Gamma are the smoothing factors of the level, the trend and the seasonal component.
+	Alpha float64
+	Beta  float64
+	Gamma float64
+
+	// interval is the index of the interval being observed, and peak the peak of its observations.
+	interval int64
+	peak     float64
+	observed bool
+	// warmup holds the points of the first season, starting at the interval warmupStart, until the model is
+	// initialized from them.
+	warmup      []float64
+	warmupStart int64
+	initialized bool
+	level       float64
+	trend       float64
+	seasonal    []float64
+	// last is the index of the last point of the series.
+	last int64
+}
+
+// NewSeasonalForecaster returns a forecaster of the series of the peaks of intervalMilliseconds, repeating every
+// seasonLength points.
+func NewSeasonalForecaster(intervalMilliseconds int64, seasonLength int64, alpha, beta, gamma float64) *SeasonalForecaster {
+	return &SeasonalForecaster{
+		IntervalMilliseconds: max(intervalMilliseconds, 1),
+		SeasonLength:         max(seasonLength, 1),
+		Alpha:                alpha,
+		Beta:                 beta,
+		Gamma:                gamma,
+	}
+}
+
+// Observe records the value observed at timestamp. An interval becomes a point of the series once a later interval
+// is observed.
+func (f *SeasonalForecaster) Observe(timestamp int64, value float64) {
+	interval := timestamp / f.IntervalMilliseconds
+	switch {
+	case !f.observed:
+		f.interval, f.peak, f.observed = interval, value, true
+	case interval == f.interval:
+		f.peak = max(f.peak, value)
+	case interval > f.interval:
+		f.addPoint(f.interval, f.peak)
+		f.interval, f.peak = interval, value
+	}
+}
+
+// Forecast returns the peak of the series forecast from the interval of timestamp to the interval of timestamp
+// plus leadMilliseconds, bounded below by zero. It is unavailable until a whole season was observed, or when no
+// point was added for a season.
+func (f *SeasonalForecaster) Forecast(timestamp int64, leadMilliseconds int64) (float64, bool) {
+	if !f.initialized {
+		return 0, false
+	}
+	from := max(timestamp/f.IntervalMilliseconds, f.last+1)
+	to := max((timestamp+leadMilliseconds)/f.IntervalMilliseconds, from)
+	if from-f.last > f.SeasonLength {
+		return 0, false
+	}
+	peak := 0.0
+	for interval := from; interval <= to; interval++ {
+		peak = max(peak, f.predict(interval))
+	}
+	return peak, true
+}
+
+// Inherit takes over the series of the previous forecaster if it has the same intervals and seasons, e.g. when the
+// policy changed, so that the history is not lost.
+func (f *SeasonalForecaster) Inherit(previous *SeasonalForecaster) {
+	if previous == nil || previous.IntervalMilliseconds != f.IntervalMilliseconds || previous.SeasonLength != f.SeasonLength {
+		return
+	}
+	alpha, beta, gamma := f.Alpha, f.Beta, f.Gamma
+	*f = *previous
+	f.warmup = append([]float64(nil), previous.warmup...)
+	f.seasonal = append([]float64(nil), previous.seasonal...)
+	f.Alpha, f.Beta, f.Gamma = alpha, beta, gamma
+}
+
+func (f *SeasonalForecaster) predict(interval int64) float64 {
+	return f.level + float64(interval-f.last)*f.trend + f.seasonal[interval%f.SeasonLength]
+}
+
+func (f *SeasonalForecaster) addPoint(interval int64, value float64) {
+	if f.initialized && interval-f.last > f.SeasonLength {
+		// The model is stale after a season without observations, it is learned again.
+		f.initialized = false
+		f.warmup = nil
+	}
+	if !f.initialized {
+		f.addWarmupPoint(interval, value)
+		return
+	}
+	// The intervals without observations are filled with the forecast.
+	for missing := f.last + 1; missing < interval; missing++ {
+		f.update(missing, f.predict(missing))
+	}
+	f.update(interval, value)
+}
+
+func (f *SeasonalForecaster) addWarmupPoint(interval int64, value float64) {
+	if len(f.warmup) == 0 || interval-f.warmupStart-int64(len(f.warmup)) > f.SeasonLength {
+		f.warmup, f.warmupStart = nil, interval
+	}
+	// The intervals without observations are filled with the previous point.
+	for missing := f.warmupStart + int64(len(f.warmup)); missing < interval; missing++ {
+		f.warmup = append(f.warmup, f.warmup[len(f.warmup)-1])
+	}
+	f.warmup = append(f.warmup, value)
+	if int64(len(f.warmup)) < f.SeasonLength {
+		return
+	}
+	// The level is the mean of the first season, without trend, and the seasonal component the deviations from it.
+	f.warmup = f.warmup[len(f.warmup)-int(f.SeasonLength):]
+	f.warmupStart = interval - f.SeasonLength + 1
+	sum := 0.0
+	for _, point := range f.warmup {
+		sum += point
+	}
+	f.level = sum / float64(f.SeasonLength)
+	f.trend = 0
+	f.seasonal = make([]float64, f.SeasonLength)
+	for i, point := range f.warmup {
+		f.seasonal[(f.warmupStart+int64(i))%f.SeasonLength] = point - f.level
+	}
+	f.last = interval
+	f.initialized = true
+	f.warmup = nil
+}
+
+func (f *SeasonalForecaster) update(interval int64, value float64) {
+	season := interval % f.SeasonLength
+	level := f.Alpha*(value-f.seasonal[season]) + (1-f.Alpha)*(f.level+f.trend)
+	f.trend = f.Beta*(level-f.level) + (1-f.Beta)*f.trend
+	f.seasonal[season] = f.Gamma*(value-level) + (1-f.Gamma)*f.seasonal[season]
+	f.level = level
+	f.last = interval
+}
+
+// ForecastInstances rounds the forecast up to instances.
+func ForecastInstances(forecast float64) int32 {
+	return getCeilDesiredInstances(forecast)
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testInterval = int64(60000)
+
+// seasonalDemand is a season of 6 intervals, with a peak of 10 in the fourth interval.
+var seasonalDemand = []float64{2, 2, 4, 10, 4, 2}
+
+func observeSeasons(f *SeasonalForecaster, seasons int) int64 {
+	timestamp := int64(0)
+	for season := 0; season < seasons; season++ {
+		for _, value := range seasonalDemand {
+			// Two observations per interval, the peak being the point of the interval.
+			f.Observe(timestamp, value/2)
+			f.Observe(timestamp+testInterval/2, value)
+			timestamp += testInterval
+		}
+	}
+	return timestamp
+}
+
+func TestSeasonalForecasterUnavailableBeforeASeason(t *testing.T) {
+	f := NewSeasonalForecaster(testInterval, int64(len(seasonalDemand)), 0.5, 0.1, 0.3)
+	for i, value := range seasonalDemand[:len(seasonalDemand)-1] {
+		f.Observe(int64(i)*testInterval, value)
+	}
+	_, ok := f.Forecast(int64(len(seasonalDemand))*testInterval, testInterval)
+	assert.False(t, ok)
+}
+
+func TestSeasonalForecasterForecastsThePeakAhead(t *testing.T) {
+	f := NewSeasonalForecaster(testInterval, int64(len(seasonalDemand)), 0.5, 0.1, 0.3)
+	timestamp := observeSeasons(f, 3)
+
+	// The next season starts, the peak is three intervals ahead.
+	forecast, ok := f.Forecast(timestamp, testInterval)
+	assert.True(t, ok)
+	assert.InDelta(t, 2, forecast, 0.5)
+	forecast, ok = f.Forecast(timestamp, 3*testInterval)
+	assert.True(t, ok)
+	assert.InDelta(t, 10, forecast, 0.5)
+	assert.Equal(t, int32(10), ForecastInstances(forecast-0.2))
+}
+
+func TestSeasonalForecasterStale(t *testing.T) {
+	f := NewSeasonalForecaster(testInterval, int64(len(seasonalDemand)), 0.5, 0.1, 0.3)
+	timestamp := observeSeasons(f, 2)
+	_, ok := f.Forecast(timestamp+int64(len(seasonalDemand)+1)*testInterval, testInterval)
+	assert.False(t, ok)
+
+	// Observations after a season without any are learned again.
+	f.Observe(timestamp+int64(2*len(seasonalDemand))*testInterval, 1)
+	f.Observe(timestamp+int64(2*len(seasonalDemand)+1)*testInterval, 1)
+	_, ok = f.Forecast(timestamp+int64(2*len(seasonalDemand)+1)*testInterval, testInterval)
+	assert.False(t, ok)
+}
+
+func TestSeasonalForecasterInherit(t *testing.T) {
+	previous := NewSeasonalForecaster(testInterval, int64(len(seasonalDemand)), 0.5, 0.1, 0.3)
+	timestamp := observeSeasons(previous, 2)
+
+	f := NewSeasonalForecaster(testInterval, int64(len(seasonalDemand)), 0.2, 0.1, 0.3)
+	f.Inherit(previous)
+	_, ok := f.Forecast(timestamp, testInterval)
+	assert.True(t, ok)
+	assert.Equal(t, 0.2, f.Alpha)
+
+	// A forecaster of other seasons does not inherit the history.
+	f = NewSeasonalForecaster(testInterval, 2*int64(len(seasonalDemand)), 0.5, 0.1, 0.3)
+	f.Inherit(previous)
+	_, ok = f.Forecast(timestamp, testInterval)
+	assert.False(t, ok)
+}
@@ -35,16 +35,31 @@ type RecommendedInstancesAlgorithm struct {
 	ExternalMetrics       Metrics
 }
 
+// Recommendation is the number of instances recommended by the metrics.
+type Recommendation struct {
+	// Instances is the recommended number of instances, bounded by MinInstances and MaxInstances.
+	Instances int32
+	// Unbounded is the number of instances the metrics ask for before it is bounded.
+	Unbounded int32
+	// Skip is true when no metric is available to recommend a number of instances.
+	Skip bool
+}
+
 func (alg *RecommendedInstancesAlgorithm) GetRecommendedInstances() (recommendedInstances int32, skip bool) {
+	recommendation := alg.GetRecommendation()
+	return recommendation.Instances, recommendation.Skip
+}
+
+func (alg *RecommendedInstancesAlgorithm) GetRecommendation() Recommendation {
 	klog.InfoS("start to getRecommendedInstances", "args", alg)
 	if alg.CurrentInstancesCount < alg.MinInstances {
-		return alg.MinInstances, false
+		return Recommendation{Instances: alg.MinInstances, Unbounded: alg.CurrentInstancesCount}
 	}
 	if alg.CurrentInstancesCount > alg.MaxInstances {
-		return alg.MaxInstances, false
+		return Recommendation{Instances: alg.MaxInstances, Unbounded: alg.CurrentInstancesCount}
 	}
-	recommendedInstances = 0
-	skip = true
+	recommendedInstances := int32(0)
+	skip := true
 	for name, target := range alg.MetricTargets {
 		externalMetric, ok := alg.ExternalMetrics[name]
 		if ok {
@@ -68,10 +83,13 @@ func (alg *RecommendedInstancesAlgorithm) GetRecommendedInstances() (recommended
 			}
 		}
 	}
-	if !skip {
-		recommendedInstances = min(max(recommendedInstances, alg.MinInstances), alg.MaxInstances)
+	if skip {
+		return Recommendation{Skip: true}
+	}
+	return Recommendation{
+		Instances: min(max(recommendedInstances, alg.MinInstances), alg.MaxInstances),
+		Unbounded: recommendedInstances,
 	}
-	return recommendedInstances, skip
 }
 
 func updateRecommendation(recommendedInstances *int32, skip *bool, desired int32) {
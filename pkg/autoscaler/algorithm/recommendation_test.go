@@ -459,3 +459,51 @@ func TestGetRecommendedInstances(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRecommendation(t *testing.T) {
+	testcases := []struct {
+		name     string
+		args     RecommendedInstancesAlgorithm
+		expected Recommendation
+	}{
+		{
+			name: "givenRecommendationAboveMaxInstances_thenKeepUnbounded",
+			args: RecommendedInstancesAlgorithm{
+				MinInstances:          int32(1),
+				MaxInstances:          int32(10),
+				CurrentInstancesCount: int32(2),
+				MetricTargets:         Metrics{"a": 1.0},
+				ExternalMetrics:       Metrics{"a": 20.0},
+			},
+			expected: Recommendation{Instances: 10, Unbounded: 20},
+		},
+		{
+			name: "givenMetricsWithinTolerance_thenUnboundedIsCurrent",
+			args: RecommendedInstancesAlgorithm{
+				MinInstances:          int32(1),
+				MaxInstances:          int32(10),
+				CurrentInstancesCount: int32(4),
+				Tolerance:             0.1,
+				MetricTargets:         Metrics{"a": 1.0},
+				ExternalMetrics:       Metrics{"a": 4.2},
+			},
+			expected: Recommendation{Instances: 4, Unbounded: 4},
+		},
+		{
+			name: "givenNoAvailableMetrics_thenSkip",
+			args: RecommendedInstancesAlgorithm{
+				MinInstances:          int32(1),
+				MaxInstances:          int32(10),
+				CurrentInstancesCount: int32(4),
+				MetricTargets:         Metrics{"a": 1.0},
+			},
+			expected: Recommendation{Skip: true},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.args.GetRecommendation())
+		})
+	}
+}
@@ -0,0 +1,163 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxScheduleDuration is the longest window of a schedule.
+const MaxScheduleDuration = 7 * 24 * time.Hour
+
+// CronSchedule is a cron expression with the five fields minute, hour, day of month, month and day of week.
+// Each field is a list of values, ranges and steps, e.g. "0,30", "9-17", "*/15" or "1-5/2". Months and days of
+// week may be named, e.g. "JAN" or "MON-FRI", and Sunday is both 0 and 7.
+type CronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	// anyDayOfMonth and anyDayOfWeek are whether the day fields start with "*". When both are restricted,
+	// a day matches either of them, like cron.
+	anyDayOfMonth, anyDayOfWeek bool
+}
+
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"", "JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// ParseCronSchedule parses a cron expression with five fields.
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron expression %q must have %d fields, got %d", expr, len(cronFields), len(fields))
+	}
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = cronFields[i].parse(field); err != nil {
+			return nil, fmt.Errorf("invalid %s %q in cron expression %q: %v", cronFields[i].name, field, expr, err)
+		}
+	}
+	// Sunday is both 0 and 7.
+	if bits[4]&(1<<7) != 0 {
+		bits[4] |= 1
+	}
+	return &CronSchedule{
+		minute:        bits[0],
+		hour:          bits[1],
+		dayOfMonth:    bits[2],
+		month:         bits[3],
+		dayOfWeek:     bits[4],
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangeExpr, stepExpr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepExpr); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepExpr)
+			}
+		}
+		var start, end int
+		if rangeExpr == "*" {
+			start, end = f.min, f.max
+		} else {
+			startExpr, endExpr, isRange := strings.Cut(rangeExpr, "-")
+			var err error
+			if start, err = f.value(startExpr); err != nil {
+				return 0, err
+			}
+			switch {
+			case isRange:
+				if end, err = f.value(endExpr); err != nil {
+					return 0, err
+				}
+			case hasStep:
+				// "a/n" runs from a to the end of the range.
+				end = f.max
+			default:
+				end = start
+			}
+		}
+		if start > end {
+			return 0, fmt.Errorf("range %q is empty", rangeExpr)
+		}
+		for value := start; value <= end; value += step {
+			bits |= 1 << value
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(expr string) (int, error) {
+	for value, name := range f.names {
+		if name != "" && strings.EqualFold(expr, name) {
+			return value, nil
+		}
+	}
+	value, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", expr)
+	}
+	if value < f.min || value > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", value, f.min, f.max)
+	}
+	return value, nil
+}
+
+// Matches returns whether the minute of t is a start of the schedule, in the location of t.
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if s.minute&(1<<t.Minute()) == 0 || s.hour&(1<<t.Hour()) == 0 || s.month&(1<<int(t.Month())) == 0 {
+		return false
+	}
+	dayOfMonth := s.dayOfMonth&(1<<t.Day()) != 0
+	dayOfWeek := s.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if s.anyDayOfMonth || s.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
+
+// Active returns whether t is within a window of the schedule, which lasts duration after each start. The
+// duration is bounded by MaxScheduleDuration.
+func (s *CronSchedule) Active(t time.Time, duration time.Duration) bool {
+	duration = min(duration, MaxScheduleDuration)
+	for start := t.Truncate(time.Minute); t.Sub(start) < duration; start = start.Add(-time.Minute) {
+		if s.Matches(start) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package algorithm
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	invalid := []string{
+		"",
+		"0 8 * *",
+		"60 8 * * *",
+		"0 8 0 * *",
+		"0 8 * 13 *",
+		"0 8 * * 8",
+		"0 17-9 * * *",
+		"*/0 * * * *",
+		"0 8 * * MON-",
+		"0 8 * FOO *",
+	}
+	for _, expr := range invalid {
+		_, err := ParseCronSchedule(expr)
+		assert.Error(t, err, "expression %q", expr)
+	}
+	for _, expr := range []string{"0 8 * * 1-5", "*/15 9-17 * * MON-FRI", "0,30 0 1 JAN,jul 7", "5/10 * * * *"} {
+		_, err := ParseCronSchedule(expr)
+		assert.NoError(t, err, "expression %q", expr)
+	}
+}
+
+func TestCronScheduleMatches(t *testing.T) {
+	// 2026-10-19 is a Monday.
+	monday := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	sunday := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	testcases := []struct {
+		expr    string
+		time    time.Time
+		matches bool
+	}{
+		{expr: "0 8 * * 1-5", time: monday, matches: true},
+		{expr: "0 8 * * 1-5", time: sunday, matches: false},
+		{expr: "0 8 * * 1-5", time: monday.Add(time.Minute), matches: false},
+		{expr: "0 8 * * 7", time: sunday, matches: true},
+		{expr: "0 8 * * SUN", time: sunday, matches: true},
+		{expr: "*/20 8 * * *", time: monday.Add(40 * time.Minute), matches: true},
+		{expr: "5/20 8 * * *", time: monday.Add(25 * time.Minute), matches: true},
+		{expr: "0 8 * OCT *", time: monday, matches: true},
+		// When both days are restricted, either of them matches.
+		{expr: "0 8 1 * MON", time: monday, matches: true},
+		{expr: "0 8 18 * MON", time: sunday, matches: true},
+		{expr: "0 8 1 * TUE", time: monday, matches: false},
+		// When a day is unrestricted, both of them must match.
+		{expr: "0 8 * * TUE", time: monday, matches: false},
+		{expr: "0 8 1 * *", time: monday, matches: false},
+	}
+	for _, tc := range testcases {
+		schedule, err := ParseCronSchedule(tc.expr)
+		require.NoError(t, err)
+		assert.Equal(t, tc.matches, schedule.Matches(tc.time), "expression %q at %s", tc.expr, tc.time)
+	}
+}
+
+func TestCronScheduleActive(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 8 * * 1-5")
+	require.NoError(t, err)
+	monday := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	assert.False(t, schedule.Active(monday.Add(7*time.Hour+59*time.Minute), 10*time.Hour))
+	assert.True(t, schedule.Active(monday.Add(8*time.Hour), 10*time.Hour))
+	assert.True(t, schedule.Active(monday.Add(17*time.Hour+59*time.Minute+30*time.Second), 10*time.Hour))
+	assert.False(t, schedule.Active(monday.Add(18*time.Hour), 10*time.Hour))
+	// The window of Friday lasts over Saturday.
+	saturday := monday.AddDate(0, 0, 5)
+	assert.True(t, schedule.Active(saturday.Add(time.Hour), 20*time.Hour))
+	assert.False(t, schedule.Active(saturday.Add(5*time.Hour), 20*time.Hour))
+}
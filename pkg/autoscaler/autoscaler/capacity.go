@@ -0,0 +1,168 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"maps"
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
+	inferControllerUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+)
+
+// defaultCapacityBackoff is how long a target of a heterogeneous target is capped after some of its replicas could
+// not be scheduled.
+const defaultCapacityBackoff = 5 * time.Minute
+
+// CapacityCap caps the replicas of a target of an optimizer at the replicas the cluster could schedule.
+type CapacityCap struct {
+	// Replicas is the maximum replicas of the target.
+	Replicas int32
+	// Until is the timestamp until which the cap holds. It is extended while replicas of the target are
+	// unschedulable.
+	Until int64
+	// Lifted is whether the cap was lifted after its backoff, and the replicas are moving back to the target. The
+	// other targets keep their replicas until the replicas of the target are scheduled and ready.
+	Lifted bool
+	// MovingBack is the replicas of the target once the replicas moved back.
+	MovingBack int32
+}
+
+// getCapacityBackoff returns the capacity backoff of a heterogeneous target in milliseconds, zero when the caps
+// are disabled.
+func getCapacityBackoff(config *workload.HeterogeneousTarget) int64 {
+	if config.CapacityBackoff == nil {
+		return defaultCapacityBackoff.Milliseconds()
+	}
+	return max(config.CapacityBackoff.Milliseconds(), 0)
+}
+
+// countReplicas returns the number of replicas of the target of the collector with a pod the scheduler could not
+// schedule, and with a pod which is not ready. A replica of a ModelServing is a ServingGroup, or a replica of the
+// role in a ServingGroup for a role target, and a pod otherwise.
+func (collector *MetricCollector) countReplicas(podLister listerv1.PodLister) (unschedulable int32, unready int32, err error) {
+	pods, err := util.GetTargetPods(podLister, collector.Scope.Namespace, collector.Target, collector.ScaleSelector)
+	if err != nil {
+		return 0, 0, err
+	}
+	unschedulableReplicas, unreadyReplicas := map[string]struct{}{}, map[string]struct{}{}
+	for _, pod := range pods {
+		if util.IsPodUnschedulable(pod) {
+			unschedulableReplicas[replicaKey(collector.Target, pod)] = struct{}{}
+		}
+		if !inferControllerUtils.IsPodRunningAndReady(pod) {
+			unreadyReplicas[replicaKey(collector.Target, pod)] = struct{}{}
+		}
+	}
+	return int32(len(unschedulableReplicas)), int32(len(unreadyReplicas)), nil
+}
+
+// replicaKey returns the key of the replica of a target a pod belongs to.
+func replicaKey(target *workload.Target, pod *corev1.Pod) string {
+	if !util.IsModelServingTarget(target) || pod.Labels[workload.GroupNameLabelKey] == "" {
+		return pod.Name
+	}
+	key := pod.Labels[workload.GroupNameLabelKey]
+	if target.SubTarget != nil && target.SubTarget.Kind == util.ModelServingRoleKind {
+		key += "/" + pod.Labels[workload.RoleIDKey]
+	}
+	return key
+}
+
+// updateCapacityCaps caps the targets with unschedulable replicas at their replicas the cluster could schedule,
+// and lifts the caps whose backoff expired, so that the replicas move back to the targets. A lifted cap is dropped
+// once the replicas moved back and are ready.
+func (optimizer *Optimizer) updateCapacityCaps(podLister listerv1.PodLister, currentInstancesCounts map[string]int32) {
+	if optimizer.Meta.CapacityBackoff == 0 {
+		optimizer.CapacityCaps = nil
+		return
+	}
+	if optimizer.CapacityCaps == nil {
+		optimizer.CapacityCaps = make(map[string]*CapacityCap)
+	}
+	now := optimizer.now()
+	for _, param := range optimizer.Meta.Config.Params {
+		name := param.Target.TargetRef.Name
+		collector, ok := optimizer.Collectors[name]
+		if !ok {
+			continue
+		}
+		unschedulable, unready, err := collector.countReplicas(podLister)
+		if err != nil {
+			klog.Warningf("failed to count unschedulable replicas of target %s: %v", name, err)
+			continue
+		}
+		capacityCap, capped := optimizer.CapacityCaps[name]
+		switch {
+		case unschedulable > 0:
+			replicas := max(currentInstancesCounts[name]-unschedulable, param.MinReplicas)
+			klog.InfoS("cap target with unschedulable replicas", "target", name, "unschedulableReplicas", unschedulable, "cappedReplicas", replicas)
+			optimizer.CapacityCaps[name] = &CapacityCap{Replicas: replicas, Until: now + optimizer.Meta.CapacityBackoff}
+		case !capped:
+		case !capacityCap.Lifted && now >= capacityCap.Until:
+			klog.InfoS("lift capacity cap of target", "target", name, "cappedReplicas", capacityCap.Replicas)
+			capacityCap.Lifted = true
+		case capacityCap.Lifted && unready == 0 && currentInstancesCounts[name] >= capacityCap.MovingBack:
+			delete(optimizer.CapacityCaps, name)
+		}
+	}
+}
+
+// cappedReplicas returns the maximum replicas of the capped targets.
+func (optimizer *Optimizer) cappedReplicas() map[string]int32 {
+	caps := make(map[string]int32, len(optimizer.CapacityCaps))
+	for name, capacityCap := range optimizer.CapacityCaps {
+		if !capacityCap.Lifted {
+			caps[name] = capacityCap.Replicas
+		}
+	}
+	if len(caps) == 0 {
+		return nil
+	}
+	return caps
+}
+
+// restoreReplicas splits instances across the targets within their capacity caps. The reason of the decision is
+// CapacityCapped when a cap moved replicas to other targets. While replicas move back to a target whose cap was
+// lifted, the other targets are not scaled down.
+func (optimizer *Optimizer) restoreReplicas(instances int32, currentInstancesCounts map[string]int32) map[string]int32 {
+	caps := optimizer.cappedReplicas()
+	replicasMap := optimizer.Meta.RestoreReplicasOfEachBackend(instances, caps)
+	if caps != nil && !maps.Equal(replicasMap, optimizer.Meta.RestoreReplicasOfEachBackend(instances, nil)) {
+		optimizer.Status.LastDecision.Reason = workload.AutoscalingReasonCapacityCapped
+	}
+	movingBack := false
+	for name, capacityCap := range optimizer.CapacityCaps {
+		if capacityCap.Lifted {
+			capacityCap.MovingBack = replicasMap[name]
+			movingBack = movingBack || replicasMap[name] > currentInstancesCounts[name]
+		}
+	}
+	if !movingBack {
+		return replicasMap
+	}
+	for name, replicas := range replicasMap {
+		if capacityCap, ok := optimizer.CapacityCaps[name]; !ok || !capacityCap.Lifted {
+			replicasMap[name] = max(replicas, currentInstancesCounts[name])
+		}
+	}
+	return replicasMap
+}
@@ -0,0 +1,208 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newCapacityBinding(capacityBackoff *metav1.Duration) *v1alpha1.AutoscalingPolicyBinding {
+	return &v1alpha1.AutoscalingPolicyBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "default"},
+		Spec: v1alpha1.AutoscalingPolicyBindingSpec{
+			HeterogeneousTarget: &v1alpha1.HeterogeneousTarget{
+				Params: []v1alpha1.HeterogeneousTargetParam{
+					{Target: v1alpha1.Target{TargetRef: corev1.ObjectReference{Name: "cheap"}}, Cost: 1, MinReplicas: 1, MaxReplicas: 5},
+					{Target: v1alpha1.Target{TargetRef: corev1.ObjectReference{Name: "expensive"}}, Cost: 5, MinReplicas: 0, MaxReplicas: 5},
+				},
+				CostExpansionRatePercent: 100,
+				CapacityBackoff:          capacityBackoff,
+			},
+		},
+	}
+}
+
+func servingGroupPod(name, modelServing, group string, unschedulable bool) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				v1alpha1.ModelServingNameLabelKey: modelServing,
+				v1alpha1.GroupNameLabelKey:        group,
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	if unschedulable {
+		pod.Status.Phase = corev1.PodPending
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}}
+	}
+	return pod
+}
+
+func TestRestoreReplicasOfEachBackendWithCaps(t *testing.T) {
+	meta := NewOptimizerMeta(newCapacityBinding(nil))
+	tests := []struct {
+		name     string
+		replicas int32
+		caps     map[string]int32
+		want     map[string]int32
+	}{
+		{name: "cheapest first", replicas: 7, want: map[string]int32{"cheap": 5, "expensive": 2}},
+		{name: "spill over the cap", replicas: 7, caps: map[string]int32{"cheap": 3}, want: map[string]int32{"cheap": 3, "expensive": 4}},
+		{name: "cap at the min replicas", replicas: 4, caps: map[string]int32{"cheap": 1}, want: map[string]int32{"cheap": 1, "expensive": 3}},
+		{name: "all targets capped", replicas: 10, caps: map[string]int32{"cheap": 2, "expensive": 3}, want: map[string]int32{"cheap": 2, "expensive": 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := meta.RestoreReplicasOfEachBackend(tt.replicas, tt.caps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RestoreReplicasOfEachBackend() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateCapacityCaps(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC).UnixMilli()
+
+	policy := &v1alpha1.AutoscalingPolicy{Spec: v1alpha1.AutoscalingPolicySpec{Metrics: []v1alpha1.AutoscalingPolicyMetric{{MetricName: "load"}}}}
+	optimizer := NewOptimizer(policy, newCapacityBinding(nil), func() int64 { return now })
+	if optimizer.Meta.CapacityBackoff != defaultCapacityBackoff.Milliseconds() {
+		t.Fatalf("CapacityBackoff = %d, want the default", optimizer.Meta.CapacityBackoff)
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	podLister := listerv1.NewPodLister(indexer)
+	// Two ServingGroups of the cheap target are unschedulable, one of them partially.
+	for _, pod := range []*corev1.Pod{
+		servingGroupPod("cheap-0-0", "cheap", "cheap-0", false),
+		servingGroupPod("cheap-1-0", "cheap", "cheap-1", false),
+		servingGroupPod("cheap-2-0", "cheap", "cheap-2", false),
+		servingGroupPod("cheap-2-1", "cheap", "cheap-2", true),
+		servingGroupPod("cheap-3-0", "cheap", "cheap-3", true),
+		servingGroupPod("cheap-3-1", "cheap", "cheap-3", true),
+		servingGroupPod("expensive-0-0", "expensive", "expensive-0", false),
+	} {
+		_ = indexer.Add(pod)
+	}
+	counts := map[string]int32{"cheap": 4, "expensive": 1}
+
+	optimizer.updateCapacityCaps(podLister, counts)
+	want := map[string]*CapacityCap{"cheap": {Replicas: 2, Until: now + defaultCapacityBackoff.Milliseconds()}}
+	if !reflect.DeepEqual(optimizer.CapacityCaps, want) {
+		t.Fatalf("CapacityCaps = %v, want %v", optimizer.CapacityCaps, want)
+	}
+	if got := optimizer.cappedReplicas(); !reflect.DeepEqual(got, map[string]int32{"cheap": 2}) {
+		t.Errorf("cappedReplicas() = %v", got)
+	}
+
+	// The cap holds during the backoff once the unschedulable ServingGroups are removed.
+	_ = indexer.Delete(servingGroupPod("cheap-2-1", "cheap", "cheap-2", true))
+	_ = indexer.Delete(servingGroupPod("cheap-3-0", "cheap", "cheap-3", true))
+	_ = indexer.Delete(servingGroupPod("cheap-3-1", "cheap", "cheap-3", true))
+	_ = indexer.Delete(servingGroupPod("cheap-2-0", "cheap", "cheap-2", false))
+	counts["cheap"] = 2
+	now += time.Minute.Milliseconds()
+	optimizer.updateCapacityCaps(podLister, counts)
+	if _, ok := optimizer.CapacityCaps["cheap"]; !ok {
+		t.Fatalf("cap lifted during the backoff")
+	}
+
+	// The cap is lifted after the backoff, and the replicas move back to the target.
+	now += defaultCapacityBackoff.Milliseconds()
+	optimizer.updateCapacityCaps(podLister, counts)
+	if capacityCap := optimizer.CapacityCaps["cheap"]; capacityCap == nil || !capacityCap.Lifted {
+		t.Fatalf("cap not lifted after the backoff: %v", capacityCap)
+	}
+	if got := optimizer.cappedReplicas(); got != nil {
+		t.Errorf("cappedReplicas() = %v with the cap lifted", got)
+	}
+	optimizer.CapacityCaps["cheap"].MovingBack = 3
+
+	// The lifted cap is kept until the replicas moved back are ready.
+	_ = indexer.Add(servingGroupPod("cheap-2-0", "cheap", "cheap-2", false))
+	pending := servingGroupPod("cheap-2-1", "cheap", "cheap-2", false)
+	pending.Status = corev1.PodStatus{Phase: corev1.PodPending}
+	_ = indexer.Add(pending)
+	counts["cheap"] = 3
+	optimizer.updateCapacityCaps(podLister, counts)
+	if _, ok := optimizer.CapacityCaps["cheap"]; !ok {
+		t.Fatalf("lifted cap dropped before the replicas are ready")
+	}
+	_ = indexer.Update(servingGroupPod("cheap-2-1", "cheap", "cheap-2", false))
+	optimizer.updateCapacityCaps(podLister, counts)
+	if len(optimizer.CapacityCaps) != 0 {
+		t.Errorf("CapacityCaps = %v once the replicas moved back, want none", optimizer.CapacityCaps)
+	}
+}
+
+func TestRestoreReplicasMovingBack(t *testing.T) {
+	policy := &v1alpha1.AutoscalingPolicy{Spec: v1alpha1.AutoscalingPolicySpec{Metrics: []v1alpha1.AutoscalingPolicyMetric{{MetricName: "load"}}}}
+	optimizer := NewOptimizer(policy, newCapacityBinding(nil), util.GetCurrentTimestamp)
+	counts := map[string]int32{"cheap": 3, "expensive": 4}
+
+	optimizer.CapacityCaps = map[string]*CapacityCap{"cheap": {Replicas: 3}}
+	if got := optimizer.restoreReplicas(7, counts); !reflect.DeepEqual(got, map[string]int32{"cheap": 3, "expensive": 4}) {
+		t.Errorf("restoreReplicas() = %v with the cap", got)
+	}
+	if optimizer.Status.LastDecision.Reason != v1alpha1.AutoscalingReasonCapacityCapped {
+		t.Errorf("reason = %s, want CapacityCapped", optimizer.Status.LastDecision.Reason)
+	}
+
+	// The expensive target keeps its replicas while the replicas move back to the cheap target.
+	optimizer.Status.LastDecision.Reason = ""
+	optimizer.CapacityCaps["cheap"].Lifted = true
+	if got := optimizer.restoreReplicas(7, counts); !reflect.DeepEqual(got, map[string]int32{"cheap": 5, "expensive": 4}) {
+		t.Errorf("restoreReplicas() = %v with the cap lifted", got)
+	}
+	if optimizer.CapacityCaps["cheap"].MovingBack != 5 {
+		t.Errorf("MovingBack = %d, want 5", optimizer.CapacityCaps["cheap"].MovingBack)
+	}
+	if optimizer.Status.LastDecision.Reason != "" {
+		t.Errorf("reason = %s with the cap lifted", optimizer.Status.LastDecision.Reason)
+	}
+
+	// The expensive target is scaled down once they moved back.
+	counts["cheap"] = 5
+	if got := optimizer.restoreReplicas(7, counts); !reflect.DeepEqual(got, map[string]int32{"cheap": 5, "expensive": 2}) {
+		t.Errorf("restoreReplicas() = %v once the replicas moved back", got)
+	}
+}
+
+func TestUpdateCapacityCapsDisabled(t *testing.T) {
+	policy := &v1alpha1.AutoscalingPolicy{Spec: v1alpha1.AutoscalingPolicySpec{Metrics: []v1alpha1.AutoscalingPolicyMetric{{MetricName: "load"}}}}
+	optimizer := NewOptimizer(policy, newCapacityBinding(&metav1.Duration{}), util.GetCurrentTimestamp)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	_ = indexer.Add(servingGroupPod("cheap-0-0", "cheap", "cheap-0", true))
+
+	optimizer.updateCapacityCaps(listerv1.NewPodLister(indexer), map[string]int32{"cheap": 1})
+	if optimizer.CapacityCaps != nil {
+		t.Errorf("CapacityCaps = %v with caps disabled", optimizer.CapacityCaps)
+	}
+}
@@ -0,0 +1,166 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"math"
+	"sort"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// NewCoupledAutoscaler returns the autoscaler of the roles of a coupled target, scaled together. The total replicas
+// of the roles are recommended like the replicas of a homogeneous target, within the sums of the min and max
+// replicas of the roles, then split across the roles by SplitReplicas.
+func NewCoupledAutoscaler(autoscalePolicy *workload.AutoscalingPolicy, binding *workload.AutoscalingPolicyBinding, now func() int64) *Autoscaler {
+	config := binding.Spec.CoupledTarget
+	total := &workload.HomogeneousTarget{Target: config.Target}
+	for _, role := range config.Roles {
+		total.MinReplicas += role.MinReplicas
+		total.MaxReplicas += role.MaxReplicas
+	}
+	return &Autoscaler{
+		Status:    NewStatus(&autoscalePolicy.Spec, now),
+		Collector: NewMetricCollector(&config.Target, binding, autoscalePolicy, now),
+		Meta: &ScalingMeta{
+			Config:    total,
+			Namespace: binding.Namespace,
+			Generations: Generations{
+				AutoscalePolicyGeneration: autoscalePolicy.Generation,
+				BindingGeneration:         binding.Generation,
+			},
+		},
+	}
+}
+
+// CoupledShares returns the share of the replicas of each role of a coupled target. With a Learned ratio, the
+// weights of the roles are multiplied by the rates of the tokens of their token types, unless the routers observed
+// no token for a role.
+func CoupledShares(config *workload.CoupledTarget, demand Demand) []float64 {
+	shares := make([]float64, len(config.Roles))
+	for i, role := range config.Roles {
+		shares[i] = float64(max(role.Weight, 1))
+	}
+	if config.Ratio != workload.CoupledRatioLearned {
+		return shares
+	}
+	learned := make([]float64, len(shares))
+	for i, role := range config.Roles {
+		switch role.TokenType {
+		case workload.CoupledTokenInput:
+			learned[i] = shares[i] * demand.InputTokensPerSecond
+		case workload.CoupledTokenOutput:
+			learned[i] = shares[i] * demand.OutputTokensPerSecond
+		}
+		if learned[i] <= 0 {
+			return shares
+		}
+	}
+	return learned
+}
+
+// SplitReplicas splits the total replicas across the roles in proportion to their shares, within the min and max
+// replicas of each role. The total is first bounded by the sums of the min and max replicas of the roles.
+func SplitReplicas(roles []workload.CoupledRole, shares []float64, total int32) []int32 {
+	replicas := make([]int32, len(roles))
+	bounded := make([]bool, len(roles))
+	minSum, maxSum := int32(0), int32(0)
+	for _, role := range roles {
+		minSum += role.MinReplicas
+		maxSum += role.MaxReplicas
+	}
+	remaining := min(max(total, minSum), maxSum)
+
+	// The roles whose proportional replicas are out of their bounds are set to their bounds, and the remaining
+	// replicas are split again across the other roles, the roles below their min replicas first.
+	ideals := make([]float64, len(roles))
+	for {
+		shareSum, unbounded := 0.0, 0
+		for i := range roles {
+			if !bounded[i] {
+				shareSum += shares[i]
+				unbounded++
+			}
+		}
+		if unbounded == 0 {
+			break
+		}
+		for i := range roles {
+			if bounded[i] {
+				continue
+			}
+			if shareSum > 0 {
+				ideals[i] = float64(remaining) * shares[i] / shareSum
+			} else {
+				ideals[i] = float64(remaining) / float64(unbounded)
+			}
+		}
+		changed := bindRoles(roles, bounded, replicas, &remaining, func(i int) (int32, bool) {
+			return roles[i].MinReplicas, ideals[i] < float64(roles[i].MinReplicas)
+		})
+		if !changed {
+			changed = bindRoles(roles, bounded, replicas, &remaining, func(i int) (int32, bool) {
+				return roles[i].MaxReplicas, ideals[i] > float64(roles[i].MaxReplicas)
+			})
+		}
+		if !changed {
+			break
+		}
+	}
+
+	// The proportional replicas are rounded down, and the replicas left are given to the largest remainders.
+	var unboundedRoles []int
+	for i := range roles {
+		if bounded[i] {
+			continue
+		}
+		replicas[i] = int32(math.Floor(ideals[i]))
+		remaining -= replicas[i]
+		unboundedRoles = append(unboundedRoles, i)
+	}
+	sort.SliceStable(unboundedRoles, func(a, b int) bool {
+		i, j := unboundedRoles[a], unboundedRoles[b]
+		return ideals[i]-math.Floor(ideals[i]) > ideals[j]-math.Floor(ideals[j])
+	})
+	for _, i := range unboundedRoles {
+		if remaining <= 0 {
+			break
+		}
+		if replicas[i] < roles[i].MaxReplicas {
+			replicas[i]++
+			remaining--
+		}
+	}
+	return replicas
+}
+
+// bindRoles sets the unbounded roles out of a bound to the bound, and returns whether a role was bound.
+func bindRoles(roles []workload.CoupledRole, bounded []bool, replicas []int32, remaining *int32, outOfBound func(i int) (int32, bool)) bool {
+	changed := false
+	for i := range roles {
+		if bounded[i] {
+			continue
+		}
+		if bound, ok := outOfBound(i); ok {
+			replicas[i] = bound
+			bounded[i] = true
+			*remaining -= bound
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,82 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestSplitReplicas(t *testing.T) {
+	roles := []v1alpha1.CoupledRole{
+		{Name: "prefill", MinReplicas: 1, MaxReplicas: 10},
+		{Name: "decode", MinReplicas: 1, MaxReplicas: 10},
+	}
+	tests := []struct {
+		name   string
+		roles  []v1alpha1.CoupledRole
+		shares []float64
+		total  int32
+		want   []int32
+	}{
+		{name: "proportional", roles: roles, shares: []float64{1, 3}, total: 8, want: []int32{2, 6}},
+		{name: "largest remainder", roles: roles, shares: []float64{1, 2}, total: 5, want: []int32{2, 3}},
+		{name: "bounded by min replicas", roles: roles, shares: []float64{1, 9}, total: 4, want: []int32{1, 3}},
+		{name: "bounded by max replicas", roles: roles, shares: []float64{1, 1}, total: 30, want: []int32{10, 10}},
+		{
+			name: "max replicas of a role",
+			roles: []v1alpha1.CoupledRole{
+				{Name: "prefill", MinReplicas: 0, MaxReplicas: 2},
+				{Name: "decode", MinReplicas: 0, MaxReplicas: 10},
+			},
+			shares: []float64{1, 1},
+			total:  8,
+			want:   []int32{2, 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SplitReplicas(tt.roles, tt.shares, tt.total); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitReplicas() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoupledShares(t *testing.T) {
+	config := &v1alpha1.CoupledTarget{
+		Roles: []v1alpha1.CoupledRole{
+			{Name: "prefill", Weight: 1, TokenType: v1alpha1.CoupledTokenInput},
+			{Name: "decode", Weight: 4, TokenType: v1alpha1.CoupledTokenOutput},
+		},
+		Ratio: v1alpha1.CoupledRatioFixed,
+	}
+	demand := Demand{InputTokensPerSecond: 1000, OutputTokensPerSecond: 50}
+	if got := CoupledShares(config, demand); !reflect.DeepEqual(got, []float64{1, 4}) {
+		t.Errorf("fixed CoupledShares() = %v", got)
+	}
+	config.Ratio = v1alpha1.CoupledRatioLearned
+	if got := CoupledShares(config, demand); !reflect.DeepEqual(got, []float64{1000, 200}) {
+		t.Errorf("learned CoupledShares() = %v", got)
+	}
+	// Without tokens observed, the replicas are split by the weights.
+	if got := CoupledShares(config, Demand{InputTokensPerSecond: 1000}); !reflect.DeepEqual(got, []float64{1, 4}) {
+		t.Errorf("learned CoupledShares() without output tokens = %v", got)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	io_prometheus_client "github.com/prometheus/client_model/go"
@@ -40,30 +41,63 @@ import (
 	"k8s.io/klog/v2"
 )
 
+const (
+	// defaultMetricWindow is the window of the histogram quantiles and the counter rates of the pod metrics.
+	defaultMetricWindow = time.Minute
+	// defaultQuantilePercentile is the quantile of the histogram pod metrics.
+	defaultQuantilePercentile = int32(95)
+	// snapshotKeepWindows is the number of windows the snapshots of a metric are kept for.
+	snapshotKeepWindows = 5
+	// maxConcurrentPodScrapes is the number of pods of a target whose metric endpoint is scraped concurrently.
+	maxConcurrentPodScrapes = 16
+)
+
 type MetricCollector struct {
-	PastHistograms  *datastructure.SnapshotSlidingWindow[map[string]HistogramInfo]
-	Target          *v1alpha1.Target
-	Scope           Scope
-	WatchMetricList sets.String
-	MetricTargets   map[string]float64
+	// PastSnapshots holds the past snapshots of the pod metrics, by metric name and pod name.
+	PastSnapshots map[string]*datastructure.SnapshotSlidingWindow[map[string]PodSnapshot]
+	Target        *v1alpha1.Target
+	Scope         Scope
+	// PodMetrics are the metrics scraped from the pods, with how they are computed. The source is nil for the defaults.
+	PodMetrics    map[string]*v1alpha1.PodMetricSource
+	MetricTargets map[string]float64
+	// ScaleSelector is the selector of the pods of a target scaled through the scale subresource, from its scale
+	// status. It is empty for a ModelServing target.
+	ScaleSelector string
+	// Fetch returns the metrics of a pod in the Prometheus text format. The metric endpoint of the pod is scraped
+	// when it is nil.
+	Fetch func(ctx context.Context, pod *corev1.Pod) (string, error)
+	// now returns the current timestamp in milliseconds.
+	now func() int64
 }
 
-func NewMetricCollector(target *v1alpha1.Target, binding *v1alpha1.AutoscalingPolicyBinding, metricTargets map[string]float64) *MetricCollector {
+func NewMetricCollector(target *v1alpha1.Target, binding *v1alpha1.AutoscalingPolicyBinding, autoscalePolicy *v1alpha1.AutoscalingPolicy, now func() int64) *MetricCollector {
+	metricTargets := GetMetricTargets(autoscalePolicy)
+	podMetrics := GetPodMetrics(autoscalePolicy)
+	pastSnapshots := make(map[string]*datastructure.SnapshotSlidingWindow[map[string]PodSnapshot], len(podMetrics))
+	for name, source := range podMetrics {
+		window := getMetricWindow(source).Milliseconds()
+		pastSnapshots[name] = datastructure.NewSnapshotSlidingWindow[map[string]PodSnapshot](window, window*snapshotKeepWindows, now)
+	}
 	return &MetricCollector{
-		PastHistograms: datastructure.NewSnapshotSlidingWindow[map[string]HistogramInfo](util.SecondToTimestamp(util.SloQuantileSlidingWindowSeconds), util.SecondToTimestamp(util.SloQuantileDataKeepSeconds)),
-		Target:         target,
+		PastSnapshots: pastSnapshots,
+		Target:        target,
 		Scope: Scope{
 			Namespace:      binding.Namespace,
 			OwnedBindingId: binding.UID,
 		},
-		MetricTargets:   metricTargets,
-		WatchMetricList: util.ExtractKeysToSet(metricTargets),
+		MetricTargets: metricTargets,
+		PodMetrics:    podMetrics,
+		now:           now,
 	}
 }
 
-type HistogramInfo struct {
+// PodSnapshot holds the cumulative series of a metric of a pod, by their labels, from which the quantiles of
+// histograms and the rates of counters are computed.
+type PodSnapshot struct {
 	PodStartTime *metav1.Time
-	HistogramMap map[string]*histogram.Snapshot
+	Timestamp    int64
+	Histograms   map[string]*histogram.Snapshot
+	Counters     map[string]float64
 }
 
 type Scope struct {
@@ -95,10 +129,40 @@ func GetMetricTargets(autoscalePolicy *v1alpha1.AutoscalingPolicy) algorithm.Met
 	return metricTargets
 }
 
+// GetPodMetrics returns the metrics scraped from the pods of the targets, with how they are computed.
+func GetPodMetrics(autoscalePolicy *v1alpha1.AutoscalingPolicy) map[string]*v1alpha1.PodMetricSource {
+	podMetrics := make(map[string]*v1alpha1.PodMetricSource)
+	if autoscalePolicy == nil {
+		return podMetrics
+	}
+	for i := range autoscalePolicy.Spec.Metrics {
+		metric := &autoscalePolicy.Spec.Metrics[i]
+		if !IsPodMetric(metric) {
+			continue
+		}
+		var source *v1alpha1.PodMetricSource
+		if metric.Source != nil {
+			source = metric.Source.Pod
+		}
+		podMetrics[metric.MetricName] = source
+	}
+	return podMetrics
+}
+
+// IsPodMetric returns whether the metric is scraped from the pods of the targets, rather than from another source.
+func IsPodMetric(metric *v1alpha1.AutoscalingPolicyMetric) bool {
+	return metric.Source == nil || metric.Source.Type == "" || metric.Source.Type == v1alpha1.MetricSourcePod
+}
+
 func (collector *MetricCollector) UpdateMetrics(ctx context.Context, podLister listerv1.PodLister) (unreadyInstancesCount int32, readyInstancesMetric algorithm.Metrics, err error) {
 	// Get pod list which will be invoked api to get metrics
 	unreadyInstancesCount = int32(0)
-	pods, err := util.GetMetricPods(podLister, collector.Scope.Namespace, collector.Target)
+	var pods []*corev1.Pod
+	if util.IsModelServingTarget(collector.Target) {
+		pods, err = util.GetMetricPods(podLister, collector.Scope.Namespace, collector.Target)
+	} else {
+		pods, err = util.GetScalePods(podLister, collector.Scope.Namespace, collector.Target, collector.ScaleSelector)
+	}
 	if err != nil {
 		klog.Errorf("list watched pod error: %v in namespace: %s, labels: %v", err, collector.Scope.Namespace, collector.Target.MetricEndpoint)
 		return
@@ -108,8 +172,8 @@ func (collector *MetricCollector) UpdateMetrics(ctx context.Context, podLister l
 		return
 	}
 
-	currentHistograms := make(map[string]HistogramInfo)
-	instanceInfo := collector.fetchMetricsFromPods(ctx, pods, &currentHistograms)
+	currentSnapshots := make(map[string]map[string]PodSnapshot, len(collector.PodMetrics))
+	instanceInfo := collector.fetchMetricsFromPods(ctx, pods, currentSnapshots)
 	klog.V(10).InfoS("finish to processInstance", "instanceInfo.isFailed", instanceInfo.IsFailed)
 	klog.V(10).InfoS("finish to processInstance", "instanceInfo.isReady", instanceInfo.IsReady)
 	klog.V(10).InfoS("finish to processInstance", "instanceInfo.metricsMap", instanceInfo.MetricsMap)
@@ -124,67 +188,119 @@ func (collector *MetricCollector) UpdateMetrics(ctx context.Context, podLister l
 		return
 	}
 	readyInstancesMetric = instanceInfo.MetricsMap
-	collector.PastHistograms.Append(currentHistograms)
+	for name, window := range collector.PastSnapshots {
+		window.Append(currentSnapshots[name])
+	}
 	return
 }
 
-func (collector *MetricCollector) fetchMetricsFromPods(ctx context.Context, pods []*corev1.Pod, currentHistograms *map[string]HistogramInfo) InstanceInfo {
+func (collector *MetricCollector) fetchMetricsFromPods(ctx context.Context, pods []*corev1.Pod, currentSnapshots map[string]map[string]PodSnapshot) InstanceInfo {
 	instanceInfo := InstanceInfo{true, false, make(algorithm.Metrics)}
-	pastHistograms, ok := collector.PastHistograms.GetLastUnfreshSnapshot()
-	if !ok {
-		pastHistograms = make(map[string]HistogramInfo)
+	pastSnapshots := make(map[string]map[string]PodSnapshot, len(collector.PastSnapshots))
+	for name, window := range collector.PastSnapshots {
+		if snapshots, ok := window.GetLastUnfreshSnapshot(); ok {
+			pastSnapshots[name] = snapshots
+		}
+	}
+	for name := range collector.PodMetrics {
+		currentSnapshots[name] = make(map[string]PodSnapshot)
 	}
+	podValues := make(map[string][]float64, len(collector.PodMetrics))
 	klog.InfoS("fetch metrics from pods start")
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	concurrency := make(chan struct{}, maxConcurrentPodScrapes)
 	for _, pod := range pods {
-		func() {
-			instanceInfo.IsReady = instanceInfo.IsReady && inferControllerUtils.IsPodRunningAndReady(pod)
-			instanceInfo.IsFailed = instanceInfo.IsFailed || util.IsPodFailed(pod) || inferControllerUtils.ContainerRestarted(pod)
-
-			pastValue, ok := pastHistograms[pod.Name]
-			var pastHistogramMap map[string]*histogram.Snapshot
-			if !ok || pod.Status.StartTime == nil || pastValue.PodStartTime == nil || !pod.Status.StartTime.Equal(pastValue.PodStartTime) {
-				pastHistogramMap = make(map[string]*histogram.Snapshot)
-			} else {
-				pastHistogramMap = pastValue.HistogramMap
-			}
+		instanceInfo.IsReady = instanceInfo.IsReady && inferControllerUtils.IsPodRunningAndReady(pod)
+		instanceInfo.IsFailed = instanceInfo.IsFailed || util.IsPodFailed(pod) || inferControllerUtils.ContainerRestarted(pod)
 
-			currentHistogramMap := make(map[string]*histogram.Snapshot)
-			ip := pod.Status.PodIP
-			podCtx, cancel := context.WithTimeout(ctx, util.AutoscaleCtxTimeoutSeconds*time.Second)
-			defer cancel()
-			url := fmt.Sprintf("http://%s:%d%s", ip, collector.Target.MetricEndpoint.Port, collector.Target.MetricEndpoint.Uri)
-
-			req, _ := http.NewRequestWithContext(podCtx, http.MethodGet, url, nil)
-			resp, err := http.DefaultClient.Do(req)
-			if err != nil {
-				klog.Errorf("get metric response error: %v", err)
-				return
+		// The snapshots of a restarted pod are discarded, its cumulative series restarted from zero.
+		podPastSnapshots := make(map[string]*PodSnapshot, len(pastSnapshots))
+		for name, snapshots := range pastSnapshots {
+			past, ok := snapshots[pod.Name]
+			if ok && pod.Status.StartTime != nil && past.PodStartTime != nil && pod.Status.StartTime.Equal(past.PodStartTime) {
+				podPastSnapshots[name] = &past
 			}
-			if resp == nil || !util.IsRequestSuccess(resp.StatusCode) || resp.Body == nil {
-				klog.Errorf("get metric response is invalid")
-				return
-			}
-			defer resp.Body.Close()
+		}
 
-			bodyStr, err := io.ReadAll(resp.Body)
-			if err != nil {
-				klog.Errorf("get metrics read response error: %v", err)
+		wg.Add(1)
+		concurrency <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-concurrency }()
+			podMetrics, podCurrentSnapshots, ok := collector.fetchMetricsFromPod(ctx, pod, podPastSnapshots)
+			if !ok {
 				return
 			}
-			result := string(bodyStr)
-			collector.processPrometheusString(result, pastHistogramMap, currentHistogramMap, instanceInfo.MetricsMap)
-			(*currentHistograms)[pod.Name] = HistogramInfo{
-				PodStartTime: pod.Status.StartTime,
-				HistogramMap: currentHistogramMap,
+			mu.Lock()
+			defer mu.Unlock()
+			for name, snapshot := range podCurrentSnapshots {
+				currentSnapshots[name][pod.Name] = *snapshot
+			}
+			for name, value := range podMetrics {
+				podValues[name] = append(podValues[name], value)
 			}
 		}()
 	}
+	wg.Wait()
+	for name, values := range podValues {
+		instanceInfo.MetricsMap[name] = aggregatePods(values, getAggregation(collector.PodMetrics[name]))
+	}
 	return instanceInfo
 }
 
-func (collector *MetricCollector) processPrometheusString(metricStr string, pastHistograms map[string]*histogram.Snapshot, currentHistograms map[string]*histogram.Snapshot, instanceMetricMap algorithm.Metrics) {
+// fetchMetricsFromPod fetches the metrics of a pod, and returns the values of its pod metrics and the snapshots of
+// their series.
+func (collector *MetricCollector) fetchMetricsFromPod(ctx context.Context, pod *corev1.Pod, pastSnapshots map[string]*PodSnapshot) (algorithm.Metrics, map[string]*PodSnapshot, bool) {
+	fetch := collector.Fetch
+	if fetch == nil {
+		fetch = collector.scrapePod
+	}
+	bodyStr, err := fetch(ctx, pod)
+	if err != nil {
+		klog.Errorf("get metrics of pod %s error: %v", pod.Name, err)
+		return nil, nil, false
+	}
+	currentSnapshots := make(map[string]*PodSnapshot, len(collector.PodMetrics))
+	podMetrics := collector.processPrometheusString(bodyStr, collector.now(), pastSnapshots, currentSnapshots)
+	for _, snapshot := range currentSnapshots {
+		snapshot.PodStartTime = pod.Status.StartTime
+	}
+	return podMetrics, currentSnapshots, true
+}
+
+// scrapePod scrapes the metric endpoint of a pod, within AutoscaleCtxTimeoutSeconds.
+func (collector *MetricCollector) scrapePod(ctx context.Context, pod *corev1.Pod) (string, error) {
+	ip := pod.Status.PodIP
+	podCtx, cancel := context.WithTimeout(ctx, util.AutoscaleCtxTimeoutSeconds*time.Second)
+	defer cancel()
+	url := fmt.Sprintf("http://%s:%d%s", ip, collector.Target.MetricEndpoint.Port, collector.Target.MetricEndpoint.Uri)
+
+	req, _ := http.NewRequestWithContext(podCtx, http.MethodGet, url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("get metric response error: %v", err)
+	}
+	if resp == nil || !util.IsRequestSuccess(resp.StatusCode) || resp.Body == nil {
+		return "", fmt.Errorf("get metric response is invalid")
+	}
+	defer resp.Body.Close()
+
+	bodyStr, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("get metrics read response error: %v", err)
+	}
+	return string(bodyStr), nil
+}
+
+// processPrometheusString returns the value of each pod metric of a pod, computed from the selected series of the
+// metric, and records the cumulative series of the metric in currentSnapshots. A metric missing from the pod is 0,
+// while a counter rate without a past snapshot is left out.
+func (collector *MetricCollector) processPrometheusString(metricStr string, now int64, pastSnapshots map[string]*PodSnapshot, currentSnapshots map[string]*PodSnapshot) algorithm.Metrics {
+	podMetrics := make(algorithm.Metrics, len(collector.PodMetrics))
 	reader := strings.NewReader(metricStr)
 	decoder := expfmt.NewDecoder(reader, expfmt.NewFormat(expfmt.TypeTextPlain))
+	unavailable := sets.New[string]()
 	for {
 		mf := &io_prometheus_client.MetricFamily{}
 		err := decoder.Decode(mf)
@@ -200,50 +316,190 @@ func (collector *MetricCollector) processPrometheusString(metricStr string, past
 			continue
 		}
 
-		if _, ok := collector.WatchMetricList[mf.GetName()]; !ok {
+		source, ok := collector.PodMetrics[mf.GetName()]
+		if !ok {
 			klog.V(4).Infof("metric name: %s is not matched with metricTargets", mf.GetName())
 			continue
 		}
 
-		metric := mf.Metric[0]
-		switch mf.GetType() {
-		case io_prometheus_client.MetricType_COUNTER:
-			addMetric(instanceMetricMap, mf.GetName(), metric.GetCounter().GetValue())
-		case io_prometheus_client.MetricType_GAUGE:
-			addMetric(instanceMetricMap, mf.GetName(), metric.GetGauge().GetValue())
-		case io_prometheus_client.MetricType_HISTOGRAM:
-			hist := metric.GetHistogram()
-			snapshot := histogram.NewSnapshotOfHistogram(hist)
-			currentHistograms[mf.GetName()] = snapshot
-
-			if pastHistograms == nil {
-				klog.Warning("pastHistograms is nil")
+		past := pastSnapshots[mf.GetName()]
+		current := &PodSnapshot{Timestamp: now, Histograms: map[string]*histogram.Snapshot{}, Counters: map[string]float64{}}
+		currentSnapshots[mf.GetName()] = current
+		var values []float64
+		var snapshots, pastSnapshots []*histogram.Snapshot
+		for _, metric := range mf.Metric {
+			if !matchesLabelPairs(metric.GetLabel(), getMatchLabels(source)) {
 				continue
 			}
-			past, ok := pastHistograms[mf.GetName()]
-			if !ok {
-				past = histogram.NewDefaultSnapshot()
+			key := formatSeriesKey(metric.GetLabel())
+			switch mf.GetType() {
+			case io_prometheus_client.MetricType_COUNTER:
+				value := metric.GetCounter().GetValue()
+				current.Counters[key] = value
+				if !getRate(source) {
+					values = append(values, value)
+					continue
+				}
+				if past == nil {
+					unavailable.Insert(mf.GetName())
+					continue
+				}
+				pastValue, ok := past.Counters[key]
+				elapsedSeconds := float64(now-past.Timestamp) / 1000
+				if !ok || elapsedSeconds <= 0 {
+					continue
+				}
+				increase := value - pastValue
+				if increase < 0 {
+					// The counter was reset.
+					increase = value
+				}
+				values = append(values, increase/elapsedSeconds)
+			case io_prometheus_client.MetricType_GAUGE:
+				values = append(values, metric.GetGauge().GetValue())
+			case io_prometheus_client.MetricType_HISTOGRAM:
+				snapshot := histogram.NewSnapshotOfHistogram(metric.GetHistogram())
+				current.Histograms[key] = snapshot
+				pastSnapshot := histogram.NewDefaultSnapshot()
+				if past != nil {
+					if pastHistogram, ok := past.Histograms[key]; ok {
+						pastSnapshot = pastHistogram
+					}
+				}
+				snapshots = append(snapshots, snapshot)
+				pastSnapshots = append(pastSnapshots, pastSnapshot)
+			default:
+				klog.InfoS("metric type is out of range", "type", mf.GetType())
 			}
-			quantileInDiffMetric, err := histogram.QuantileInDiff(util.SloQuantilePercentile, snapshot, past)
-			if err == nil {
-				addMetric(instanceMetricMap, mf.GetName(), quantileInDiffMetric)
+		}
+		if len(snapshots) > 0 {
+			values = quantileOfSeries(getQuantilePercentile(source), snapshots, pastSnapshots, getAggregation(source))
+		}
+		if len(values) > 0 {
+			podMetrics[mf.GetName()] = aggregateSeries(values, getAggregation(source))
+		}
+	}
+
+	for key := range collector.PodMetrics {
+		if _, ok := podMetrics[key]; !ok && !unavailable.Contains(key) {
+			podMetrics[key] = 0
+		}
+	}
+	return podMetrics
+}
+
+// quantileOfSeries returns the quantiles of the observations of the series of a histogram metric since their past
+// snapshots. With Max, it returns the quantile of each series, otherwise the quantile of all the observations: adding
+// up the quantiles of the series would multiply the latency by the number of series.
+func quantileOfSeries(percentile int32, snapshots, pastSnapshots []*histogram.Snapshot, aggregation v1alpha1.MetricAggregation) []float64 {
+	if aggregation != v1alpha1.MetricAggregationMax {
+		snapshot, err := histogram.Merge(snapshots...)
+		if err != nil {
+			klog.Errorf("error merging the series of the histogram: %v", err)
+			return nil
+		}
+		pastSnapshot, err := histogram.Merge(pastSnapshots...)
+		if err != nil {
+			klog.Errorf("error merging the past series of the histogram: %v", err)
+			return nil
+		}
+		snapshots = []*histogram.Snapshot{snapshot}
+		pastSnapshots = []*histogram.Snapshot{pastSnapshot}
+	}
+	var quantiles []float64
+	for i, snapshot := range snapshots {
+		quantile, err := histogram.QuantileInDiff(percentile, snapshot, pastSnapshots[i])
+		if err == nil {
+			quantiles = append(quantiles, quantile)
+		}
+	}
+	return quantiles
+}
+
+// aggregateSeries combines the values of the series of a metric of a pod.
+func aggregateSeries(values []float64, aggregation v1alpha1.MetricAggregation) float64 {
+	result := 0.0
+	for i, value := range values {
+		switch aggregation {
+		case v1alpha1.MetricAggregationMax:
+			if i == 0 || value > result {
+				result = value
 			}
 		default:
-			klog.InfoS("metric type is out of range", "type", mf.GetType())
+			result += value
 		}
 	}
+	if aggregation == v1alpha1.MetricAggregationAvg {
+		result /= float64(len(values))
+	}
+	return result
+}
+
+// aggregatePods combines the values of a metric of the pods of a target into the sum the algorithm compares to the
+// target value of each pod: the sum of the values, or the value of the busiest pod for each pod with Max.
+func aggregatePods(values []float64, aggregation v1alpha1.MetricAggregation) float64 {
+	if aggregation == v1alpha1.MetricAggregationMax {
+		return aggregateSeries(values, aggregation) * float64(len(values))
+	}
+	return aggregateSeries(values, v1alpha1.MetricAggregationSum)
+}
 
-	for key := range collector.WatchMetricList {
-		if _, ok := instanceMetricMap[key]; !ok {
-			instanceMetricMap[key] = 0
+func matchesLabelPairs(labels []*io_prometheus_client.LabelPair, matchLabels map[string]string) bool {
+	for name, value := range matchLabels {
+		found := false
+		for _, label := range labels {
+			if label.GetName() == name {
+				found = label.GetValue() == value
+				break
+			}
+		}
+		if !found {
+			return false
 		}
 	}
+	return true
 }
 
-func addMetric(instanceMetricMap algorithm.Metrics, metricName string, metricValue float64) {
-	if oldValue, ok := instanceMetricMap[metricName]; ok {
-		instanceMetricMap[metricName] = oldValue + metricValue
-	} else {
-		instanceMetricMap[metricName] = metricValue
+// formatSeriesKey identifies a series of a metric family by its labels, which the exposition format sorts by name.
+func formatSeriesKey(labels []*io_prometheus_client.LabelPair) string {
+	var builder strings.Builder
+	for _, label := range labels {
+		builder.WriteString(label.GetName())
+		builder.WriteByte('=')
+		builder.WriteString(label.GetValue())
+		builder.WriteByte(',')
+	}
+	return builder.String()
+}
+
+func getMetricWindow(source *v1alpha1.PodMetricSource) time.Duration {
+	if source == nil || source.Window == nil {
+		return defaultMetricWindow
+	}
+	return source.Window.Duration
+}
+
+func getQuantilePercentile(source *v1alpha1.PodMetricSource) int32 {
+	if source == nil || source.QuantilePercentile == nil {
+		return defaultQuantilePercentile
+	}
+	return *source.QuantilePercentile
+}
+
+func getAggregation(source *v1alpha1.PodMetricSource) v1alpha1.MetricAggregation {
+	if source == nil || source.Aggregation == "" {
+		return v1alpha1.MetricAggregationSum
+	}
+	return source.Aggregation
+}
+
+func getRate(source *v1alpha1.PodMetricSource) bool {
+	return source != nil && source.Rate
+}
+
+func getMatchLabels(source *v1alpha1.PodMetricSource) map[string]string {
+	if source == nil {
+		return nil
 	}
+	return source.MatchLabels
 }
@@ -0,0 +1,162 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/histogram"
+)
+
+const podMetrics = `# TYPE vllm:num_requests_waiting gauge
+vllm:num_requests_waiting{model_name="llama"} 4
+vllm:num_requests_waiting{model_name="lora-a"} 2
+vllm:num_requests_waiting{model_name="qwen"} 9
+# TYPE vllm:request_success_total counter
+vllm:request_success_total{model_name="llama"} 130
+vllm:request_success_total{model_name="qwen"} 50
+`
+
+func TestProcessPrometheusString(t *testing.T) {
+	collector := &MetricCollector{PodMetrics: map[string]*v1alpha1.PodMetricSource{
+		"vllm:num_requests_waiting": {
+			MatchLabels: map[string]string{"model_name": "llama"},
+		},
+		"vllm:request_success_total": {
+			Rate: true,
+		},
+		"vllm:gpu_cache_usage_perc": nil,
+	}}
+
+	// Without a past snapshot, the rate of the counter is unavailable.
+	current := map[string]*PodSnapshot{}
+	metrics := collector.processPrometheusString(podMetrics, 60000, map[string]*PodSnapshot{}, current)
+	if _, ok := metrics["vllm:request_success_total"]; ok {
+		t.Fatalf("expected no counter rate without a past snapshot, got %v", metrics)
+	}
+	if metrics["vllm:num_requests_waiting"] != 4 {
+		t.Fatalf("expected the waiting requests of llama 4, got %v", metrics["vllm:num_requests_waiting"])
+	}
+	if value, ok := metrics["vllm:gpu_cache_usage_perc"]; !ok || value != 0 {
+		t.Fatalf("expected a missing metric to be 0, got %v", metrics)
+	}
+	if len(current["vllm:request_success_total"].Counters) != 2 {
+		t.Fatalf("expected the counter series in the snapshot, got %v", current["vllm:request_success_total"])
+	}
+
+	// The counters increased by 120 over 60 seconds.
+	past := map[string]*PodSnapshot{"vllm:request_success_total": {
+		Timestamp: 0,
+		Counters: map[string]float64{
+			`model_name=llama,`: 10,
+			`model_name=qwen,`:  50,
+		},
+	}}
+	metrics = collector.processPrometheusString(podMetrics, 60000, past, map[string]*PodSnapshot{})
+	if metrics["vllm:request_success_total"] != 2 {
+		t.Fatalf("expected a rate of 2 requests per second, got %v", metrics["vllm:request_success_total"])
+	}
+
+	collector.PodMetrics["vllm:num_requests_waiting"] = &v1alpha1.PodMetricSource{Aggregation: v1alpha1.MetricAggregationMax}
+	metrics = collector.processPrometheusString(podMetrics, 60000, past, map[string]*PodSnapshot{})
+	if metrics["vllm:num_requests_waiting"] != 9 {
+		t.Fatalf("expected the max waiting requests 9, got %v", metrics["vllm:num_requests_waiting"])
+	}
+}
+
+func TestProcessPrometheusString_Histogram(t *testing.T) {
+	body := `# TYPE vllm:time_to_first_token_seconds histogram
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="0.1"} 10
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="1"} 20
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="+Inf"} 20
+vllm:time_to_first_token_seconds_sum{model_name="llama"} 6
+vllm:time_to_first_token_seconds_count{model_name="llama"} 20
+`
+	percentile := int32(50)
+	collector := &MetricCollector{PodMetrics: map[string]*v1alpha1.PodMetricSource{
+		"vllm:time_to_first_token_seconds": {QuantilePercentile: &percentile},
+	}}
+	current := map[string]*PodSnapshot{}
+	metrics := collector.processPrometheusString(body, 0, map[string]*PodSnapshot{}, current)
+	snapshot := current["vllm:time_to_first_token_seconds"].Histograms[`model_name=llama,`]
+	expected, err := histogram.QuantileInDiff(percentile, snapshot, histogram.NewDefaultSnapshot())
+	if err != nil {
+		t.Fatalf("QuantileInDiff error: %v", err)
+	}
+	if metrics["vllm:time_to_first_token_seconds"] != expected {
+		t.Fatalf("expected the median %v, got %v", expected, metrics["vllm:time_to_first_token_seconds"])
+	}
+}
+
+func TestProcessPrometheusString_HistogramSeries(t *testing.T) {
+	// The requests of llama are all fast and those of qwen all slow.
+	body := `# TYPE vllm:time_to_first_token_seconds histogram
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="0.1"} 30
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="1"} 30
+vllm:time_to_first_token_seconds_bucket{model_name="llama",le="+Inf"} 30
+vllm:time_to_first_token_seconds_sum{model_name="llama"} 1.5
+vllm:time_to_first_token_seconds_count{model_name="llama"} 30
+vllm:time_to_first_token_seconds_bucket{model_name="qwen",le="0.1"} 0
+vllm:time_to_first_token_seconds_bucket{model_name="qwen",le="1"} 10
+vllm:time_to_first_token_seconds_bucket{model_name="qwen",le="+Inf"} 10
+vllm:time_to_first_token_seconds_sum{model_name="qwen"} 5
+vllm:time_to_first_token_seconds_count{model_name="qwen"} 10
+`
+	percentile := int32(50)
+	collector := &MetricCollector{PodMetrics: map[string]*v1alpha1.PodMetricSource{
+		"vllm:time_to_first_token_seconds": {QuantilePercentile: &percentile},
+	}}
+
+	// The median of the 40 requests is fast, rather than the sum of the medians of the series.
+	current := map[string]*PodSnapshot{}
+	metrics := collector.processPrometheusString(body, 0, map[string]*PodSnapshot{}, current)
+	if value := metrics["vllm:time_to_first_token_seconds"]; value < 0 || value > 0.1 {
+		t.Fatalf("expected the median of all the requests within 0.1, got %v", value)
+	}
+
+	// The past snapshots of the series are merged too: only the slow requests are new.
+	past := current
+	body = strings.ReplaceAll(body, `model_name="qwen",le="1"} 10`, `model_name="qwen",le="1"} 20`)
+	body = strings.ReplaceAll(body, `model_name="qwen",le="+Inf"} 10`, `model_name="qwen",le="+Inf"} 20`)
+	body = strings.ReplaceAll(body, `count{model_name="qwen"} 10`, `count{model_name="qwen"} 20`)
+	metrics = collector.processPrometheusString(body, 60000, past, map[string]*PodSnapshot{})
+	if value := metrics["vllm:time_to_first_token_seconds"]; value <= 0.1 || value > 1 {
+		t.Fatalf("expected the median of the new requests within (0.1, 1], got %v", value)
+	}
+
+	// With Max, the median of the slowest series.
+	collector.PodMetrics["vllm:time_to_first_token_seconds"].Aggregation = v1alpha1.MetricAggregationMax
+	metrics = collector.processPrometheusString(body, 0, map[string]*PodSnapshot{}, map[string]*PodSnapshot{})
+	if value := metrics["vllm:time_to_first_token_seconds"]; value <= 0.1 || value > 1 {
+		t.Fatalf("expected the median of qwen within (0.1, 1], got %v", value)
+	}
+}
+
+func TestAggregatePods(t *testing.T) {
+	values := []float64{1, 2, 6}
+	if value := aggregatePods(values, v1alpha1.MetricAggregationSum); value != 9 {
+		t.Fatalf("expected sum 9, got %v", value)
+	}
+	if value := aggregatePods(values, v1alpha1.MetricAggregationAvg); value != 9 {
+		t.Fatalf("expected avg compared per pod as the sum 9, got %v", value)
+	}
+	if value := aggregatePods(values, v1alpha1.MetricAggregationMax); value != 18 {
+		t.Fatalf("expected the busiest pod for each pod 18, got %v", value)
+	}
+}
@@ -30,7 +30,11 @@ type Optimizer struct {
 	Meta       *OptimizerMeta
 	Collectors map[string]*MetricCollector
 	Status     *Status
+	// CapacityCaps are the caps of the targets some of whose replicas could not be scheduled, by target name.
+	CapacityCaps map[string]*CapacityCap
 	Generations
+	// now returns the current timestamp in milliseconds.
+	now func() int64
 }
 
 type OptimizerMeta struct {
@@ -40,6 +44,9 @@ type OptimizerMeta struct {
 	MinReplicas   int32
 	MaxReplicas   int32
 	Scope         Scope
+	// CapacityBackoff is how long a target is capped after some of its replicas could not be scheduled, in
+	// milliseconds. Zero disables the caps.
+	CapacityBackoff int64
 }
 
 type ReplicaBlock struct {
@@ -49,7 +56,9 @@ type ReplicaBlock struct {
 	cost     int64
 }
 
-func (meta *OptimizerMeta) RestoreReplicasOfEachBackend(replicas int32) map[string]int32 {
+// RestoreReplicasOfEachBackend splits replicas across the targets, filling the cheapest replica blocks first. The
+// blocks of a target in caps are filled up to its cap only, and the replicas beyond spill over to the next blocks.
+func (meta *OptimizerMeta) RestoreReplicasOfEachBackend(replicas int32, caps map[string]int32) map[string]int32 {
 	replicasMap := make(map[string]int32, len(meta.Config.Params))
 	for _, param := range meta.Config.Params {
 		replicasMap[param.Target.TargetRef.Name] = param.MinReplicas
@@ -58,6 +67,9 @@ func (meta *OptimizerMeta) RestoreReplicasOfEachBackend(replicas int32) map[stri
 	replicas -= meta.MinReplicas
 	for _, block := range meta.ScalingOrder {
 		slot := min(replicas, block.replicas)
+		if limit, ok := caps[block.name]; ok {
+			slot = max(min(slot, limit-replicasMap[block.name]), 0)
+		}
 		replicasMap[block.name] += slot
 		replicas -= slot
 		if replicas <= 0 {
@@ -112,10 +124,11 @@ func NewOptimizerMeta(binding *workload.AutoscalingPolicyBinding) *OptimizerMeta
 		return scalingOrder[i].index < scalingOrder[j].index
 	})
 	return &OptimizerMeta{
-		Config:       binding.Spec.HeterogeneousTarget,
-		MinReplicas:  minReplicas,
-		MaxReplicas:  maxReplicas,
-		ScalingOrder: scalingOrder,
+		Config:          binding.Spec.HeterogeneousTarget,
+		MinReplicas:     minReplicas,
+		MaxReplicas:     maxReplicas,
+		ScalingOrder:    scalingOrder,
+		CapacityBackoff: getCapacityBackoff(binding.Spec.HeterogeneousTarget),
 		Scope: Scope{
 			OwnedBindingId: binding.UID,
 			Namespace:      binding.Namespace,
@@ -123,11 +136,12 @@ func NewOptimizerMeta(binding *workload.AutoscalingPolicyBinding) *OptimizerMeta
 	}
 }
 
-func NewOptimizer(autoscalePolicy *workload.AutoscalingPolicy, binding *workload.AutoscalingPolicyBinding) *Optimizer {
+// NewOptimizer creates the optimizer of a heterogeneous target. now returns the current timestamp in milliseconds.
+func NewOptimizer(autoscalePolicy *workload.AutoscalingPolicy, binding *workload.AutoscalingPolicyBinding, now func() int64) *Optimizer {
 	metricTargets := GetMetricTargets(autoscalePolicy)
 	collectors := make(map[string]*MetricCollector)
 	for _, param := range binding.Spec.HeterogeneousTarget.Params {
-		collectors[param.Target.TargetRef.Name] = NewMetricCollector(&param.Target, binding, metricTargets)
+		collectors[param.Target.TargetRef.Name] = NewMetricCollector(&param.Target, binding, autoscalePolicy, now)
 	}
 
 	meta := NewOptimizerMeta(binding)
@@ -135,11 +149,12 @@ func NewOptimizer(autoscalePolicy *workload.AutoscalingPolicy, binding *workload
 	return &Optimizer{
 		Meta:       meta,
 		Collectors: collectors,
-		Status:     NewStatus(&autoscalePolicy.Spec.Behavior),
+		Status:     NewStatus(&autoscalePolicy.Spec, now),
 		Generations: Generations{
 			AutoscalePolicyGeneration: autoscalePolicy.Generation,
 			BindingGeneration:         binding.Generation,
 		},
+		now: now,
 	}
 }
 
@@ -148,7 +163,9 @@ func (optimizer *Optimizer) NeedUpdate(policy *workload.AutoscalingPolicy, bindi
 		optimizer.Generations.BindingGeneration != binding.Generation
 }
 
-func (optimizer *Optimizer) Optimize(ctx context.Context, podLister listerv1.PodLister, autoscalePolicy *workload.AutoscalingPolicy, currentInstancesCounts map[string]int32) (map[string]int32, error) {
+// Optimize returns the replicas of each target recommended by the metrics scraped from their pods and the external
+// metrics, which are the values of the metrics of the other sources for all the targets.
+func (optimizer *Optimizer) Optimize(ctx context.Context, podLister listerv1.PodLister, autoscalePolicy *workload.AutoscalingPolicy, currentInstancesCounts map[string]int32, externalMetrics algorithm.Metrics) (map[string]int32, error) {
 	size := len(optimizer.Meta.Config.Params)
 	unreadyInstancesCount := int32(0)
 	readyInstancesMetrics := make([]algorithm.Metrics, 0, size)
@@ -170,22 +187,37 @@ func (optimizer *Optimizer) Optimize(ctx context.Context, podLister listerv1.Pod
 		unreadyInstancesCount += currentUnreadyInstancesCount
 		readyInstancesMetrics = append(readyInstancesMetrics, currentReadyInstancesMetrics)
 	}
+	optimizer.updateCapacityCaps(podLister, currentInstancesCounts)
+	// The schedules and the forecast of the policy raise the minimum instances, within the maximum instances.
+	minInstances, raisedReason := optimizer.Status.raiseMinInstances(&autoscalePolicy.Spec, optimizer.Meta.MinReplicas)
+	minInstances = min(minInstances, optimizer.Meta.MaxReplicas)
 	// Get recommended replicas of all model serving instances
 	instancesAlgorithm := algorithm.RecommendedInstancesAlgorithm{
-		MinInstances:          optimizer.Meta.MinReplicas,
+		MinInstances:          minInstances,
 		MaxInstances:          optimizer.Meta.MaxReplicas,
 		CurrentInstancesCount: instancesCountSum,
 		Tolerance:             float64(autoscalePolicy.Spec.TolerancePercent) * 0.01,
 		MetricTargets:         optimizer.Meta.MetricTargets,
 		UnreadyInstancesCount: unreadyInstancesCount,
 		ReadyInstancesMetrics: readyInstancesMetrics,
-		ExternalMetrics:       make(algorithm.Metrics),
+		ExternalMetrics:       externalMetrics,
 	}
-	recommendedInstances, skip := instancesAlgorithm.GetRecommendedInstances()
-	if skip {
+	optimizer.Status.observeDemand(instancesAlgorithm)
+	recommendation := instancesAlgorithm.GetRecommendation()
+	optimizer.Status.LastDecision.Metrics = observedMetrics(instancesAlgorithm.MetricTargets, readyInstancesMetrics, externalMetrics)
+	if recommendation.Skip {
 		klog.Warning("skip recommended instances")
+		optimizer.Status.LastDecision.Reason = workload.AutoscalingReasonMetricsUnavailable
+		// The unschedulable replicas of the capped targets move to the other targets even without metrics, since
+		// the metrics of a target are unavailable while its replicas are pending.
+		for name, replicas := range optimizer.cappedReplicas() {
+			if currentInstancesCounts[name] > replicas {
+				return optimizer.restoreReplicas(instancesCountSum, currentInstancesCounts), nil
+			}
+		}
 		return nil, nil
 	}
+	recommendedInstances := recommendation.Instances
 	if recommendedInstances*100 >= instancesCountSum*(*autoscalePolicy.Spec.Behavior.ScaleUp.PanicPolicy.PanicThresholdPercent) {
 		optimizer.Status.RefreshPanicMode()
 	}
@@ -193,16 +225,19 @@ func (optimizer *Optimizer) Optimize(ctx context.Context, podLister listerv1.Pod
 		IsPanic:              optimizer.Status.IsPanicMode(),
 		History:              optimizer.Status.History,
 		Behavior:             &autoscalePolicy.Spec.Behavior,
-		MinInstances:         optimizer.Meta.MinReplicas,
+		MinInstances:         minInstances,
 		MaxInstances:         optimizer.Meta.MaxReplicas,
 		CurrentInstances:     instancesCountSum,
 		RecommendedInstances: recommendedInstances}
-	recommendedInstances = CorrectedInstancesAlgorithm.GetCorrectedInstances()
+	correctedInstances := CorrectedInstancesAlgorithm.GetCorrectedInstances()
+	optimizer.Status.LastDecision.Reason = decisionReason(recommendation, correctedInstances, instancesCountSum, raisedReason)
 
-	klog.InfoS("autoscale controller", "recommendedInstances", recommendedInstances, "correctedInstances", recommendedInstances)
+	klog.InfoS("autoscale controller", "recommendedInstances", recommendedInstances, "correctedInstances", correctedInstances)
+	// The recommendation, rather than the corrected instances, is recorded like for a single target, so that a
+	// stabilized recommendation does not keep itself in the stabilization window.
 	optimizer.Status.AppendRecommendation(recommendedInstances)
-	optimizer.Status.AppendCorrected(recommendedInstances)
+	optimizer.Status.AppendCorrected(correctedInstances)
 
-	replicasMap := optimizer.Meta.RestoreReplicasOfEachBackend(recommendedInstances)
+	replicasMap := optimizer.restoreReplicas(correctedInstances, currentInstancesCounts)
 	return replicasMap, nil
 }
@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+)
+
+func TestOptimizeScaleDownStabilization(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC).UnixMilli()
+	policy := &v1alpha1.AutoscalingPolicy{Spec: v1alpha1.AutoscalingPolicySpec{
+		Metrics: []v1alpha1.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}},
+		Behavior: v1alpha1.AutoscalingPolicyBehavior{
+			ScaleUp: v1alpha1.AutoscalingPolicyScaleUpPolicy{
+				PanicPolicy: v1alpha1.AutoscalingPolicyPanicPolicy{Period: metav1.Duration{Duration: time.Minute}, PanicThresholdPercent: ptr.To[int32](1000)},
+			},
+			ScaleDown: v1alpha1.AutoscalingPolicyStablePolicy{
+				Instances:           ptr.To[int32](10),
+				Percent:             ptr.To[int32](100),
+				SelectPolicy:        v1alpha1.SelectPolicyOr,
+				StabilizationWindow: &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+	}}
+	optimizer := NewOptimizer(policy, newCapacityBinding(nil), func() int64 { return now })
+	podLister := listerv1.NewPodLister(cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}))
+	counts := map[string]int32{"cheap": 5, "expensive": 3}
+	optimize := func(load float64) map[string]int32 {
+		replicas, err := optimizer.Optimize(context.Background(), podLister, policy, counts, algorithm.Metrics{"load": load})
+		if err != nil {
+			t.Fatalf("Optimize() error: %v", err)
+		}
+		return replicas
+	}
+
+	if got := optimize(8); !reflect.DeepEqual(got, counts) {
+		t.Fatalf("Optimize() = %v, want the current replicas", got)
+	}
+	// The scale down is stabilized while the recommendation of 8 instances is in the window, and the stabilized
+	// decisions every minute don't hold the replicas once it left.
+	for minute := 1; minute <= 5; minute++ {
+		now += time.Minute.Milliseconds()
+		if got := optimize(2); !reflect.DeepEqual(got, counts) {
+			t.Fatalf("Optimize() = %v after %d minutes, want the stabilized replicas", got, minute)
+		}
+		if optimizer.Status.LastDecision.Reason != v1alpha1.AutoscalingReasonStabilized {
+			t.Errorf("reason = %s after %d minutes, want Stabilized", optimizer.Status.LastDecision.Reason, minute)
+		}
+	}
+	now += time.Minute.Milliseconds()
+	if got := optimize(2); !reflect.DeepEqual(got, map[string]int32{"cheap": 2, "expensive": 0}) {
+		t.Fatalf("Optimize() = %v, want the replicas scaled down after the stabilization window", got)
+	}
+}
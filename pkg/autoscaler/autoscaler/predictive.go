@@ -0,0 +1,126 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"math"
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
+	"k8s.io/klog/v2"
+)
+
+const (
+	defaultSeason                   = 24 * time.Hour
+	defaultForecastInterval         = 5 * time.Minute
+	defaultLeadTime                 = 10 * time.Minute
+	defaultLevelSmoothingPercent    = 50
+	defaultTrendSmoothingPercent    = 10
+	defaultSeasonalSmoothingPercent = 30
+)
+
+// ScheduledMinReplicas returns the highest minimum replicas of the schedules active at now, and whether a schedule
+// is active. The invalid schedules are ignored.
+func ScheduledMinReplicas(schedules []workload.AutoscalingSchedule, now time.Time) (int32, bool) {
+	minReplicas, active := int32(0), false
+	for _, schedule := range schedules {
+		cron, err := algorithm.ParseCronSchedule(schedule.Schedule)
+		if err != nil {
+			klog.Errorf("invalid schedule %s: %v", schedule.Name, err)
+			continue
+		}
+		location := time.UTC
+		if schedule.TimeZone != "" {
+			if location, err = time.LoadLocation(schedule.TimeZone); err != nil {
+				klog.Errorf("invalid time zone of schedule %s: %v", schedule.Name, err)
+				continue
+			}
+		}
+		if cron.Active(now.In(location), schedule.Duration.Duration) {
+			minReplicas, active = max(minReplicas, schedule.MinReplicas), true
+		}
+	}
+	return minReplicas, active
+}
+
+// NewForecaster returns the forecaster of a predictive policy, nil without predictive policy.
+func NewForecaster(predictive *workload.PredictivePolicy) *algorithm.SeasonalForecaster {
+	if predictive == nil {
+		return nil
+	}
+	season, interval := defaultSeason, defaultForecastInterval
+	if predictive.Season != nil {
+		season = predictive.Season.Duration
+	}
+	if predictive.Interval != nil && predictive.Interval.Duration > 0 {
+		interval = predictive.Interval.Duration
+	}
+	percent := func(value *int32, defaultValue int32) float64 {
+		if value == nil {
+			return float64(defaultValue) / 100
+		}
+		return float64(*value) / 100
+	}
+	return algorithm.NewSeasonalForecaster(interval.Milliseconds(), int64(season/interval),
+		percent(predictive.LevelSmoothingPercent, defaultLevelSmoothingPercent),
+		percent(predictive.TrendSmoothingPercent, defaultTrendSmoothingPercent),
+		percent(predictive.SeasonalSmoothingPercent, defaultSeasonalSmoothingPercent))
+}
+
+// raiseMinInstances returns minInstances raised to the minimum replicas of the active schedules of the policy and
+// to the instances forecast for the lead time of its predictive policy, and the reason of the raise, empty if
+// minInstances was not raised. They are recorded in the last decision.
+func (s *Status) raiseMinInstances(spec *workload.AutoscalingPolicySpec, minInstances int32) (int32, workload.AutoscalingReason) {
+	now := s.now()
+	s.LastDecision.ScheduledMinInstances, s.LastDecision.PredictedInstances = nil, nil
+	raised, reason := minInstances, workload.AutoscalingReason("")
+	if scheduled, ok := ScheduledMinReplicas(spec.Schedules, time.UnixMilli(now)); ok {
+		s.LastDecision.ScheduledMinInstances = &scheduled
+		if scheduled > raised {
+			raised, reason = scheduled, workload.AutoscalingReasonScheduled
+		}
+	}
+	if s.Forecaster != nil && spec.Predictive != nil {
+		leadTime := defaultLeadTime
+		if spec.Predictive.LeadTime != nil {
+			leadTime = spec.Predictive.LeadTime.Duration
+		}
+		if forecast, ok := s.Forecaster.Forecast(now, leadTime.Milliseconds()); ok {
+			predicted := algorithm.ForecastInstances(forecast)
+			s.LastDecision.PredictedInstances = &predicted
+			if predicted > raised {
+				raised, reason = predicted, workload.AutoscalingReasonPredicted
+			}
+		}
+	}
+	return raised, reason
+}
+
+// observeDemand records the instances the metrics ask for in the history of the forecaster. They are recommended
+// without tolerance nor bounds, so that the history does not depend on the current instances.
+func (s *Status) observeDemand(instancesAlgorithm algorithm.RecommendedInstancesAlgorithm) {
+	if s.Forecaster == nil {
+		return
+	}
+	instancesAlgorithm.Tolerance = 0
+	instancesAlgorithm.MinInstances = 0
+	instancesAlgorithm.MaxInstances = math.MaxInt32
+	if demand := instancesAlgorithm.GetRecommendation(); !demand.Skip {
+		s.Forecaster.Observe(s.now(), float64(demand.Unbounded))
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package autoscaler
+
+import (
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"k8s.io/klog/v2"
+)
+
+const defaultIdleWindow = 5 * time.Minute
+
+// Demand is the demand the routers observed for the ModelServers of a target.
+type Demand struct {
+	// Waiting is the number of requests held by the routers until the target is ready.
+	Waiting int32
+	// LastRequestTime is the timestamp of the last request, or 0 if there was none.
+	LastRequestTime int64
+	// InputTokensPerSecond and OutputTokensPerSecond are the rates of the tokens over the last minute.
+	InputTokensPerSecond  float64
+	OutputTokensPerSecond float64
+}
+
+// ScaleToZero returns the replicas of a target which scales to zero, and whether they override the replicas
+// recommended by Scale. The target is scaled to zero once it received no request during the idle window, and
+// back to MinReplicas, at least one, once it receives a request again. It is neither scaled to nor kept at zero
+// while the schedules or the forecast of the policy ask for instances.
+func (autoscaler *Autoscaler) ScaleToZero(autoscalePolicy *workload.AutoscalingPolicy, demand Demand, currentInstancesCount int32) (int32, bool) {
+	config := autoscaler.Meta.Config
+	if config.ScaleToZero == nil {
+		return 0, false
+	}
+	if raised, _ := autoscaler.Status.raiseMinInstances(&autoscalePolicy.Spec, 0); raised > 0 {
+		return 0, false
+	}
+	idleWindow := defaultIdleWindow
+	if config.ScaleToZero.IdleWindow != nil {
+		idleWindow = config.ScaleToZero.IdleWindow.Duration
+	}
+
+	now := autoscaler.Status.now()
+	newRequest := demand.LastRequestTime > autoscaler.Status.LastActiveTime
+	if newRequest {
+		autoscaler.Status.LastActiveTime = demand.LastRequestTime
+	}
+	if demand.Waiting > 0 {
+		autoscaler.Status.LastActiveTime = now
+	}
+
+	if currentInstancesCount == 0 {
+		if newRequest || demand.Waiting > 0 {
+			klog.InfoS("scale target from zero", "waiting", demand.Waiting)
+			autoscaler.Status.LastDecision = Decision{Reason: workload.AutoscalingReasonScaledFromZero}
+			return max(config.MinReplicas, 1), true
+		}
+		autoscaler.Status.LastDecision = Decision{Reason: workload.AutoscalingReasonScaledToZero}
+		return 0, true
+	}
+	if now-autoscaler.Status.LastActiveTime >= idleWindow.Milliseconds() {
+		klog.InfoS("scale idle target to zero", "idleWindow", idleWindow)
+		autoscaler.Status.LastDecision = Decision{Reason: workload.AutoscalingReasonScaledToZero}
+		return 0, true
+	}
+	return 0, false
+}
@@ -37,10 +37,11 @@ type ScalingMeta struct {
 	Generations
 }
 
-func NewAutoscaler(autoscalePolicy *workload.AutoscalingPolicy, binding *workload.AutoscalingPolicyBinding) *Autoscaler {
+// NewAutoscaler creates the autoscaler of a homogeneous target. now returns the current timestamp in milliseconds.
+func NewAutoscaler(autoscalePolicy *workload.AutoscalingPolicy, binding *workload.AutoscalingPolicyBinding, now func() int64) *Autoscaler {
 	return &Autoscaler{
-		Status:    NewStatus(&autoscalePolicy.Spec.Behavior),
-		Collector: NewMetricCollector(&binding.Spec.HomogeneousTarget.Target, binding, GetMetricTargets(autoscalePolicy)),
+		Status:    NewStatus(&autoscalePolicy.Spec, now),
+		Collector: NewMetricCollector(&binding.Spec.HomogeneousTarget.Target, binding, autoscalePolicy, now),
 		Meta: &ScalingMeta{
 			Config:    binding.Spec.HomogeneousTarget,
 			Namespace: binding.Namespace,
@@ -64,28 +65,42 @@ func (autoscaler *Autoscaler) UpdateAutoscalePolicy(autoscalePolicy *workload.Au
 	autoscaler.Meta.Generations.AutoscalePolicyGeneration = autoscalePolicy.Generation
 }
 
-func (autoscaler *Autoscaler) Scale(ctx context.Context, podLister listerv1.PodLister, autoscalePolicy *workload.AutoscalingPolicy, currentInstancesCount int32) (int32, error) {
+// Scale returns the replicas of the target recommended by the metrics scraped from its pods and the external
+// metrics, which are the values of the metrics of the other sources.
+func (autoscaler *Autoscaler) Scale(ctx context.Context, podLister listerv1.PodLister, autoscalePolicy *workload.AutoscalingPolicy, currentInstancesCount int32, externalMetrics algorithm.Metrics) (int32, error) {
 	unreadyInstancesCount, readyInstancesMetrics, err := autoscaler.Collector.UpdateMetrics(ctx, podLister)
 	if err != nil {
 		klog.Errorf("update metrics error: %v", err)
 		return -1, err
 	}
+	// A target which scales to zero is only scaled to zero once it is idle, see ScaleToZero.
+	minInstances := autoscaler.Meta.Config.MinReplicas
+	if autoscaler.Meta.Config.ScaleToZero != nil {
+		minInstances = max(minInstances, 1)
+	}
+	// The schedules and the forecast of the policy raise the minimum instances, within the maximum instances.
+	minInstances, raisedReason := autoscaler.Status.raiseMinInstances(&autoscalePolicy.Spec, minInstances)
+	minInstances = min(minInstances, autoscaler.Meta.Config.MaxReplicas)
 	// minInstance <- AutoscaleScope, currentInstancesCount(replicas) <- workload
 	instancesAlgorithm := algorithm.RecommendedInstancesAlgorithm{
-		MinInstances:          autoscaler.Meta.Config.MinReplicas,
+		MinInstances:          minInstances,
 		MaxInstances:          autoscaler.Meta.Config.MaxReplicas,
 		CurrentInstancesCount: currentInstancesCount,
 		Tolerance:             float64(autoscalePolicy.Spec.TolerancePercent) * 0.01,
 		MetricTargets:         autoscaler.Collector.MetricTargets,
 		UnreadyInstancesCount: unreadyInstancesCount,
 		ReadyInstancesMetrics: []algorithm.Metrics{readyInstancesMetrics},
-		ExternalMetrics:       make(algorithm.Metrics),
+		ExternalMetrics:       externalMetrics,
 	}
-	recommendedInstances, skip := instancesAlgorithm.GetRecommendedInstances()
-	if skip {
+	autoscaler.Status.observeDemand(instancesAlgorithm)
+	recommendation := instancesAlgorithm.GetRecommendation()
+	autoscaler.Status.LastDecision.Metrics = observedMetrics(instancesAlgorithm.MetricTargets, instancesAlgorithm.ReadyInstancesMetrics, externalMetrics)
+	if recommendation.Skip {
 		klog.InfoS("skip recommended instances")
+		autoscaler.Status.LastDecision.Reason = workload.AutoscalingReasonMetricsUnavailable
 		return -1, nil
 	}
+	recommendedInstances := recommendation.Instances
 	if autoscalePolicy.Spec.Behavior.ScaleUp.PanicPolicy.PanicThresholdPercent != nil && recommendedInstances*100 >= currentInstancesCount*(*autoscalePolicy.Spec.Behavior.ScaleUp.PanicPolicy.PanicThresholdPercent) {
 		autoscaler.Status.RefreshPanicMode()
 	}
@@ -93,12 +108,13 @@ func (autoscaler *Autoscaler) Scale(ctx context.Context, podLister listerv1.PodL
 		IsPanic:              autoscaler.Status.IsPanicMode(),
 		History:              autoscaler.Status.History,
 		Behavior:             &autoscalePolicy.Spec.Behavior,
-		MinInstances:         autoscaler.Meta.Config.MinReplicas,
+		MinInstances:         minInstances,
 		MaxInstances:         autoscaler.Meta.Config.MaxReplicas,
 		CurrentInstances:     currentInstancesCount,
 		RecommendedInstances: recommendedInstances,
 	}
 	correctedInstances := CorrectedInstancesAlgorithm.GetCorrectedInstances()
+	autoscaler.Status.LastDecision.Reason = decisionReason(recommendation, correctedInstances, currentInstancesCount, raisedReason)
 
 	klog.InfoS("autoscale controller", "currentInstancesCount", currentInstancesCount, "recommendedInstances", recommendedInstances, "correctedInstances", correctedInstances)
 	autoscaler.Status.AppendRecommendation(recommendedInstances)
@@ -20,16 +20,38 @@ import (
 	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
 	"github.com/volcano-sh/kthena/pkg/autoscaler/datastructure"
-	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
 )
 
 type Status struct {
 	PanicModeEndsAt           int64
 	PanicModeHoldMilliseconds int64
 	History                   *algorithm.History
+	// LastActiveTime is the timestamp of the last request the routers received for a target which scales to zero.
+	LastActiveTime int64
+	// LastDecision is the last scaling decision.
+	LastDecision Decision
+	// Forecaster forecasts the instances the metrics ask for, nil without predictive policy.
+	Forecaster *algorithm.SeasonalForecaster
+	// now returns the current timestamp in milliseconds.
+	now func() int64
 }
 
-func NewStatus(behavior *v1alpha1.AutoscalingPolicyBehavior) *Status {
+// Decision is a scaling decision of an Autoscaler or an Optimizer.
+type Decision struct {
+	Reason v1alpha1.AutoscalingReason
+	// Metrics are the observed values of the metrics. The values of the metrics scraped from the pods
+	// are summed over the ready pods.
+	Metrics algorithm.Metrics
+	// ScheduledMinInstances is the minimum instances of the active schedules, nil when no schedule is active.
+	ScheduledMinInstances *int32
+	// PredictedInstances is the peak of the instances forecast for the lead time, nil without forecast.
+	PredictedInstances *int32
+}
+
+// NewStatus creates the status of a target scaled by the policy. now returns the current timestamp in milliseconds,
+// the wall clock or e.g. the simulated clock of the simulator.
+func NewStatus(spec *v1alpha1.AutoscalingPolicySpec, now func() int64) *Status {
+	behavior := &spec.Behavior
 	panicModeHoldMilliseconds := int64(0)
 	if behavior.ScaleUp.PanicPolicy.PanicModeHold != nil {
 		panicModeHoldMilliseconds = behavior.ScaleUp.PanicPolicy.PanicModeHold.Milliseconds()
@@ -53,13 +75,16 @@ func NewStatus(behavior *v1alpha1.AutoscalingPolicyBehavior) *Status {
 	return &Status{
 		PanicModeEndsAt:           0,
 		PanicModeHoldMilliseconds: panicModeHoldMilliseconds,
+		LastActiveTime:            now(),
+		Forecaster:                NewForecaster(spec.Predictive),
 		History: &algorithm.History{
-			MaxRecommendation:     datastructure.NewMaximumRecordSlidingWindow[int32](scaleDownStabilizationWindowMilliseconds),
-			MinRecommendation:     datastructure.NewMinimumRecordSlidingWindow[int32](scaleUpStabilizationWindowMilliseconds),
-			MaxCorrected:          datastructure.NewMinimumLineChartSlidingWindow[int32](scaleDownPeriodMilliseconds),
-			MinCorrectedForStable: datastructure.NewMinimumLineChartSlidingWindow[int32](scaleUpStablePolicyPeriodMilliseconds),
-			MinCorrectedForPanic:  datastructure.NewMinimumLineChartSlidingWindow[int32](behavior.ScaleUp.PanicPolicy.Period.Milliseconds()),
+			MaxRecommendation:     datastructure.NewMaximumRecordSlidingWindow[int32](scaleDownStabilizationWindowMilliseconds, now),
+			MinRecommendation:     datastructure.NewMinimumRecordSlidingWindow[int32](scaleUpStabilizationWindowMilliseconds, now),
+			MaxCorrected:          datastructure.NewMinimumLineChartSlidingWindow[int32](scaleDownPeriodMilliseconds, now),
+			MinCorrectedForStable: datastructure.NewMinimumLineChartSlidingWindow[int32](scaleUpStablePolicyPeriodMilliseconds, now),
+			MinCorrectedForPanic:  datastructure.NewMinimumLineChartSlidingWindow[int32](behavior.ScaleUp.PanicPolicy.Period.Milliseconds(), now),
 		},
+		now: now,
 	}
 }
 
@@ -78,10 +103,57 @@ func (s *Status) RefreshPanicMode() {
 	if s.PanicModeHoldMilliseconds == 0 {
 		s.PanicModeEndsAt = 0
 	} else {
-		s.PanicModeEndsAt = util.GetCurrentTimestamp() + s.PanicModeHoldMilliseconds
+		s.PanicModeEndsAt = s.now() + s.PanicModeHoldMilliseconds
 	}
 }
 
 func (s *Status) IsPanicMode() bool {
-	return s.PanicModeHoldMilliseconds > 0 && util.GetCurrentTimestamp() <= s.PanicModeEndsAt
+	return s.PanicModeHoldMilliseconds > 0 && s.now() <= s.PanicModeEndsAt
+}
+
+// InheritForecast takes over the history of the forecaster of the previous status of the target, e.g. when the
+// policy changed.
+func (s *Status) InheritForecast(previous *Status) {
+	if s.Forecaster != nil && previous != nil {
+		s.Forecaster.Inherit(previous.Forecaster)
+	}
+}
+
+// decisionReason explains why the replicas became corrected, given the recommendation of the metrics and the
+// reason the minimum instances were raised, if any.
+func decisionReason(recommendation algorithm.Recommendation, corrected int32, current int32, raised v1alpha1.AutoscalingReason) v1alpha1.AutoscalingReason {
+	switch {
+	case recommendation.Skip:
+		return v1alpha1.AutoscalingReasonMetricsUnavailable
+	case corrected != recommendation.Instances:
+		return v1alpha1.AutoscalingReasonStabilized
+	case recommendation.Instances < recommendation.Unbounded:
+		return v1alpha1.AutoscalingReasonBoundedByMaxReplicas
+	case recommendation.Instances > recommendation.Unbounded && raised != "":
+		return raised
+	case recommendation.Instances > recommendation.Unbounded:
+		return v1alpha1.AutoscalingReasonBoundedByMinReplicas
+	case recommendation.Unbounded == current:
+		return v1alpha1.AutoscalingReasonWithinTolerance
+	default:
+		return v1alpha1.AutoscalingReasonRecommended
+	}
+}
+
+// observedMetrics returns the values of the metrics of metricTargets, the external metrics and the sums of the
+// metrics scraped from the pods.
+func observedMetrics(metricTargets algorithm.Metrics, readyInstancesMetrics []algorithm.Metrics, externalMetrics algorithm.Metrics) algorithm.Metrics {
+	observed := make(algorithm.Metrics, len(metricTargets))
+	for name := range metricTargets {
+		if value, ok := externalMetrics[name]; ok {
+			observed[name] = value
+			continue
+		}
+		for _, metrics := range readyInstancesMetrics {
+			if value, ok := metrics[name]; ok {
+				observed[name] += value
+			}
+		}
+	}
+	return observed
 }
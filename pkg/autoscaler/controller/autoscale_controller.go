@@ -19,17 +19,24 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
 	corev1 "k8s.io/api/core/v1"
 
 	clientset "github.com/volcano-sh/kthena/client-go/clientset/versioned"
+	kthenascheme "github.com/volcano-sh/kthena/client-go/clientset/versioned/scheme"
 	informersv1alpha1 "github.com/volcano-sh/kthena/client-go/informers/externalversions"
+	networkingLister "github.com/volcano-sh/kthena/client-go/listers/networking/v1alpha1"
 	workloadLister "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
 	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/metrics"
 	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
 	"istio.io/istio/pkg/util/sets"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -37,8 +44,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -57,15 +68,41 @@ type AutoscaleController struct {
 	modelServingInformer               cache.Controller
 	podsLister                         listerv1.PodLister
 	podsInformer                       cache.Controller
-	scalerMap                          map[string]*autoscaler.Autoscaler
-	optimizerMap                       map[string]*autoscaler.Optimizer
+	// modelServerLister, modelRouteLister and routerPodsLister find the routers and the ModelServers and
+	// ModelRoutes of the targets, whose demand is pulled from the routers.
+	modelServerLister   networkingLister.ModelServerLister
+	modelServerInformer cache.Controller
+	modelRouteLister    networkingLister.ModelRouteLister
+	modelRouteInformer  cache.Controller
+	routerPodsLister    listerv1.PodLister
+	routerPodsInformer  cache.Controller
+	// scaleClient and restMapper scale the targets other than ModelServing through the scale subresource, whose
+	// pods are listed by scaleTargetPodsLister rather than podsLister, which only holds the pods of ModelServings.
+	// The informer of scaleTargetPodsLister watches all the pods, so it is only started, by closing
+	// startScaleTargetPods, once such a target is scaled.
+	scaleClient              scale.ScalesGetter
+	restMapper               meta.RESTMapper
+	scaleTargetPodsLister    listerv1.PodLister
+	scaleTargetPodsInformer  cache.Controller
+	startScaleTargetPods     chan struct{}
+	startScaleTargetPodsOnce sync.Once
+	// mu guards scalerMap and optimizerMap, shared by the workers. A binding is only processed by one worker at a time.
+	mu           sync.Mutex
+	scalerMap    map[string]*autoscaler.Autoscaler
+	optimizerMap map[string]*autoscaler.Optimizer
+	// recorder emits the events of the scale actions on the bindings.
+	recorder record.EventRecorder
+	// workQueue holds the keys of the bindings to evaluate. Each binding is added back after its sync period.
+	workQueue workqueue.TypedRateLimitingInterface[string]
 }
 
-func NewAutoscaleController(kubeClient kubernetes.Interface, client clientset.Interface, namespace string) *AutoscaleController {
+func NewAutoscaleController(kubeClient kubernetes.Interface, client clientset.Interface, scaleClient scale.ScalesGetter, restMapper meta.RESTMapper, namespace string) *AutoscaleController {
 	informerFactory := informersv1alpha1.NewSharedInformerFactory(client, 0)
 	modelInferInformer := informerFactory.Workload().V1alpha1().ModelServings()
 	autoscalingPoliciesInformer := informerFactory.Workload().V1alpha1().AutoscalingPolicies()
 	autoscalingPoliciesBindingInformer := informerFactory.Workload().V1alpha1().AutoscalingPolicyBindings()
+	modelServerInformer := informerFactory.Networking().V1alpha1().ModelServers()
+	modelRouteInformer := informerFactory.Networking().V1alpha1().ModelRoutes()
 
 	selector, err := labels.NewRequirement(workload.GroupNameLabelKey, selection.Exists, nil)
 	if err != nil {
@@ -78,6 +115,20 @@ func NewAutoscaleController(kubeClient kubernetes.Interface, client clientset.In
 		}),
 	)
 	podsInformer := kubeInformerFactory.Core().V1().Pods()
+	scaleTargetInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0, informers.WithNamespace(namespace))
+	scaleTargetPodsInformer := scaleTargetInformerFactory.Core().V1().Pods()
+	routerInformerFactory := informers.NewSharedInformerFactoryWithOptions(
+		kubeClient, 0, informers.WithNamespace(namespace), informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = util.RouterLabelSelector
+		}),
+	)
+	routerPodsInformer := routerInformerFactory.Core().V1().Pods()
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+	recorder := eventBroadcaster.NewRecorder(kthenascheme.Scheme, corev1.EventSource{Component: "autoscale-controller"})
+
 	ac := &AutoscaleController{
 		kubeClient:                         kubeClient,
 		client:                             client,
@@ -90,87 +141,230 @@ func NewAutoscaleController(kubeClient kubernetes.Interface, client clientset.In
 		modelServingInformer:               modelInferInformer.Informer(),
 		podsLister:                         podsInformer.Lister(),
 		podsInformer:                       podsInformer.Informer(),
+		modelServerLister:                  modelServerInformer.Lister(),
+		modelServerInformer:                modelServerInformer.Informer(),
+		modelRouteLister:                   modelRouteInformer.Lister(),
+		modelRouteInformer:                 modelRouteInformer.Informer(),
+		routerPodsLister:                   routerPodsInformer.Lister(),
+		routerPodsInformer:                 routerPodsInformer.Informer(),
+		scaleClient:                        scaleClient,
+		restMapper:                         restMapper,
+		scaleTargetPodsLister:              scaleTargetPodsInformer.Lister(),
+		scaleTargetPodsInformer:            scaleTargetPodsInformer.Informer(),
+		startScaleTargetPods:               make(chan struct{}),
 		scalerMap:                          make(map[string]*autoscaler.Autoscaler),
 		optimizerMap:                       make(map[string]*autoscaler.Optimizer),
+		recorder:                           recorder,
+		workQueue: workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{Name: "autoscaler"}),
+	}
+	_, err = autoscalingPoliciesBindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ac.addBinding,
+		UpdateFunc: ac.updateBinding,
+		DeleteFunc: ac.deleteBinding,
+	})
+	if err != nil {
+		klog.Errorf("unable to add autoscaling policy binding event handler, err: %v", err)
+		return nil
+	}
+	_, err = autoscalingPoliciesInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    ac.addPolicy,
+		UpdateFunc: ac.updatePolicy,
+		DeleteFunc: ac.addPolicy,
+	})
+	if err != nil {
+		klog.Errorf("unable to add autoscaling policy event handler, err: %v", err)
+		return nil
 	}
 	return ac
 }
 
-func (ac *AutoscaleController) Run(ctx context.Context) {
+func (ac *AutoscaleController) Run(ctx context.Context, workers int) {
 	defer utilruntime.HandleCrash()
+	defer ac.workQueue.ShutDown()
+	// The state of the autoscalers is only valid while leading, a new leader starts from scratch.
+	defer ac.resetAutoscalers()
 
 	// start informers
 	go ac.autoscalingPoliciesInformer.RunWithContext(ctx)
 	go ac.autoscalingPoliciesBindingInformer.RunWithContext(ctx)
 	go ac.modelServingInformer.RunWithContext(ctx)
 	go ac.podsInformer.RunWithContext(ctx)
+	go ac.modelServerInformer.RunWithContext(ctx)
+	go ac.modelRouteInformer.RunWithContext(ctx)
+	go ac.routerPodsInformer.RunWithContext(ctx)
+	go ac.runScaleTargetPodsInformer(ctx)
 	cache.WaitForCacheSync(ctx.Done(),
 		ac.autoscalingPoliciesInformer.HasSynced,
 		ac.autoscalingPoliciesBindingInformer.HasSynced,
 		ac.modelServingInformer.HasSynced,
 		ac.podsInformer.HasSynced,
+		ac.modelServerInformer.HasSynced,
+		ac.modelRouteInformer.HasSynced,
+		ac.routerPodsInformer.HasSynced,
 	)
 
 	klog.Info("start autoscale controller")
-	go wait.Until(func() {
-		ac.Reconcile(ctx)
-	}, util.AutoscalingSyncPeriodSeconds*time.Second, nil)
+	for i := 0; i < workers; i++ {
+		go ac.worker(ctx)
+	}
+	go wait.UntilWithContext(ctx, ac.updatePolicyStatus, util.AutoscalingSyncPeriodSeconds*time.Second)
 
 	<-ctx.Done()
 	klog.Info("shut down autoscale controller")
 }
 
-// Reconcile is part of the main kubernetes reconciliation loop which aims to
-// move the current state of the cluster closer to the desired state.
-func (ac *AutoscaleController) Reconcile(ctx context.Context) {
-	klog.V(4).Info("start to reconcile")
-	ctx, cancel := context.WithTimeout(ctx, util.AutoscaleCtxTimeoutSeconds*time.Second)
-	defer cancel()
-	bindingList, err := ac.client.WorkloadV1alpha1().AutoscalingPolicyBindings(ac.namespace).List(ctx, metav1.ListOptions{})
+func (ac *AutoscaleController) worker(ctx context.Context) {
+	for ac.processNextWorkItem(ctx) {
+	}
+}
+
+func (ac *AutoscaleController) processNextWorkItem(ctx context.Context) bool {
+	key, quit := ac.workQueue.Get()
+	if quit {
+		return false
+	}
+	defer ac.workQueue.Done(key)
+
+	err := ac.syncBinding(ctx, key)
+	if err == nil {
+		ac.workQueue.Forget(key)
+		return true
+	}
+	utilruntime.HandleError(fmt.Errorf("sync %q failed with %v", key, err))
+	ac.workQueue.AddRateLimited(key)
+	return true
+}
+
+// syncBinding evaluates a binding within its sync period, and adds it back to the queue for the next period.
+func (ac *AutoscaleController) syncBinding(ctx context.Context, key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
 	if err != nil {
-		klog.Errorf("failed to list autoscaling policy bindings, err: %v", err)
+		return err
+	}
+	binding, err := ac.autoscalingPoliciesBindingLister.AutoscalingPolicyBindings(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		klog.V(4).Infof("binding %s deleted, remove its autoscalers", key)
+		ac.deleteAutoscalers(key, "")
+		metrics.DeleteBinding(namespace, name)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	syncPeriod := getSyncPeriod(binding)
+	defer ac.workQueue.AddAfter(key, syncPeriod)
+
+	if binding.Spec.PolicyRef.Name == "" {
+		klog.Warningf("invalid autoscaling policy name, binding name: %s", binding.Name)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, syncPeriod)
+	defer cancel()
+	return ac.schedule(ctx, binding)
+}
+
+func (ac *AutoscaleController) addBinding(obj any) {
+	binding, ok := obj.(*workload.AutoscalingPolicyBinding)
+	if !ok {
+		klog.Error("failed to parse AutoscalingPolicyBinding when addBinding")
 		return
 	}
+	ac.enqueueBinding(binding)
+}
 
-	scalerSet := sets.New[string]()
-	optimizerSet := sets.New[string]()
+func (ac *AutoscaleController) updateBinding(old any, new any) {
+	oldBinding, ok := old.(*workload.AutoscalingPolicyBinding)
+	if !ok {
+		klog.Error("failed to parse old AutoscalingPolicyBinding when updateBinding")
+		return
+	}
+	newBinding, ok := new.(*workload.AutoscalingPolicyBinding)
+	if !ok {
+		klog.Error("failed to parse new AutoscalingPolicyBinding when updateBinding")
+		return
+	}
+	// The status updates of the autoscaler itself don't trigger an evaluation.
+	if oldBinding.Generation == newBinding.Generation {
+		return
+	}
+	ac.enqueueBinding(newBinding)
+}
 
-	for _, binding := range bindingList.Items {
-		policyName := binding.Spec.PolicyRef.Name
-		if policyName == "" {
-			klog.Warningf("invalid autoscaling policy name, binding name: %s", binding.Name)
-			continue
-		}
-		if binding.Spec.HomogeneousTarget != nil {
-			scalerSet.Insert(formatAutoscalerMapKey(binding.Name, &binding.Spec.HomogeneousTarget.Target.TargetRef))
-		} else if binding.Spec.HeterogeneousTarget != nil {
-			optimizerSet.Insert(formatAutoscalerMapKey(binding.Name, nil))
-		} else {
-			klog.Warningf("Either homogeneous or heterogeneous not set, binding name: %s", binding.Name)
-		}
+func (ac *AutoscaleController) deleteBinding(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	binding, ok := obj.(*workload.AutoscalingPolicyBinding)
+	if !ok {
+		klog.Error("failed to parse AutoscalingPolicyBinding when deleteBinding")
+		return
 	}
+	ac.enqueueBinding(binding)
+}
 
-	for key := range ac.scalerMap {
-		if !scalerSet.Contains(key) {
-			delete(ac.scalerMap, key)
-		}
+func (ac *AutoscaleController) enqueueBinding(binding *workload.AutoscalingPolicyBinding) {
+	if ac.namespace != "" && binding.Namespace != ac.namespace {
+		return
 	}
+	if key, err := cache.MetaNamespaceKeyFunc(binding); err != nil {
+		utilruntime.HandleError(err)
+	} else {
+		ac.workQueue.Add(key)
+	}
+}
 
-	for key := range ac.optimizerMap {
-		if !optimizerSet.Contains(key) {
-			delete(ac.optimizerMap, key)
-		}
+func (ac *AutoscaleController) addPolicy(obj any) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	policy, ok := obj.(*workload.AutoscalingPolicy)
+	if !ok {
+		klog.Error("failed to parse AutoscalingPolicy when addPolicy")
+		return
 	}
+	ac.enqueueBindingsOfPolicy(policy)
+}
 
-	for _, binding := range bindingList.Items {
-		err := ac.schedule(ctx, &binding)
-		if err != nil {
-			klog.Errorf("failed to process autoscale,err: %v", err)
-			continue
+func (ac *AutoscaleController) updatePolicy(old any, new any) {
+	oldPolicy, ok := old.(*workload.AutoscalingPolicy)
+	if !ok {
+		klog.Error("failed to parse old AutoscalingPolicy when updatePolicy")
+		return
+	}
+	newPolicy, ok := new.(*workload.AutoscalingPolicy)
+	if !ok {
+		klog.Error("failed to parse new AutoscalingPolicy when updatePolicy")
+		return
+	}
+	if oldPolicy.Generation == newPolicy.Generation {
+		return
+	}
+	ac.enqueueBindingsOfPolicy(newPolicy)
+}
+
+// enqueueBindingsOfPolicy evaluates the bindings referencing a policy once it changed.
+func (ac *AutoscaleController) enqueueBindingsOfPolicy(policy *workload.AutoscalingPolicy) {
+	bindings, err := ac.autoscalingPoliciesBindingLister.AutoscalingPolicyBindings(policy.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list autoscaling policy bindings, err: %v", err)
+		return
+	}
+	for _, binding := range bindings {
+		if binding.Spec.PolicyRef.Name == policy.Name {
+			ac.enqueueBinding(binding)
 		}
 	}
 }
 
+// getSyncPeriod returns the period at which a binding is evaluated.
+func getSyncPeriod(binding *workload.AutoscalingPolicyBinding) time.Duration {
+	if binding.Spec.SyncPeriod == nil || binding.Spec.SyncPeriod.Duration <= 0 {
+		return util.AutoscalingSyncPeriodSeconds * time.Second
+	}
+	return binding.Spec.SyncPeriod.Duration
+}
+
 func (ac *AutoscaleController) updateTargetReplicas(ctx context.Context, target *workload.Target, replicas int32) error {
 	targetRef := target.TargetRef
 	namespaceScope := targetRef.Namespace
@@ -205,11 +399,15 @@ func (ac *AutoscaleController) updateTargetReplicas(ctx context.Context, target
 		if _, err = ac.client.WorkloadV1alpha1().ModelServings(namespaceScope).Update(ctx, instance_copy, metav1.UpdateOptions{}); err == nil {
 			return nil
 		}
+	} else if target.SubTarget == nil {
+		return util.UpdateScaleReplicas(ctx, ac.scaleClient, ac.restMapper, namespaceScope, &targetRef, replicas)
 	}
 	return fmt.Errorf("target ref kind %s, name: %s not supported", targetRef.Kind, targetRef.Name)
 }
 
-func (ac *AutoscaleController) getTargetReplicas(target *workload.Target) (int32, error) {
+// getTargetReplicas returns the replicas of a target. For a target scaled through the scale subresource, the
+// selector of its pods from its scale status is returned as well.
+func (ac *AutoscaleController) getTargetReplicas(ctx context.Context, target *workload.Target) (int32, string, error) {
 	targetRef := target.TargetRef
 	namespaceScope := targetRef.Namespace
 	if namespaceScope == "" {
@@ -219,21 +417,59 @@ func (ac *AutoscaleController) getTargetReplicas(target *workload.Target) (int32
 	if targetRef.Kind == workload.ModelServingKind.Kind || targetRef.Kind == "" {
 		instance, err := ac.modelServingLister.ModelServings(namespaceScope).Get(targetRef.Name)
 		if err != nil {
-			return 0, err
+			return 0, "", err
 		}
 		if target.SubTarget == nil {
 			if instance.Spec.Replicas != nil {
-				return *instance.Spec.Replicas, nil
+				return *instance.Spec.Replicas, "", nil
 			}
 		} else if target.SubTarget.Kind == util.ModelServingRoleKind && target.SubTarget.Name != "" {
 			for _, role := range instance.Spec.Template.Roles {
 				if role.Name == target.SubTarget.Name && role.Replicas != nil {
-					return *role.Replicas, nil
+					return *role.Replicas, "", nil
 				}
 			}
 		}
+	} else if target.SubTarget == nil {
+		targetScale, _, err := util.GetScale(ctx, ac.scaleClient, ac.restMapper, namespaceScope, &targetRef)
+		if err != nil {
+			return 0, "", err
+		}
+		if targetScale.Status.Selector == "" {
+			return 0, "", fmt.Errorf("target ref kind %s, name: %s has no selector in its scale status", targetRef.Kind, targetRef.Name)
+		}
+		return targetScale.Spec.Replicas, targetScale.Status.Selector, nil
+	}
+	return 0, "", fmt.Errorf("target ref kind %s, name: %s not supported", targetRef.Kind, targetRef.Name)
+}
+
+// runScaleTargetPodsInformer runs the informer of the pods of the targets scaled through the scale subresource,
+// once the first of them is scaled.
+func (ac *AutoscaleController) runScaleTargetPodsInformer(ctx context.Context) {
+	select {
+	case <-ac.startScaleTargetPods:
+		klog.Info("start watching the pods of the targets scaled through the scale subresource")
+		ac.scaleTargetPodsInformer.RunWithContext(ctx)
+	case <-ctx.Done():
+	}
+}
+
+// getPodsLister returns the lister of the pods of the targets, which only holds the pods of ModelServings unless
+// a target is scaled through the scale subresource. The pods of these targets are watched from the first call on.
+func (ac *AutoscaleController) getPodsLister(ctx context.Context, targets []*workload.Target) (listerv1.PodLister, error) {
+	for _, target := range targets {
+		if util.IsModelServingTarget(target) {
+			continue
+		}
+		ac.startScaleTargetPodsOnce.Do(func() {
+			close(ac.startScaleTargetPods)
+		})
+		if !cache.WaitForCacheSync(ctx.Done(), ac.scaleTargetPodsInformer.HasSynced) {
+			return nil, fmt.Errorf("failed to sync the pods of target ref kind %s, name: %s", target.TargetRef.Kind, target.TargetRef.Name)
+		}
+		return ac.scaleTargetPodsLister, nil
 	}
-	return 0, fmt.Errorf("target ref kind %s, name: %s not supported", targetRef.Kind, targetRef.Name)
+	return ac.podsLister, nil
 }
 
 func (ac *AutoscaleController) schedule(ctx context.Context, binding *workload.AutoscalingPolicyBinding) error {
@@ -253,77 +489,112 @@ func (ac *AutoscaleController) schedule(ctx context.Context, binding *workload.A
 			klog.Errorf("failed to do scale, err: %v", err)
 			return err
 		}
+	} else if binding.Spec.CoupledTarget != nil {
+		if err := ac.doCoupledScale(ctx, binding, autoscalePolicy); err != nil {
+			klog.Errorf("failed to do coupled scale, err: %v", err)
+			return err
+		}
 	} else {
-		klog.Warningf("binding %s has no scalingConfiguration and optimizerConfiguration", binding.Name)
+		klog.Warningf("binding %s has no homogeneous, heterogeneous or coupled target", binding.Name)
 	}
 
 	return nil
 }
 
 func (ac *AutoscaleController) doOptimize(ctx context.Context, binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy) error {
-	key := formatAutoscalerMapKey(binding.Name, nil)
-	optimizer, ok := ac.optimizerMap[key]
-	if !ok || optimizer.NeedUpdate(autoscalePolicy, binding) {
-		optimizer = autoscaler.NewOptimizer(autoscalePolicy, binding)
-		ac.optimizerMap[key] = optimizer
-		klog.Infof("asp: %s or binding: %s changed, create new optimizer", autoscalePolicy.Name, binding.Name)
-	}
+	optimizer := ac.getOrCreateOptimizer(binding, autoscalePolicy)
 	// Fetch current replicas
 	replicasMap := make(map[string]int32, len(optimizer.Meta.Config.Params))
 	for _, param := range optimizer.Meta.Config.Params {
-		currentInstancesCount, err := ac.getTargetReplicas(&param.Target)
+		currentInstancesCount, scaleSelector, err := ac.getTargetReplicas(ctx, &param.Target)
 		if err != nil {
 			klog.Errorf("failed to get current replicas, err: %v", err)
 			return err
 		}
 		replicasMap[param.Target.TargetRef.Name] = currentInstancesCount
+		if collector, ok := optimizer.Collectors[param.Target.TargetRef.Name]; ok {
+			collector.ScaleSelector = scaleSelector
+		}
 	}
 
 	// Get recommended replicas
-	recommendedInstances, err := optimizer.Optimize(ctx, ac.podsLister, autoscalePolicy, replicasMap)
+	targets := make([]*workload.Target, 0, len(optimizer.Meta.Config.Params))
+	for i := range optimizer.Meta.Config.Params {
+		targets = append(targets, &optimizer.Meta.Config.Params[i].Target)
+	}
+	podsLister, err := ac.getPodsLister(ctx, targets)
+	if err != nil {
+		return err
+	}
+	externalMetrics := ac.getExternalMetrics(ctx, autoscalePolicy, binding.Namespace, targets)
+	recommendedInstances, err := optimizer.Optimize(ctx, podsLister, autoscalePolicy, replicasMap, externalMetrics)
 	if err != nil {
 		klog.Errorf("failed to do optimize, err: %v", err)
 		return err
 	}
 	// Do update replicas
-	for _, param := range optimizer.Meta.Config.Params {
+	scalings := make([]targetScaling, 0, len(optimizer.Meta.Config.Params))
+	for i, param := range optimizer.Meta.Config.Params {
+		scaling := targetScaling{target: targets[i], current: replicasMap[param.Target.TargetRef.Name], desired: replicasMap[param.Target.TargetRef.Name]}
+		if capacityCap, ok := optimizer.CapacityCaps[param.Target.TargetRef.Name]; ok && !capacityCap.Lifted {
+			capped := capacityCap.Replicas
+			scaling.capped = &capped
+		}
 		instancesCount, exists := recommendedInstances[param.Target.TargetRef.Name]
 		if !exists {
-			klog.Warningf("recommended instances not exists, target ref name: %s", param.Target.TargetRef.Name)
+			if recommendedInstances != nil {
+				klog.Warningf("recommended instances not exists, target ref name: %s", param.Target.TargetRef.Name)
+			}
+			scalings = append(scalings, scaling)
 			continue
 		}
 		if err := ac.updateTargetReplicas(ctx, &param.Target, instancesCount); err != nil {
 			klog.Errorf("failed to update target kind:%s name: %s replicas:%d, err: %v", param.Target.TargetRef.Kind, param.Target.TargetRef.Name, instancesCount, err)
 			return err
 		}
+		scaling.desired = instancesCount
+		scalings = append(scalings, scaling)
 	}
+	ac.recordScaling(ctx, binding, autoscalePolicy, optimizer.Status, scalings)
 
 	return nil
 }
 
 func (ac *AutoscaleController) doScale(ctx context.Context, binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy) error {
 	target := binding.Spec.HomogeneousTarget.Target
-	key := formatAutoscalerMapKey(binding.Name, &target.TargetRef)
-	scaler, ok := ac.scalerMap[key]
-	if !ok || scaler.NeedUpdate(autoscalePolicy, binding) {
-		scaler = autoscaler.NewAutoscaler(autoscalePolicy, binding)
-		ac.scalerMap[key] = scaler
-		klog.Infof("asp: %s or binding: %s changed, create new scaler", autoscalePolicy.Name, binding.Name)
-	}
+	scaler := ac.getOrCreateScaler(binding, autoscalePolicy, &target.TargetRef)
 	// Fetch current replicas
-	currentInstancesCount, err := ac.getTargetReplicas(&target)
+	currentInstancesCount, scaleSelector, err := ac.getTargetReplicas(ctx, &target)
 	if err != nil {
 		klog.Errorf("failed to get current replicas, err: %v", err)
 		return err
 	}
+	scaler.Collector.ScaleSelector = scaleSelector
 	// Get recommended replicas
 	klog.InfoS("do homogeneous scaling for target", "targetRef", target.TargetRef, "currentInstancesCount", currentInstancesCount)
-	recommendedInstances, err := scaler.Scale(ctx, ac.podsLister, autoscalePolicy, currentInstancesCount)
-	if err != nil {
-		klog.Errorf("failed to do homogeneous scaling for target %s, err: %v", target.TargetRef.Name, err)
-		return err
+	recommendedInstances, overridden := int32(0), false
+	if binding.Spec.HomogeneousTarget.ScaleToZero != nil {
+		// Without the demand of the routers, the target is neither scaled to nor from zero.
+		if demand, err := ac.getTargetDemand(ctx, &target); err != nil {
+			klog.Errorf("failed to get demand of target %s, err: %v", target.TargetRef.Name, err)
+		} else {
+			recommendedInstances, overridden = scaler.ScaleToZero(autoscalePolicy, demand, currentInstancesCount)
+		}
+	}
+	if !overridden {
+		podsLister, err := ac.getPodsLister(ctx, []*workload.Target{&target})
+		if err != nil {
+			return err
+		}
+		externalMetrics := ac.getExternalMetrics(ctx, autoscalePolicy, binding.Namespace, []*workload.Target{&target})
+		recommendedInstances, err = scaler.Scale(ctx, podsLister, autoscalePolicy, currentInstancesCount, externalMetrics)
+		if err != nil {
+			klog.Errorf("failed to do homogeneous scaling for target %s, err: %v", target.TargetRef.Name, err)
+			return err
+		}
 	}
 	if recommendedInstances < 0 {
+		ac.recordScaling(ctx, binding, autoscalePolicy, scaler.Status, []targetScaling{{target: &target, current: currentInstancesCount, desired: currentInstancesCount}})
 		return nil
 	}
 	// Do update replicas
@@ -332,9 +603,97 @@ func (ac *AutoscaleController) doScale(ctx context.Context, binding *workload.Au
 		return err
 	}
 	klog.InfoS("successfully update target replicas", "targetRef", target.TargetRef, "recommendedInstances", recommendedInstances)
+	ac.recordScaling(ctx, binding, autoscalePolicy, scaler.Status, []targetScaling{{target: &target, current: currentInstancesCount, desired: recommendedInstances}})
+	return nil
+}
+
+// doCoupledScale scales the roles of a coupled target together. The total replicas recommended for the roles are
+// split across them by their ratio, and the replicas of all the roles are updated in one write of the ModelServing.
+func (ac *AutoscaleController) doCoupledScale(ctx context.Context, binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy) error {
+	config := binding.Spec.CoupledTarget
+	target := config.Target
+	scaler := ac.getOrCreateScaler(binding, autoscalePolicy, &target.TargetRef)
+	namespace := target.TargetRef.Namespace
+	if namespace == "" {
+		namespace = ac.namespace
+	}
+	instance, err := ac.modelServingLister.ModelServings(namespace).Get(target.TargetRef.Name)
+	if err != nil {
+		klog.Errorf("failed to get model serving %s/%s, err: %v", namespace, target.TargetRef.Name, err)
+		return err
+	}
+	// Fetch current replicas
+	scalings := make([]targetScaling, len(config.Roles))
+	currentInstancesCount := int32(0)
+	for i, role := range config.Roles {
+		replicas, err := getRoleReplicas(instance, role.Name)
+		if err != nil {
+			return err
+		}
+		roleTarget := target
+		roleTarget.SubTarget = &workload.SubTarget{Kind: util.ModelServingRoleKind, Name: role.Name}
+		scalings[i] = targetScaling{target: &roleTarget, current: replicas, desired: replicas}
+		currentInstancesCount += replicas
+	}
+	// Get recommended replicas
+	klog.InfoS("do coupled scaling for target", "targetRef", target.TargetRef, "currentInstancesCount", currentInstancesCount)
+	externalMetrics := ac.getExternalMetrics(ctx, autoscalePolicy, binding.Namespace, []*workload.Target{&target})
+	recommendedInstances, err := scaler.Scale(ctx, ac.podsLister, autoscalePolicy, currentInstancesCount, externalMetrics)
+	if err != nil {
+		klog.Errorf("failed to do coupled scaling for target %s, err: %v", target.TargetRef.Name, err)
+		return err
+	}
+	if recommendedInstances < 0 {
+		ac.recordScaling(ctx, binding, autoscalePolicy, scaler.Status, scalings)
+		return nil
+	}
+	// Without the token rates of the routers, the replicas are split by the weights of the roles.
+	demand := autoscaler.Demand{}
+	if config.Ratio == workload.CoupledRatioLearned {
+		if demand, err = ac.getTargetDemand(ctx, &target); err != nil {
+			klog.Errorf("failed to get demand of target %s, err: %v", target.TargetRef.Name, err)
+		}
+	}
+	desired := autoscaler.SplitReplicas(config.Roles, autoscaler.CoupledShares(config, demand), recommendedInstances)
+	// Do update replicas
+	instanceCopy := instance.DeepCopy()
+	changed := false
+	for i, role := range config.Roles {
+		scalings[i].desired = desired[i]
+		if desired[i] == scalings[i].current {
+			continue
+		}
+		for idx := range instanceCopy.Spec.Template.Roles {
+			if instanceCopy.Spec.Template.Roles[idx].Name == role.Name {
+				instanceCopy.Spec.Template.Roles[idx].Replicas = &desired[i]
+				changed = true
+			}
+		}
+	}
+	if changed {
+		if _, err := ac.client.WorkloadV1alpha1().ModelServings(namespace).Update(ctx, instanceCopy, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to update role replicas of model serving %s, err: %v", target.TargetRef.Name, err)
+			return err
+		}
+		klog.InfoS("successfully update role replicas", "targetRef", target.TargetRef, "replicas", desired)
+	}
+	ac.recordScaling(ctx, binding, autoscalePolicy, scaler.Status, scalings)
 	return nil
 }
 
+// getRoleReplicas returns the replicas of a role of a ModelServing.
+func getRoleReplicas(instance *workload.ModelServing, roleName string) (int32, error) {
+	for _, role := range instance.Spec.Template.Roles {
+		if role.Name == roleName {
+			if role.Replicas == nil {
+				return 1, nil
+			}
+			return *role.Replicas, nil
+		}
+	}
+	return 0, fmt.Errorf("role %s not found in model serving %s/%s", roleName, instance.Namespace, instance.Name)
+}
+
 func (ac *AutoscaleController) getAutoscalePolicy(autoscalingPolicyName string, namespace string) (*workload.AutoscalingPolicy, error) {
 	autoscalingPolicy, err := ac.autoscalingPoliciesLister.AutoscalingPolicies(namespace).Get(autoscalingPolicyName)
 	if err != nil {
@@ -344,12 +703,106 @@ func (ac *AutoscaleController) getAutoscalePolicy(autoscalingPolicyName string,
 	return autoscalingPolicy, nil
 }
 
-func formatAutoscalerMapKey(bindingName string, targetRef *corev1.ObjectReference) string {
+// getOrCreateScaler returns the autoscaler of the homogeneous target of a binding, created anew when the binding or
+// the policy changed, keeping the history of its forecast. The other autoscalers of the binding, e.g. of its
+// previous target, are dropped.
+func (ac *AutoscaleController) getOrCreateScaler(binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy, targetRef *corev1.ObjectReference) *autoscaler.Autoscaler {
+	bindingKey := formatBindingKey(binding)
+	key := formatAutoscalerMapKey(bindingKey, targetRef)
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.deleteAutoscalersLocked(bindingKey, key)
+	scaler, ok := ac.scalerMap[key]
+	if !ok || scaler.NeedUpdate(autoscalePolicy, binding) {
+		previous := scaler
+		if binding.Spec.CoupledTarget != nil {
+			scaler = autoscaler.NewCoupledAutoscaler(autoscalePolicy, binding, util.GetCurrentTimestamp)
+		} else {
+			scaler = autoscaler.NewAutoscaler(autoscalePolicy, binding, util.GetCurrentTimestamp)
+		}
+		if previous != nil {
+			scaler.Status.InheritForecast(previous.Status)
+		}
+		ac.scalerMap[key] = scaler
+		klog.Infof("asp: %s or binding: %s changed, create new scaler", autoscalePolicy.Name, binding.Name)
+	}
+	return scaler
+}
+
+// getOrCreateOptimizer returns the optimizer of the heterogeneous target of a binding, created anew when the binding
+// or the policy changed, keeping the history of its forecast and its capacity caps. The other autoscalers of the
+// binding are dropped.
+func (ac *AutoscaleController) getOrCreateOptimizer(binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy) *autoscaler.Optimizer {
+	key := formatBindingKey(binding)
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.deleteAutoscalersLocked(key, key)
+	optimizer, ok := ac.optimizerMap[key]
+	if !ok || optimizer.NeedUpdate(autoscalePolicy, binding) {
+		previous := optimizer
+		optimizer = autoscaler.NewOptimizer(autoscalePolicy, binding, util.GetCurrentTimestamp)
+		if previous != nil {
+			optimizer.Status.InheritForecast(previous.Status)
+			optimizer.CapacityCaps = previous.CapacityCaps
+		}
+		ac.optimizerMap[key] = optimizer
+		klog.Infof("asp: %s or binding: %s changed, create new optimizer", autoscalePolicy.Name, binding.Name)
+	}
+	return optimizer
+}
+
+// deleteAutoscalers drops the autoscalers and the optimizer of a binding, but the one of the key keep.
+func (ac *AutoscaleController) deleteAutoscalers(bindingKey string, keep string) {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	ac.deleteAutoscalersLocked(bindingKey, keep)
+}
+
+func (ac *AutoscaleController) deleteAutoscalersLocked(bindingKey string, keep string) {
+	for key := range ac.scalerMap {
+		if key != keep && (key == bindingKey || strings.HasPrefix(key, bindingKey+"#")) {
+			delete(ac.scalerMap, key)
+		}
+	}
+	for key := range ac.optimizerMap {
+		if key != keep && (key == bindingKey || strings.HasPrefix(key, bindingKey+"#")) {
+			delete(ac.optimizerMap, key)
+		}
+	}
+}
+
+// resetAutoscalers drops all the autoscalers and their metrics.
+func (ac *AutoscaleController) resetAutoscalers() {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	bindingKeys := sets.New[string]()
+	for key := range ac.scalerMap {
+		bindingKey, _, _ := strings.Cut(key, "#")
+		bindingKeys.Insert(bindingKey)
+	}
+	for key := range ac.optimizerMap {
+		bindingKeys.Insert(key)
+	}
+	for bindingKey := range bindingKeys {
+		if namespace, name, err := cache.SplitMetaNamespaceKey(bindingKey); err == nil {
+			metrics.DeleteBinding(namespace, name)
+		}
+	}
+	ac.scalerMap = make(map[string]*autoscaler.Autoscaler)
+	ac.optimizerMap = make(map[string]*autoscaler.Optimizer)
+}
+
+// formatBindingKey returns the namespace/name key of a binding.
+func formatBindingKey(binding *workload.AutoscalingPolicyBinding) string {
+	return binding.Namespace + "/" + binding.Name
+}
+
+func formatAutoscalerMapKey(bindingKey string, targetRef *corev1.ObjectReference) string {
 	if targetRef == nil {
-		return bindingName
+		return bindingKey
 	}
 	if targetRef.Kind == "" {
 		targetRef.Kind = workload.ModelServingKind.Kind
 	}
-	return bindingName + "#" + targetRef.Kind + "#" + targetRef.Name
+	return bindingKey + "#" + targetRef.Kind + "#" + targetRef.Name
 }
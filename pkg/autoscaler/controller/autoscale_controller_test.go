@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"net/http/httptest"
@@ -27,15 +28,29 @@ import (
 	"time"
 
 	clientfake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	networkingLister "github.com/volcano-sh/kthena/client-go/listers/networking/v1alpha1"
 	workloadLister "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
 	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	listerv1 "k8s.io/client-go/listers/core/v1"
+	scalefake "k8s.io/client-go/scale/fake"
+	k8stesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
 )
 
 type fakePodNamespaceLister struct{ pods []*corev1.Pod }
@@ -103,13 +118,16 @@ func TestToleranceHigh_then_DoScale_expect_NoUpdateActions(t *testing.T) {
 
 	lbs := map[string]string{}
 	pods := []*corev1.Pod{readyPod(ns, "pod-a", host, lbs)}
-	ac := &AutoscaleController{client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
 
 	if err := ac.doScale(context.Background(), binding, policy); err != nil {
 		t.Fatalf("doScale error: %v", err)
 	}
-	if len(client.Fake.Actions()) != 0 {
-		t.Fatalf("expected no update actions with tolerance=100, got %d", len(client.Fake.Actions()))
+	// The status of the binding is still written, the ModelServing is left untouched.
+	for _, a := range client.Fake.Actions() {
+		if a.GetResource().Resource == "modelservings" {
+			t.Fatalf("expected no update actions with tolerance=100, got %v", a)
+		}
 	}
 }
 
@@ -131,7 +149,7 @@ func TestHighLoad_then_DoScale_expect_Replicas10(t *testing.T) {
 
 	lbs := map[string]string{}
 	pods := []*corev1.Pod{readyPod(ns, "pod-up", host, lbs)}
-	ac := &AutoscaleController{client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
 
 	if err := ac.doScale(context.Background(), binding, policy); err != nil {
 		t.Fatalf("doScale error: %v", err)
@@ -145,6 +163,109 @@ func TestHighLoad_then_DoScale_expect_Replicas10(t *testing.T) {
 	}
 }
 
+func TestScheduleActive_then_DoScale_expect_ScheduledMinReplicas(t *testing.T) {
+	ns := "ns"
+	ms := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-scheduled", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1)}}
+	srv := httptest.NewServer(httpHandlerWithBody("# TYPE load gauge\nload 1\n"))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port := toInt32(portStr)
+
+	target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-scheduled"}, MetricEndpoint: workload.MetricEndpoint{Uri: u.Path, Port: port}}
+	// The schedule starts every minute, so it is always active.
+	schedules := []workload.AutoscalingSchedule{
+		{Name: "always", Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Hour}, MinReplicas: 4},
+		{Name: "never", Schedule: "0 0 31 2 *", Duration: metav1.Duration{Duration: time.Hour}, MinReplicas: 8},
+	}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{TolerancePercent: 0, Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}, Schedules: schedules}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-scheduled", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{Target: target, MinReplicas: 1, MaxReplicas: 10}}}
+	client := clientfake.NewSimpleClientset(ms, binding)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+	pods := []*corev1.Pod{readyPod(ns, "pod-scheduled", host, map[string]string{})}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+
+	if err := ac.doScale(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doScale error: %v", err)
+	}
+	updated, err := client.WorkloadV1alpha1().ModelServings(ns).Get(context.Background(), "ms-scheduled", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated modelserving error: %v", err)
+	}
+	if updated.Spec.Replicas == nil || *updated.Spec.Replicas != 4 {
+		t.Fatalf("expected replicas raised to the scheduled 4, got %v", updated.Spec.Replicas)
+	}
+	updatedBinding, err := client.WorkloadV1alpha1().AutoscalingPolicyBindings(ns).Get(context.Background(), "binding-scheduled", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated binding error: %v", err)
+	}
+	if updatedBinding.Status.Reason != workload.AutoscalingReasonScheduled {
+		t.Fatalf("expected reason %s, got %s", workload.AutoscalingReasonScheduled, updatedBinding.Status.Reason)
+	}
+	if updatedBinding.Status.ScheduledMinReplicas == nil || *updatedBinding.Status.ScheduledMinReplicas != 4 {
+		t.Fatalf("expected scheduled min replicas 4, got %v", updatedBinding.Status.ScheduledMinReplicas)
+	}
+}
+
+func TestDeploymentHighLoad_then_DoScale_expect_ScaleUpdated(t *testing.T) {
+	ns := "ns"
+	client := clientfake.NewSimpleClientset()
+	srv := httptest.NewServer(httpHandlerWithBody("# TYPE load gauge\nload 4\n"))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port := toInt32(portStr)
+
+	scaleClient := &scalefake.FakeScaleClient{}
+	var updatedReplicas int32
+	scaleClient.AddReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: ns},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: 1},
+			Status:     autoscalingv1.ScaleStatus{Replicas: 1, Selector: "app=web"},
+		}, nil
+	})
+	scaleClient.AddReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		updatedReplicas = scale.Spec.Replicas
+		return true, scale, nil
+	})
+	restMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{appsv1.SchemeGroupVersion})
+	restMapper.Add(appsv1.SchemeGroupVersion.WithKind("Deployment"), meta.RESTScopeNamespace)
+
+	// Only the pods selected by the scale status are scraped, the other one is not ready.
+	kubeClient := kubefake.NewSimpleClientset(
+		readyPod(ns, "web-a", host, map[string]string{"app": "web"}),
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "other", Labels: map[string]string{"app": "other"}}},
+	)
+	podsInformer := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0, informers.WithNamespace(ns)).Core().V1().Pods()
+
+	target := workload.Target{TargetRef: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment", Namespace: ns, Name: "web"}, MetricEndpoint: workload.MetricEndpoint{Uri: u.Path, Port: port}}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{TolerancePercent: 0, Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-web", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{Target: target, MinReplicas: 1, MaxReplicas: 10}}}
+
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, scaleClient: scaleClient, restMapper: restMapper, podsLister: fakePodLister{}, scaleTargetPodsLister: podsInformer.Lister(), scaleTargetPodsInformer: podsInformer.Informer(), startScaleTargetPods: make(chan struct{}), scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go ac.runScaleTargetPodsInformer(ctx)
+
+	// The pods are only watched once a target scaled through the scale subresource shows up.
+	time.Sleep(50 * time.Millisecond)
+	if ac.scaleTargetPodsInformer.HasSynced() {
+		t.Fatalf("expected the scale target pods informer not to be started")
+	}
+	if err := ac.doScale(ctx, binding, policy); err != nil {
+		t.Fatalf("doScale error: %v", err)
+	}
+	if !ac.scaleTargetPodsInformer.HasSynced() {
+		t.Fatalf("expected the scale target pods informer to be synced")
+	}
+	if updatedReplicas != 4 {
+		t.Fatalf("expected deployment scaled to 4, got %d", updatedReplicas)
+	}
+}
+
 func TestTwoBackends_then_DoOptimize_expect_UpdateActions(t *testing.T) {
 	ns := "ns"
 	msA := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-a", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1)}}
@@ -167,7 +288,7 @@ func TestTwoBackends_then_DoOptimize_expect_UpdateActions(t *testing.T) {
 	lbsA := map[string]string{}
 	lbsB := map[string]string{}
 	pods := []*corev1.Pod{readyPod(ns, "pod-a", host, lbsA), readyPod(ns, "pod-b", host, lbsB)}
-	ac := &AutoscaleController{client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
 
 	if err := ac.doOptimize(context.Background(), binding, policy); err != nil {
 		t.Fatalf("doOptimize error: %v", err)
@@ -205,7 +326,7 @@ func TestTwoBackendsHighLoad_then_DoOptimize_expect_DistributionA5B4(t *testing.
 	lbsA := map[string]string{}
 	lbsB := map[string]string{}
 	pods := []*corev1.Pod{readyPod(ns, "pod-a2", host, lbsA), readyPod(ns, "pod-b2", host, lbsB)}
-	ac := &AutoscaleController{client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
 
 	if err := ac.doOptimize(context.Background(), binding, policy); err != nil {
 		t.Fatalf("doOptimize error: %v", err)
@@ -223,6 +344,57 @@ func TestTwoBackendsHighLoad_then_DoOptimize_expect_DistributionA5B4(t *testing.
 	}
 }
 
+func TestCoupledRolesHighLoad_then_DoCoupledScale_expect_OneUpdateWithRatio(t *testing.T) {
+	ns := "ns"
+	ms := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-pd", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1), Template: workload.ServingGroup{Roles: []workload.Role{
+		{Name: "prefill", Replicas: ptrInt32(1)},
+		{Name: "decode", Replicas: ptrInt32(1)},
+	}}}}
+	client := clientfake.NewSimpleClientset(ms)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+	srv := httptest.NewServer(httpHandlerWithBody("# TYPE load gauge\nload 4\n"))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	port := toInt32(portStr)
+
+	target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-pd"}, MetricEndpoint: workload.MetricEndpoint{Uri: u.Path, Port: port}}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{TolerancePercent: 0, Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-pd", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, CoupledTarget: &workload.CoupledTarget{
+		Target: target,
+		Roles: []workload.CoupledRole{
+			{Name: "prefill", Weight: 1, MinReplicas: 1, MaxReplicas: 10},
+			{Name: "decode", Weight: 3, MinReplicas: 1, MaxReplicas: 10},
+		},
+		Ratio: workload.CoupledRatioFixed,
+	}}}
+
+	pods := []*corev1.Pod{readyPod(ns, "pod-prefill", host, map[string]string{}), readyPod(ns, "pod-decode", host, map[string]string{})}
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+
+	if err := ac.doCoupledScale(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doCoupledScale error: %v", err)
+	}
+	updates := 0
+	for _, a := range client.Fake.Actions() {
+		if a.GetResource().Resource == "modelservings" && a.GetVerb() == "update" {
+			updates++
+		}
+	}
+	if updates != 1 {
+		t.Fatalf("expected the roles updated in 1 write, got %d", updates)
+	}
+	updated, err := client.WorkloadV1alpha1().ModelServings(ns).Get(context.Background(), "ms-pd", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated modelserving error: %v", err)
+	}
+	prefill, decode := *updated.Spec.Template.Roles[0].Replicas, *updated.Spec.Template.Roles[1].Replicas
+	if prefill != 2 || decode != 6 {
+		t.Fatalf("expected prefill=2 decode=6, got prefill=%d decode=%d", prefill, decode)
+	}
+}
+
 func httpHandlerWithBody(body string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) })
 }
@@ -232,3 +404,130 @@ func toInt32(s string) int32  { v, _ := strconv.Atoi(s); return int32(v) }
 
 type autoscalerAutoscaler = autoscaler.Autoscaler
 type autoscalerOptimizer = autoscaler.Optimizer
+
+func TestScaleToZero_then_DoScale(t *testing.T) {
+	ns := "ns"
+	lastRequestTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	tests := []struct {
+		name     string
+		replicas int32
+		waiting  int32
+		expected int32
+	}{
+		{name: "idle target is scaled to zero", replicas: 3, expected: 0},
+		{name: "idle target stays at zero", replicas: 0, expected: 0},
+		{name: "target with waiting requests is scaled from zero", replicas: 0, waiting: 1, expected: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := &workload.ModelServing{
+				ObjectMeta: metav1.ObjectMeta{Name: "ms-idle", Namespace: ns},
+				Spec: workload.ModelServingSpec{
+					Replicas: ptrInt32(tt.replicas),
+					Template: workload.ServingGroup{Roles: []workload.Role{{
+						Name:          "leader",
+						EntryTemplate: workload.PodTemplateSpec{Metadata: &workload.Metadata{Labels: map[string]string{"app": "idle"}}},
+					}}},
+				},
+			}
+			modelServer := &networking.ModelServer{
+				ObjectMeta: metav1.ObjectMeta{Name: "idle", Namespace: ns},
+				Spec:       networking.ModelServerSpec{WorkloadSelector: &networking.WorkloadSelector{MatchLabels: map[string]string{"app": "idle"}}},
+			}
+			client := clientfake.NewSimpleClientset(ms, modelServer)
+			msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+			body, _ := json.Marshal(routerdemand.Demand{ModelServers: []routerdemand.ModelServerDemand{
+				{Namespace: ns, Name: "idle", Requests: 1, Waiting: tt.waiting, LastRequestTime: lastRequestTime},
+				{Namespace: ns, Name: "other", Requests: 1, Waiting: 5, LastRequestTime: metav1.Now()},
+			}})
+			srv := httptest.NewServer(httpHandlerWithBody(string(body)))
+			defer srv.Close()
+			u, _ := url.Parse(srv.URL)
+			host, portStr, _ := net.SplitHostPort(u.Host)
+			router := readyPod(ns, "router", host, map[string]string{"app.kubernetes.io/component": "kthena-router"})
+			router.Spec.Containers = []corev1.Container{{Name: "kthena-router", Ports: []corev1.ContainerPort{{Name: routerdemand.PortName, ContainerPort: toInt32(portStr)}}}}
+			routerPodsLister := listerv1.NewPodLister(newModelServingIndexer(router))
+
+			target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-idle"}}
+			policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}}}
+			binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-idle", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{
+				Target: target, MinReplicas: 2, MaxReplicas: 10, ScaleToZero: &workload.ScaleToZero{IdleWindow: &metav1.Duration{}},
+			}}}
+
+			ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), routerPodsLister: routerPodsLister, modelServerLister: networkingLister.NewModelServerLister(newModelServingIndexer(modelServer)), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+			if err := ac.doScale(context.Background(), binding, policy); err != nil {
+				t.Fatalf("doScale error: %v", err)
+			}
+			updated, err := client.WorkloadV1alpha1().ModelServings(ns).Get(context.Background(), "ms-idle", metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("get updated modelserving error: %v", err)
+			}
+			if *updated.Spec.Replicas != tt.expected {
+				t.Fatalf("expected replicas %d, got %d", tt.expected, *updated.Spec.Replicas)
+			}
+		})
+	}
+}
+
+func TestDeletedBinding_then_SyncBinding_expect_AutoscalersRemoved(t *testing.T) {
+	ns := "ns"
+	bindingLister := workloadLister.NewAutoscalingPolicyBindingLister(newModelServingIndexer())
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	defer queue.ShutDown()
+	ac := &AutoscaleController{
+		namespace:                        ns,
+		autoscalingPoliciesBindingLister: bindingLister,
+		scalerMap: map[string]*autoscalerAutoscaler{
+			"ns/binding-gone#ModelServing#ms": {},
+			"ns/binding-kept#ModelServing#ms": {},
+		},
+		optimizerMap: map[string]*autoscalerOptimizer{"ns/binding-gone": {}},
+		workQueue:    queue,
+	}
+	if err := ac.syncBinding(context.Background(), "ns/binding-gone"); err != nil {
+		t.Fatalf("syncBinding error: %v", err)
+	}
+	if len(ac.scalerMap) != 1 || ac.scalerMap["ns/binding-kept#ModelServing#ms"] == nil || len(ac.optimizerMap) != 0 {
+		t.Fatalf("expected only the autoscalers of the deleted binding removed, got %v and %v", ac.scalerMap, ac.optimizerMap)
+	}
+	// A deleted binding isn't evaluated again.
+	if queue.Len() != 0 {
+		t.Fatalf("expected no binding added back, got %d", queue.Len())
+	}
+}
+
+func TestStatusUpdate_then_UpdateBinding_expect_NotEnqueued(t *testing.T) {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	defer queue.ShutDown()
+	ac := &AutoscaleController{namespace: "ns", workQueue: queue}
+	old := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "ns", Generation: 1}}
+	statusUpdated := old.DeepCopy()
+	statusUpdated.Status.Reason = workload.AutoscalingReasonRecommended
+	ac.updateBinding(old, statusUpdated)
+	if queue.Len() != 0 {
+		t.Fatalf("expected a status update not to enqueue the binding")
+	}
+	specUpdated := old.DeepCopy()
+	specUpdated.Generation = 2
+	ac.updateBinding(old, specUpdated)
+	if queue.Len() != 1 {
+		t.Fatalf("expected a spec update to enqueue the binding")
+	}
+	// The bindings of the other namespaces are left to their own autoscaler.
+	ac.addBinding(&workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "other"}})
+	if queue.Len() != 1 {
+		t.Fatalf("expected a binding of another namespace not to be enqueued")
+	}
+}
+
+func TestGetSyncPeriod(t *testing.T) {
+	binding := &workload.AutoscalingPolicyBinding{}
+	if period := getSyncPeriod(binding); period != 15*time.Second {
+		t.Fatalf("expected the default sync period 15s, got %v", period)
+	}
+	binding.Spec.SyncPeriod = &metav1.Duration{Duration: 5 * time.Second}
+	if period := getSyncPeriod(binding); period != 5*time.Second {
+		t.Fatalf("expected sync period 5s, got %v", period)
+	}
+}
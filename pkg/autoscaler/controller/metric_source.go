@@ -0,0 +1,339 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	promapi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	io_prometheus_client "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/prometheus/common/model"
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
+	"istio.io/istio/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const externalMetricsAPIPath = "/apis/external.metrics.k8s.io/v1beta1/namespaces"
+
+// externalMetricValueList is the subset of the ExternalMetricValueList of the external metrics API used by the autoscaler.
+type externalMetricValueList struct {
+	Items []struct {
+		Value resource.Quantity `json:"value"`
+	} `json:"items"`
+}
+
+// getExternalMetrics returns the values of the metrics of the policy which are not scraped from the pods of the
+// targets. A metric which fails to be fetched is left out, so that it takes no part in the recommendation.
+func (ac *AutoscaleController) getExternalMetrics(ctx context.Context, autoscalePolicy *workload.AutoscalingPolicy, namespace string, targets []*workload.Target) algorithm.Metrics {
+	externalMetrics := make(algorithm.Metrics)
+	for i := range autoscalePolicy.Spec.Metrics {
+		metric := &autoscalePolicy.Spec.Metrics[i]
+		if autoscaler.IsPodMetric(metric) {
+			continue
+		}
+		value, err := ac.getExternalMetric(ctx, metric, namespace, targets)
+		if err != nil {
+			klog.Errorf("failed to get metric %s from %s source, err: %v", metric.MetricName, metric.Source.Type, err)
+			continue
+		}
+		externalMetrics[metric.MetricName] = value
+	}
+	return externalMetrics
+}
+
+func (ac *AutoscaleController) getExternalMetric(ctx context.Context, metric *workload.AutoscalingPolicyMetric, namespace string, targets []*workload.Target) (float64, error) {
+	ctx, cancel := context.WithTimeout(ctx, util.AutoscaleCtxTimeoutSeconds*time.Second)
+	defer cancel()
+	source := metric.Source
+	switch source.Type {
+	case workload.MetricSourcePrometheus:
+		if source.Prometheus == nil {
+			return 0, fmt.Errorf("prometheus is not set")
+		}
+		return queryPrometheus(ctx, source.Prometheus)
+	case workload.MetricSourceExternal:
+		return ac.getExternalMetricsAPIValue(ctx, namespace, metric.MetricName, source.External)
+	case workload.MetricSourceRouterAggregate:
+		return ac.getRouterAggregateValue(ctx, metric.MetricName, source.RouterAggregate)
+	case workload.MetricSourceRouterDemand:
+		if source.RouterDemand == nil {
+			return 0, fmt.Errorf("routerDemand is not set")
+		}
+		return ac.getRouterDemandValue(ctx, targets, source.RouterDemand.Signal)
+	default:
+		return 0, fmt.Errorf("unsupported metric source type %s", source.Type)
+	}
+}
+
+func queryPrometheus(ctx context.Context, source *workload.PrometheusMetricSource) (float64, error) {
+	client, err := promapi.NewClient(promapi.Config{Address: source.Address})
+	if err != nil {
+		return 0, fmt.Errorf("failed to create prometheus client: %v", err)
+	}
+	result, warnings, err := promv1.NewAPI(client).Query(ctx, source.Query, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to query %s: %v", source.Address, err)
+	}
+	if len(warnings) > 0 {
+		klog.Warningf("query %q to %s returned warnings: %v", source.Query, source.Address, warnings)
+	}
+	return sumPrometheusValue(result)
+}
+
+// sumPrometheusValue returns the sum of the samples of the result of an instant query.
+func sumPrometheusValue(value model.Value) (float64, error) {
+	sum := 0.0
+	switch v := value.(type) {
+	case *model.Scalar:
+		sum = float64(v.Value)
+	case model.Vector:
+		if len(v) == 0 {
+			return 0, fmt.Errorf("query returned no sample")
+		}
+		for _, sample := range v {
+			sum += float64(sample.Value)
+		}
+	default:
+		return 0, fmt.Errorf("unsupported query result type %s", value.Type())
+	}
+	if math.IsNaN(sum) || math.IsInf(sum, 0) {
+		return 0, fmt.Errorf("query returned %v", sum)
+	}
+	return sum, nil
+}
+
+func (ac *AutoscaleController) getExternalMetricsAPIValue(ctx context.Context, namespace string, metricName string, source *workload.ExternalMetricSource) (float64, error) {
+	restClient := ac.kubeClient.Discovery().RESTClient()
+	if restClient == nil {
+		return 0, fmt.Errorf("no rest client for the external metrics API")
+	}
+	request := restClient.Get().AbsPath(externalMetricsAPIPath, namespace, strings.ToLower(metricName))
+	if source != nil && source.Selector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(source.Selector)
+		if err != nil {
+			return 0, fmt.Errorf("invalid selector: %v", err)
+		}
+		request = request.Param("labelSelector", selector.String())
+	}
+	body, err := request.DoRaw(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get external metric: %v", err)
+	}
+	return sumExternalMetricValues(body)
+}
+
+// sumExternalMetricValues returns the sum of the values of an ExternalMetricValueList.
+func sumExternalMetricValues(body []byte) (float64, error) {
+	values := externalMetricValueList{}
+	if err := json.Unmarshal(body, &values); err != nil {
+		return 0, fmt.Errorf("failed to decode external metric values: %v", err)
+	}
+	if len(values.Items) == 0 {
+		return 0, fmt.Errorf("no external metric value found")
+	}
+	sum := 0.0
+	for _, item := range values.Items {
+		sum += item.Value.AsApproximateFloat64()
+	}
+	return sum, nil
+}
+
+func (ac *AutoscaleController) getRouterAggregateValue(ctx context.Context, metricName string, source *workload.RouterAggregateMetricSource) (float64, error) {
+	routers, err := ac.getReadyRouters()
+	if err != nil {
+		return 0, err
+	}
+	var matchLabels map[string]string
+	if source != nil {
+		matchLabels = source.MatchLabels
+	}
+	sum := 0.0
+	for _, router := range routers {
+		url := getRouterURL(router, util.RouterPortName, util.DefaultRouterPort, util.RouterMetricsPath)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get metrics of router %s: %v", router.Name, err)
+		}
+		value, err := func() (float64, error) {
+			defer resp.Body.Close()
+			if !util.IsRequestSuccess(resp.StatusCode) {
+				return 0, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+			}
+			return sumRouterMetric(resp.Body, metricName, matchLabels)
+		}()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get metrics of router %s: %v", router.Name, err)
+		}
+		sum += value
+	}
+	return sum, nil
+}
+
+// sumRouterMetric returns the sum of the values of the series of the metric whose labels match, in the
+// Prometheus text format. Only counters, gauges and untyped metrics are supported.
+func sumRouterMetric(reader io.Reader, metricName string, matchLabels map[string]string) (float64, error) {
+	decoder := expfmt.NewDecoder(reader, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for {
+		mf := &io_prometheus_client.MetricFamily{}
+		err := decoder.Decode(mf)
+		if err == io.EOF {
+			// The series of a metric are only exposed once they are observed, so a missing metric is zero.
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error decoding metric: %v", err)
+		}
+		if mf.GetName() != metricName {
+			continue
+		}
+		sum := 0.0
+		for _, metric := range mf.Metric {
+			if !matchesLabels(metric, matchLabels) {
+				continue
+			}
+			switch mf.GetType() {
+			case io_prometheus_client.MetricType_COUNTER:
+				sum += metric.GetCounter().GetValue()
+			case io_prometheus_client.MetricType_GAUGE:
+				sum += metric.GetGauge().GetValue()
+			case io_prometheus_client.MetricType_UNTYPED:
+				sum += metric.GetUntyped().GetValue()
+			default:
+				return 0, fmt.Errorf("unsupported type %s of metric %s", mf.GetType(), metricName)
+			}
+		}
+		return sum, nil
+	}
+}
+
+func matchesLabels(metric *io_prometheus_client.Metric, matchLabels map[string]string) bool {
+	matched := 0
+	for _, label := range metric.GetLabel() {
+		if value, ok := matchLabels[label.GetName()]; ok {
+			if value != label.GetValue() {
+				return false
+			}
+			matched++
+		}
+	}
+	return matched == len(matchLabels)
+}
+
+// getRouterDemandValue returns the sum of the demand signal across the routers, for the ModelServers selecting the
+// pods of the targets, or for the models of the ModelRoutes routing to them.
+func (ac *AutoscaleController) getRouterDemandValue(ctx context.Context, targets []*workload.Target, signal workload.RouterDemandSignal) (float64, error) {
+	modelServerNames := sets.New[types.NamespacedName]()
+	models := sets.New[string]()
+	for _, target := range targets {
+		namespace, names, err := ac.getTargetModelServers(target)
+		if err != nil {
+			return 0, err
+		}
+		for name := range names {
+			modelServerNames.Insert(types.NamespacedName{Namespace: namespace, Name: name})
+		}
+		if signal != workload.RouterDemandQueueLength && signal != workload.RouterDemandRateLimited {
+			continue
+		}
+		modelRoutes, err := ac.modelRouteLister.ModelRoutes(namespace).List(labels.Everything())
+		if err != nil {
+			return 0, fmt.Errorf("failed to list model routes: %v", err)
+		}
+		for _, modelRoute := range modelRoutes {
+			if routesToModelServers(modelRoute, names) {
+				if modelRoute.Spec.ModelName != "" {
+					models.Insert(modelRoute.Spec.ModelName)
+				}
+				for _, loraAdapter := range modelRoute.Spec.LoraAdapters {
+					models.Insert(loraAdapter)
+				}
+			}
+		}
+		if models.Len() == 0 {
+			return 0, fmt.Errorf("no model route routes to the model servers %v", sets.SortedList(names))
+		}
+	}
+
+	routerDemands, err := ac.fetchRouterDemands(ctx)
+	if err != nil {
+		return 0, err
+	}
+	sum := 0.0
+	for _, routerDemand := range routerDemands {
+		for _, model := range routerDemand.Models {
+			if !models.Contains(model.Name) {
+				continue
+			}
+			switch signal {
+			case workload.RouterDemandQueueLength:
+				sum += float64(model.QueueLength)
+			case workload.RouterDemandRateLimited:
+				sum += model.RateLimitedPerSecond
+			}
+		}
+		for _, modelServer := range routerDemand.ModelServers {
+			if !modelServerNames.Contains(types.NamespacedName{Namespace: modelServer.Namespace, Name: modelServer.Name}) {
+				continue
+			}
+			switch signal {
+			case workload.RouterDemandWaiting:
+				sum += float64(modelServer.Waiting)
+			case workload.RouterDemandActiveRequests:
+				sum += float64(modelServer.ActiveRequests)
+			case workload.RouterDemandNotFound:
+				sum += modelServer.NotFoundPerSecond
+			}
+		}
+	}
+	return sum, nil
+}
+
+// routesToModelServers returns whether a rule of the model route targets one of the model servers.
+func routesToModelServers(modelRoute *networking.ModelRoute, modelServerNames sets.Set[string]) bool {
+	for _, rule := range modelRoute.Spec.Rules {
+		if rule == nil {
+			continue
+		}
+		for _, targetModel := range rule.TargetModels {
+			if targetModel != nil && modelServerNames.Contains(targetModel.ModelServerName) {
+				return true
+			}
+		}
+	}
+	return false
+}
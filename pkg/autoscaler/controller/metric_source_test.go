@@ -0,0 +1,207 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	clientfake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	networkingLister "github.com/volcano-sh/kthena/client-go/listers/networking/v1alpha1"
+	workloadLister "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const routerMetrics = `# TYPE kthena_router_fairness_queue_size gauge
+kthena_router_fairness_queue_size{model="llama",user_id="a"} 3
+kthena_router_fairness_queue_size{model="llama",user_id="b"} 4
+kthena_router_fairness_queue_size{model="qwen",user_id="a"} 100
+# TYPE kthena_router_requests_total counter
+kthena_router_requests_total{error_type="",model="llama",path="/v1/chat/completions",status_code="200"} 5
+`
+
+func TestSumRouterMetric(t *testing.T) {
+	tests := []struct {
+		name        string
+		metricName  string
+		matchLabels map[string]string
+		expected    float64
+	}{
+		{name: "all series", metricName: "kthena_router_fairness_queue_size", expected: 107},
+		{name: "series matching labels", metricName: "kthena_router_fairness_queue_size", matchLabels: map[string]string{"model": "llama"}, expected: 7},
+		{name: "label missing from series", metricName: "kthena_router_fairness_queue_size", matchLabels: map[string]string{"model_server": "llama"}, expected: 0},
+		{name: "counter", metricName: "kthena_router_requests_total", matchLabels: map[string]string{"model": "llama"}, expected: 5},
+		{name: "metric not observed yet", metricName: "kthena_router_active_downstream_requests", expected: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := sumRouterMetric(strings.NewReader(routerMetrics), tt.metricName, tt.matchLabels)
+			if err != nil {
+				t.Fatalf("sumRouterMetric error: %v", err)
+			}
+			if value != tt.expected {
+				t.Fatalf("expected %v, got %v", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestSumExternalMetricValues(t *testing.T) {
+	value, err := sumExternalMetricValues([]byte(`{"kind":"ExternalMetricValueList","apiVersion":"external.metrics.k8s.io/v1beta1",
+		"items":[{"metricName":"queue_depth","value":"1500m"},{"metricName":"queue_depth","value":"2"}]}`))
+	if err != nil {
+		t.Fatalf("sumExternalMetricValues error: %v", err)
+	}
+	if value != 3.5 {
+		t.Fatalf("expected 3.5, got %v", value)
+	}
+	if _, err := sumExternalMetricValues([]byte(`{"items":[]}`)); err == nil {
+		t.Fatalf("expected error without values")
+	}
+}
+
+func TestExternalSources_then_DoScale_expect_MaxOfSources(t *testing.T) {
+	ns := "ns"
+	ms := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-external", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1)}}
+	client := clientfake.NewSimpleClientset(ms)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+	prometheus := httptest.NewServer(httpHandlerWithBody(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"12"]}]}}`))
+	defer prometheus.Close()
+	routerSrv := httptest.NewServer(httpHandlerWithBody(routerMetrics))
+	defer routerSrv.Close()
+	u, _ := url.Parse(routerSrv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	router := readyPod(ns, "router", host, map[string]string{"app.kubernetes.io/component": "kthena-router"})
+	router.Spec.Containers = []corev1.Container{{Name: "kthena-router", Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: toInt32(portStr)}}}}
+	routerPodsLister := listerv1.NewPodLister(newModelServingIndexer(router))
+
+	target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-external"}}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{Metrics: []workload.AutoscalingPolicyMetric{
+		{
+			MetricName:  "request_rate",
+			TargetValue: resource.MustParse("2"),
+			Source: &workload.MetricSource{Type: workload.MetricSourcePrometheus, Prometheus: &workload.PrometheusMetricSource{
+				Address: prometheus.URL,
+				Query:   `sum(rate(kthena_router_requests_total{model="llama"}[1m]))`,
+			}},
+		},
+		{
+			MetricName:  "kthena_router_fairness_queue_size",
+			TargetValue: resource.MustParse("1"),
+			Source: &workload.MetricSource{Type: workload.MetricSourceRouterAggregate, RouterAggregate: &workload.RouterAggregateMetricSource{
+				MatchLabels: map[string]string{"model": "llama"},
+			}},
+		},
+	}}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-external", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{Target: target, MinReplicas: 1, MaxReplicas: 10}}}
+
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), routerPodsLister: routerPodsLister, client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	if err := ac.doScale(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doScale error: %v", err)
+	}
+	updated, err := client.WorkloadV1alpha1().ModelServings(ns).Get(context.Background(), "ms-external", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated modelserving error: %v", err)
+	}
+	// The request rate of 12 asks for 6 replicas, the 7 requests waiting in the router for 7.
+	if *updated.Spec.Replicas != 7 {
+		t.Fatalf("expected replicas 7, got %d", *updated.Spec.Replicas)
+	}
+}
+
+func TestRouterDemand_then_DoScale_expect_MaxOfSignals(t *testing.T) {
+	ns := "ns"
+	ms := &workload.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "ms-demand", Namespace: ns},
+		Spec: workload.ModelServingSpec{
+			Replicas: ptrInt32(1),
+			Template: workload.ServingGroup{Roles: []workload.Role{{
+				Name:          "leader",
+				EntryTemplate: workload.PodTemplateSpec{Metadata: &workload.Metadata{Labels: map[string]string{"app": "llama"}}},
+			}}},
+		},
+	}
+	modelServer := &networking.ModelServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: ns},
+		Spec:       networking.ModelServerSpec{WorkloadSelector: &networking.WorkloadSelector{MatchLabels: map[string]string{"app": "llama"}}},
+	}
+	modelRoute := &networking.ModelRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "llama", Namespace: ns},
+		Spec: networking.ModelRouteSpec{
+			ModelName: "llama",
+			Rules:     []*networking.Rule{{TargetModels: []*networking.TargetModel{{ModelServerName: "llama"}}}},
+		},
+	}
+	client := clientfake.NewSimpleClientset(ms, modelServer, modelRoute)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+	body, _ := json.Marshal(routerdemand.Demand{
+		Models: []routerdemand.ModelDemand{{Name: "llama", QueueLength: 18}, {Name: "qwen", QueueLength: 100}},
+		ModelServers: []routerdemand.ModelServerDemand{
+			{Namespace: ns, Name: "llama", ActiveRequests: 5},
+			{Namespace: ns, Name: "qwen", ActiveRequests: 100},
+		},
+	})
+	srv := httptest.NewServer(httpHandlerWithBody(string(body)))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	router := readyPod(ns, "router", host, map[string]string{"app.kubernetes.io/component": "kthena-router"})
+	router.Spec.Containers = []corev1.Container{{Name: "kthena-router", Ports: []corev1.ContainerPort{{Name: routerdemand.PortName, ContainerPort: toInt32(portStr)}}}}
+	routerPodsLister := listerv1.NewPodLister(newModelServingIndexer(router))
+
+	target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-demand"}}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{Metrics: []workload.AutoscalingPolicyMetric{
+		{
+			MetricName:  "router_queue_length",
+			TargetValue: resource.MustParse("3"),
+			Source:      &workload.MetricSource{Type: workload.MetricSourceRouterDemand, RouterDemand: &workload.RouterDemandMetricSource{Signal: workload.RouterDemandQueueLength}},
+		},
+		{
+			MetricName:  "router_active_requests",
+			TargetValue: resource.MustParse("1"),
+			Source:      &workload.MetricSource{Type: workload.MetricSourceRouterDemand, RouterDemand: &workload.RouterDemandMetricSource{Signal: workload.RouterDemandActiveRequests}},
+		},
+	}}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-demand", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{Target: target, MinReplicas: 1, MaxReplicas: 10}}}
+
+	ac := &AutoscaleController{recorder: record.NewFakeRecorder(100), routerPodsLister: routerPodsLister, modelServerLister: networkingLister.NewModelServerLister(newModelServingIndexer(modelServer)), modelRouteLister: networkingLister.NewModelRouteLister(newModelServingIndexer(modelRoute)), client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	if err := ac.doScale(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doScale error: %v", err)
+	}
+	updated, err := client.WorkloadV1alpha1().ModelServings(ns).Get(context.Background(), "ms-demand", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated modelserving error: %v", err)
+	}
+	// The 18 requests queued for llama ask for 6 replicas, the 5 active requests of its model server for 5.
+	if *updated.Spec.Replicas != 6 {
+		t.Fatalf("expected replicas 6, got %d", *updated.Spec.Replicas)
+	}
+}
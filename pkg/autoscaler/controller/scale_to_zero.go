@@ -0,0 +1,170 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
+	inferControllerUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
+	"istio.io/istio/pkg/util/sets"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// getTargetDemand returns the demand the routers observed for the ModelServers selecting the pods of the target.
+// The demand is pulled from every ready router pod in the namespace of the autoscaler.
+func (ac *AutoscaleController) getTargetDemand(ctx context.Context, target *workload.Target) (autoscaler.Demand, error) {
+	demand := autoscaler.Demand{}
+	namespace, modelServerNames, err := ac.getTargetModelServers(target)
+	if err != nil {
+		return demand, err
+	}
+	routerDemands, err := ac.fetchRouterDemands(ctx)
+	if err != nil {
+		return demand, err
+	}
+	for _, routerDemand := range routerDemands {
+		for _, modelServer := range routerDemand.ModelServers {
+			if modelServer.Namespace != namespace || !modelServerNames.Contains(modelServer.Name) {
+				continue
+			}
+			demand.Waiting += modelServer.Waiting
+			demand.InputTokensPerSecond += modelServer.InputTokensPerSecond
+			demand.OutputTokensPerSecond += modelServer.OutputTokensPerSecond
+			if !modelServer.LastRequestTime.IsZero() {
+				demand.LastRequestTime = max(demand.LastRequestTime, modelServer.LastRequestTime.UnixMilli())
+			}
+		}
+	}
+	return demand, nil
+}
+
+// getTargetModelServers returns the namespace and the names of the ModelServers selecting the pods of the target.
+func (ac *AutoscaleController) getTargetModelServers(target *workload.Target) (string, sets.Set[string], error) {
+	namespace := target.TargetRef.Namespace
+	if namespace == "" {
+		namespace = ac.namespace
+	}
+	instance, err := ac.modelServingLister.ModelServings(namespace).Get(target.TargetRef.Name)
+	if err != nil {
+		return namespace, nil, err
+	}
+	modelServers, err := ac.modelServerLister.ModelServers(namespace).List(labels.Everything())
+	if err != nil {
+		return namespace, nil, fmt.Errorf("failed to list model servers: %v", err)
+	}
+	modelServerNames := sets.New[string]()
+	for _, modelServer := range modelServers {
+		if selectsModelServing(modelServer, instance, target.SubTarget) {
+			modelServerNames.Insert(modelServer.Name)
+		}
+	}
+	if modelServerNames.Len() == 0 {
+		return namespace, nil, fmt.Errorf("no model server selects the pods of model serving %s/%s", namespace, instance.Name)
+	}
+	return namespace, modelServerNames, nil
+}
+
+// fetchRouterDemands pulls the demand from every ready router pod in the namespace of the autoscaler.
+func (ac *AutoscaleController) fetchRouterDemands(ctx context.Context) ([]*routerdemand.Demand, error) {
+	routers, err := ac.getReadyRouters()
+	if err != nil {
+		return nil, err
+	}
+	demands := make([]*routerdemand.Demand, 0, len(routers))
+	for _, router := range routers {
+		routerCtx, cancel := context.WithTimeout(ctx, util.AutoscaleCtxTimeoutSeconds*time.Second)
+		url := getRouterURL(router, routerdemand.PortName, routerdemand.DefaultPort, routerdemand.Path)
+		demand, err := routerdemand.Fetch(routerCtx, http.DefaultClient, url)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get demand of router %s: %v", router.Name, err)
+		}
+		demands = append(demands, demand)
+	}
+	return demands, nil
+}
+
+// getReadyRouters returns the ready router pods in the namespace of the autoscaler.
+func (ac *AutoscaleController) getReadyRouters() ([]*corev1.Pod, error) {
+	routers, err := ac.routerPodsLister.Pods(ac.namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list router pods: %v", err)
+	}
+	var readyRouters []*corev1.Pod
+	for _, router := range routers {
+		if inferControllerUtils.IsPodRunningAndReady(router) {
+			readyRouters = append(readyRouters, router)
+		}
+	}
+	if len(readyRouters) == 0 {
+		return nil, fmt.Errorf("no ready router pod found in namespace %s", ac.namespace)
+	}
+	return readyRouters, nil
+}
+
+// selectsModelServing returns whether the model server selects the entry pods of the model serving, or of its role
+// if the sub target is set.
+func selectsModelServing(modelServer *networking.ModelServer, instance *workload.ModelServing, subTarget *workload.SubTarget) bool {
+	if modelServer.Spec.WorkloadSelector == nil {
+		return false
+	}
+	selector := labels.SelectorFromSet(modelServer.Spec.WorkloadSelector.MatchLabels)
+	for _, role := range instance.Spec.Template.Roles {
+		if subTarget != nil && subTarget.Kind == util.ModelServingRoleKind && role.Name != subTarget.Name {
+			continue
+		}
+		podLabels := labels.Set{}
+		if role.EntryTemplate.Metadata != nil {
+			for k, v := range role.EntryTemplate.Metadata.Labels {
+				podLabels[k] = v
+			}
+		}
+		podLabels[workload.ModelServingNameLabelKey] = instance.Name
+		podLabels[workload.RoleLabelKey] = role.Name
+		podLabels[workload.EntryLabelKey] = util.Entry
+		if selector.Matches(podLabels) {
+			return true
+		}
+	}
+	return false
+}
+
+// getRouterURL returns the URL of the path on the named container port of the router pod, or on the default port
+// if the pod doesn't name it.
+func getRouterURL(router *corev1.Pod, portName string, defaultPort int32, path string) string {
+	return fmt.Sprintf("http://%s:%d%s", router.Status.PodIP, getRouterPort(router, portName, defaultPort), path)
+}
+
+func getRouterPort(router *corev1.Pod, portName string, defaultPort int32) int32 {
+	for _, container := range router.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == portName {
+				return port.ContainerPort
+			}
+		}
+	}
+	return defaultPort
+}
@@ -0,0 +1,195 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// maxDecisionHistory is the number of scale actions kept in the status of a binding.
+	maxDecisionHistory = 10
+
+	// rescaledEventReason is the reason of the events emitted on the scale actions.
+	rescaledEventReason = "SuccessfulRescale"
+	// capacityCappedEventReason is the reason of the events emitted when a target is capped because some of its
+	// replicas could not be scheduled.
+	capacityCappedEventReason = "CapacityCapped"
+)
+
+// targetScaling is the current and desired replicas of a target of a binding.
+type targetScaling struct {
+	target  *workload.Target
+	current int32
+	desired int32
+	// capped is the maximum replicas of a target capped because some of its replicas could not be scheduled.
+	capped *int32
+}
+
+// recordScaling reports the last decision of the autoscaler for the targets of a binding: it emits an event
+// for each target whose replicas changed, and updates the metrics and the status of the binding.
+func (ac *AutoscaleController) recordScaling(ctx context.Context, binding *workload.AutoscalingPolicyBinding, autoscalePolicy *workload.AutoscalingPolicy, status *autoscaler.Status, targets []targetScaling) {
+	decision := status.LastDecision
+	panicMode := status.IsPanicMode()
+	newStatus := binding.Status.DeepCopy()
+	newStatus.ObservedGeneration = binding.Generation
+	newStatus.Reason = decision.Reason
+	newStatus.PanicMode = panicMode
+	newStatus.ScheduledMinReplicas = decision.ScheduledMinInstances
+	newStatus.PredictedReplicas = decision.PredictedInstances
+	newStatus.Targets = make([]workload.AutoscalingTargetStatus, 0, len(targets))
+
+	wasCapped := make(map[string]bool, len(binding.Status.Targets))
+	for _, target := range binding.Status.Targets {
+		wasCapped[target.Name] = target.CappedReplicas != nil
+	}
+	var actions []string
+	for _, t := range targets {
+		name := formatTargetName(t.target)
+		newStatus.Targets = append(newStatus.Targets, workload.AutoscalingTargetStatus{
+			Name:            name,
+			CurrentReplicas: t.current,
+			DesiredReplicas: t.desired,
+			CappedReplicas:  t.capped,
+		})
+		if t.capped != nil && !wasCapped[name] {
+			ac.recorder.Eventf(binding, corev1.EventTypeWarning, capacityCappedEventReason, "replicas of %s could not be scheduled, capped %s at %d replicas", name, name, *t.capped)
+		}
+		metrics.CurrentReplicas.WithLabelValues(binding.Namespace, binding.Name, name).Set(float64(t.current))
+		metrics.DesiredReplicas.WithLabelValues(binding.Namespace, binding.Name, name).Set(float64(t.desired))
+		if t.current == t.desired {
+			continue
+		}
+		direction := metrics.DirectionUp
+		if t.desired < t.current {
+			direction = metrics.DirectionDown
+		}
+		metrics.ScaleActions.WithLabelValues(binding.Namespace, binding.Name, name, direction).Inc()
+		action := fmt.Sprintf("scaled %s from %d to %d replicas", name, t.current, t.desired)
+		ac.recorder.Eventf(binding, corev1.EventTypeNormal, rescaledEventReason, "%s, reason: %s", action, decision.Reason)
+		actions = append(actions, action)
+	}
+
+	newStatus.Metrics = make([]workload.AutoscalingMetricStatus, 0, len(autoscalePolicy.Spec.Metrics))
+	for _, metric := range autoscalePolicy.Spec.Metrics {
+		metricStatus := workload.AutoscalingMetricStatus{
+			MetricName:  metric.MetricName,
+			TargetValue: metric.TargetValue,
+		}
+		metrics.MetricTarget.WithLabelValues(binding.Namespace, binding.Name, metric.MetricName).Set(metric.TargetValue.AsApproximateFloat64())
+		if value, ok := decision.Metrics[metric.MetricName]; ok {
+			metricStatus.CurrentValue = resource.NewMilliQuantity(int64(math.Round(value*1000)), resource.DecimalSI)
+			metrics.MetricValue.WithLabelValues(binding.Namespace, binding.Name, metric.MetricName).Set(value)
+		} else {
+			metrics.MetricValue.DeleteLabelValues(binding.Namespace, binding.Name, metric.MetricName)
+		}
+		newStatus.Metrics = append(newStatus.Metrics, metricStatus)
+	}
+
+	if decision.PredictedInstances != nil {
+		metrics.PredictedReplicas.WithLabelValues(binding.Namespace, binding.Name).Set(float64(*decision.PredictedInstances))
+	} else {
+		metrics.PredictedReplicas.DeleteLabelValues(binding.Namespace, binding.Name)
+	}
+
+	if panicMode {
+		metrics.PanicMode.WithLabelValues(binding.Namespace, binding.Name).Set(1)
+	} else {
+		metrics.PanicMode.WithLabelValues(binding.Namespace, binding.Name).Set(0)
+	}
+
+	if len(actions) > 0 {
+		now := metav1.Now()
+		newStatus.LastScaleTime = &now
+		newStatus.History = append([]workload.AutoscalingDecision{{
+			Time:    now,
+			Reason:  decision.Reason,
+			Message: strings.Join(actions, "; "),
+		}}, newStatus.History...)
+		if len(newStatus.History) > maxDecisionHistory {
+			newStatus.History = newStatus.History[:maxDecisionHistory]
+		}
+	}
+
+	if equality.Semantic.DeepEqual(binding.Status, *newStatus) {
+		return
+	}
+	bindingCopy := binding.DeepCopy()
+	bindingCopy.Status = *newStatus
+	if _, err := ac.client.WorkloadV1alpha1().AutoscalingPolicyBindings(binding.Namespace).UpdateStatus(ctx, bindingCopy, metav1.UpdateOptions{}); err != nil {
+		klog.Errorf("failed to update status of binding %s, err: %v", klog.KObj(binding), err)
+	}
+}
+
+// updatePolicyStatus records the bindings referencing each policy in its status.
+func (ac *AutoscaleController) updatePolicyStatus(ctx context.Context) {
+	bindings, err := ac.autoscalingPoliciesBindingLister.AutoscalingPolicyBindings(ac.namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list autoscaling policy bindings, err: %v", err)
+		return
+	}
+	bindingNames := make(map[types.NamespacedName][]string)
+	for _, binding := range bindings {
+		if binding.Spec.PolicyRef.Name != "" {
+			policyName := types.NamespacedName{Namespace: binding.Namespace, Name: binding.Spec.PolicyRef.Name}
+			bindingNames[policyName] = append(bindingNames[policyName], binding.Name)
+		}
+	}
+	policies, err := ac.autoscalingPoliciesLister.AutoscalingPolicies(ac.namespace).List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list autoscaling policies, err: %v", err)
+		return
+	}
+	for _, policy := range policies {
+		newStatus := workload.AutoscalingPolicyStatus{
+			ObservedGeneration: policy.Generation,
+			Bindings:           bindingNames[types.NamespacedName{Namespace: policy.Namespace, Name: policy.Name}],
+		}
+		sort.Strings(newStatus.Bindings)
+		if equality.Semantic.DeepEqual(policy.Status, newStatus) {
+			continue
+		}
+		policyCopy := policy.DeepCopy()
+		policyCopy.Status = newStatus
+		if _, err := ac.client.WorkloadV1alpha1().AutoscalingPolicies(policy.Namespace).UpdateStatus(ctx, policyCopy, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to update status of autoscaling policy %s, err: %v", klog.KObj(policy), err)
+		}
+	}
+}
+
+// formatTargetName returns the name of a target, followed by the name of its role for role targets.
+func formatTargetName(target *workload.Target) string {
+	if target.SubTarget != nil && target.SubTarget.Name != "" {
+		return target.TargetRef.Name + "/" + target.SubTarget.Name
+	}
+	return target.TargetRef.Name
+}
@@ -0,0 +1,175 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	clientfake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workloadLister "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/metrics"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestBoundedByMax_then_DoScale_expect_StatusEventAndMetrics(t *testing.T) {
+	ns := "ns"
+	ms := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-status", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1)}}
+	target := workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-status"}}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-status", Namespace: ns, Generation: 2}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HomogeneousTarget: &workload.HomogeneousTarget{Target: target, MinReplicas: 1, MaxReplicas: 10}}}
+	client := clientfake.NewSimpleClientset(ms, binding)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(ms))
+
+	srv := httptest.NewServer(httpHandlerWithBody("# TYPE load gauge\nload 20\n"))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	host, portStr, _ := net.SplitHostPort(u.Host)
+	binding.Spec.HomogeneousTarget.Target.MetricEndpoint = workload.MetricEndpoint{Uri: u.Path, Port: toInt32(portStr)}
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}}}
+
+	recorder := record.NewFakeRecorder(10)
+	pods := []*corev1.Pod{readyPod(ns, "pod-status", host, map[string]string{})}
+	ac := &AutoscaleController{recorder: recorder, client: client, namespace: ns, modelServingLister: msLister, podsLister: fakePodLister{podsByNs: map[string][]*corev1.Pod{ns: pods}}, scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	if err := ac.doScale(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doScale error: %v", err)
+	}
+
+	updated, err := client.WorkloadV1alpha1().AutoscalingPolicyBindings(ns).Get(context.Background(), "binding-status", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated binding error: %v", err)
+	}
+	status := updated.Status
+	if status.ObservedGeneration != 2 || status.Reason != workload.AutoscalingReasonBoundedByMaxReplicas || status.LastScaleTime == nil {
+		t.Fatalf("unexpected status %+v", status)
+	}
+	if len(status.Targets) != 1 || status.Targets[0] != (workload.AutoscalingTargetStatus{Name: "ms-status", CurrentReplicas: 1, DesiredReplicas: 10}) {
+		t.Fatalf("unexpected targets %+v", status.Targets)
+	}
+	if len(status.Metrics) != 1 || status.Metrics[0].CurrentValue == nil || status.Metrics[0].CurrentValue.Value() != 20 {
+		t.Fatalf("unexpected metrics %+v", status.Metrics)
+	}
+	if len(status.History) != 1 || status.History[0].Message != "scaled ms-status from 1 to 10 replicas" {
+		t.Fatalf("unexpected history %+v", status.History)
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, rescaledEventReason) || !strings.Contains(event, "reason: BoundedByMaxReplicas") {
+			t.Fatalf("unexpected event %q", event)
+		}
+	default:
+		t.Fatalf("expected a scale event")
+	}
+
+	if value := testutil.ToFloat64(metrics.DesiredReplicas.WithLabelValues(ns, "binding-status", "ms-status")); value != 10 {
+		t.Fatalf("expected desired replicas gauge 10, got %v", value)
+	}
+	if value := testutil.ToFloat64(metrics.MetricValue.WithLabelValues(ns, "binding-status", "load")); value != 20 {
+		t.Fatalf("expected metric value gauge 20, got %v", value)
+	}
+	count := testutil.CollectAndCount(metrics.DesiredReplicas)
+	metrics.DeleteBinding(ns, "binding-status")
+	if after := testutil.CollectAndCount(metrics.DesiredReplicas); after != count-1 {
+		t.Fatalf("expected the desired replicas gauge of the binding to be deleted, got %d gauges, had %d", after, count)
+	}
+}
+
+func TestUnschedulableServingGroup_then_DoOptimize_expect_CappedStatusAndEvent(t *testing.T) {
+	ns := "ns"
+	msCheap := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-cheap", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(3)}}
+	msExpensive := &workload.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "ms-expensive", Namespace: ns}, Spec: workload.ModelServingSpec{Replicas: ptrInt32(1)}}
+	paramCheap := workload.HeterogeneousTargetParam{Target: workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-cheap"}}, MinReplicas: 1, MaxReplicas: 5, Cost: 1}
+	paramExpensive := workload.HeterogeneousTargetParam{Target: workload.Target{TargetRef: corev1.ObjectReference{Kind: workload.ModelServingKind.Kind, Namespace: ns, Name: "ms-expensive"}}, MinReplicas: 1, MaxReplicas: 5, Cost: 5}
+	binding := &workload.AutoscalingPolicyBinding{ObjectMeta: metav1.ObjectMeta{Name: "binding-capped", Namespace: ns}, Spec: workload.AutoscalingPolicyBindingSpec{PolicyRef: corev1.LocalObjectReference{Name: "ap"}, HeterogeneousTarget: &workload.HeterogeneousTarget{Params: []workload.HeterogeneousTargetParam{paramCheap, paramExpensive}, CostExpansionRatePercent: 100}}}
+	client := clientfake.NewSimpleClientset(msCheap, msExpensive, binding)
+	msLister := workloadLister.NewModelServingLister(newModelServingIndexer(msCheap, msExpensive))
+	policy := &workload.AutoscalingPolicy{Spec: workload.AutoscalingPolicySpec{Metrics: []workload.AutoscalingPolicyMetric{{MetricName: "load", TargetValue: resource.MustParse("1")}}}}
+
+	// The third ServingGroup of the cheap target is unschedulable, so the metrics of the cheap target are
+	// unavailable, and the expensive target has no pod to scrape.
+	podIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for i := 0; i < 3; i++ {
+		group := "ms-cheap-" + strconv.Itoa(i)
+		pod := readyPod(ns, group+"-0", "127.0.0.1", map[string]string{workload.ModelServingNameLabelKey: "ms-cheap", workload.GroupNameLabelKey: group, workload.EntryLabelKey: "true"})
+		if i == 2 {
+			pod.Status = corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}}}
+		}
+		_ = podIndexer.Add(pod)
+	}
+
+	recorder := record.NewFakeRecorder(10)
+	ac := &AutoscaleController{recorder: recorder, client: client, namespace: ns, modelServingLister: msLister, podsLister: listerv1.NewPodLister(podIndexer), scalerMap: map[string]*autoscalerAutoscaler{}, optimizerMap: map[string]*autoscalerOptimizer{}}
+	if err := ac.doOptimize(context.Background(), binding, policy); err != nil {
+		t.Fatalf("doOptimize error: %v", err)
+	}
+
+	// The unschedulable replica spills over to the expensive target.
+	updated, err := client.WorkloadV1alpha1().AutoscalingPolicyBindings(ns).Get(context.Background(), "binding-capped", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get updated binding error: %v", err)
+	}
+	status := updated.Status
+	if status.Reason != workload.AutoscalingReasonCapacityCapped {
+		t.Fatalf("unexpected reason %s", status.Reason)
+	}
+	wantTargets := []workload.AutoscalingTargetStatus{
+		{Name: "ms-cheap", CurrentReplicas: 3, DesiredReplicas: 2, CappedReplicas: ptrInt32(2)},
+		{Name: "ms-expensive", CurrentReplicas: 1, DesiredReplicas: 2},
+	}
+	if !equality.Semantic.DeepEqual(status.Targets, wantTargets) {
+		t.Fatalf("unexpected targets %+v", status.Targets)
+	}
+
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	if len(events) != 3 || !strings.Contains(events[0], capacityCappedEventReason) || !strings.Contains(events[0], "capped ms-cheap at 2 replicas") {
+		t.Fatalf("unexpected events %q", events)
+	}
+
+	// No event is emitted again while the target stays capped.
+	binding.Status = status
+	ac.recordScaling(context.Background(), binding, policy, ac.optimizerMap[formatBindingKey(binding)].Status, []targetScaling{{target: &paramCheap.Target, current: 2, desired: 2, capped: ptrInt32(2)}})
+	if len(recorder.Events) != 0 {
+		t.Fatalf("unexpected event %q", <-recorder.Events)
+	}
+}
+
+func TestFormatTargetName(t *testing.T) {
+	target := &workload.Target{TargetRef: corev1.ObjectReference{Name: "ms"}}
+	if name := formatTargetName(target); name != "ms" {
+		t.Fatalf("expected ms, got %s", name)
+	}
+	target.SubTarget = &workload.SubTarget{Kind: "Role", Name: "prefill"}
+	if name := formatTargetName(target); name != "ms/prefill" {
+		t.Fatalf("expected ms/prefill, got %s", name)
+	}
+}
@@ -18,7 +18,6 @@ package datastructure
 
 import (
 	"github.com/gammazero/deque"
-	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
 )
 
 type snapshotRecord[T any] struct {
@@ -41,25 +40,25 @@ type RmqRecordSlidingWindow[T Number] struct {
 	getCurrentTimestamp func() int64
 }
 
-func NewMaximumRecordSlidingWindow[T Number](freshMilliseconds int64) *RmqRecordSlidingWindow[T] {
+func NewMaximumRecordSlidingWindow[T Number](freshMilliseconds int64, getCurrentTimestamp func() int64) *RmqRecordSlidingWindow[T] {
 	return &RmqRecordSlidingWindow[T]{
 		pool:              deque.Deque[snapshotRecord[T]]{},
 		freshMilliseconds: freshMilliseconds,
 		isBetter: func(me T, other T) bool {
 			return me > other
 		},
-		getCurrentTimestamp: util.GetCurrentTimestamp,
+		getCurrentTimestamp: getCurrentTimestamp,
 	}
 }
 
-func NewMinimumRecordSlidingWindow[T Number](freshMilliseconds int64) *RmqRecordSlidingWindow[T] {
+func NewMinimumRecordSlidingWindow[T Number](freshMilliseconds int64, getCurrentTimestamp func() int64) *RmqRecordSlidingWindow[T] {
 	return &RmqRecordSlidingWindow[T]{
 		pool:              deque.Deque[snapshotRecord[T]]{},
 		freshMilliseconds: freshMilliseconds,
 		isBetter: func(me T, other T) bool {
 			return me < other
 		},
-		getCurrentTimestamp: util.GetCurrentTimestamp,
+		getCurrentTimestamp: getCurrentTimestamp,
 	}
 }
 
@@ -103,7 +102,7 @@ type RmqLineChartSlidingWindow[T Number] struct {
 	getCurrentTimestamp     func() int64
 }
 
-func NewMaximumLineChartSlidingWindow[T Number](freshMilliseconds int64) *RmqLineChartSlidingWindow[T] {
+func NewMaximumLineChartSlidingWindow[T Number](freshMilliseconds int64, getCurrentTimestamp func() int64) *RmqLineChartSlidingWindow[T] {
 	var driftingValue T
 	return &RmqLineChartSlidingWindow[T]{
 		pool:                    deque.Deque[snapshotRecord[T]]{},
@@ -114,11 +113,11 @@ func NewMaximumLineChartSlidingWindow[T Number](freshMilliseconds int64) *RmqLin
 		isBetter: func(me T, other T) bool {
 			return me > other
 		},
-		getCurrentTimestamp: util.GetCurrentTimestamp,
+		getCurrentTimestamp: getCurrentTimestamp,
 	}
 }
 
-func NewMinimumLineChartSlidingWindow[T Number](freshMilliseconds int64) *RmqLineChartSlidingWindow[T] {
+func NewMinimumLineChartSlidingWindow[T Number](freshMilliseconds int64, getCurrentTimestamp func() int64) *RmqLineChartSlidingWindow[T] {
 	var driftingValue T
 	return &RmqLineChartSlidingWindow[T]{
 		pool:                    deque.Deque[snapshotRecord[T]]{},
@@ -129,7 +128,7 @@ func NewMinimumLineChartSlidingWindow[T Number](freshMilliseconds int64) *RmqLin
 		isBetter: func(me T, other T) bool {
 			return me < other
 		},
-		getCurrentTimestamp: util.GetCurrentTimestamp,
+		getCurrentTimestamp: getCurrentTimestamp,
 	}
 }
 
@@ -189,12 +188,12 @@ type SnapshotSlidingWindow[T any] struct {
 	getCurrentTimestamp func() int64
 }
 
-func NewSnapshotSlidingWindow[T any](freshMilliseconds int64, expireMilliseconds int64) *SnapshotSlidingWindow[T] {
+func NewSnapshotSlidingWindow[T any](freshMilliseconds int64, expireMilliseconds int64, getCurrentTimestamp func() int64) *SnapshotSlidingWindow[T] {
 	return &SnapshotSlidingWindow[T]{
 		pool:                deque.Deque[snapshotRecord[T]]{},
 		freshMilliseconds:   freshMilliseconds,
 		expireMilliseconds:  expireMilliseconds,
-		getCurrentTimestamp: util.GetCurrentTimestamp,
+		getCurrentTimestamp: getCurrentTimestamp,
 	}
 }
 
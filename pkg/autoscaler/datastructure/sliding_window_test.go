@@ -30,7 +30,7 @@ func dateFunc(sec int) func() int64 {
 func Test_maximumRecordSlidingWindow(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMaximumRecordSlidingWindow[int](10000)
+	window := NewMaximumRecordSlidingWindow[int](10000, dateFunc(0))
 
 	_, ok := window.GetBest()
 	assert.False(ok)
@@ -74,7 +74,7 @@ func Test_maximumRecordSlidingWindow(t *testing.T) {
 func Test_minimumRecordSlidingWindow(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMinimumRecordSlidingWindow[int](10000)
+	window := NewMinimumRecordSlidingWindow[int](10000, dateFunc(0))
 
 	_, ok := window.GetBest()
 	assert.False(ok)
@@ -118,7 +118,7 @@ func Test_minimumRecordSlidingWindow(t *testing.T) {
 func Test_recordSlidingWindowWithZeroTTL(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMinimumRecordSlidingWindow[int](0)
+	window := NewMinimumRecordSlidingWindow[int](0, dateFunc(0))
 
 	_, ok := window.GetBest()
 	assert.False(ok)
@@ -131,7 +131,7 @@ func Test_recordSlidingWindowWithZeroTTL(t *testing.T) {
 func Test_maximumLineChartSlidingWindow(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMaximumLineChartSlidingWindow[int](10000)
+	window := NewMaximumLineChartSlidingWindow[int](10000, dateFunc(0))
 
 	best, ok := window.GetBest(6)
 	assert.Equal(6, best)
@@ -191,7 +191,7 @@ func Test_maximumLineChartSlidingWindow(t *testing.T) {
 func Test_minimumLineChartSlidingWindow(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMinimumLineChartSlidingWindow[int](10000)
+	window := NewMinimumLineChartSlidingWindow[int](10000, dateFunc(0))
 
 	best, ok := window.GetBest(6)
 	assert.Equal(6, best)
@@ -251,7 +251,7 @@ func Test_minimumLineChartSlidingWindow(t *testing.T) {
 func Test_lineChartSlidingWindowWithZeroTTL(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewMinimumLineChartSlidingWindow[int](0)
+	window := NewMinimumLineChartSlidingWindow[int](0, dateFunc(0))
 
 	_, ok := window.GetBest(0)
 	assert.False(ok)
@@ -264,7 +264,7 @@ func Test_lineChartSlidingWindowWithZeroTTL(t *testing.T) {
 func Test_snapshotSlidingWindow(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewSnapshotSlidingWindow[string](10000, 15000)
+	window := NewSnapshotSlidingWindow[string](10000, 15000, dateFunc(0))
 
 	_, ok := window.GetLastUnfreshSnapshot()
 	assert.False(ok)
@@ -317,7 +317,7 @@ func Test_snapshotSlidingWindow(t *testing.T) {
 func Test_snapshotSlidingWindowWithZeroTTL(t *testing.T) {
 	assert := assert.New(t)
 
-	window := NewSnapshotSlidingWindow[string](0, 0)
+	window := NewSnapshotSlidingWindow[string](0, 0, dateFunc(0))
 
 	_, ok := window.GetLastUnfreshSnapshot()
 	assert.False(ok)
@@ -120,3 +120,31 @@ func QuantileInDiff(percentile int32, now *Snapshot, past *Snapshot) (float64, e
 	}
 	return 0, fmt.Errorf("percentile %v not found", percentile)
 }
+
+// Merge adds up the observations of snapshots of histograms with the same buckets, e.g. the series of a metric.
+// Default snapshots, without buckets, are empty and skipped.
+func Merge(snapshots ...*Snapshot) (*Snapshot, error) {
+	merged := NewDefaultSnapshot()
+	for _, snapshot := range snapshots {
+		if snapshot == nil || len(snapshot.buckets) == 0 {
+			continue
+		}
+		if len(merged.buckets) == 0 {
+			merged.buckets = make([]Bucket, len(snapshot.buckets))
+			for i, bucket := range snapshot.buckets {
+				merged.buckets[i].leValue = bucket.leValue
+			}
+		} else if len(merged.buckets) != len(snapshot.buckets) {
+			return nil, fmt.Errorf("unmatched buckets lengths (%v, %v)", len(merged.buckets), len(snapshot.buckets))
+		}
+		for i, bucket := range snapshot.buckets {
+			if merged.buckets[i].leValue != bucket.leValue {
+				return nil, fmt.Errorf("unmatched bucket bounds (%v, %v)", merged.buckets[i].leValue, bucket.leValue)
+			}
+			merged.buckets[i].count += bucket.count
+		}
+		merged.sum += snapshot.sum
+		merged.count += snapshot.count
+	}
+	return merged, nil
+}
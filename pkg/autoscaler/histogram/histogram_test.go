@@ -230,3 +230,35 @@ func Test_givenTwoHistogramsWithEverythingInOneBucket_whenQuantileInDiff_thenRet
 	assert.InDelta(0.65, result, Epsilon)
 	assert.Nil(err)
 }
+
+func Test_givenTwoHistograms_whenMerge_thenAddBuckets(t *testing.T) {
+	assert := assert.New(t)
+
+	merged, err := Merge(
+		newSnapshot(2, 20, []Bucket{{0.1, 20}, {1, 20}, {math.Inf(1), 20}}),
+		NewDefaultSnapshot(),
+		newSnapshot(18, 20, []Bucket{{0.1, 0}, {1, 20}, {math.Inf(1), 20}}),
+	)
+	assert.Nil(err)
+	assert.Equal(newSnapshot(20, 40, []Bucket{{0.1, 20}, {1, 40}, {math.Inf(1), 40}}), merged)
+
+	quantile, err := QuantileInDiff(50, merged, NewDefaultSnapshot())
+	assert.Nil(err)
+	assert.InDelta(0.1, quantile, Epsilon)
+}
+
+func Test_givenUnmatchedBuckets_whenMerge_thenError(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := Merge(
+		newSnapshot(2, 20, []Bucket{{0.1, 20}, {math.Inf(1), 20}}),
+		newSnapshot(2, 20, []Bucket{{0.5, 20}, {math.Inf(1), 20}}),
+	)
+	assert.NotNil(err)
+
+	_, err = Merge(
+		newSnapshot(2, 20, []Bucket{{0.1, 20}, {math.Inf(1), 20}}),
+		newSnapshot(2, 20, []Bucket{{math.Inf(1), 20}}),
+	)
+	assert.NotNil(err)
+}
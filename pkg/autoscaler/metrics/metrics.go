@@ -0,0 +1,116 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Label names
+	LabelNamespace = "namespace"
+	LabelBinding   = "binding"
+	LabelTarget    = "target"
+	LabelMetric    = "metric"
+	LabelDirection = "direction"
+
+	// Scale action direction values
+	DirectionUp   = "up"
+	DirectionDown = "down"
+)
+
+var (
+	// CurrentReplicas is the number of replicas of a target of a binding.
+	CurrentReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_current_replicas",
+			Help: "Number of replicas of the target of an autoscaling policy binding",
+		},
+		[]string{LabelNamespace, LabelBinding, LabelTarget},
+	)
+
+	// DesiredReplicas is the number of replicas the autoscaler wants for a target of a binding.
+	DesiredReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_desired_replicas",
+			Help: "Number of replicas the autoscaler wants for the target of an autoscaling policy binding",
+		},
+		[]string{LabelNamespace, LabelBinding, LabelTarget},
+	)
+
+	// MetricValue is the last observed value of a metric of the policy of a binding.
+	MetricValue = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_metric_value",
+			Help: "Last observed value of a metric of an autoscaling policy binding, summed over the ready pods for pod metrics",
+		},
+		[]string{LabelNamespace, LabelBinding, LabelMetric},
+	)
+
+	// MetricTarget is the target value of a metric of the policy of a binding.
+	MetricTarget = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_metric_target",
+			Help: "Target value per replica of a metric of an autoscaling policy binding",
+		},
+		[]string{LabelNamespace, LabelBinding, LabelMetric},
+	)
+
+	// PanicMode is 1 while the panic policy of a binding is active, 0 otherwise.
+	PanicMode = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_panic_mode",
+			Help: "Whether the panic policy of an autoscaling policy binding is active",
+		},
+		[]string{LabelNamespace, LabelBinding},
+	)
+
+	// PredictedReplicas is the peak of the replicas forecast for the lead time of the predictive policy of a binding.
+	PredictedReplicas = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_autoscaler_predicted_replicas",
+			Help: "Peak of the replicas forecast for the lead time of the predictive policy of an autoscaling policy binding",
+		},
+		[]string{LabelNamespace, LabelBinding},
+	)
+
+	// ScaleActions counts the changes of the replicas of a target of a binding.
+	ScaleActions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kthena_autoscaler_scale_actions_total",
+			Help: "Total number of scale actions on the target of an autoscaling policy binding",
+		},
+		[]string{LabelNamespace, LabelBinding, LabelTarget, LabelDirection},
+	)
+)
+
+// DeleteBinding removes the metrics of a deleted binding, of the bindings with that name in all the namespaces
+// when namespace is empty.
+func DeleteBinding(namespace, name string) {
+	labels := prometheus.Labels{LabelBinding: name}
+	if namespace != "" {
+		labels[LabelNamespace] = namespace
+	}
+	CurrentReplicas.DeletePartialMatch(labels)
+	DesiredReplicas.DeletePartialMatch(labels)
+	MetricValue.DeletePartialMatch(labels)
+	MetricTarget.DeletePartialMatch(labels)
+	PanicMode.DeletePartialMatch(labels)
+	PredictedReplicas.DeletePartialMatch(labels)
+	ScaleActions.DeletePartialMatch(labels)
+}
@@ -0,0 +1,368 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// The defaults the API server sets from the kubebuilder markers of the API types, which TestDefaultsMatchCRD checks
+// against the generated CRDs.
+const (
+	defaultTolerancePercent         = 10
+	defaultCostExpansionRatePercent = 200
+	defaultStablePolicyInstances    = 1
+	defaultStablePolicyPercent      = 100
+	defaultStablePolicyPeriod       = 15 * time.Second
+	defaultPanicPolicyPercent       = 1000
+	defaultPanicThresholdPercent    = 200
+	defaultPanicModeHold            = 60 * time.Second
+)
+
+// Scenario is the policy and the binding to simulate, and the load of the targets over time.
+type Scenario struct {
+	// Policy is the autoscaling policy. The defaults of the API server and of the webhook are set on it.
+	Policy *workload.AutoscalingPolicy `json:"policy"`
+	// Binding binds the policy to the targets. Its namespace defaults to default.
+	Binding *workload.AutoscalingPolicyBinding `json:"binding"`
+	// Start is the wall-clock time of the start of the series, which the schedules and the seasons of the
+	// forecast of the policy follow. It defaults to the Unix epoch.
+	Start *metav1.Time `json:"start,omitempty"`
+	// Duration is how long the scenario is simulated for, by default until the last point of the series.
+	Duration *metav1.Duration `json:"duration,omitempty"`
+	// Period is the period of the evaluations of the binding, its sync period by default.
+	Period *metav1.Duration `json:"period,omitempty"`
+	// ReadinessDelay is how long a new replica takes to become ready.
+	ReadinessDelay metav1.Duration `json:"readinessDelay,omitempty"`
+	// InitialReplicas are the replicas of the targets at the start, by target name, or by role name for a coupled
+	// target. They default to the min replicas of the targets, and are ready from the start.
+	InitialReplicas map[string]int32 `json:"initialReplicas,omitempty"`
+	// Capacity is the value of each metric a replica serves within the SLO, from which the replicas needed by the
+	// load are computed. It defaults to the target value of the metric in the policy, and should be set to compare
+	// policies with different target values.
+	Capacity map[string]float64 `json:"capacity,omitempty"`
+	// Series is the recorded load of the targets.
+	Series []Point `json:"series,omitempty"`
+	// Synthetic are series generated for metrics of the policy, added to Series.
+	Synthetic []SyntheticSeries `json:"synthetic,omitempty"`
+}
+
+// Point is the load of the targets from a time of the series until the next point setting the same values.
+type Point struct {
+	// Time is the offset of the point from the start of the series.
+	Time metav1.Duration `json:"time"`
+	// Metrics are the values of metrics of the policy. The value of a metric scraped from the pods is the sum of the
+	// values of the pods of all the targets, shared evenly by the ready pods. The values of the other sources are
+	// used as they are. A metric missing from the point keeps its previous value.
+	Metrics map[string]float64 `json:"metrics,omitempty"`
+	// Demand is the demand the routers observe, for scale to zero and the learned ratio of a coupled target.
+	// It keeps its previous value when it is missing from the point.
+	Demand *Demand `json:"demand,omitempty"`
+	// Schedulable are the replicas of each target the cluster can schedule, by target name, or by role name for a
+	// coupled target. The replicas beyond stay pending and unschedulable until the cluster can schedule them. A
+	// target missing from the point keeps its previous value, and the replicas of a target are not limited until
+	// a point sets them.
+	Schedulable map[string]int32 `json:"schedulable,omitempty"`
+}
+
+// Demand is the demand the routers observe for the targets.
+type Demand struct {
+	// Idle is whether the targets receive no request, after which a target which scales to zero is scaled to zero.
+	Idle bool `json:"idle,omitempty"`
+	// InputTokensPerSecond and OutputTokensPerSecond are the rates of the tokens of the requests.
+	InputTokensPerSecond  float64 `json:"inputTokensPerSecond,omitempty"`
+	OutputTokensPerSecond float64 `json:"outputTokensPerSecond,omitempty"`
+}
+
+// SyntheticSeries is a series of a metric following a sine wave, e.g. a daily traffic pattern, with an optional
+// burst.
+type SyntheticSeries struct {
+	// MetricName is the name of the metric of the policy.
+	MetricName string `json:"metricName"`
+	// Base is the mean of the wave.
+	Base float64 `json:"base"`
+	// Amplitude is the amplitude of the wave. The values below zero are set to zero.
+	Amplitude float64 `json:"amplitude,omitempty"`
+	// Period is the period of the wave, 24h by default.
+	Period *metav1.Duration `json:"period,omitempty"`
+	// Duration is how long the series lasts.
+	Duration metav1.Duration `json:"duration"`
+	// Step is the time between the points of the series, 1m by default.
+	Step *metav1.Duration `json:"step,omitempty"`
+	// Burst adds a value to the wave during a window of the series.
+	Burst *Burst `json:"burst,omitempty"`
+}
+
+// Burst is a value added to a synthetic series during a window.
+type Burst struct {
+	// Start is the offset of the window from the start of the series.
+	Start metav1.Duration `json:"start"`
+	// Duration is how long the window lasts.
+	Duration metav1.Duration `json:"duration"`
+	// Value is the value added to the series during the window.
+	Value float64 `json:"value"`
+}
+
+// ReadScenario reads a scenario from YAML or JSON. The tolerance of the policy is defaulted like the API server
+// does, when it is missing. The policy may be missing, e.g. to be read by ReadPolicy.
+func ReadScenario(data []byte) (*Scenario, error) {
+	scenario := &Scenario{}
+	if err := yaml.UnmarshalStrict(data, scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %v", err)
+	}
+	if scenario.Policy == nil {
+		return scenario, nil
+	}
+	raw := struct {
+		Policy json.RawMessage `json:"policy"`
+	}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario: %v", err)
+	}
+	if err := setDefaultTolerance(scenario.Policy, raw.Policy); err != nil {
+		return nil, err
+	}
+	return scenario, nil
+}
+
+// ReadPolicy reads an AutoscalingPolicy from YAML or JSON, e.g. to simulate it instead of the policy of a
+// scenario. The tolerance of the policy is defaulted like the API server does, when it is missing.
+func ReadPolicy(data []byte) (*workload.AutoscalingPolicy, error) {
+	policy := &workload.AutoscalingPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if policy.Kind != "" && policy.Kind != workload.AutoscalingPolicyKind.Kind {
+		return nil, fmt.Errorf("%q is not an AutoscalingPolicy", policy.Kind)
+	}
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if err := setDefaultTolerance(policy, jsonData); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// setDefaultTolerance sets the default tolerance on the policy if the JSON of the policy has none, since a
+// tolerance of zero is valid.
+func setDefaultTolerance(policy *workload.AutoscalingPolicy, data []byte) error {
+	raw := struct {
+		Spec struct {
+			TolerancePercent *int32 `json:"tolerancePercent"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("failed to parse policy: %v", err)
+	}
+	if raw.Spec.TolerancePercent == nil {
+		policy.Spec.TolerancePercent = defaultTolerancePercent
+	}
+	return nil
+}
+
+// ReadSeriesCSV reads a series from CSV, e.g. exported from Prometheus. The header is "time" followed by the names
+// of the metrics, and each record is the time of a point followed by the values of the metrics, empty when they
+// are unchanged. The time is either an offset from the start of the series, e.g. 90s or 90, or an RFC 3339
+// timestamp, in which case the first timestamp is returned as the start of the series.
+func ReadSeriesCSV(r io.Reader) ([]Point, *time.Time, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read series: %v", err)
+	}
+	if len(records) == 0 || len(records[0]) < 2 || strings.TrimSpace(records[0][0]) != "time" {
+		return nil, nil, errors.New(`series must have a header "time" followed by metric names`)
+	}
+	header := records[0]
+	var points []Point
+	var start *time.Time
+	for line, record := range records[1:] {
+		timeExpr := strings.TrimSpace(record[0])
+		var offset time.Duration
+		if timestamp, err := time.Parse(time.RFC3339, timeExpr); err == nil {
+			if start == nil {
+				start = &timestamp
+			}
+			offset = timestamp.Sub(*start)
+		} else if seconds, err := strconv.ParseFloat(timeExpr, 64); err == nil {
+			offset = time.Duration(seconds * float64(time.Second))
+		} else if offset, err = time.ParseDuration(timeExpr); err != nil {
+			return nil, nil, fmt.Errorf("invalid time %q on line %d", timeExpr, line+2)
+		}
+		point := Point{Time: metav1.Duration{Duration: offset}, Metrics: map[string]float64{}}
+		for i := 1; i < len(record) && i < len(header); i++ {
+			valueExpr := strings.TrimSpace(record[i])
+			if valueExpr == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(valueExpr, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid value %q of %s on line %d", valueExpr, header[i], line+2)
+			}
+			point.Metrics[strings.TrimSpace(header[i])] = value
+		}
+		points = append(points, point)
+	}
+	return points, start, nil
+}
+
+// Points returns the points of the synthetic series.
+func (s *SyntheticSeries) Points() []Point {
+	period, step := 24*time.Hour, time.Minute
+	if s.Period != nil && s.Period.Duration > 0 {
+		period = s.Period.Duration
+	}
+	if s.Step != nil && s.Step.Duration > 0 {
+		step = s.Step.Duration
+	}
+	var points []Point
+	for offset := time.Duration(0); offset <= s.Duration.Duration; offset += step {
+		value := s.Base + s.Amplitude*math.Sin(2*math.Pi*float64(offset)/float64(period))
+		if s.Burst != nil && offset >= s.Burst.Start.Duration && offset < s.Burst.Start.Duration+s.Burst.Duration.Duration {
+			value += s.Burst.Value
+		}
+		points = append(points, Point{
+			Time:    metav1.Duration{Duration: offset},
+			Metrics: map[string]float64{s.MetricName: max(value, 0)},
+		})
+	}
+	return points
+}
+
+// points returns the points of the series and of the synthetic series, in time order.
+func (s *Scenario) points() []Point {
+	points := append([]Point(nil), s.Series...)
+	for i := range s.Synthetic {
+		points = append(points, s.Synthetic[i].Points()...)
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].Time.Duration < points[j].Time.Duration
+	})
+	return points
+}
+
+// validate checks that the scenario can be simulated.
+func (s *Scenario) validate() error {
+	if s.Policy == nil {
+		return errors.New("scenario has no policy")
+	}
+	if s.Binding == nil {
+		return errors.New("scenario has no binding")
+	}
+	spec := &s.Binding.Spec
+	targets := 0
+	for _, set := range []bool{spec.HomogeneousTarget != nil, spec.HeterogeneousTarget != nil, spec.CoupledTarget != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return errors.New("binding must have exactly one of homogeneousTarget, heterogeneousTarget and coupledTarget")
+	}
+	if spec.HeterogeneousTarget != nil && len(spec.HeterogeneousTarget.Params) == 0 {
+		return errors.New("heterogeneous target has no params")
+	}
+	if spec.CoupledTarget != nil && len(spec.CoupledTarget.Roles) == 0 {
+		return errors.New("coupled target has no roles")
+	}
+	if len(s.Policy.Spec.Metrics) == 0 {
+		return errors.New("policy has no metrics")
+	}
+	if s.Period != nil && s.Period.Duration < time.Second {
+		return errors.New("period must be at least 1s")
+	}
+	if s.ReadinessDelay.Duration < 0 {
+		return errors.New("readiness delay must not be negative")
+	}
+	for _, point := range s.Series {
+		for name, replicas := range point.Schedulable {
+			if replicas < 0 {
+				return fmt.Errorf("schedulable replicas of target %s must not be negative", name)
+			}
+		}
+	}
+	return nil
+}
+
+// setDefaults sets the defaults the API server and the webhook set on the policy and the binding, except for the
+// tolerance of the policy, see ReadScenario. Like the API server, the defaults of the schema are set on the
+// policies of the behavior which are set, before the webhook sets the policies which are not.
+func (s *Scenario) setDefaults() error {
+	if s.Binding.Namespace == "" {
+		s.Binding.Namespace = "default"
+	}
+	if s.Policy.Namespace == "" {
+		s.Policy.Namespace = s.Binding.Namespace
+	}
+	behavior := &s.Policy.Spec.Behavior
+	for _, stable := range []*workload.AutoscalingPolicyStablePolicy{&behavior.ScaleUp.StablePolicy, &behavior.ScaleDown} {
+		if *stable == (workload.AutoscalingPolicyStablePolicy{}) {
+			continue
+		}
+		if stable.Instances == nil {
+			stable.Instances = ptr.To(int32(defaultStablePolicyInstances))
+		}
+		if stable.Percent == nil {
+			stable.Percent = ptr.To(int32(defaultStablePolicyPercent))
+		}
+		if stable.Period == nil {
+			stable.Period = &metav1.Duration{Duration: defaultStablePolicyPeriod}
+		}
+		if stable.SelectPolicy == "" {
+			stable.SelectPolicy = workload.SelectPolicyOr
+		}
+	}
+	if panicPolicy := &behavior.ScaleUp.PanicPolicy; *panicPolicy != (workload.AutoscalingPolicyPanicPolicy{}) {
+		if panicPolicy.Percent == nil {
+			panicPolicy.Percent = ptr.To(int32(defaultPanicPolicyPercent))
+		}
+		if panicPolicy.PanicThresholdPercent == nil {
+			panicPolicy.PanicThresholdPercent = ptr.To(int32(defaultPanicThresholdPercent))
+		}
+		if panicPolicy.PanicModeHold == nil {
+			panicPolicy.PanicModeHold = &metav1.Duration{Duration: defaultPanicModeHold}
+		}
+	}
+	if err := webhook.DefaultAutoscalingPolicy(s.Policy); err != nil {
+		return fmt.Errorf("failed to set defaults of policy: %v", err)
+	}
+	for i := range s.Policy.Spec.Metrics {
+		if source := s.Policy.Spec.Metrics[i].Source; source != nil && source.Type == "" {
+			source.Type = workload.MetricSourcePod
+		}
+	}
+	if target := s.Binding.Spec.HeterogeneousTarget; target != nil && target.CostExpansionRatePercent == 0 {
+		target.CostExpansionRatePercent = defaultCostExpansionRatePercent
+	}
+	return nil
+}
@@ -0,0 +1,636 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator replays the load of a scenario through the autoscaler offline, with a simulated clock and
+// simulated pods, to compare autoscaling policies before rolling them out.
+//
+// The recommendations are made by the code of the autoscaler: the metrics are scraped from the simulated pods by
+// its metric collector, and the replicas recommended and corrected by its scaler or optimizer.
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/autoscaler"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// targetLabelKey labels the simulated pods of a target scaled through the scale subresource, which its scale
+// selector selects.
+const targetLabelKey = "simulator.kthena.volcano.sh/target"
+
+// Result is the outcome of a simulation.
+type Result struct {
+	// Steps are the evaluations of the binding.
+	Steps   []Step  `json:"steps"`
+	Summary Summary `json:"summary"`
+}
+
+// Step is an evaluation of the binding.
+type Step struct {
+	// Time is the offset of the evaluation from the start of the series.
+	Time metav1.Duration `json:"time"`
+	// Metrics are the values of the metrics of the series.
+	Metrics map[string]float64 `json:"metrics"`
+	// NeededReplicas are the replicas the metrics ask for at the capacity of a replica, without tolerance nor bounds.
+	NeededReplicas int32 `json:"neededReplicas"`
+	// ReadyReplicas are the ready replicas of all the targets.
+	ReadyReplicas int32 `json:"readyReplicas"`
+	// SLOViolated is whether fewer replicas than needed were ready.
+	SLOViolated bool         `json:"sloViolated,omitempty"`
+	Targets     []TargetStep `json:"targets"`
+	// Reason, PanicMode, ScheduledMinReplicas and PredictedReplicas are the decision of the autoscaler, like in
+	// the status of the binding.
+	Reason               workload.AutoscalingReason `json:"reason,omitempty"`
+	PanicMode            bool                       `json:"panicMode,omitempty"`
+	ScheduledMinReplicas *int32                     `json:"scheduledMinReplicas,omitempty"`
+	PredictedReplicas    *int32                     `json:"predictedReplicas,omitempty"`
+}
+
+// TargetStep is the evaluation of a target, or of a role of a coupled target.
+type TargetStep struct {
+	Name            string `json:"name"`
+	ReadyReplicas   int32  `json:"readyReplicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	DesiredReplicas int32  `json:"desiredReplicas"`
+	// UnschedulableReplicas are the current replicas the cluster could not schedule.
+	UnschedulableReplicas int32 `json:"unschedulableReplicas,omitempty"`
+	// CappedReplicas is the maximum replicas of a target of a heterogeneous target capped because some of its
+	// replicas could not be scheduled.
+	CappedReplicas *int32 `json:"cappedReplicas,omitempty"`
+}
+
+// Summary sums up the steps of a simulation.
+type Summary struct {
+	Duration    metav1.Duration `json:"duration"`
+	Evaluations int             `json:"evaluations"`
+	// ReplicaHours are the hours of the replicas, ready or not, of all the targets.
+	ReplicaHours float64 `json:"replicaHours"`
+	// Cost is the replica hours weighted by the cost of the targets, which is the cost of the params of a
+	// heterogeneous target, and 1 otherwise.
+	Cost         float64 `json:"cost"`
+	PeakReplicas int32   `json:"peakReplicas"`
+	// ScaleUps and ScaleDowns are the number of changes of the replicas of the targets.
+	ScaleUps   int `json:"scaleUps"`
+	ScaleDowns int `json:"scaleDowns"`
+	// SLOViolations are the evaluations at which fewer replicas than needed were ready, and SLOViolationDuration
+	// the time until the next evaluations.
+	SLOViolations        int             `json:"sloViolations"`
+	SLOViolationDuration metav1.Duration `json:"sloViolationDuration"`
+	SLOViolationPercent  float64         `json:"sloViolationPercent"`
+	// MetricsUnavailable are the evaluations skipped for lack of metrics, e.g. while a pod is not ready.
+	MetricsUnavailable int `json:"metricsUnavailable"`
+}
+
+// Simulate evaluates the binding of the scenario at each period of the series, and scales the simulated targets
+// by the replicas the autoscaler recommends. The new replicas become ready after the readiness delay, and the
+// replicas removed are the newest first. The scenario is not modified.
+func Simulate(ctx context.Context, scenario *Scenario) (*Result, error) {
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+	s := *scenario
+	s.Policy, s.Binding = scenario.Policy.DeepCopy(), scenario.Binding.DeepCopy()
+	if err := s.setDefaults(); err != nil {
+		return nil, err
+	}
+	points := s.points()
+	if len(points) == 0 {
+		return nil, errors.New("scenario has no series")
+	}
+	period := util.AutoscalingSyncPeriodSeconds * time.Second
+	if s.Period != nil {
+		period = s.Period.Duration
+	} else if s.Binding.Spec.SyncPeriod != nil && s.Binding.Spec.SyncPeriod.Duration > 0 {
+		period = s.Binding.Spec.SyncPeriod.Duration
+	}
+	end := points[len(points)-1].Time.Duration
+	if s.Duration != nil {
+		end = s.Duration.Duration
+	}
+	start := time.Unix(0, 0)
+	if s.Start != nil {
+		start = s.Start.Time
+	}
+
+	// The autoscaler follows the simulated clock.
+	var now atomic.Int64
+	now.Store(start.UnixMilli())
+
+	sim, err := newSimulation(&s, now.Load)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{}
+	next := 0
+	for offset := time.Duration(0); offset <= end; offset += period {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		now.Store(start.Add(offset).UnixMilli())
+		for ; next < len(points) && points[next].Time.Duration <= offset; next++ {
+			sim.apply(&points[next])
+		}
+		sim.advance(now.Load())
+		step, err := sim.evaluate(ctx, now.Load())
+		if err != nil {
+			return nil, err
+		}
+		step.Time = metav1.Duration{Duration: offset}
+		result.Steps = append(result.Steps, *step)
+		result.Summary.add(step, sim.targets, min(period, end-offset))
+	}
+	result.Summary.Duration = metav1.Duration{Duration: end}
+	if end > 0 {
+		result.Summary.SLOViolationPercent = 100 * float64(result.Summary.SLOViolationDuration.Duration) / float64(end)
+	}
+	return result, nil
+}
+
+func (summary *Summary) add(step *Step, targets []*simTarget, interval time.Duration) {
+	summary.Evaluations++
+	replicas := int32(0)
+	for i, target := range step.Targets {
+		replicas += target.DesiredReplicas
+		hours := float64(target.DesiredReplicas) * interval.Hours()
+		summary.ReplicaHours += hours
+		summary.Cost += hours * targets[i].cost
+		switch {
+		case target.DesiredReplicas > target.CurrentReplicas:
+			summary.ScaleUps++
+		case target.DesiredReplicas < target.CurrentReplicas:
+			summary.ScaleDowns++
+		}
+	}
+	summary.PeakReplicas = max(summary.PeakReplicas, replicas)
+	if step.SLOViolated {
+		summary.SLOViolations++
+		summary.SLOViolationDuration.Duration += interval
+	}
+	if step.Reason == workload.AutoscalingReasonMetricsUnavailable {
+		summary.MetricsUnavailable++
+	}
+}
+
+// simulation is the state of the simulated targets and of their autoscaler.
+type simulation struct {
+	scenario  *Scenario
+	namespace string
+	indexer   cache.Indexer
+	lister    listerv1.PodLister
+	targets   []*simTarget
+	// pods are the simulated pods by name.
+	pods map[string]*simPod
+	// podMetrics are the metrics scraped from the pods, and load the current values of the metrics of the series.
+	podMetrics map[string]*workload.PodMetricSource
+	load       map[string]float64
+	demand     Demand
+	// schedulable are the replicas of the targets the cluster can schedule, by target name.
+	schedulable map[string]int32
+
+	scaler    *autoscaler.Autoscaler
+	optimizer *autoscaler.Optimizer
+}
+
+// simTarget is a simulated target, or a role of a coupled target.
+type simTarget struct {
+	name     string
+	podName  string
+	labels   map[string]string
+	cost     float64
+	pods     []*simPod
+	podIndex int
+}
+
+// simPod is a simulated pod, and the values of its metrics: the gauges, or the counters of the rates.
+type simPod struct {
+	pod       *corev1.Pod
+	scheduled bool
+	readyAt   int64
+	values    map[string]float64
+	counters  map[string]float64
+	// lastUpdate is the timestamp at which the counters were last increased.
+	lastUpdate int64
+}
+
+// newSimulation creates the simulated targets and their autoscaler, which reads the time from now.
+func newSimulation(s *Scenario, now func() int64) (*simulation, error) {
+	sim := &simulation{
+		scenario:    s,
+		namespace:   s.Binding.Namespace,
+		indexer:     cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}),
+		pods:        map[string]*simPod{},
+		podMetrics:  autoscaler.GetPodMetrics(s.Policy),
+		load:        map[string]float64{},
+		schedulable: map[string]int32{},
+	}
+	sim.lister = listerv1.NewPodLister(sim.indexer)
+
+	spec := &s.Binding.Spec
+	var collectors map[string]*autoscaler.MetricCollector
+	var minReplicas []int32
+	switch {
+	case spec.HomogeneousTarget != nil:
+		target := &spec.HomogeneousTarget.Target
+		if err := sim.addTarget(target, nil, target.TargetRef.Name, 1); err != nil {
+			return nil, err
+		}
+		minReplicas = append(minReplicas, spec.HomogeneousTarget.MinReplicas)
+		sim.scaler = autoscaler.NewAutoscaler(s.Policy, s.Binding, now)
+		collectors = map[string]*autoscaler.MetricCollector{target.TargetRef.Name: sim.scaler.Collector}
+	case spec.HeterogeneousTarget != nil:
+		for i := range spec.HeterogeneousTarget.Params {
+			param := &spec.HeterogeneousTarget.Params[i]
+			if err := sim.addTarget(&param.Target, nil, param.Target.TargetRef.Name, float64(param.Cost)); err != nil {
+				return nil, err
+			}
+			minReplicas = append(minReplicas, param.MinReplicas)
+		}
+		sim.optimizer = autoscaler.NewOptimizer(s.Policy, s.Binding, now)
+		collectors = sim.optimizer.Collectors
+	case spec.CoupledTarget != nil:
+		target := &spec.CoupledTarget.Target
+		if !util.IsModelServingTarget(target) {
+			return nil, fmt.Errorf("coupled target %s is not a ModelServing", target.TargetRef.Name)
+		}
+		for i := range spec.CoupledTarget.Roles {
+			role := &spec.CoupledTarget.Roles[i]
+			if err := sim.addTarget(target, &role.Name, role.Name, 1); err != nil {
+				return nil, err
+			}
+			minReplicas = append(minReplicas, role.MinReplicas)
+		}
+		sim.scaler = autoscaler.NewCoupledAutoscaler(s.Policy, s.Binding, now)
+		collectors = map[string]*autoscaler.MetricCollector{target.TargetRef.Name: sim.scaler.Collector}
+	}
+	for name, collector := range collectors {
+		collector.Fetch = sim.fetch
+		if !util.IsModelServingTarget(collector.Target) {
+			collector.ScaleSelector = labels.SelectorFromSet(labels.Set{targetLabelKey: name}).String()
+		}
+	}
+
+	// The initial replicas are ready from the start.
+	start := now()
+	for i, target := range sim.targets {
+		replicas, ok := s.InitialReplicas[target.name]
+		if !ok {
+			replicas = minReplicas[i]
+		}
+		sim.scale(target, replicas, start, 0)
+	}
+	return sim, nil
+}
+
+// addTarget adds a simulated target, whose pods have the labels the autoscaler selects the pods of the target by.
+func (sim *simulation) addTarget(target *workload.Target, role *string, name string, cost float64) error {
+	podLabels := map[string]string{}
+	if selector := target.MetricEndpoint.LabelSelector; selector != nil {
+		if len(selector.MatchExpressions) > 0 {
+			return fmt.Errorf("label selector of target %s has match expressions, which are not simulated", name)
+		}
+		for key, value := range selector.MatchLabels {
+			podLabels[key] = value
+		}
+	}
+	podName := target.TargetRef.Name
+	if util.IsModelServingTarget(target) {
+		podLabels[workload.ModelServingNameLabelKey] = target.TargetRef.Name
+		podLabels[workload.EntryLabelKey] = util.Entry
+		if target.SubTarget != nil && target.SubTarget.Kind == util.ModelServingRoleKind {
+			podLabels[workload.RoleLabelKey] = target.SubTarget.Name
+			podName += "-" + target.SubTarget.Name
+		}
+		if role != nil {
+			podLabels[workload.RoleLabelKey] = *role
+			podName += "-" + *role
+		}
+	} else {
+		podLabels[targetLabelKey] = target.TargetRef.Name
+	}
+	sim.targets = append(sim.targets, &simTarget{
+		name:    name,
+		podName: podName,
+		labels:  podLabels,
+		cost:    cost,
+	})
+	return nil
+}
+
+// apply sets the load of a point of the series.
+func (sim *simulation) apply(point *Point) {
+	for name, value := range point.Metrics {
+		sim.load[name] = value
+	}
+	if point.Demand != nil {
+		sim.demand = *point.Demand
+	}
+	for name, replicas := range point.Schedulable {
+		sim.schedulable[name] = replicas
+	}
+}
+
+// advance schedules the pending pods the cluster can schedule, makes the pods ready once their readiness delay
+// elapsed, and shares the load of the pod metrics evenly across the ready pods. The counters of the rates increase
+// by the rates of the previous load.
+func (sim *simulation) advance(now int64) {
+	ready := 0
+	for _, target := range sim.targets {
+		sim.schedule(target, now, sim.scenario.ReadinessDelay.Milliseconds())
+		for _, pod := range target.pods {
+			if pod.scheduled && !isReady(pod.pod) && now >= pod.readyAt {
+				setReady(pod.pod)
+				_ = sim.indexer.Update(pod.pod)
+			}
+			if isReady(pod.pod) {
+				ready++
+			}
+		}
+	}
+	for _, target := range sim.targets {
+		for _, pod := range target.pods {
+			elapsedSeconds := float64(now-pod.lastUpdate) / 1000
+			pod.lastUpdate = now
+			for name, source := range sim.podMetrics {
+				if source != nil && source.Rate {
+					pod.counters[name] += pod.values[name] * elapsedSeconds
+				}
+				pod.values[name] = 0
+				if isReady(pod.pod) {
+					pod.values[name] = sim.load[name] / float64(ready)
+				}
+			}
+		}
+	}
+}
+
+// evaluate evaluates the binding, and scales the targets by the replicas recommended.
+func (sim *simulation) evaluate(ctx context.Context, now int64) (*Step, error) {
+	policy := sim.scenario.Policy
+	step := &Step{Metrics: make(map[string]float64, len(sim.load))}
+	for name, value := range sim.load {
+		step.Metrics[name] = value
+	}
+	for _, metric := range policy.Spec.Metrics {
+		capacity, ok := sim.scenario.Capacity[metric.MetricName]
+		if !ok {
+			capacity = metric.TargetValue.AsFloat64Slow()
+		}
+		if value, ok := sim.load[metric.MetricName]; ok && capacity > 0 {
+			step.NeededReplicas = max(step.NeededReplicas, int32(math.Ceil(value/capacity)))
+		}
+	}
+	current := make([]int32, len(sim.targets))
+	for i, target := range sim.targets {
+		current[i] = int32(len(target.pods))
+		ready := target.readyReplicas()
+		step.ReadyReplicas += ready
+		step.Targets = append(step.Targets, TargetStep{
+			Name:                  target.name,
+			ReadyReplicas:         ready,
+			CurrentReplicas:       current[i],
+			UnschedulableReplicas: target.unschedulableReplicas(),
+		})
+	}
+	step.SLOViolated = step.ReadyReplicas < step.NeededReplicas
+
+	externalMetrics := algorithm.Metrics{}
+	for i := range policy.Spec.Metrics {
+		metric := &policy.Spec.Metrics[i]
+		if value, ok := sim.load[metric.MetricName]; ok && !autoscaler.IsPodMetric(metric) {
+			externalMetrics[metric.MetricName] = value
+		}
+	}
+	desired := append([]int32(nil), current...)
+	var status *autoscaler.Status
+	spec := &sim.scenario.Binding.Spec
+	switch {
+	case spec.HomogeneousTarget != nil:
+		status = sim.scaler.Status
+		recommended, overridden := int32(0), false
+		if spec.HomogeneousTarget.ScaleToZero != nil {
+			demand := autoscaler.Demand{}
+			if !sim.demand.Idle {
+				demand.LastRequestTime = now
+			}
+			recommended, overridden = sim.scaler.ScaleToZero(policy, demand, current[0])
+		}
+		if !overridden {
+			var err error
+			if recommended, err = sim.scaler.Scale(ctx, sim.lister, policy, current[0], externalMetrics); err != nil {
+				return nil, err
+			}
+		}
+		if recommended >= 0 {
+			desired[0] = recommended
+		}
+	case spec.HeterogeneousTarget != nil:
+		status = sim.optimizer.Status
+		currentCounts := make(map[string]int32, len(sim.targets))
+		for i, target := range sim.targets {
+			currentCounts[target.name] = current[i]
+		}
+		recommended, err := sim.optimizer.Optimize(ctx, sim.lister, policy, currentCounts, externalMetrics)
+		if err != nil {
+			return nil, err
+		}
+		for i, target := range sim.targets {
+			if replicas, ok := recommended[target.name]; ok {
+				desired[i] = replicas
+			}
+			if capacityCap, ok := sim.optimizer.CapacityCaps[target.name]; ok && !capacityCap.Lifted {
+				capped := capacityCap.Replicas
+				step.Targets[i].CappedReplicas = &capped
+			}
+		}
+	case spec.CoupledTarget != nil:
+		status = sim.scaler.Status
+		total := int32(0)
+		for _, replicas := range current {
+			total += replicas
+		}
+		recommended, err := sim.scaler.Scale(ctx, sim.lister, policy, total, externalMetrics)
+		if err != nil {
+			return nil, err
+		}
+		if recommended >= 0 {
+			demand := autoscaler.Demand{
+				InputTokensPerSecond:  sim.demand.InputTokensPerSecond,
+				OutputTokensPerSecond: sim.demand.OutputTokensPerSecond,
+			}
+			desired = autoscaler.SplitReplicas(spec.CoupledTarget.Roles, autoscaler.CoupledShares(spec.CoupledTarget, demand), recommended)
+		}
+	}
+	for i, target := range sim.targets {
+		sim.scale(target, desired[i], now, sim.scenario.ReadinessDelay.Milliseconds())
+		step.Targets[i].DesiredReplicas = desired[i]
+	}
+	step.Reason = status.LastDecision.Reason
+	step.PanicMode = status.IsPanicMode()
+	step.ScheduledMinReplicas = status.LastDecision.ScheduledMinInstances
+	step.PredictedReplicas = status.LastDecision.PredictedInstances
+	return step, nil
+}
+
+// scale adds pods becoming ready after readinessDelay once they are scheduled, or removes the newest pods.
+func (sim *simulation) scale(target *simTarget, replicas int32, now int64, readinessDelay int64) {
+	for int32(len(target.pods)) < replicas {
+		target.podIndex++
+		pod := &simPod{
+			pod: &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              target.podName + "-" + strconv.Itoa(target.podIndex),
+					Namespace:         sim.namespace,
+					Labels:            target.labels,
+					CreationTimestamp: metav1.NewTime(time.UnixMilli(now)),
+				},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodPending,
+					StartTime: &metav1.Time{Time: time.UnixMilli(now)},
+				},
+			},
+			values:     map[string]float64{},
+			counters:   map[string]float64{},
+			lastUpdate: now,
+		}
+		target.pods = append(target.pods, pod)
+		sim.pods[pod.pod.Name] = pod
+		_ = sim.indexer.Add(pod.pod)
+	}
+	for int32(len(target.pods)) > replicas {
+		pod := target.pods[len(target.pods)-1]
+		target.pods = target.pods[:len(target.pods)-1]
+		delete(sim.pods, pod.pod.Name)
+		_ = sim.indexer.Delete(pod.pod)
+	}
+	sim.schedule(target, now, readinessDelay)
+}
+
+// schedule schedules the pending pods of a target, the oldest first, within the replicas of the target the
+// cluster can schedule, and marks the others unschedulable. The scheduled pods become ready after readinessDelay.
+func (sim *simulation) schedule(target *simTarget, now int64, readinessDelay int64) {
+	limit, limited := sim.schedulable[target.name]
+	scheduled := int32(0)
+	for _, pod := range target.pods {
+		if pod.scheduled {
+			scheduled++
+		}
+	}
+	for _, pod := range target.pods {
+		if pod.scheduled {
+			continue
+		}
+		if limited && scheduled >= limit {
+			if !util.IsPodUnschedulable(pod.pod) {
+				pod.pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}}
+				_ = sim.indexer.Update(pod.pod)
+			}
+			continue
+		}
+		scheduled++
+		pod.scheduled = true
+		pod.readyAt = now + readinessDelay
+		pod.pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}}
+		if readinessDelay == 0 {
+			setReady(pod.pod)
+		}
+		_ = sim.indexer.Update(pod.pod)
+	}
+}
+
+// fetch returns the metrics of a simulated pod in the Prometheus text format, with the labels the pod metrics
+// select their series by. The rates are exposed as counters, and the other metrics as gauges.
+func (sim *simulation) fetch(_ context.Context, pod *corev1.Pod) (string, error) {
+	simulated, ok := sim.pods[pod.Name]
+	if !ok {
+		return "", fmt.Errorf("pod %s is not simulated", pod.Name)
+	}
+	names := make([]string, 0, len(sim.podMetrics))
+	for name := range sim.podMetrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var builder strings.Builder
+	for _, name := range names {
+		source := sim.podMetrics[name]
+		metricType, value := "gauge", simulated.values[name]
+		if source != nil && source.Rate {
+			metricType, value = "counter", simulated.counters[name]
+		}
+		fmt.Fprintf(&builder, "# TYPE %s %s\n%s%s %s\n", name, metricType, name, formatLabels(source), strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	return builder.String(), nil
+}
+
+func formatLabels(source *workload.PodMetricSource) string {
+	if source == nil || len(source.MatchLabels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(source.MatchLabels))
+	for key := range source.MatchLabels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+strconv.Quote(source.MatchLabels[key]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (target *simTarget) readyReplicas() int32 {
+	ready := int32(0)
+	for _, pod := range target.pods {
+		if isReady(pod.pod) {
+			ready++
+		}
+	}
+	return ready
+}
+
+func (target *simTarget) unschedulableReplicas() int32 {
+	unschedulable := int32(0)
+	for _, pod := range target.pods {
+		if util.IsPodUnschedulable(pod.pod) {
+			unschedulable++
+		}
+	}
+	return unschedulable
+}
+
+func setReady(pod *corev1.Pod) {
+	pod.Status.Phase = corev1.PodRunning
+	pod.Status.Conditions = append(pod.Status.Conditions, corev1.PodCondition{Type: corev1.PodReady, Status: corev1.ConditionTrue})
+}
+
+func isReady(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodRunning
+}
@@ -0,0 +1,468 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const policyYAML = `
+policy:
+  metadata:
+    name: policy
+  spec:
+    metrics:
+    - metricName: load
+      targetValue: "10"
+`
+
+func readScenario(t *testing.T, data string) *Scenario {
+	t.Helper()
+	scenario, err := ReadScenario([]byte(policyYAML + data))
+	require.NoError(t, err)
+	return scenario
+}
+
+func lastStep(result *Result) Step {
+	return result.Steps[len(result.Steps)-1]
+}
+
+func stepAt(t *testing.T, result *Result, offset time.Duration) Step {
+	t.Helper()
+	for _, step := range result.Steps {
+		if step.Time.Duration == offset {
+			return step
+		}
+	}
+	t.Fatalf("no step at %s", offset)
+	return Step{}
+}
+
+func TestSimulate_Homogeneous(t *testing.T) {
+	scenario := readScenario(t, `
+binding:
+  metadata:
+    name: binding
+  spec:
+    policyRef:
+      name: policy
+    homogeneousTarget:
+      target:
+        targetRef:
+          name: llama
+      minReplicas: 1
+      maxReplicas: 10
+readinessDelay: 1m
+series:
+- time: 0s
+  metrics:
+    load: 10
+- time: 2m
+  metrics:
+    load: 60
+- time: 10m
+  metrics:
+    load: 5
+- time: 20m
+`)
+	result, err := Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+
+	assert.Equal(t, 81, result.Summary.Evaluations)
+	assert.Equal(t, 20*time.Minute, result.Summary.Duration.Duration)
+	assert.Equal(t, int32(6), result.Summary.PeakReplicas)
+	// The metrics are unavailable while the new pods are not ready, and the load exceeds the ready pods.
+	assert.Positive(t, result.Summary.MetricsUnavailable)
+	assert.Positive(t, result.Summary.SLOViolations)
+	assert.True(t, stepAt(t, result, 2*time.Minute).SLOViolated)
+	assert.Equal(t, int32(1), stepAt(t, result, 2*time.Minute).Targets[0].ReadyReplicas)
+	assert.Equal(t, int32(6), stepAt(t, result, 9*time.Minute).ReadyReplicas)
+	// The replicas are scaled down after the stabilization window of the webhook defaults.
+	assert.Equal(t, int32(6), stepAt(t, result, 14*time.Minute).Targets[0].DesiredReplicas)
+	assert.Equal(t, int32(1), lastStep(result).Targets[0].DesiredReplicas)
+	assert.Equal(t, result.Summary.ReplicaHours, result.Summary.Cost)
+
+	// The scenario is not modified.
+	assert.Nil(t, scenario.Policy.Spec.Behavior.ScaleDown.StabilizationWindow)
+	assert.Empty(t, scenario.Binding.Namespace)
+}
+
+func TestSimulate_Heterogeneous(t *testing.T) {
+	scenario := readScenario(t, `
+binding:
+  metadata:
+    name: binding
+  spec:
+    policyRef:
+      name: policy
+    heterogeneousTarget:
+      costExpansionRatePercent: 100
+      params:
+      - target:
+          targetRef:
+            name: cheap
+        cost: 1
+        minReplicas: 1
+        maxReplicas: 3
+      - target:
+          targetRef:
+            name: expensive
+        cost: 5
+        minReplicas: 0
+        maxReplicas: 10
+series:
+- time: 0s
+  metrics:
+    load: 50
+- time: 5m
+`)
+	result, err := Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+
+	last := lastStep(result)
+	assert.Equal(t, []TargetStep{
+		{Name: "cheap", ReadyReplicas: 3, CurrentReplicas: 3, DesiredReplicas: 3},
+		{Name: "expensive", ReadyReplicas: 2, CurrentReplicas: 2, DesiredReplicas: 2},
+	}, last.Targets)
+	assert.False(t, last.SLOViolated)
+	assert.Greater(t, result.Summary.Cost, result.Summary.ReplicaHours)
+
+	// The replicas needed follow the capacity of a replica rather than the target value of the metric.
+	scenario.Capacity = map[string]float64{"load": 5}
+	result, err = Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), lastStep(result).NeededReplicas)
+	assert.True(t, lastStep(result).SLOViolated)
+}
+
+func TestSimulate_HeterogeneousCapacity(t *testing.T) {
+	scenario := readScenario(t, `
+binding:
+  metadata:
+    name: binding
+  spec:
+    policyRef:
+      name: policy
+    heterogeneousTarget:
+      costExpansionRatePercent: 100
+      params:
+      - target:
+          targetRef:
+            name: cheap
+        cost: 1
+        minReplicas: 1
+        maxReplicas: 5
+      - target:
+          targetRef:
+            name: expensive
+        cost: 5
+        minReplicas: 0
+        maxReplicas: 10
+readinessDelay: 30s
+series:
+- time: 0s
+  metrics:
+    load: 70
+  schedulable:
+    cheap: 3
+- time: 10m
+  schedulable:
+    cheap: 5
+- time: 20m
+`)
+	result, err := Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+
+	// The replicas of the cheap target beyond the schedulable ones spill over to the expensive target.
+	step := stepAt(t, result, 5*time.Minute)
+	require.NotNil(t, step.Targets[0].CappedReplicas)
+	assert.Equal(t, int32(3), *step.Targets[0].CappedReplicas)
+	assert.Equal(t, int32(3), step.Targets[0].DesiredReplicas)
+	assert.Equal(t, int32(4), step.Targets[1].DesiredReplicas)
+	assert.Zero(t, step.Targets[0].UnschedulableReplicas)
+	assert.False(t, step.SLOViolated)
+
+	// Once the cluster can schedule them and the backoff expired, the replicas move back to the cheap target.
+	last := lastStep(result)
+	assert.Nil(t, last.Targets[0].CappedReplicas)
+	assert.Equal(t, int32(5), last.Targets[0].DesiredReplicas)
+	assert.Equal(t, int32(2), last.Targets[1].DesiredReplicas)
+	assert.False(t, last.SLOViolated)
+
+	// Without caps, the unschedulable replicas of the cheap target stay pending.
+	scenario.Binding.Spec.HeterogeneousTarget.CapacityBackoff = &metav1.Duration{}
+	result, err = Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+	step = stepAt(t, result, 5*time.Minute)
+	assert.Equal(t, int32(5), step.Targets[0].DesiredReplicas)
+	assert.Equal(t, int32(2), step.Targets[0].UnschedulableReplicas)
+	assert.True(t, step.SLOViolated)
+}
+
+func TestSimulate_Coupled(t *testing.T) {
+	scenario := readScenario(t, `
+binding:
+  metadata:
+    name: binding
+  spec:
+    policyRef:
+      name: policy
+    coupledTarget:
+      target:
+        targetRef:
+          name: llama
+      roles:
+      - name: prefill
+        weight: 1
+        minReplicas: 1
+        maxReplicas: 10
+      - name: decode
+        weight: 3
+        minReplicas: 1
+        maxReplicas: 30
+series:
+- time: 0s
+  metrics:
+    load: 80
+- time: 5m
+`)
+	result, err := Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+
+	last := lastStep(result)
+	assert.Equal(t, "prefill", last.Targets[0].Name)
+	assert.Equal(t, int32(2), last.Targets[0].DesiredReplicas)
+	assert.Equal(t, "decode", last.Targets[1].Name)
+	assert.Equal(t, int32(6), last.Targets[1].DesiredReplicas)
+}
+
+func TestSimulate_ScheduleAndRate(t *testing.T) {
+	scenario, err := ReadScenario([]byte(`
+policy:
+  metadata:
+    name: policy
+  spec:
+    metrics:
+    - metricName: requests_total
+      targetValue: "5"
+      source:
+        pod:
+          rate: true
+          window: 1m
+    schedules:
+    - name: business-hours
+      schedule: "0 8 * * *"
+      duration: 1h
+      minReplicas: 4
+binding:
+  metadata:
+    name: binding
+  spec:
+    policyRef:
+      name: policy
+    homogeneousTarget:
+      target:
+        targetRef:
+          kind: Deployment
+          apiVersion: apps/v1
+          name: llama
+      minReplicas: 1
+      maxReplicas: 10
+start: "2026-10-19T07:55:00Z"
+series:
+- time: 0s
+  metrics:
+    requests_total: 10
+- time: 10m
+`))
+	require.NoError(t, err)
+	result, err := Simulate(context.Background(), scenario)
+	require.NoError(t, err)
+
+	// The rate is unavailable until the counters were observed for a window.
+	assert.Equal(t, workload.AutoscalingReasonMetricsUnavailable, result.Steps[0].Reason)
+	assert.Equal(t, int32(2), stepAt(t, result, 2*time.Minute).Targets[0].DesiredReplicas)
+	// The schedule raises the replicas from 8:00.
+	step := stepAt(t, result, 5*time.Minute)
+	assert.Equal(t, int32(4), step.Targets[0].DesiredReplicas)
+	assert.Equal(t, workload.AutoscalingReasonScheduled, step.Reason)
+	require.NotNil(t, step.ScheduledMinReplicas)
+	assert.Equal(t, int32(4), *step.ScheduledMinReplicas)
+}
+
+func TestSimulate_Invalid(t *testing.T) {
+	_, err := Simulate(context.Background(), readScenario(t, ""))
+	assert.ErrorContains(t, err, "no binding")
+
+	scenario := readScenario(t, `
+binding:
+  spec:
+    policyRef:
+      name: policy
+    homogeneousTarget:
+      target:
+        targetRef:
+          name: llama
+      minReplicas: 1
+      maxReplicas: 10
+`)
+	_, err = Simulate(context.Background(), scenario)
+	assert.ErrorContains(t, err, "no series")
+}
+
+func TestReadScenario(t *testing.T) {
+	scenario := readScenario(t, "")
+	assert.Equal(t, int32(defaultTolerancePercent), scenario.Policy.Spec.TolerancePercent)
+
+	scenario, err := ReadScenario([]byte(`
+policy:
+  spec:
+    tolerancePercent: 0
+`))
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), scenario.Policy.Spec.TolerancePercent)
+
+	scenario, err = ReadScenario([]byte("binding: {}\n"))
+	require.NoError(t, err)
+	_, err = Simulate(context.Background(), scenario)
+	assert.ErrorContains(t, err, "no policy")
+	_, err = ReadScenario([]byte("policy: {}\nunknown: 1\n"))
+	assert.Error(t, err)
+}
+
+func TestReadPolicy(t *testing.T) {
+	policy, err := ReadPolicy([]byte(`
+apiVersion: workload.serving.volcano.sh/v1alpha1
+kind: AutoscalingPolicy
+metadata:
+  name: policy
+spec:
+  metrics:
+  - metricName: load
+    targetValue: "10"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "policy", policy.Name)
+	assert.Equal(t, int32(defaultTolerancePercent), policy.Spec.TolerancePercent)
+
+	_, err = ReadPolicy([]byte("kind: ModelBooster\n"))
+	assert.ErrorContains(t, err, "not an AutoscalingPolicy")
+}
+
+func TestReadSeriesCSV(t *testing.T) {
+	points, start, err := ReadSeriesCSV(strings.NewReader(`time,load,queue
+2026-10-19T08:00:00Z,10,1
+2026-10-19T08:01:30Z,,2
+`))
+	require.NoError(t, err)
+	require.NotNil(t, start)
+	assert.Equal(t, time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), *start)
+	require.Len(t, points, 2)
+	assert.Equal(t, map[string]float64{"load": 10, "queue": 1}, points[0].Metrics)
+	assert.Equal(t, 90*time.Second, points[1].Time.Duration)
+	assert.Equal(t, map[string]float64{"queue": 2}, points[1].Metrics)
+
+	points, start, err = ReadSeriesCSV(strings.NewReader("time,load\n0,1\n1m,2\n"))
+	require.NoError(t, err)
+	assert.Nil(t, start)
+	assert.Equal(t, time.Minute, points[1].Time.Duration)
+
+	_, _, err = ReadSeriesCSV(strings.NewReader("load\n1\n"))
+	assert.Error(t, err)
+	_, _, err = ReadSeriesCSV(strings.NewReader("time,load\nyesterday,1\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestSyntheticSeriesPoints(t *testing.T) {
+	series := &SyntheticSeries{MetricName: "load", Base: 10, Amplitude: 20}
+	series.Duration.Duration = 24 * time.Hour
+	series.Burst = &Burst{Value: 100}
+	series.Burst.Start.Duration = time.Hour
+	series.Burst.Duration.Duration = time.Minute
+	points := series.Points()
+
+	require.Len(t, points, 24*60+1)
+	assert.InDelta(t, 10, points[0].Metrics["load"], 1e-9)
+	assert.InDelta(t, 30, points[6*60].Metrics["load"], 1e-9)
+	// The values below zero are set to zero.
+	assert.Equal(t, 0.0, points[18*60].Metrics["load"])
+	assert.InDelta(t, 10+20*0.258819+100, points[60].Metrics["load"], 1e-3)
+	assert.Less(t, points[61].Metrics["load"], 100.0)
+}
+
+// crdDefault returns the default of the field at path in the schema of the generated CRD, "[]" standing for the
+// items of an array.
+func crdDefault(t *testing.T, file string, path ...string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", "..", "charts", "kthena", "charts", "workload", "crds", file))
+	require.NoError(t, err)
+	crd := &apiextensionsv1.CustomResourceDefinition{}
+	require.NoError(t, yaml.Unmarshal(data, crd))
+	require.Len(t, crd.Spec.Versions, 1)
+	schema := crd.Spec.Versions[0].Schema.OpenAPIV3Schema
+	for _, name := range path {
+		if name == "[]" {
+			require.NotNil(t, schema.Items, "no items at %s", name)
+			schema = schema.Items.Schema
+			continue
+		}
+		property, ok := schema.Properties[name]
+		require.True(t, ok, "no property %s in %s", name, file)
+		schema = &property
+	}
+	require.NotNil(t, schema.Default, "no default at %v in %s", path, file)
+	return strings.Trim(string(schema.Default.Raw), `"`)
+}
+
+// TestDefaultsMatchCRD checks the defaults the simulator sets in place of the API server against the generated CRDs.
+func TestDefaultsMatchCRD(t *testing.T) {
+	const policies = "workload.serving.volcano.sh_autoscalingpolicies.yaml"
+	const bindings = "workload.serving.volcano.sh_autoscalingpolicybindings.yaml"
+	duration := func(value string) time.Duration {
+		d, err := time.ParseDuration(value)
+		require.NoError(t, err)
+		return d
+	}
+
+	assert.Equal(t, strconv.Itoa(defaultTolerancePercent), crdDefault(t, policies, "spec", "tolerancePercent"))
+	assert.Equal(t, string(workload.MetricSourcePod), crdDefault(t, policies, "spec", "metrics", "[]", "source", "type"))
+	for _, stablePath := range [][]string{{"spec", "behavior", "scaleUp", "stablePolicy"}, {"spec", "behavior", "scaleDown"}} {
+		assert.Equal(t, strconv.Itoa(defaultStablePolicyInstances), crdDefault(t, policies, append(stablePath, "instances")...))
+		assert.Equal(t, strconv.Itoa(defaultStablePolicyPercent), crdDefault(t, policies, append(stablePath, "percent")...))
+		assert.Equal(t, defaultStablePolicyPeriod, duration(crdDefault(t, policies, append(stablePath, "period")...)))
+		assert.Equal(t, string(workload.SelectPolicyOr), crdDefault(t, policies, append(stablePath, "selectPolicy")...))
+	}
+	panicPath := []string{"spec", "behavior", "scaleUp", "panicPolicy"}
+	assert.Equal(t, strconv.Itoa(defaultPanicPolicyPercent), crdDefault(t, policies, append(panicPath, "percent")...))
+	assert.Equal(t, strconv.Itoa(defaultPanicThresholdPercent), crdDefault(t, policies, append(panicPath, "panicThresholdPercent")...))
+	assert.Equal(t, defaultPanicModeHold, duration(crdDefault(t, policies, append(panicPath, "panicModeHold")...)))
+	assert.Equal(t, strconv.Itoa(defaultCostExpansionRatePercent),
+		crdDefault(t, bindings, "spec", "heterogeneousTarget", "costExpansionRatePercent"))
+}
@@ -40,6 +40,20 @@ func IsPodFailed(pod *corev1.Pod) bool {
 	return status.Phase == corev1.PodFailed || metaData.DeletionTimestamp != nil
 }
 
+// IsPodUnschedulable returns whether the scheduler could not schedule a pod, e.g. for lack of resources or because
+// Volcano could not schedule the gang of its PodGroup.
+func IsPodUnschedulable(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodPending || pod.DeletionTimestamp != nil || pod.Spec.NodeName != "" {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled {
+			return condition.Status == corev1.ConditionFalse && condition.Reason == corev1.PodReasonUnschedulable
+		}
+	}
+	return false
+}
+
 func ExtractKeysToSet[K comparable, V any](m map[K]V) map[K]struct{} {
 	set := make(map[K]struct{})
 	for key := range m {
@@ -0,0 +1,112 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/scale"
+)
+
+// IsModelServingTarget returns whether the target is a ModelServing, scaled through its spec, rather than an object
+// scaled through the scale subresource.
+func IsModelServingTarget(target *workload.Target) bool {
+	return target.TargetRef.Kind == "" || target.TargetRef.Kind == workload.ModelServingKind.Kind
+}
+
+// GetScale returns the scale subresource of the object referenced by targetRef, whose resource is resolved from
+// its API version and kind by the mapper.
+func GetScale(ctx context.Context, scaleClient scale.ScalesGetter, mapper meta.RESTMapper, namespace string, targetRef *corev1.ObjectReference) (*autoscalingv1.Scale, schema.GroupResource, error) {
+	groupVersion, err := schema.ParseGroupVersion(targetRef.APIVersion)
+	if err != nil {
+		return nil, schema.GroupResource{}, fmt.Errorf("invalid target ref api version %s: %v", targetRef.APIVersion, err)
+	}
+	var versions []string
+	if groupVersion.Version != "" {
+		versions = append(versions, groupVersion.Version)
+	}
+	mapping, err := mapper.RESTMapping(schema.GroupKind{Group: groupVersion.Group, Kind: targetRef.Kind}, versions...)
+	if err != nil {
+		return nil, schema.GroupResource{}, fmt.Errorf("unable to map target ref kind %s, api version %s: %v", targetRef.Kind, targetRef.APIVersion, err)
+	}
+	groupResource := mapping.Resource.GroupResource()
+	targetScale, err := scaleClient.Scales(namespace).Get(ctx, groupResource, targetRef.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, groupResource, err
+	}
+	return targetScale, groupResource, nil
+}
+
+// UpdateScaleReplicas sets the replicas of the object referenced by targetRef through its scale subresource.
+func UpdateScaleReplicas(ctx context.Context, scaleClient scale.ScalesGetter, mapper meta.RESTMapper, namespace string, targetRef *corev1.ObjectReference, replicas int32) error {
+	targetScale, groupResource, err := GetScale(ctx, scaleClient, mapper, namespace, targetRef)
+	if err != nil {
+		return err
+	}
+	if targetScale.Spec.Replicas == replicas {
+		return nil
+	}
+	targetScale = targetScale.DeepCopy()
+	targetScale.Spec.Replicas = replicas
+	_, err = scaleClient.Scales(namespace).Update(ctx, groupResource, targetScale, metav1.UpdateOptions{})
+	return err
+}
+
+// GetScalePods returns the pods selected by the selector of the scale status of a target, narrowed by the label
+// selector of its metric endpoint.
+func GetScalePods(lister listerv1.PodLister, namespace string, target *workload.Target, scaleSelector string) ([]*corev1.Pod, error) {
+	selector, err := labels.Parse(scaleSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid scale selector %q of target %s: %v", scaleSelector, target.TargetRef.Name, err)
+	}
+	if target.MetricEndpoint.LabelSelector != nil {
+		endpointSelector, err := metav1.LabelSelectorAsSelector(target.MetricEndpoint.LabelSelector)
+		if err != nil {
+			return nil, err
+		}
+		requirements, _ := endpointSelector.Requirements()
+		selector = selector.Add(requirements...)
+	}
+	return lister.Pods(namespace).List(selector)
+}
+
+// GetTargetPods returns all the pods of a target rather than only the pods its metrics are scraped from: the pods
+// of all the ServingGroups of a ModelServing, or of its role, or the pods selected by the selector of the scale
+// status of another target.
+func GetTargetPods(lister listerv1.PodLister, namespace string, target *workload.Target, scaleSelector string) ([]*corev1.Pod, error) {
+	if !IsModelServingTarget(target) {
+		selector, err := labels.Parse(scaleSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid scale selector %q of target %s: %v", scaleSelector, target.TargetRef.Name, err)
+		}
+		return lister.Pods(namespace).List(selector)
+	}
+	set := labels.Set{workload.ModelServingNameLabelKey: target.TargetRef.Name}
+	if target.SubTarget != nil && target.SubTarget.Kind == ModelServingRoleKind {
+		set[workload.RoleLabelKey] = target.SubTarget.Name
+	}
+	return lister.Pods(namespace).List(labels.SelectorFromSet(set))
+}
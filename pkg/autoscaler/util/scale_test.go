@@ -0,0 +1,169 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"testing"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestGetScalePods(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-leader", Namespace: "default", Labels: map[string]string{"app": "web", "role": "leader"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-worker", Namespace: "default", Labels: map[string]string{"app": "web", "role": "worker"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", Labels: map[string]string{"app": "other"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-other-namespace", Namespace: "other-namespace", Labels: map[string]string{"app": "web"}}},
+	} {
+		_ = indexer.Add(pod)
+	}
+	podLister := listerv1.NewPodLister(indexer)
+
+	tests := []struct {
+		name          string
+		scaleSelector string
+		labelSelector *metav1.LabelSelector
+		wantPodNames  []string
+		wantErr       bool
+	}{
+		{
+			name:          "pods selected by the scale selector",
+			scaleSelector: "app=web",
+			wantPodNames:  []string{"web-leader", "web-worker"},
+		},
+		{
+			name:          "pods narrowed by the metric endpoint label selector",
+			scaleSelector: "app=web",
+			labelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"role": "leader"}},
+			wantPodNames:  []string{"web-leader"},
+		},
+		{
+			name:          "invalid scale selector",
+			scaleSelector: "app in (",
+			wantErr:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := &workload.Target{MetricEndpoint: workload.MetricEndpoint{LabelSelector: tt.labelSelector}}
+			target.TargetRef.Name = "web"
+			target.TargetRef.Kind = "Deployment"
+
+			pods, err := GetScalePods(podLister, "default", target, tt.scaleSelector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetScalePods() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := map[string]bool{}
+			for _, pod := range pods {
+				got[pod.Name] = true
+			}
+			if len(got) != len(tt.wantPodNames) {
+				t.Fatalf("GetScalePods() got pods %v, want %v", got, tt.wantPodNames)
+			}
+			for _, name := range tt.wantPodNames {
+				if !got[name] {
+					t.Errorf("GetScalePods() missing pod %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestGetTargetPods(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pod := range []*corev1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Name: "llama-0-prefill-0", Namespace: "default", Labels: map[string]string{workload.ModelServingNameLabelKey: "llama", workload.RoleLabelKey: "prefill", workload.EntryLabelKey: Entry}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "llama-0-prefill-1", Namespace: "default", Labels: map[string]string{workload.ModelServingNameLabelKey: "llama", workload.RoleLabelKey: "prefill"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "llama-0-decode-0", Namespace: "default", Labels: map[string]string{workload.ModelServingNameLabelKey: "llama", workload.RoleLabelKey: "decode", workload.EntryLabelKey: Entry}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web-0", Namespace: "default", Labels: map[string]string{"app": "web"}}},
+	} {
+		_ = indexer.Add(pod)
+	}
+	podLister := listerv1.NewPodLister(indexer)
+
+	tests := []struct {
+		name          string
+		target        workload.Target
+		scaleSelector string
+		wantPodNames  []string
+	}{
+		{
+			name:         "all the pods of a ModelServing",
+			target:       workload.Target{TargetRef: corev1.ObjectReference{Name: "llama"}},
+			wantPodNames: []string{"llama-0-prefill-0", "llama-0-prefill-1", "llama-0-decode-0"},
+		},
+		{
+			name:         "all the pods of a role",
+			target:       workload.Target{TargetRef: corev1.ObjectReference{Name: "llama"}, SubTarget: &workload.SubTarget{Kind: ModelServingRoleKind, Name: "prefill"}},
+			wantPodNames: []string{"llama-0-prefill-0", "llama-0-prefill-1"},
+		},
+		{
+			name:          "pods selected by the scale selector",
+			target:        workload.Target{TargetRef: corev1.ObjectReference{Kind: "Deployment", Name: "web"}},
+			scaleSelector: "app=web",
+			wantPodNames:  []string{"web-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods, err := GetTargetPods(podLister, "default", &tt.target, tt.scaleSelector)
+			if err != nil {
+				t.Fatalf("GetTargetPods() error = %v", err)
+			}
+			got := map[string]bool{}
+			for _, pod := range pods {
+				got[pod.Name] = true
+			}
+			if len(got) != len(tt.wantPodNames) {
+				t.Fatalf("GetTargetPods() got pods %v, want %v", got, tt.wantPodNames)
+			}
+			for _, name := range tt.wantPodNames {
+				if !got[name] {
+					t.Errorf("GetTargetPods() missing pod %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestIsPodUnschedulable(t *testing.T) {
+	unschedulable := corev1.PodCondition{Type: corev1.PodScheduled, Status: corev1.ConditionFalse, Reason: corev1.PodReasonUnschedulable}
+	tests := []struct {
+		name string
+		pod  corev1.Pod
+		want bool
+	}{
+		{name: "unschedulable", pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{unschedulable}}}, want: true},
+		{name: "pending without condition", pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending}}},
+		{name: "scheduled", pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{{Type: corev1.PodScheduled, Status: corev1.ConditionTrue}}}}},
+		{name: "running", pod: corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodRunning}}},
+		{name: "deleted", pod: corev1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}}, Status: corev1.PodStatus{Phase: corev1.PodPending, Conditions: []corev1.PodCondition{unschedulable}}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPodUnschedulable(&tt.pod); got != tt.want {
+				t.Errorf("IsPodUnschedulable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
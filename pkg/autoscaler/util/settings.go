@@ -17,9 +17,13 @@ limitations under the License.
 package util
 
 const (
-	AutoscalingSyncPeriodSeconds    = 15
-	SloQuantileSlidingWindowSeconds = 60
-	SloQuantileDataKeepSeconds      = 300
-	SloQuantilePercentile           = 95
-	AutoscaleCtxTimeoutSeconds      = 3
+	AutoscalingSyncPeriodSeconds = 15
+	AutoscaleCtxTimeoutSeconds   = 3
+	// RouterLabelSelector selects the router pods in the namespace of the autoscaler, whose demand is pulled
+	// to scale the targets to and from zero, and whose metrics are pulled for RouterAggregate metrics.
+	RouterLabelSelector = "app.kubernetes.io/component=kthena-router"
+	// RouterPortName is the name of the container port serving the metrics of the router pods.
+	RouterPortName    = "http"
+	DefaultRouterPort = 8080
+	RouterMetricsPath = "/metrics"
 )
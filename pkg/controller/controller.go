@@ -29,7 +29,11 @@ import (
 	apiextclient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/scale"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/leaderelection"
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
@@ -98,7 +102,13 @@ func SetupController(ctx context.Context, cc Config) {
 				if err != nil {
 					klog.Fatalf("failed to get in-cluster namespace: %v", err)
 				}
-				ac = autoscaler.NewAutoscaleController(kubeClient, client, namespace)
+				discoveryClient := memory.NewMemCacheClient(kubeClient.Discovery())
+				restMapper := restmapper.NewDeferredDiscoveryRESTMapper(discoveryClient)
+				scaleClient, err := scale.NewForConfig(config, restMapper, dynamic.LegacyAPIPathResolverFunc, scale.NewDiscoveryScaleKindResolver(discoveryClient))
+				if err != nil {
+					klog.Fatalf("failed to create scale client: %v", err)
+				}
+				ac = autoscaler.NewAutoscaleController(kubeClient, client, scaleClient, restMapper, namespace)
 			}
 		}
 	}
@@ -122,7 +132,7 @@ func SetupController(ctx context.Context, cc Config) {
 			}
 		}
 		if ac != nil {
-			go ac.Run(ctx)
+			go ac.Run(ctx, cc.Workers)
 			klog.Info("Autoscaler controller started")
 		}
 	}
@@ -0,0 +1,112 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+)
+
+const (
+	defaultTimeout             = 5 * time.Minute
+	defaultMaxBufferedRequests = 100
+	defaultPollInterval        = 500 * time.Millisecond
+)
+
+var (
+	// ErrBufferFull is returned when a ModelServer already holds the maximum number of requests.
+	ErrBufferFull = errors.New("too many requests waiting for the model server to scale from zero")
+	// ErrTimeout is returned when no pod of a ModelServer is ready before the timeout.
+	ErrTimeout = errors.New("timed out waiting for the model server to scale from zero")
+)
+
+// Activator records the demand of the models and the ModelServers, and holds the requests for a ModelServer
+// without ready pods until one of them is ready, so that a ModelServer scaled to zero can be scaled from zero
+// on demand.
+type Activator struct {
+	mu           sync.Mutex
+	models       map[string]*modelDemand
+	modelServers map[types.NamespacedName]*modelServerDemand
+	// queueLengths returns the lengths of the fairness queues of the models.
+	queueLengths func() map[string]int
+	pollInterval time.Duration
+	now          func() time.Time
+	lastPrune    time.Time
+}
+
+// NewActivator creates an Activator. queueLengths, if set, returns the lengths of the fairness queues of the models.
+func NewActivator(queueLengths func() map[string]int) *Activator {
+	return &Activator{
+		models:       make(map[string]*modelDemand),
+		modelServers: make(map[types.NamespacedName]*modelServerDemand),
+		queueLengths: queueLengths,
+		pollInterval: defaultPollInterval,
+		now:          time.Now,
+	}
+}
+
+// Wait holds the request until ready returns true, which is checked periodically. It returns ErrBufferFull if
+// the ModelServer already holds the maximum number of requests, and ErrTimeout if the timeout expires first.
+func (a *Activator) Wait(ctx context.Context, modelServerName types.NamespacedName, config *v1alpha1.ScaleFromZero, ready func() bool) error {
+	timeout := defaultTimeout
+	maxBufferedRequests := int32(defaultMaxBufferedRequests)
+	if config.Timeout != nil {
+		timeout = config.Timeout.Duration
+	}
+	if config.MaxBufferedRequests > 0 {
+		maxBufferedRequests = config.MaxBufferedRequests
+	}
+
+	a.mu.Lock()
+	demand := a.getOrCreate(modelServerName)
+	if demand.waiting >= maxBufferedRequests {
+		a.mu.Unlock()
+		return ErrBufferFull
+	}
+	demand.waiting++
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		demand.waiting--
+		demand.lastSeen = a.now()
+		a.mu.Unlock()
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(a.pollInterval)
+	defer ticker.Stop()
+	for {
+		if ready() {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return ErrTimeout
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+)
+
+func newTestActivator() *Activator {
+	a := NewActivator(nil)
+	a.pollInterval = 10 * time.Millisecond
+	return a
+}
+
+func TestWait(t *testing.T) {
+	modelServer := types.NamespacedName{Namespace: "default", Name: "ms"}
+	config := &v1alpha1.ScaleFromZero{Timeout: &metav1.Duration{Duration: time.Second}, MaxBufferedRequests: 1}
+
+	t.Run("released once ready", func(t *testing.T) {
+		a := newTestActivator()
+		var checks atomic.Int32
+		err := a.Wait(context.Background(), modelServer, config, func() bool {
+			return checks.Add(1) >= 3
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, int32(3), checks.Load())
+		assert.Equal(t, int32(0), a.Demand().ModelServers[0].Waiting)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		a := newTestActivator()
+		config := &v1alpha1.ScaleFromZero{Timeout: &metav1.Duration{Duration: 50 * time.Millisecond}}
+		err := a.Wait(context.Background(), modelServer, config, func() bool { return false })
+		assert.ErrorIs(t, err, ErrTimeout)
+	})
+
+	t.Run("buffer full", func(t *testing.T) {
+		a := newTestActivator()
+		release := make(chan struct{})
+		done := make(chan error)
+		go func() {
+			done <- a.Wait(context.Background(), modelServer, config, func() bool {
+				select {
+				case <-release:
+					return true
+				default:
+					return false
+				}
+			})
+		}()
+		require.Eventually(t, func() bool {
+			demand := a.Demand()
+			return len(demand.ModelServers) == 1 && demand.ModelServers[0].Waiting == 1
+		}, time.Second, 5*time.Millisecond)
+
+		err := a.Wait(context.Background(), modelServer, config, func() bool { return true })
+		assert.ErrorIs(t, err, ErrBufferFull)
+
+		close(release)
+		assert.NoError(t, <-done)
+	})
+
+	t.Run("request canceled", func(t *testing.T) {
+		a := newTestActivator()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := a.Wait(ctx, modelServer, config, func() bool { return false })
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
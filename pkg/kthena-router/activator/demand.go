@@ -0,0 +1,239 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
+)
+
+const (
+	// rateWindow is the window over which the rates of the demand are computed.
+	rateWindow = time.Minute
+	// idleTTL is how long a model or a ModelServer without requests is kept in the demand. It is longer than the
+	// rate window, so the rates have dropped to zero when it is pruned.
+	idleTTL = time.Hour
+	// pruneInterval is the minimum interval between two prunings of the idle models and ModelServers.
+	pruneInterval = time.Minute
+)
+
+type modelDemand struct {
+	rateLimited windowCounter
+	lastSeen    time.Time
+}
+
+type modelServerDemand struct {
+	// lastSeen is the last time the ModelServer was recorded, which prunes it once it is idle for idleTTL.
+	lastSeen        time.Time
+	requests        int64
+	waiting         int32
+	lastRequestTime time.Time
+	activeRequests  int32
+	notFound        windowCounter
+	inputTokens     windowCounter
+	outputTokens    windowCounter
+}
+
+// windowCounter counts events in one-second buckets over rateWindow.
+type windowCounter struct {
+	counts  [int(rateWindow / time.Second)]int64
+	seconds [int(rateWindow / time.Second)]int64
+}
+
+func (w *windowCounter) add(now time.Time, count int64) {
+	second := now.Unix()
+	i := second % int64(len(w.counts))
+	if w.seconds[i] != second {
+		w.seconds[i] = second
+		w.counts[i] = 0
+	}
+	w.counts[i] += count
+}
+
+func (w *windowCounter) rate(now time.Time) float64 {
+	second := now.Unix()
+	count := int64(0)
+	for i := range w.counts {
+		if w.seconds[i] <= second && second-w.seconds[i] < int64(len(w.counts)) {
+			count += w.counts[i]
+		}
+	}
+	return float64(count) / rateWindow.Seconds()
+}
+
+// Record records a request for the ModelServer.
+func (a *Activator) Record(modelServerName types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	demand := a.getOrCreate(modelServerName)
+	demand.requests++
+	demand.lastRequestTime = a.now()
+}
+
+// RecordRateLimited records a request rejected by the rate limits of the model.
+func (a *Activator) RecordRateLimited(model string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	a.prune(now)
+	demand, ok := a.models[model]
+	if !ok {
+		demand = &modelDemand{}
+		a.models[model] = demand
+	}
+	demand.lastSeen = now
+	demand.rateLimited.add(now, 1)
+}
+
+// RecordNotFound records a request rejected because the ModelServer had no ready pods.
+func (a *Activator) RecordNotFound(modelServerName types.NamespacedName) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.getOrCreate(modelServerName).notFound.add(a.now(), 1)
+}
+
+// RecordInputTokens records the input tokens of a request for the ModelServer.
+func (a *Activator) RecordInputTokens(modelServerName types.NamespacedName, tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.getOrCreate(modelServerName).inputTokens.add(a.now(), int64(tokens))
+}
+
+// RecordOutputTokens records the output tokens of a response of the ModelServer.
+func (a *Activator) RecordOutputTokens(modelServerName types.NamespacedName, tokens int) {
+	if tokens <= 0 {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.getOrCreate(modelServerName).outputTokens.add(a.now(), int64(tokens))
+}
+
+// StartRequest records a request being proxied to the pods of the ModelServer, until the returned function is called.
+func (a *Activator) StartRequest(modelServerName types.NamespacedName) func() {
+	a.mu.Lock()
+	demand := a.getOrCreate(modelServerName)
+	demand.activeRequests++
+	a.mu.Unlock()
+	return func() {
+		a.mu.Lock()
+		demand.activeRequests--
+		demand.lastSeen = a.now()
+		a.mu.Unlock()
+	}
+}
+
+// Demand returns the demand of the models and the ModelServers, sorted by name.
+func (a *Activator) Demand() routerdemand.Demand {
+	var queueLengths map[string]int
+	if a.queueLengths != nil {
+		queueLengths = a.queueLengths()
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	a.prune(now)
+	demand := routerdemand.Demand{
+		Models:       make([]routerdemand.ModelDemand, 0, len(a.models)+len(queueLengths)),
+		ModelServers: make([]routerdemand.ModelServerDemand, 0, len(a.modelServers)),
+	}
+	for name, d := range a.models {
+		demand.Models = append(demand.Models, routerdemand.ModelDemand{
+			Name:                 name,
+			QueueLength:          int32(queueLengths[name]),
+			RateLimitedPerSecond: d.rateLimited.rate(now),
+		})
+	}
+	for name, length := range queueLengths {
+		if _, ok := a.models[name]; !ok {
+			demand.Models = append(demand.Models, routerdemand.ModelDemand{Name: name, QueueLength: int32(length)})
+		}
+	}
+	for name, d := range a.modelServers {
+		demand.ModelServers = append(demand.ModelServers, routerdemand.ModelServerDemand{
+			Namespace:             name.Namespace,
+			Name:                  name.Name,
+			Requests:              d.requests,
+			Waiting:               d.waiting,
+			LastRequestTime:       metav1.NewTime(d.lastRequestTime),
+			ActiveRequests:        d.activeRequests,
+			NotFoundPerSecond:     d.notFound.rate(now),
+			InputTokensPerSecond:  d.inputTokens.rate(now),
+			OutputTokensPerSecond: d.outputTokens.rate(now),
+		})
+	}
+	sort.Slice(demand.Models, func(i, j int) bool {
+		return demand.Models[i].Name < demand.Models[j].Name
+	})
+	sort.Slice(demand.ModelServers, func(i, j int) bool {
+		if demand.ModelServers[i].Namespace != demand.ModelServers[j].Namespace {
+			return demand.ModelServers[i].Namespace < demand.ModelServers[j].Namespace
+		}
+		return demand.ModelServers[i].Name < demand.ModelServers[j].Name
+	})
+	return demand
+}
+
+// ServeHTTP serves the demand of the models and the ModelServers as JSON.
+func (a *Activator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(a.Demand())
+}
+
+// getOrCreate returns the demand of the ModelServer, and records that it is seen. a.mu must be held.
+func (a *Activator) getOrCreate(modelServerName types.NamespacedName) *modelServerDemand {
+	now := a.now()
+	a.prune(now)
+	demand, ok := a.modelServers[modelServerName]
+	if !ok {
+		demand = &modelServerDemand{}
+		a.modelServers[modelServerName] = demand
+	}
+	demand.lastSeen = now
+	return demand
+}
+
+// prune removes the models and the ModelServers without requests for idleTTL, at most once per pruneInterval,
+// so that the demand doesn't keep the models and the ModelServers which no longer receive requests.
+// A ModelServer with waiting or active requests is kept. a.mu must be held.
+func (a *Activator) prune(now time.Time) {
+	if now.Sub(a.lastPrune) < pruneInterval {
+		return
+	}
+	a.lastPrune = now
+	for name, demand := range a.models {
+		if now.Sub(demand.lastSeen) >= idleTTL {
+			delete(a.models, name)
+		}
+	}
+	for name, demand := range a.modelServers {
+		if demand.waiting == 0 && demand.activeRequests == 0 && now.Sub(demand.lastSeen) >= idleTTL {
+			delete(a.modelServers, name)
+		}
+	}
+}
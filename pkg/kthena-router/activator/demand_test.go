@@ -0,0 +1,119 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/volcano-sh/kthena/pkg/routerdemand"
+)
+
+func TestDemand(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	a := NewActivator(func() map[string]int { return map[string]int{"llama": 4, "qwen": 2} })
+	a.now = func() time.Time { return now }
+	modelServer := types.NamespacedName{Namespace: "ns", Name: "ms"}
+
+	for i := 0; i < 30; i++ {
+		a.RecordRateLimited("llama")
+		a.RecordNotFound(modelServer)
+	}
+	now = now.Add(30 * time.Second)
+	for i := 0; i < 30; i++ {
+		a.RecordNotFound(modelServer)
+	}
+	a.RecordInputTokens(modelServer, 600)
+	a.RecordOutputTokens(modelServer, 1800)
+	a.RecordOutputTokens(modelServer, 0)
+	done := a.StartRequest(modelServer)
+	a.StartRequest(modelServer)
+
+	demand := a.Demand()
+	assert.Equal(t, []routerdemand.ModelDemand{
+		{Name: "llama", QueueLength: 4, RateLimitedPerSecond: 0.5},
+		{Name: "qwen", QueueLength: 2},
+	}, demand.Models)
+	require.Len(t, demand.ModelServers, 1)
+	assert.Equal(t, int32(2), demand.ModelServers[0].ActiveRequests)
+	assert.Equal(t, 1.0, demand.ModelServers[0].NotFoundPerSecond)
+	assert.Equal(t, 10.0, demand.ModelServers[0].InputTokensPerSecond)
+	assert.Equal(t, 30.0, demand.ModelServers[0].OutputTokensPerSecond)
+
+	// The requests older than the window are no longer counted.
+	done()
+	now = now.Add(45 * time.Second)
+	demand = a.Demand()
+	assert.Equal(t, 0.0, demand.Models[0].RateLimitedPerSecond)
+	assert.Equal(t, int32(1), demand.ModelServers[0].ActiveRequests)
+	assert.Equal(t, 0.5, demand.ModelServers[0].NotFoundPerSecond)
+}
+
+func TestFetchDemand(t *testing.T) {
+	a := NewActivator(nil)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	a.now = func() time.Time { return now }
+	a.Record(types.NamespacedName{Namespace: "ns", Name: "b"})
+	a.Record(types.NamespacedName{Namespace: "ns", Name: "a"})
+	a.Record(types.NamespacedName{Namespace: "ns", Name: "a"})
+
+	server := httptest.NewServer(a)
+	defer server.Close()
+
+	demand, err := routerdemand.Fetch(context.Background(), http.DefaultClient, server.URL+routerdemand.Path)
+	require.NoError(t, err)
+	assert.Equal(t, []routerdemand.ModelServerDemand{
+		{Namespace: "ns", Name: "a", Requests: 2, LastRequestTime: metav1.NewTime(now)},
+		{Namespace: "ns", Name: "b", Requests: 1, LastRequestTime: metav1.NewTime(now)},
+	}, demand.ModelServers)
+}
+
+func TestDemand_PrunesIdleEntries(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	a := NewActivator(nil)
+	a.now = func() time.Time { return now }
+	idle := types.NamespacedName{Namespace: "ns", Name: "idle"}
+	active := types.NamespacedName{Namespace: "ns", Name: "active"}
+
+	a.Record(idle)
+	a.RecordRateLimited("llama")
+	done := a.StartRequest(active)
+	now = now.Add(idleTTL)
+	a.Record(types.NamespacedName{Namespace: "ns", Name: "recent"})
+
+	// The idle ModelServer and model are pruned, the one with an active request is kept.
+	demand := a.Demand()
+	assert.Empty(t, demand.Models)
+	require.Len(t, demand.ModelServers, 2)
+	assert.Equal(t, "active", demand.ModelServers[0].Name)
+	assert.Equal(t, "recent", demand.ModelServers[1].Name)
+
+	// The ModelServer is kept for idleTTL after its last request ends.
+	done()
+	now = now.Add(idleTTL - pruneInterval)
+	assert.Len(t, a.Demand().ModelServers, 2)
+	now = now.Add(pruneInterval)
+	assert.Empty(t, a.Demand().ModelServers)
+}
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/klog/v2"
 
+	"github.com/volcano-sh/kthena/pkg/kthena-router/backend/mindie"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/backend/sglang"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/backend/vllm"
 )
@@ -35,6 +36,7 @@ type MetricsProvider interface {
 }
 
 var engineRegistry = map[string]MetricsProvider{
+	"MindIE": mindie.NewMindIEEngine(),
 	"SGLang": sglang.NewSglangEngine(),
 	"vLLM":   vllm.NewVllmEngine(),
 }
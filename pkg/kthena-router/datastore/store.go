@@ -233,6 +233,8 @@ type PodInfo struct {
 	// Protected fields - use accessor methods for thread-safe access
 	models      sets.Set[string]               // running models. Including base model and lora adapters.
 	modelServer sets.Set[types.NamespacedName] // The modelservers this pod belongs to
+	// loraLastUsed is the last time each lora adapter was requested on this pod, used to evict the least recently used adapters.
+	loraLastUsed map[string]time.Time
 }
 
 // modelRouteInfo stores the mapping between a ModelRoute resource and its associated models.
@@ -542,6 +544,9 @@ func (s *store) AddOrUpdatePod(pod *corev1.Pod, modelServers []*aiv1alpha1.Model
 	var oldPodInfo *PodInfo
 	if value, ok := s.pods.Load(podName); ok {
 		oldPodInfo = value.(*PodInfo)
+		// Keep the models and lora adapter usage of the pod until they are refreshed.
+		newPodInfo.models = oldPodInfo.GetModels()
+		newPodInfo.loraLastUsed = oldPodInfo.getLoraLastUsed()
 		oldModelServers := oldPodInfo.GetModelServers()
 		// Handle the case where the pod is no longer belong to some model servers
 		for msName := range oldModelServers.Difference(newPodInfo.modelServer) {
@@ -1144,6 +1149,22 @@ func (p *PodInfo) UpdateModels(models []string) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 	p.models = sets.New[string](models...)
+	for adapter := range p.loraLastUsed {
+		if !p.models.Contains(adapter) {
+			delete(p.loraLastUsed, adapter)
+		}
+	}
+}
+
+// AddModel adds a model to the models set, e.g. after a lora adapter is loaded on the pod
+func (p *PodInfo) AddModel(model string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.models == nil {
+		p.models = sets.New[string]()
+	}
+	p.models.Insert(model)
 }
 
 // RemoveModel removes a model from the models set
@@ -1154,6 +1175,38 @@ func (p *PodInfo) RemoveModel(model string) {
 	if p.models != nil {
 		p.models.Delete(model)
 	}
+	delete(p.loraLastUsed, model)
+}
+
+// TouchLoraAdapter records that the lora adapter is requested on the pod at the given time
+func (p *PodInfo) TouchLoraAdapter(adapter string, now time.Time) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.loraLastUsed == nil {
+		p.loraLastUsed = make(map[string]time.Time)
+	}
+	p.loraLastUsed[adapter] = now
+}
+
+// GetLoraAdapterLastUsed returns the last time the lora adapter was requested on the pod.
+// It returns the zero time if the adapter has never been requested through the router.
+func (p *PodInfo) GetLoraAdapterLastUsed(adapter string) time.Time {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.loraLastUsed[adapter]
+}
+
+func (p *PodInfo) getLoraLastUsed() map[string]time.Time {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	result := make(map[string]time.Time, len(p.loraLastUsed))
+	for adapter, lastUsed := range p.loraLastUsed {
+		result[adapter] = lastUsed
+	}
+	return result
 }
 
 // GetModelServers returns a copy of the modelServer set
@@ -0,0 +1,226 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	"github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/kthena-router/datastore"
+	"github.com/volcano-sh/kthena/pkg/kthena-router/utils"
+	"github.com/volcano-sh/kthena/pkg/runtime/lora"
+)
+
+const defaultLoraLoadTimeout = 5 * time.Minute
+
+// loraPlacer loads lora adapters on the pods of a model server on demand.
+// Concurrent requests for the same adapter are coalesced into one load. The adapter slot of a pod is reserved
+// before an adapter is loaded on it, so that the adapter slots of a pod are never over committed while the
+// adapters are loaded concurrently.
+type loraPlacer struct {
+	client *lora.Client
+	group  singleflight.Group
+	// slots tracks the reserved adapter slots of each model server, key is the model server name.
+	slots sync.Map
+	now   func() time.Time
+}
+
+// loraSlots tracks the adapter slots of the pods of a model server reserved by the loads in flight.
+type loraSlots struct {
+	mu sync.Mutex
+	// reserved is the number of adapters being loaded on each pod, key is the pod name.
+	reserved map[types.NamespacedName]int
+}
+
+func newLoraPlacer() *loraPlacer {
+	return &loraPlacer{
+		client: lora.NewClient(&http.Client{}),
+		now:    time.Now,
+	}
+}
+
+// ensureLoraAdapter makes sure the adapter is loaded on at least one of the pods, and returns the pods serving it.
+// It returns the pods unchanged if the adapter can't be loaded on demand. It stops waiting for the load once ctx,
+// the context of the request, is done, while the load goes on for the other requests waiting on it.
+func (p *loraPlacer) ensureLoraAdapter(ctx context.Context, modelServer *v1alpha1.ModelServer, pods []*datastore.PodInfo, adapter string) ([]*datastore.PodInfo, error) {
+	placement := modelServer.Spec.LoraPlacement
+	source := findLoraAdapterSource(placement, adapter)
+	if source == nil {
+		return pods, nil
+	}
+	if servingPods := p.touchLoraAdapter(pods, adapter); len(servingPods) > 0 {
+		return servingPods, nil
+	}
+
+	modelServerName := utils.GetNamespaceName(modelServer)
+	result := p.group.DoChan(modelServerName.String()+"/"+adapter, func() (any, error) {
+		return nil, p.placeLoraAdapter(modelServer, pods, source)
+	})
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("stopped waiting for lora adapter %s: %w", adapter, ctx.Err())
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+	}
+	return p.touchLoraAdapter(pods, adapter), nil
+}
+
+// touchLoraAdapter records the usage of the adapter on the pods serving it, and returns these pods.
+func (p *loraPlacer) touchLoraAdapter(pods []*datastore.PodInfo, adapter string) []*datastore.PodInfo {
+	now := p.now()
+	var servingPods []*datastore.PodInfo
+	for _, pod := range pods {
+		if pod.Contains(adapter) {
+			pod.TouchLoraAdapter(adapter, now)
+			servingPods = append(servingPods, pod)
+		}
+	}
+	return servingPods
+}
+
+// placeLoraAdapter loads the adapter on the pod with a free adapter slot and the least requests. If all slots are
+// taken, the least recently used adapter which can be loaded on demand is unloaded first.
+func (p *loraPlacer) placeLoraAdapter(modelServer *v1alpha1.ModelServer, pods []*datastore.PodInfo, source *v1alpha1.LoraAdapterSource) error {
+	value, _ := p.slots.LoadOrStore(utils.GetNamespaceName(modelServer), &loraSlots{reserved: map[types.NamespacedName]int{}})
+	slots := value.(*loraSlots)
+	pod, evicted, err := slots.reserve(modelServer, pods, source.Name)
+	if err != nil || pod == nil {
+		return err
+	}
+	defer slots.release(pod)
+
+	placement := modelServer.Spec.LoraPlacement
+	port := placement.RuntimePort
+	if port == 0 {
+		port = lora.DefaultRuntimePort
+	}
+	timeout := defaultLoraLoadTimeout
+	if placement.LoadTimeout != nil {
+		timeout = placement.LoadTimeout.Duration
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if evicted != "" {
+		klog.V(4).Infof("Unload lora adapter %s from pod %s to make room for %s", evicted, klog.KObj(pod.Pod), source.Name)
+		if err := p.client.Unload(ctx, pod.Pod.Status.PodIP, port, evicted); err != nil {
+			pod.AddModel(evicted)
+			return fmt.Errorf("failed to unload lora adapter %s from pod %s: %v", evicted, klog.KObj(pod.Pod), err)
+		}
+	}
+
+	klog.V(4).Infof("Load lora adapter %s on pod %s", source.Name, klog.KObj(pod.Pod))
+	if err := p.client.Load(ctx, pod.Pod.Status.PodIP, port, source.Name, source.ArtifactURL, path.Join(placement.DownloadPath, source.Name)); err != nil {
+		return fmt.Errorf("failed to load lora adapter %s on pod %s: %v", source.Name, klog.KObj(pod.Pod), err)
+	}
+	pod.AddModel(source.Name)
+	return nil
+}
+
+// reserve picks the pod to load the adapter on and reserves one of its adapter slots, until it is released.
+// The adapter to evict from the pod, if any, is removed from the models of the pod at once, so that it is neither
+// routed to nor evicted again. It returns no pod if the adapter is already loaded.
+func (s *loraSlots) reserve(modelServer *v1alpha1.ModelServer, pods []*datastore.PodInfo, adapter string) (*datastore.PodInfo, string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The adapter may have been loaded by the time the lock is held.
+	for _, pod := range pods {
+		if pod.Contains(adapter) {
+			return nil, "", nil
+		}
+	}
+	pod, evicted := pickLoraPod(modelServer, pods, s.reserved)
+	if pod == nil {
+		return nil, "", fmt.Errorf("no free lora adapter slot on the pods of model server %s", utils.GetNamespaceName(modelServer))
+	}
+	if evicted != "" {
+		pod.RemoveModel(evicted)
+	}
+	s.reserved[utils.GetNamespaceName(pod.Pod)]++
+	return pod, evicted, nil
+}
+
+// release releases the adapter slot reserved on the pod.
+func (s *loraSlots) release(pod *datastore.PodInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	name := utils.GetNamespaceName(pod.Pod)
+	s.reserved[name]--
+	if s.reserved[name] <= 0 {
+		delete(s.reserved, name)
+	}
+}
+
+// pickLoraPod picks the pod to load an adapter on. The pods with free adapter slots are preferred, and the one with the
+// least requests is picked. Otherwise it picks the pod with the least recently used adapter which can be unloaded.
+// The reserved slots of each pod are taken.
+func pickLoraPod(modelServer *v1alpha1.ModelServer, pods []*datastore.PodInfo, reserved map[types.NamespacedName]int) (*datastore.PodInfo, string) {
+	placement := modelServer.Spec.LoraPlacement
+	var freePod *datastore.PodInfo
+	var lruPod *datastore.PodInfo
+	var lruAdapter string
+	var lruTime time.Time
+	for _, pod := range pods {
+		adapters := reserved[utils.GetNamespaceName(pod.Pod)]
+		for model := range pod.GetModels() {
+			if modelServer.Spec.Model != nil && model == *modelServer.Spec.Model {
+				continue
+			}
+			adapters++
+			if findLoraAdapterSource(placement, model) == nil {
+				// Adapters not loaded on demand, e.g. loaded by the ModelBooster controller, are never unloaded.
+				continue
+			}
+			if lastUsed := pod.GetLoraAdapterLastUsed(model); lruPod == nil || lastUsed.Before(lruTime) {
+				lruPod, lruAdapter, lruTime = pod, model, lastUsed
+			}
+		}
+		if adapters < int(placement.MaxLoras) && (freePod == nil || podRequests(pod) < podRequests(freePod)) {
+			freePod = pod
+		}
+	}
+	if freePod != nil {
+		return freePod, ""
+	}
+	return lruPod, lruAdapter
+}
+
+func podRequests(pod *datastore.PodInfo) float64 {
+	return pod.GetRequestRunningNum() + pod.GetRequestWaitingNum()
+}
+
+func findLoraAdapterSource(placement *v1alpha1.LoraPlacement, adapter string) *v1alpha1.LoraAdapterSource {
+	if placement == nil {
+		return nil
+	}
+	for i := range placement.Adapters {
+		if placement.Adapters[i].Name == adapter {
+			return &placement.Adapters[i]
+		}
+	}
+	return nil
+}
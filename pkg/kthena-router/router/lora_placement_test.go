@@ -0,0 +1,248 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	aiv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/kthena-router/datastore"
+)
+
+type fakeLoraRuntime struct {
+	mu       sync.Mutex
+	requests []string
+	// received is the number of requests received, including the blocked ones.
+	received int
+	// release blocks the load requests until it is closed, if set.
+	release chan struct{}
+}
+
+func (f *fakeLoraRuntime) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.mu.Lock()
+	f.received++
+	f.mu.Unlock()
+	if f.release != nil {
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.URL.Path+" "+body["lora_name"].(string))
+}
+
+func (f *fakeLoraRuntime) takeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func newLoraPlacementTest(t *testing.T, runtime *fakeLoraRuntime) (*loraPlacer, *aiv1alpha1.ModelServer) {
+	server := httptest.NewServer(runtime)
+	t.Cleanup(server.Close)
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	runtimePort, err := strconv.Atoi(port)
+	assert.NoError(t, err)
+
+	placer := newLoraPlacer()
+	var clockMu sync.Mutex
+	clock := time.Unix(0, 0)
+	placer.now = func() time.Time {
+		clockMu.Lock()
+		defer clockMu.Unlock()
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	modelServer := &aiv1alpha1.ModelServer{
+		ObjectMeta: v1.ObjectMeta{Name: "llama", Namespace: "default"},
+		Spec: aiv1alpha1.ModelServerSpec{
+			Model: ptr.To("llama-2-7b"),
+			LoraPlacement: &aiv1alpha1.LoraPlacement{
+				MaxLoras:     1,
+				RuntimePort:  int32(runtimePort),
+				DownloadPath: "/cache/lora",
+				Adapters: []aiv1alpha1.LoraAdapterSource{
+					{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"},
+					{Name: "chat-lora", ArtifactURL: "s3://bucket/chat-lora"},
+					{Name: "code-lora", ArtifactURL: "s3://bucket/code-lora"},
+				},
+			},
+		},
+	}
+	return placer, modelServer
+}
+
+func newLoraPod(name string, models ...string) *datastore.PodInfo {
+	pod := buildPodInfo(name, "127.0.0.1")
+	pod.UpdateModels(append([]string{"llama-2-7b"}, models...))
+	return pod
+}
+
+func TestEnsureLoraAdapter(t *testing.T) {
+	runtime := &fakeLoraRuntime{}
+	placer, modelServer := newLoraPlacementTest(t, runtime)
+	busyPod, idlePod := newLoraPod("busy"), newLoraPod("idle")
+	busyPod.RequestRunningNum = 2
+	pods := []*datastore.PodInfo{busyPod, idlePod}
+
+	// Load the adapter on the pod with the least requests.
+	got, err := placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+	assert.NoError(t, err)
+	assert.Equal(t, []*datastore.PodInfo{idlePod}, got)
+	assert.Equal(t, []string{"/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+
+	// A loaded adapter is not loaded again.
+	got, err = placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+	assert.NoError(t, err)
+	assert.Equal(t, []*datastore.PodInfo{idlePod}, got)
+	assert.Empty(t, runtime.takeRequests())
+
+	// Load the adapter on the pod with a free slot.
+	got, err = placer.ensureLoraAdapter(context.Background(), modelServer, pods, "chat-lora")
+	assert.NoError(t, err)
+	assert.Equal(t, []*datastore.PodInfo{busyPod}, got)
+	assert.Equal(t, []string{"/v1/load_lora_adapter chat-lora"}, runtime.takeRequests())
+
+	// Evict the least recently used adapter when all slots are taken.
+	_, err = placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+	assert.NoError(t, err)
+	got, err = placer.ensureLoraAdapter(context.Background(), modelServer, pods, "code-lora")
+	assert.NoError(t, err)
+	assert.Equal(t, []*datastore.PodInfo{busyPod}, got)
+	assert.Equal(t, []string{"/v1/unload_lora_adapter chat-lora", "/v1/load_lora_adapter code-lora"}, runtime.takeRequests())
+	assert.False(t, busyPod.Contains("chat-lora"))
+
+	// Adapters which are not loaded on demand are left to the scheduler.
+	got, err = placer.ensureLoraAdapter(context.Background(), modelServer, pods, "unknown-lora")
+	assert.NoError(t, err)
+	assert.Equal(t, pods, got)
+	assert.Empty(t, runtime.takeRequests())
+}
+
+func TestEnsureLoraAdapter_NoFreeSlot(t *testing.T) {
+	runtime := &fakeLoraRuntime{}
+	placer, modelServer := newLoraPlacementTest(t, runtime)
+	// The adapter loaded by the ModelBooster controller is never unloaded.
+	pods := []*datastore.PodInfo{newLoraPod("pod-0", "pinned-lora")}
+
+	_, err := placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+	assert.ErrorContains(t, err, "no free lora adapter slot")
+	assert.Empty(t, runtime.takeRequests())
+}
+
+func TestEnsureLoraAdapter_Coalescing(t *testing.T) {
+	runtime := &fakeLoraRuntime{release: make(chan struct{})}
+	placer, modelServer := newLoraPlacementTest(t, runtime)
+	pods := []*datastore.PodInfo{newLoraPod("pod-0")}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+		}()
+	}
+	// Give the requests time to join the in-flight load.
+	time.Sleep(100 * time.Millisecond)
+	close(runtime.release)
+	wg.Wait()
+	assert.Equal(t, []string{"/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+}
+
+func TestEnsureLoraAdapter_ConcurrentLoads(t *testing.T) {
+	runtime := &fakeLoraRuntime{release: make(chan struct{})}
+	placer, modelServer := newLoraPlacementTest(t, runtime)
+	pods := []*datastore.PodInfo{newLoraPod("pod-0"), newLoraPod("pod-1")}
+
+	var wg sync.WaitGroup
+	for _, adapter := range []string{"sql-lora", "chat-lora"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := placer.ensureLoraAdapter(context.Background(), modelServer, pods, adapter)
+			assert.NoError(t, err)
+			assert.Len(t, got, 1)
+		}()
+	}
+	// The adapters are loaded at the same time, each on the pod where its slot is reserved.
+	assert.Eventually(t, func() bool {
+		runtime.mu.Lock()
+		defer runtime.mu.Unlock()
+		return runtime.received == 2
+	}, time.Second, 10*time.Millisecond)
+	close(runtime.release)
+	wg.Wait()
+	assert.ElementsMatch(t, []string{"/v1/load_lora_adapter sql-lora", "/v1/load_lora_adapter chat-lora"}, runtime.takeRequests())
+	assert.NotEqual(t, pods[0].Contains("sql-lora"), pods[1].Contains("sql-lora"))
+	assert.NotEqual(t, pods[0].Contains("chat-lora"), pods[1].Contains("chat-lora"))
+}
+
+func TestEnsureLoraAdapter_RequestCancelled(t *testing.T) {
+	runtime := &fakeLoraRuntime{release: make(chan struct{})}
+	placer, modelServer := newLoraPlacementTest(t, runtime)
+	pods := []*datastore.PodInfo{newLoraPod("pod-0")}
+
+	// A cancelled request stops waiting for the load, which goes on for the other requests.
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := placer.ensureLoraAdapter(ctx, modelServer, pods, "sql-lora")
+		errs <- err
+	}()
+	assert.Eventually(t, func() bool {
+		runtime.mu.Lock()
+		defer runtime.mu.Unlock()
+		return runtime.received == 1
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+	select {
+	case err := <-errs:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("the cancelled request kept waiting for the load")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got, err := placer.ensureLoraAdapter(context.Background(), modelServer, pods, "sql-lora")
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+	}()
+	close(runtime.release)
+	<-done
+	assert.Equal(t, []string{"/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+}
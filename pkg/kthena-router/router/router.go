@@ -37,6 +37,7 @@ import (
 
 	"github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/accesslog"
+	"github.com/volcano-sh/kthena/pkg/kthena-router/activator"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/common"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/connectors"
 	"github.com/volcano-sh/kthena/pkg/kthena-router/datastore"
@@ -78,6 +79,13 @@ type Router struct {
 
 	// KV Connector management
 	connectorFactory *connectors.Factory
+
+	// loraPlacer loads lora adapters on demand
+	loraPlacer *loraPlacer
+
+	// activator records the demand of the model servers, and holds the requests for the model servers
+	// scaled to zero until they are ready
+	activator *activator.Activator
 }
 
 func NewRouter(store datastore.Store, routerConfigPath string) *Router {
@@ -154,9 +162,17 @@ func NewRouter(store datastore.Store, routerConfigPath string) *Router {
 		metrics:          metricsInstance,
 		tokenizer:        tokenizerInstance,
 		connectorFactory: connectors.NewDefaultFactory(),
+		loraPlacer:       newLoraPlacer(),
+		activator:        activator.NewActivator(queueLengths(store)),
 	}
 }
 
+// DemandHandler serves the demand of the model servers observed by the router, which the autoscaler pulls to
+// scale the model servers to and from zero.
+func (r *Router) DemandHandler() gin.HandlerFunc {
+	return gin.WrapH(r.activator)
+}
+
 type ModelRequest map[string]interface{}
 
 func (r *Router) HandlerFunc() gin.HandlerFunc {
@@ -220,6 +236,8 @@ func (r *Router) HandlerFunc() gin.HandlerFunc {
 
 		// Record input tokens immediately
 		metricsRecorder.RecordInputTokens(inputTokens)
+		// Store input tokens in context for the demand of the model server
+		c.Set("inputTokens", inputTokens)
 
 		// Apply rate limiting using the unified rate limiter
 		if err := r.loadRateLimiter.RateLimit(modelName, promptStr); err != nil {
@@ -244,6 +262,7 @@ func (r *Router) HandlerFunc() gin.HandlerFunc {
 
 			// Record rate limit exceeded
 			metricsRecorder.RecordRateLimitExceeded(tokenType)
+			r.activator.RecordRateLimited(modelName)
 			c.AbortWithStatusJSON(http.StatusTooManyRequests, errorMsg)
 			c.Set("finishReason", "rate_limit")
 			return
@@ -303,9 +322,28 @@ func (r *Router) doLoadbalance(c *gin.Context, modelRequest ModelRequest) {
 		// step 3: Find pods and model server details
 		klog.V(4).Infof("modelServer is %v, is_lora: %v", modelServerName, isLora)
 
+		r.activator.Record(modelServerName)
+		if inputTokens, ok := c.Get("inputTokens"); ok {
+			r.activator.RecordInputTokens(modelServerName, inputTokens.(int))
+		}
 		pods, modelServer, err = r.getPodsAndServer(modelServerName)
+		if err != nil && modelRoute != nil && modelRoute.Spec.ScaleFromZero != nil && r.store.GetModelServer(modelServerName) != nil {
+			// Hold the request until the model server is scaled from zero.
+			klog.V(4).Infof("model server %v has no ready pods, waiting for it to scale from zero", modelServerName)
+			if err = r.activator.Wait(c.Request.Context(), modelServerName, modelRoute.Spec.ScaleFromZero, func() bool {
+				pods, _ := r.store.GetPodsByModelServer(modelServerName)
+				return len(pods) > 0
+			}); err != nil {
+				klog.Errorf("failed to wait for model server %v to scale from zero: %v", modelServerName, err)
+				accesslog.SetError(c, "scale_from_zero", err.Error())
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, fmt.Sprintf("model server %v is not ready: %v", modelServerName, err))
+				return
+			}
+			pods, modelServer, err = r.getPodsAndServer(modelServerName)
+		}
 		if err != nil || len(pods) == 0 {
 			klog.Errorf("failed to get pods and model server: %v, %v", modelServerName, err)
+			r.activator.RecordNotFound(modelServerName)
 			accesslog.SetError(c, "pod_discovery", fmt.Sprintf("can't find model server: %v", modelServerName))
 			c.AbortWithStatusJSON(http.StatusNotFound, fmt.Sprintf("can't find model server: %v", modelServerName))
 			return
@@ -316,6 +354,17 @@ func (r *Router) doLoadbalance(c *gin.Context, modelRequest ModelRequest) {
 			modelRequest["model"] = *model
 		}
 
+		// Load the lora adapter on demand if no pod serves it, and only schedule to the pods serving it.
+		if isLora && modelServer.Spec.LoraPlacement != nil && (modelServer.Spec.WorkloadSelector == nil || modelServer.Spec.WorkloadSelector.PDGroup == nil) {
+			pods, err = r.loraPlacer.ensureLoraAdapter(c.Request.Context(), modelServer, pods, modelName)
+			if err != nil {
+				klog.Errorf("failed to place lora adapter %s: %v", modelName, err)
+				accesslog.SetError(c, "lora_placement", err.Error())
+				c.AbortWithStatusJSON(http.StatusServiceUnavailable, fmt.Sprintf("can't place lora adapter: %v", err))
+				return
+			}
+		}
+
 		port = modelServer.Spec.WorkloadPort.Port
 	} else if matched, inferencePoolName := r.handleHTTPRoute(c, gatewayKey); matched {
 		// If ModelRoute is not matched, try to match HTTPRoute
@@ -410,6 +459,10 @@ func (r *Router) doLoadbalance(c *gin.Context, modelRequest ModelRequest) {
 		accesslog.SetRequestRouting(c, modelRouteName, modelServerFullName, "")
 	}
 
+	if modelServer != nil {
+		defer r.activator.StartRequest(modelServerName)()
+	}
+
 	req := c.Request
 	if err := r.proxyModelEndpoint(c, req, ctx, modelRequest, port); err != nil {
 		klog.Errorf("request failed reqID: %s: %v", c.Request.Header.Get("x-request-id"), err)
@@ -418,6 +471,18 @@ func (r *Router) doLoadbalance(c *gin.Context, modelRequest ModelRequest) {
 	}
 }
 
+// queueLengths returns the lengths of the fairness queues of the models in the store.
+func queueLengths(store datastore.Store) func() map[string]int {
+	return func() map[string]int {
+		stats := store.GetRequestWaitingQueueStats()
+		lengths := make(map[string]int, len(stats))
+		for _, stat := range stats {
+			lengths[stat.Model] = stat.Length
+		}
+		return lengths
+	}
+}
+
 func ParseModelRequest(c *gin.Context) (ModelRequest, error) {
 	bodyBytes, err := io.ReadAll(c.Request.Body)
 	if err != nil {
@@ -704,6 +769,7 @@ func (r *Router) proxyModelEndpoint(
 				// Record output tokens
 				metricsRecorder.RecordOutputTokens(resp.Usage.CompletionTokens)
 			}
+			r.activator.RecordOutputTokens(ctx.ModelServerName, resp.Usage.CompletionTokens)
 			if userID == "" || modelName == "" {
 				return
 			}
@@ -948,6 +1014,7 @@ func (r *Router) proxyToPDDisaggregated(
 		if metricsRecorder != nil {
 			metricsRecorder.RecordOutputTokens(outputTokens)
 		}
+		r.activator.RecordOutputTokens(ctx.ModelServerName, outputTokens)
 
 		// Record successful operation in cache
 		r.scheduler.RunPostHooks(ctx, i)
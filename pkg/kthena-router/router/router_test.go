@@ -29,6 +29,7 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/agiledragon/gomonkey/v2"
 	"github.com/gin-gonic/gin"
@@ -364,6 +365,88 @@ func TestRouter_HandlerFunc_ModelNotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "route not found")
 }
 
+func TestRouter_HandlerFunc_ScaleFromZero(t *testing.T) {
+	backendHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, `{"id":"response-id"}`)
+	})
+	router, store, backend := setupTestRouter(backendHandler)
+	defer backend.Close()
+
+	backendURL, _ := url.Parse(backend.URL)
+	backendIP := backendURL.Hostname()
+	backendPort, _ := strconv.Atoi(backendURL.Port())
+
+	modelServer := &aiv1alpha1.ModelServer{
+		ObjectMeta: v1.ObjectMeta{Name: "ms-1", Namespace: "default"},
+		Spec: aiv1alpha1.ModelServerSpec{
+			WorkloadPort:    aiv1alpha1.WorkloadPort{Port: int32(backendPort)},
+			InferenceEngine: "vLLM",
+		},
+	}
+	pod1 := &corev1.Pod{
+		ObjectMeta: v1.ObjectMeta{Name: "pod-1", Namespace: "default"},
+		Status:     corev1.PodStatus{PodIP: backendIP, Phase: corev1.PodRunning},
+	}
+	newModelRoute := func(scaleFromZero *aiv1alpha1.ScaleFromZero) *aiv1alpha1.ModelRoute {
+		return &aiv1alpha1.ModelRoute{
+			ObjectMeta: v1.ObjectMeta{Name: "mr-1", Namespace: "default"},
+			Spec: aiv1alpha1.ModelRouteSpec{
+				ModelName: "test-model",
+				Rules: []*aiv1alpha1.Rule{
+					{
+						TargetModels: []*aiv1alpha1.TargetModel{
+							{ModelServerName: "ms-1"},
+						},
+					},
+				},
+				ScaleFromZero: scaleFromZero,
+			},
+		}
+	}
+	store.AddOrUpdateModelServer(modelServer, sets.New[types.NamespacedName]())
+
+	doRequest := func() *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		reqBody := `{"model": "test-model", "prompt": "hello"}`
+		c.Request, _ = http.NewRequest("POST", "/v1/chat/completions", bytes.NewBufferString(reqBody))
+		c.Request.Header.Set("Content-Type", "application/json")
+		router.HandlerFunc()(c)
+		return w
+	}
+
+	// Without scaleFromZero, the request is rejected.
+	store.AddOrUpdateModelRoute(newModelRoute(nil))
+	w := doRequest()
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// The request times out if no pod gets ready.
+	store.AddOrUpdateModelRoute(newModelRoute(&aiv1alpha1.ScaleFromZero{Timeout: &v1.Duration{Duration: 10 * time.Millisecond}}))
+	w = doRequest()
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "timed out waiting for the model server to scale from zero")
+
+	// The request is held until a pod is ready.
+	store.AddOrUpdateModelRoute(newModelRoute(&aiv1alpha1.ScaleFromZero{Timeout: &v1.Duration{Duration: 10 * time.Second}}))
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		store.AddOrUpdatePod(pod1, []*aiv1alpha1.ModelServer{modelServer})
+		store.AddOrUpdateModelServer(modelServer, sets.New(types.NamespacedName{Name: "pod-1", Namespace: "default"}))
+	}()
+	w = doRequest()
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"response-id"`)
+
+	demand := router.activator.Demand()
+	assert.Len(t, demand.ModelServers, 1)
+	assert.Equal(t, int64(3), demand.ModelServers[0].Requests)
+	assert.Equal(t, int32(0), demand.ModelServers[0].Waiting)
+	assert.Equal(t, int32(0), demand.ModelServers[0].ActiveRequests)
+	// The request rejected without scaleFromZero is counted as not found.
+	assert.Equal(t, 1/60.0, demand.ModelServers[0].NotFoundPerSecond)
+}
+
 func TestRouter_HandlerFunc_ScheduleFailure(t *testing.T) {
 	backendHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// This should not be called
@@ -24,21 +24,55 @@ import (
 	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
 )
 
 func (mc *ModelBoosterController) createOrUpdateAutoscalingPolicyAndBinding(ctx context.Context, model *v1alpha1.ModelBooster) error {
-	// Create autoscaling policy and scaling policy binding for single backend
-	if model.Spec.AutoscalingPolicy != nil {
-		backend := model.Spec.Backend
-		asp := convert.BuildAutoscalingPolicy(model.Spec.AutoscalingPolicy, model, backend.Name)
-		aspBinding := convert.BuildScalingPolicyBinding(model, &backend, utils.GetBackendResourceName(model.Name, backend.Name))
+	var aspName, aspBindingName string
+	if asp, aspBinding := convert.BuildAutoscaling(model); asp != nil {
 		if err := mc.createOrUpdateAsp(ctx, asp); err != nil {
 			return err
 		}
 		if err := mc.createOrUpdateAspBinding(ctx, aspBinding); err != nil {
 			return err
 		}
+		aspName, aspBindingName = asp.Name, aspBinding.Name
+	}
+	return mc.deleteStaleAutoscalingPolicyAndBinding(ctx, model, aspName, aspBindingName)
+}
+
+// deleteStaleAutoscalingPolicyAndBinding deletes the autoscaling policies and bindings of the model other than the current ones,
+// e.g. after the model switched from a single backend to multiple backends or the autoscaling policy was removed.
+func (mc *ModelBoosterController) deleteStaleAutoscalingPolicyAndBinding(ctx context.Context, model *v1alpha1.ModelBooster, aspName, aspBindingName string) error {
+	selector := labels.SelectorFromSet(map[string]string{
+		utils.OwnerUIDKey: string(model.UID),
+	})
+	bindings, err := mc.autoscalingPolicyBindingsLister.AutoscalingPolicyBindings(model.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, binding := range bindings {
+		if binding.Name == aspBindingName {
+			continue
+		}
+		if err := mc.client.WorkloadV1alpha1().AutoscalingPolicyBindings(model.Namespace).Delete(ctx, binding.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.V(4).Infof("Delete AutoscalingPolicyBinding %s", binding.Name)
+	}
+	policies, err := mc.autoscalingPoliciesLister.AutoscalingPolicies(model.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	for _, policy := range policies {
+		if policy.Name == aspName {
+			continue
+		}
+		if err := mc.client.WorkloadV1alpha1().AutoscalingPolicies(model.Namespace).Delete(ctx, policy.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.V(4).Infof("Delete AutoscalingPolicy %s", policy.Name)
 	}
 	return nil
 }
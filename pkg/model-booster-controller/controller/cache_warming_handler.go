@@ -0,0 +1,284 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/metrics"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+)
+
+// syncCacheWarming creates the cache warmers of the backends with CacheWarming, labels the nodes holding the model
+// in their cache, and updates the CacheWarming status of the model. The warmers and node labels of the backends
+// which no longer warm their cache, or whose model changed, are removed.
+func (mc *ModelBoosterController) syncCacheWarming(ctx context.Context, model *workload.ModelBooster) error {
+	var statuses []workload.CacheWarmingStatus
+	warming := map[string]string{}
+	for _, backend := range utils.GetModelBackends(model) {
+		if backend.CacheWarming == nil {
+			continue
+		}
+		status, err := mc.syncBackendCacheWarming(ctx, model, backend)
+		if err != nil {
+			return err
+		}
+		warming[backend.Name] = status.CacheKey
+		statuses = append(statuses, *status)
+		metrics.ModelCacheWarmNodes.WithLabelValues(model.Namespace, model.Name, backend.Name).Set(float64(status.WarmNodes))
+	}
+
+	for _, old := range model.Status.CacheWarming {
+		cacheKey, ok := warming[old.BackendName]
+		if ok && cacheKey == old.CacheKey {
+			continue
+		}
+		if !ok {
+			if err := mc.deleteCacheWarmer(ctx, model, old.BackendName); err != nil {
+				return err
+			}
+			metrics.ModelCacheWarmNodes.DeleteLabelValues(model.Namespace, model.Name, old.BackendName)
+		}
+		// The node labels of the old model are kept where another cache warmer still holds it.
+		if err := mc.syncModelCache(ctx, old.CacheKey); err != nil {
+			return err
+		}
+	}
+
+	if equality.Semantic.DeepEqual(model.Status.CacheWarming, statuses) {
+		return nil
+	}
+	model.Status.CacheWarming = statuses
+	return mc.updateModelBoosterStatus(ctx, model)
+}
+
+// syncBackendCacheWarming warms the cache of the backend with a DaemonSet for a hostpath cache, or a Job for a
+// pvc cache, and returns its status.
+func (mc *ModelBoosterController) syncBackendCacheWarming(ctx context.Context, model *workload.ModelBooster,
+	backend *workload.ModelBackend) (*workload.CacheWarmingStatus, error) {
+	status := &workload.CacheWarmingStatus{
+		BackendName: backend.Name,
+		CacheKey:    convert.GetModelCacheKey(backend),
+	}
+	if !convert.IsNodeLocalCache(backend) {
+		job, err := mc.createOrUpdateCacheWarmerJob(ctx, model, backend)
+		if err != nil {
+			return nil, err
+		}
+		status.DesiredNodes = 1
+		if job.Status.Succeeded > 0 {
+			status.WarmNodes = 1
+		}
+		return status, nil
+	}
+
+	daemonSet, err := mc.createOrUpdateCacheWarmerDaemonSet(ctx, model, backend)
+	if err != nil {
+		return nil, err
+	}
+	if err := mc.syncModelCache(ctx, status.CacheKey); err != nil {
+		return nil, err
+	}
+	status.DesiredNodes = daemonSet.Status.DesiredNumberScheduled
+	status.WarmNodes = daemonSet.Status.NumberReady
+	return status, nil
+}
+
+func (mc *ModelBoosterController) createOrUpdateCacheWarmerDaemonSet(ctx context.Context, model *workload.ModelBooster,
+	backend *workload.ModelBackend) (*appsv1.DaemonSet, error) {
+	daemonSet, err := convert.BuildCacheWarmerDaemonSet(model, backend)
+	if err != nil {
+		return nil, err
+	}
+	client := mc.kubeClient.AppsV1().DaemonSets(model.Namespace)
+	old, err := mc.daemonSetsLister.DaemonSets(model.Namespace).Get(daemonSet.Name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		klog.V(4).Infof("Create cache warmer DaemonSet %s", klog.KObj(daemonSet))
+		return client.Create(ctx, daemonSet, metav1.CreateOptions{})
+	}
+	if old.Labels[utils.RevisionLabelKey] == daemonSet.Labels[utils.RevisionLabelKey] {
+		return old, nil
+	}
+	if !equality.Semantic.DeepEqual(old.Spec.Selector, daemonSet.Spec.Selector) {
+		// The selector is immutable, it changes with the model, so the warmer of the old model is replaced.
+		klog.V(4).Infof("Recreate cache warmer DaemonSet %s for a new model", klog.KObj(daemonSet))
+		if err := client.Delete(ctx, old.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		return client.Create(ctx, daemonSet, metav1.CreateOptions{})
+	}
+	daemonSet.ResourceVersion = old.ResourceVersion
+	daemonSet.Status = *old.Status.DeepCopy()
+	return client.Update(ctx, daemonSet, metav1.UpdateOptions{})
+}
+
+func (mc *ModelBoosterController) createOrUpdateCacheWarmerJob(ctx context.Context, model *workload.ModelBooster,
+	backend *workload.ModelBackend) (*batchv1.Job, error) {
+	job, err := convert.BuildCacheWarmerJob(model, backend)
+	if err != nil {
+		return nil, err
+	}
+	client := mc.kubeClient.BatchV1().Jobs(model.Namespace)
+	old, err := mc.jobsLister.Jobs(model.Namespace).Get(job.Name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		klog.V(4).Infof("Create cache warmer Job %s", klog.KObj(job))
+		return client.Create(ctx, job, metav1.CreateOptions{})
+	}
+	if old.Labels[utils.RevisionLabelKey] == job.Labels[utils.RevisionLabelKey] {
+		return old, nil
+	}
+	// The pod template of a Job is immutable, so the Job is replaced.
+	klog.V(4).Infof("Recreate cache warmer Job %s", klog.KObj(job))
+	if err := client.Delete(ctx, old.Name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	}); err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	return client.Create(ctx, job, metav1.CreateOptions{})
+}
+
+// deleteCacheWarmer deletes the DaemonSet or Job warming the cache of the backend.
+func (mc *ModelBoosterController) deleteCacheWarmer(ctx context.Context, model *workload.ModelBooster, backendName string) error {
+	name := convert.GetCacheWarmerName(model, &workload.ModelBackend{Name: backendName})
+	err := mc.kubeClient.AppsV1().DaemonSets(model.Namespace).Delete(ctx, name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	err = mc.kubeClient.BatchV1().Jobs(model.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		PropagationPolicy: ptr.To(metav1.DeletePropagationBackground),
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// listWarmNodes returns the nodes where a cache warmer of the model is ready, in any namespace.
+// Backends of different ModelBoosters sharing the same cache and model share the warm nodes.
+func (mc *ModelBoosterController) listWarmNodes(cacheKey string) (sets.Set[string], error) {
+	pods, err := mc.podsLister.List(labels.SelectorFromSet(map[string]string{utils.ModelCacheKeyLabelKey: cacheKey}))
+	if err != nil {
+		return nil, err
+	}
+	nodes := sets.New[string]()
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Spec.NodeName != "" && icUtils.IsPodRunningAndReady(pod) {
+			nodes.Insert(pod.Spec.NodeName)
+		}
+	}
+	return nodes, nil
+}
+
+// syncModelCache syncs the node labels of the model cache with the ready cache warmers, in any namespace.
+func (mc *ModelBoosterController) syncModelCache(ctx context.Context, cacheKey string) error {
+	warmNodes, err := mc.listWarmNodes(cacheKey)
+	if err != nil {
+		return err
+	}
+	return mc.syncModelCacheNodeLabels(ctx, cacheKey, warmNodes)
+}
+
+// syncModelCacheNodeLabels labels the warm nodes with the cache label of the model, and removes it from the others.
+func (mc *ModelBoosterController) syncModelCacheNodeLabels(ctx context.Context, cacheKey string, warmNodes sets.Set[string]) error {
+	labelKey := utils.ModelCacheNodeLabelPrefix + cacheKey
+	requirement, err := labels.NewRequirement(labelKey, selection.Exists, nil)
+	if err != nil {
+		return err
+	}
+	nodes, err := mc.nodesLister.List(labels.NewSelector().Add(*requirement))
+	if err != nil {
+		return err
+	}
+	labelled := sets.New[string]()
+	for _, node := range nodes {
+		labelled.Insert(node.Name)
+		if !warmNodes.Has(node.Name) {
+			if err := mc.patchNodeLabel(ctx, node.Name, labelKey, nil); err != nil {
+				return err
+			}
+		}
+	}
+	for _, nodeName := range sets.List(warmNodes.Difference(labelled)) {
+		if err := mc.patchNodeLabel(ctx, nodeName, labelKey, ptr.To("true")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchNodeLabel sets the label of the node, or removes it if value is nil.
+func (mc *ModelBoosterController) patchNodeLabel(ctx context.Context, nodeName string, key string, value *string) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"labels": map[string]*string{key: value},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = mc.kubeClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update label %s of node %s: %v", key, nodeName, err)
+	}
+	return nil
+}
+
+// recordModelCacheLookup records whether the serving pod was scheduled on a node holding the model in its cache.
+func (mc *ModelBoosterController) recordModelCacheLookup(model *workload.ModelBooster, pod *corev1.Pod) {
+	backendName := pod.Labels[utils.BackendNameLabelKey]
+	for _, backend := range utils.GetModelBackends(model) {
+		if backend.Name != backendName || backend.CacheWarming == nil || !convert.IsNodeLocalCache(backend) {
+			continue
+		}
+		warmNodes, err := mc.listWarmNodes(convert.GetModelCacheKey(backend))
+		if err != nil {
+			klog.Errorf("failed to list warm nodes of backend %s: %v", backend.Name, err)
+			return
+		}
+		result := metrics.ResultMiss
+		if warmNodes.Has(pod.Spec.NodeName) {
+			result = metrics.ResultHit
+		}
+		metrics.ModelCacheLookups.WithLabelValues(model.Namespace, model.Name, backend.Name, result).Inc()
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/metrics"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSyncCacheWarming(t *testing.T) {
+	ctx := context.Background()
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.UID = "cache-model-uid"
+	backend := model.Spec.Backend
+	backend.CacheWarming = &workload.CacheWarming{NodeSelector: map[string]string{"node-pool": "ascend"}}
+	cacheKey := convert.GetModelCacheKey(backend)
+	labelKey := convert.GetModelCacheNodeLabelKey(backend)
+
+	kubeClient := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		// The label of a node which no longer holds the model is removed.
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b", Labels: map[string]string{labelKey: "true"}}},
+	)
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller := NewModelBoosterController(kubeClient, kthenaClient)
+	model, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Create(ctx, model, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	warmer := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "warmer-a",
+			Namespace: model.Namespace,
+			Labels: map[string]string{
+				utils.ModelNameLabelKey:     model.Name,
+				utils.OwnerUIDKey:           string(model.UID),
+				utils.ModelCacheKeyLabelKey: cacheKey,
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	assert.NoError(t, controller.podsInformer.GetIndexer().Add(warmer))
+	syncNodeInformer(t, kubeClient, controller)
+	assert.NoError(t, controller.syncCacheWarming(ctx, model))
+	syncNodeInformer(t, kubeClient, controller)
+
+	daemonSet, err := kubeClient.AppsV1().DaemonSets(model.Namespace).Get(ctx, "test-model-backend1-cache-warmer", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, backend.CacheWarming.NodeSelector, daemonSet.Spec.Template.Spec.NodeSelector)
+	nodeLabels := func(name string) map[string]string {
+		node, err := kubeClient.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		return node.Labels
+	}
+	assert.Equal(t, "true", nodeLabels("node-a")[labelKey])
+	assert.NotContains(t, nodeLabels("node-b"), labelKey)
+	assert.Equal(t, []workload.CacheWarmingStatus{{BackendName: "backend1", CacheKey: cacheKey}}, model.Status.CacheWarming)
+
+	// The serving pods scheduled on a warm node hit the cache.
+	servingPod := func(nodeName string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{utils.BackendNameLabelKey: backend.Name}},
+			Spec:       corev1.PodSpec{NodeName: nodeName},
+		}
+	}
+	hits := metrics.ModelCacheLookups.WithLabelValues(model.Namespace, model.Name, backend.Name, metrics.ResultHit)
+	misses := metrics.ModelCacheLookups.WithLabelValues(model.Namespace, model.Name, backend.Name, metrics.ResultMiss)
+	controller.recordModelCacheLookup(model, servingPod("node-a"))
+	controller.recordModelCacheLookup(model, servingPod("node-b"))
+	controller.recordModelCacheLookup(model, servingPod("node-b"))
+	assert.Equal(t, 1.0, testutil.ToFloat64(hits))
+	assert.Equal(t, 2.0, testutil.ToFloat64(misses))
+
+	// Disabling the cache warming removes the warmer and the node labels.
+	assert.NoError(t, controller.podsInformer.GetIndexer().Delete(warmer))
+	model.Spec.Backend.CacheWarming = nil
+	assert.NoError(t, controller.syncCacheWarming(ctx, model))
+	_, err = kubeClient.AppsV1().DaemonSets(model.Namespace).Get(ctx, daemonSet.Name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err))
+	assert.NotContains(t, nodeLabels("node-a"), labelKey)
+	assert.Empty(t, model.Status.CacheWarming)
+}
+
+func TestDeleteModelBoosterRemovesCacheNodeLabels(t *testing.T) {
+	ctx := context.Background()
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	backend.CacheWarming = &workload.CacheWarming{}
+	cacheKey := convert.GetModelCacheKey(backend)
+	labelKey := convert.GetModelCacheNodeLabelKey(backend)
+	model.Status.CacheWarming = []workload.CacheWarmingStatus{{BackendName: backend.Name, CacheKey: cacheKey}}
+
+	kubeClient := fake.NewClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a", Labels: map[string]string{labelKey: "true"}}})
+	controller := NewModelBoosterController(kubeClient, kthenafake.NewSimpleClientset())
+	syncNodeInformer(t, kubeClient, controller)
+	warmer := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "warmer-a",
+			Namespace: model.Namespace,
+			Labels:    map[string]string{utils.ModelCacheKeyLabelKey: cacheKey},
+		},
+		Spec: corev1.PodSpec{NodeName: "node-a"},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	assert.NoError(t, controller.podsInformer.GetIndexer().Add(warmer))
+	nodeLabels := func() map[string]string {
+		node, err := kubeClient.CoreV1().Nodes().Get(ctx, "node-a", metav1.GetOptions{})
+		assert.NoError(t, err)
+		return node.Labels
+	}
+
+	// The label is kept while the cache warmer of the deleted ModelBooster is still ready.
+	controller.deleteModelBooster(model)
+	assert.Equal(t, 1, controller.cacheKeyQueue.Len())
+	assert.True(t, controller.processNextCacheKey(ctx))
+	assert.Equal(t, "true", nodeLabels()[labelKey])
+
+	// The label is removed once the cache warmer is deleted.
+	assert.NoError(t, controller.podsInformer.GetIndexer().Delete(warmer))
+	controller.deletePod(warmer)
+	assert.True(t, controller.processNextCacheKey(ctx))
+	assert.NotContains(t, nodeLabels(), labelKey)
+}
+
+// syncNodeInformer copies the nodes of the client into the node informer of the controller, as the informer would.
+func syncNodeInformer(t *testing.T, kubeClient *fake.Clientset, controller *ModelBoosterController) {
+	nodes, err := kubeClient.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	assert.NoError(t, err)
+	indexer := controller.kubeInformerFactory.Core().V1().Nodes().Informer().GetIndexer()
+	for i := range nodes.Items {
+		assert.NoError(t, indexer.Update(&nodes.Items[i]))
+	}
+}
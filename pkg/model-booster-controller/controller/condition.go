@@ -30,6 +30,16 @@ const (
 	ModelActiveReason     = "ModelAvailable"
 	ModelProcessingReason = "ModelProcessing"
 	ModelFailedReason     = "ModelAbnormal"
+
+	ModelArtifactPendingReason   = "ArtifactPending"
+	ModelArtifactPreparingReason = "ArtifactPreparing"
+	ModelArtifactReadyReason     = "ArtifactReady"
+	ModelArtifactFailedReason    = "ArtifactFailed"
+
+	ModelDryRunNoChangesReason      = "NoChanges"
+	ModelDryRunChangesPendingReason = "ChangesPending"
+	ModelDryRunRolloutPendingReason = "RolloutPending"
+	ModelDryRunFailedReason         = "RenderFailed"
 )
 
 // setModelInitCondition sets model condition to initialized
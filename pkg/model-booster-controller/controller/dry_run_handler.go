@@ -0,0 +1,155 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+)
+
+// isDryRun returns whether the model only requests a dry run of its changes.
+func isDryRun(model *workload.ModelBooster) bool {
+	return model.Annotations[utils.DryRunAnnotationKey] == "true"
+}
+
+// syncDryRun renders the objects of the model and compares them with the existing ones, without applying them.
+// The summary of the changes is reported by the DryRun condition, and the changed fields are logged.
+func (mc *ModelBoosterController) syncDryRun(ctx context.Context, model *workload.ModelBooster) error {
+	diffs, err := mc.diffModelBooster(ctx, model)
+	if err != nil {
+		return mc.setModelDryRunCondition(ctx, model, metav1.ConditionFalse, ModelDryRunFailedReason, err.Error())
+	}
+	reason := ModelDryRunNoChangesReason
+	for _, diff := range diffs {
+		if diff.Action == convert.DiffActionNone {
+			continue
+		}
+		klog.InfoS("Dry run of model", "model", klog.KObj(model), "kind", diff.Kind, "name", diff.Name,
+			"action", diff.Action, "rollout", diff.Rollout, "changes", diff.Changes)
+		if diff.Rollout {
+			reason = ModelDryRunRolloutPendingReason
+		} else if reason == ModelDryRunNoChangesReason {
+			reason = ModelDryRunChangesPendingReason
+		}
+	}
+	return mc.setModelDryRunCondition(ctx, model, metav1.ConditionTrue, reason, convert.SummarizeDiff(diffs))
+}
+
+// setModelDryRunCondition sets the DryRun condition of the model, if it changed.
+func (mc *ModelBoosterController) setModelDryRunCondition(ctx context.Context, model *workload.ModelBooster,
+	status metav1.ConditionStatus, reason, message string) error {
+	condition := meta.FindStatusCondition(model.Status.Conditions, string(workload.ModelStatusConditionTypeDryRun))
+	if condition != nil && condition.Status == status && condition.Reason == reason && condition.Message == message &&
+		model.Status.ObservedGeneration == model.Generation {
+		return nil
+	}
+	meta.SetStatusCondition(&model.Status.Conditions, newCondition(string(workload.ModelStatusConditionTypeDryRun),
+		status, reason, message))
+	return mc.updateModelBoosterStatus(ctx, model)
+}
+
+// diffModelBooster returns the changes applying the model would make to its existing objects.
+func (mc *ModelBoosterController) diffModelBooster(ctx context.Context, model *workload.ModelBooster) ([]convert.ObjectDiff, error) {
+	desired, err := convert.Render(model)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := mc.listModelBoosterObjects(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return convert.Diff(desired, existing)
+}
+
+// listModelBoosterObjects returns copies of the existing objects derived from the model.
+func (mc *ModelBoosterController) listModelBoosterObjects(ctx context.Context, model *workload.ModelBooster) (*convert.RenderedObjects, error) {
+	existing := &convert.RenderedObjects{}
+	modelServings, err := mc.listModelServingsByLabel(model)
+	if err != nil {
+		return nil, err
+	}
+	for _, modelServing := range modelServings {
+		existing.ModelServings = append(existing.ModelServings, modelServing.DeepCopy())
+	}
+	modelServers, err := mc.listModelServerByLabel(model)
+	if err != nil {
+		return nil, err
+	}
+	for _, modelServer := range modelServers {
+		existing.ModelServers = append(existing.ModelServers, modelServer.DeepCopy())
+	}
+	modelRoute, err := mc.modelRoutesLister.ModelRoutes(model.Namespace).Get(model.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	if err == nil {
+		existing.ModelRoutes = []*networking.ModelRoute{modelRoute.DeepCopy()}
+	}
+	selector := labels.SelectorFromSet(map[string]string{
+		utils.OwnerUIDKey: string(model.UID),
+	})
+	policies, err := mc.autoscalingPoliciesLister.AutoscalingPolicies(model.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, policy := range policies {
+		existing.AutoscalingPolicies = append(existing.AutoscalingPolicies, policy.DeepCopy())
+	}
+	bindings, err := mc.autoscalingPolicyBindingsLister.AutoscalingPolicyBindings(model.Namespace).List(selector)
+	if err != nil {
+		return nil, err
+	}
+	for _, binding := range bindings {
+		existing.AutoscalingPolicyBindings = append(existing.AutoscalingPolicyBindings, binding.DeepCopy())
+	}
+	// The cache warmers are only removed once their backend no longer warms its cache, so the backends of the
+	// status are looked up as well.
+	backendNames := map[string]struct{}{}
+	for _, backend := range utils.GetModelBackends(model) {
+		backendNames[backend.Name] = struct{}{}
+	}
+	for _, status := range model.Status.CacheWarming {
+		backendNames[status.BackendName] = struct{}{}
+	}
+	for backendName := range backendNames {
+		name := convert.GetCacheWarmerName(model, &workload.ModelBackend{Name: backendName})
+		daemonSet, err := mc.kubeClient.AppsV1().DaemonSets(model.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			existing.CacheWarmerDaemonSets = append(existing.CacheWarmerDaemonSets, daemonSet)
+		}
+		job, err := mc.kubeClient.BatchV1().Jobs(model.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err == nil {
+			existing.CacheWarmerJobs = append(existing.CacheWarmerJobs, job)
+		}
+	}
+	return existing, nil
+}
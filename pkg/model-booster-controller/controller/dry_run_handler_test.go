@@ -0,0 +1,74 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// TestReconcile_DryRun checks that a model with the dry-run annotation only reports its changes in the DryRun
+// condition, and that they are applied once the annotation is removed.
+func TestReconcile_DryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeClient := fake.NewClientset()
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller := NewModelBoosterController(kubeClient, kthenaClient)
+	assert.NotNil(t, controller)
+	go controller.Run(ctx, 1)
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.Annotations = map[string]string{utils.DryRunAnnotationKey: "true"}
+
+	_, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Create(ctx, model, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.True(t, waitForCondition(func() bool {
+		model, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+		return meta.FindStatusCondition(model.Status.Conditions, string(workload.ModelStatusConditionTypeDryRun)) != nil
+	}))
+	condition := meta.FindStatusCondition(model.Status.Conditions, string(workload.ModelStatusConditionTypeDryRun))
+	assert.Equal(t, ModelDryRunChangesPendingReason, condition.Reason)
+	assert.Equal(t, "Create ModelServing test-model-backend1; Create ModelServer test-model-backend1; Create ModelRoute test-model; "+
+		"Create AutoscalingPolicy test-model-backend1; Create AutoscalingPolicyBinding test-model-backend1", condition.Message)
+	modelServings, err := kthenaClient.WorkloadV1alpha1().ModelServings(model.Namespace).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, modelServings.Items)
+
+	// Removing the annotation applies the model.
+	delete(model.Annotations, utils.DryRunAnnotationKey)
+	_, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Update(ctx, model, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.True(t, waitForCondition(func() bool {
+		modelServings, err := kthenaClient.WorkloadV1alpha1().ModelServings(model.Namespace).List(ctx, metav1.ListOptions{})
+		return err == nil && len(modelServings.Items) == 1
+	}))
+	assert.True(t, waitForCondition(func() bool {
+		model, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+		return err == nil && meta.FindStatusCondition(model.Status.Conditions, string(workload.ModelStatusConditionTypeDryRun)) == nil
+	}))
+}
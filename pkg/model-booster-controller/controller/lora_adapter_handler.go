@@ -0,0 +1,266 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/env"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/runtime/lora"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// loraAdapterRetryInterval is the interval to retry the LoRA adapters which failed to load or unload.
+	loraAdapterRetryInterval = 30 * time.Second
+	// loraAdapterCallTimeout bounds each load and unload call, so that an unresponsive runtime sidecar doesn't hold
+	// the reconcile worker. A load which times out is recorded as failed and retried.
+	loraAdapterCallTimeout = 30 * time.Second
+)
+
+// syncLoraAdapters loads the LoRA adapters of each backend on its ready serving pods, and unloads the adapters which
+// are removed or replaced. The per-pod load status is recorded in the ModelBooster status, so an adapter is only
+// loaded again when the pod is recreated or restarted. An adapter which failed to unload keeps its status until it
+// is unloaded, and a replaced adapter is only loaded once the old one is unloaded from all the pods.
+func (mc *ModelBoosterController) syncLoraAdapters(ctx context.Context, model *workload.ModelBooster) error {
+	oldStatuses := make(map[string]*workload.LoraAdapterStatus, len(model.Status.LoraAdapters))
+	for i := range model.Status.LoraAdapters {
+		status := &model.Status.LoraAdapters[i]
+		oldStatuses[loraAdapterKey(status.BackendName, status.Name)] = status
+	}
+	var statuses []workload.LoraAdapterStatus
+	backends := make(map[string]*workload.ModelBackend)
+	backendPods := make(map[string][]*corev1.Pod)
+	for _, backend := range utils.GetModelBackends(model) {
+		pods, err := mc.listLoraAdapterPods(model, backend)
+		if err != nil {
+			return err
+		}
+		backends[backend.Name] = backend
+		backendPods[backend.Name] = pods
+		for i := range backend.LoraAdapters {
+			adapter := &backend.LoraAdapters[i]
+			key := loraAdapterKey(backend.Name, adapter.Name)
+			oldStatus := oldStatuses[key]
+			delete(oldStatuses, key)
+			if oldStatus != nil && oldStatus.ArtifactURL != adapter.ArtifactURL {
+				// The adapter is replaced, the old one must be unloaded before loading the new one with the same name.
+				if pending := mc.unloadLoraAdapter(ctx, backend, oldStatus, pods); pending != nil {
+					statuses = append(statuses, *pending)
+					continue
+				}
+				oldStatus = nil
+			}
+			statuses = append(statuses, mc.loadLoraAdapter(ctx, backend, adapter, oldStatus, pods))
+		}
+	}
+	// Unload the adapters removed from the backends, in the order of the status. The pods of a removed backend are
+	// deleted with its ModelServing.
+	for i := range model.Status.LoraAdapters {
+		oldStatus := &model.Status.LoraAdapters[i]
+		if _, ok := oldStatuses[loraAdapterKey(oldStatus.BackendName, oldStatus.Name)]; !ok {
+			continue
+		}
+		backend, ok := backends[oldStatus.BackendName]
+		if !ok {
+			continue
+		}
+		if pending := mc.unloadLoraAdapter(ctx, backend, oldStatus, backendPods[oldStatus.BackendName]); pending != nil {
+			statuses = append(statuses, *pending)
+		}
+	}
+
+	if hasFailedLoraAdapter(statuses) {
+		if key, err := cache.MetaNamespaceKeyFunc(model); err == nil {
+			mc.workQueue.AddAfter(key, loraAdapterRetryInterval)
+		}
+	}
+	if equality.Semantic.DeepEqual(model.Status.LoraAdapters, statuses) {
+		return nil
+	}
+	model.Status.LoraAdapters = statuses
+	return mc.updateModelBoosterStatus(ctx, model)
+}
+
+// loadLoraAdapter loads the adapter on the pods where it is not loaded yet, and returns the load status of the adapter.
+func (mc *ModelBoosterController) loadLoraAdapter(ctx context.Context, backend *workload.ModelBackend, adapter *workload.LoraAdapter,
+	oldStatus *workload.LoraAdapterStatus, pods []*corev1.Pod) workload.LoraAdapterStatus {
+	status := workload.LoraAdapterStatus{
+		Name:        adapter.Name,
+		BackendName: backend.Name,
+		ArtifactURL: adapter.ArtifactURL,
+	}
+	if len(pods) == 0 {
+		return status
+	}
+	port := loraRuntimePort(backend)
+	outputDir := convert.GetLoraAdapterPath(backend, adapter)
+	status.Pods = make([]workload.LoraAdapterPodStatus, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		restartCount := podRestartCount(pod)
+		if old := findLoraAdapterPodStatus(oldStatus, pod); old != nil && old.State == workload.LoraAdapterLoaded && old.RestartCount == restartCount {
+			status.Pods[i] = *old
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			podStatus := workload.LoraAdapterPodStatus{
+				Name:         pod.Name,
+				UID:          string(pod.UID),
+				RestartCount: restartCount,
+				State:        workload.LoraAdapterLoaded,
+			}
+			callCtx, cancel := context.WithTimeout(ctx, loraAdapterCallTimeout)
+			defer cancel()
+			if err := mc.loraClient.Load(callCtx, pod.Status.PodIP, port, adapter.Name, adapter.ArtifactURL, outputDir); err != nil {
+				klog.Errorf("failed to load LoRA adapter %s on pod %s: %v", adapter.Name, klog.KObj(pod), err)
+				podStatus.State = workload.LoraAdapterFailed
+				podStatus.Message = err.Error()
+			} else {
+				klog.V(4).Infof("Loaded LoRA adapter %s on pod %s", adapter.Name, klog.KObj(pod))
+			}
+			status.Pods[i] = podStatus
+		}()
+	}
+	wg.Wait()
+	return status
+}
+
+// unloadLoraAdapter unloads the adapter from the pods where it was loaded or failed to unload. It returns the status
+// of the adapter on the pods it failed to unload from, to retry them later, or nil if the adapter is unloaded.
+// Pods which were deleted or restarted are skipped, since the adapter is gone with the old process.
+func (mc *ModelBoosterController) unloadLoraAdapter(ctx context.Context, backend *workload.ModelBackend,
+	status *workload.LoraAdapterStatus, pods []*corev1.Pod) *workload.LoraAdapterStatus {
+	port := loraRuntimePort(backend)
+	podStatuses := make([]*workload.LoraAdapterPodStatus, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		old := findLoraAdapterPodStatus(status, pod)
+		if old == nil || old.RestartCount != podRestartCount(pod) ||
+			(old.State != workload.LoraAdapterLoaded && old.State != workload.LoraAdapterUnloadFailed) {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			callCtx, cancel := context.WithTimeout(ctx, loraAdapterCallTimeout)
+			defer cancel()
+			if err := mc.loraClient.Unload(callCtx, pod.Status.PodIP, port, status.Name); err != nil {
+				klog.Errorf("failed to unload LoRA adapter %s from pod %s: %v", status.Name, klog.KObj(pod), err)
+				podStatus := *old
+				podStatus.State = workload.LoraAdapterUnloadFailed
+				podStatus.Message = err.Error()
+				podStatuses[i] = &podStatus
+				return
+			}
+			klog.V(4).Infof("Unloaded LoRA adapter %s from pod %s", status.Name, klog.KObj(pod))
+		}()
+	}
+	wg.Wait()
+
+	var pending []workload.LoraAdapterPodStatus
+	for _, podStatus := range podStatuses {
+		if podStatus != nil {
+			pending = append(pending, *podStatus)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return &workload.LoraAdapterStatus{
+		Name:        status.Name,
+		BackendName: status.BackendName,
+		ArtifactURL: status.ArtifactURL,
+		Pods:        pending,
+	}
+}
+
+// loraRuntimePort returns the port of the runtime sidecar of the backend.
+func loraRuntimePort(backend *workload.ModelBackend) int32 {
+	return env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, lora.DefaultRuntimePort)
+}
+
+// listLoraAdapterPods lists the ready entry pods of the backend. The runtime sidecar only runs in the entry pods.
+func (mc *ModelBoosterController) listLoraAdapterPods(model *workload.ModelBooster, backend *workload.ModelBackend) ([]*corev1.Pod, error) {
+	pods, err := mc.podsLister.Pods(model.Namespace).List(labels.SelectorFromSet(map[string]string{
+		workload.ModelServingNameLabelKey: utils.GetBackendResourceName(model.Name, backend.Name),
+		workload.EntryLabelKey:            icUtils.Entry,
+	}))
+	if err != nil {
+		return nil, err
+	}
+	var readyPods []*corev1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Status.PodIP != "" && icUtils.IsPodRunningAndReady(pod) {
+			readyPods = append(readyPods, pod)
+		}
+	}
+	sort.Slice(readyPods, func(i, j int) bool {
+		return readyPods[i].Name < readyPods[j].Name
+	})
+	return readyPods, nil
+}
+
+// hasFailedLoraAdapter returns whether an adapter failed to load or unload on a pod, and needs to be retried.
+func hasFailedLoraAdapter(statuses []workload.LoraAdapterStatus) bool {
+	for _, status := range statuses {
+		for _, pod := range status.Pods {
+			if pod.State == workload.LoraAdapterFailed || pod.State == workload.LoraAdapterUnloadFailed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func findLoraAdapterPodStatus(status *workload.LoraAdapterStatus, pod *corev1.Pod) *workload.LoraAdapterPodStatus {
+	if status == nil {
+		return nil
+	}
+	for i := range status.Pods {
+		if status.Pods[i].UID == string(pod.UID) {
+			return &status.Pods[i]
+		}
+	}
+	return nil
+}
+
+// podRestartCount returns the total restart count of the pod containers.
+func podRestartCount(pod *corev1.Pod) int32 {
+	var count int32
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		count += containerStatus.RestartCount
+	}
+	return count
+}
+
+func loraAdapterKey(backendName, adapterName string) string {
+	return backendName + "/" + adapterName
+}
@@ -0,0 +1,227 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/env"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/runtime/lora"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+type fakeRuntime struct {
+	mu       sync.Mutex
+	requests []string
+	bodies   []map[string]any
+	// failing is the name of the adapter which fails to load.
+	failing string
+	// failingUnload is the name of the adapter which fails to unload.
+	failingUnload string
+}
+
+func (f *fakeRuntime) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	name, _ := body["lora_name"].(string)
+	f.requests = append(f.requests, r.URL.Path+" "+name)
+	f.bodies = append(f.bodies, body)
+	if r.URL.Path == lora.LoadPath && name == f.failing {
+		http.Error(w, "failed to download", http.StatusInternalServerError)
+		return
+	}
+	if r.URL.Path == lora.UnloadPath && name == f.failingUnload {
+		http.Error(w, "engine busy", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (f *fakeRuntime) takeRequests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	requests := f.requests
+	f.requests = nil
+	return requests
+}
+
+func TestSyncLoraAdapters(t *testing.T) {
+	ctx := context.Background()
+	runtime := &fakeRuntime{failing: "bad-lora"}
+	server := httptest.NewServer(runtime)
+	defer server.Close()
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller := NewModelBoosterController(fake.NewClientset(), kthenaClient)
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.UID = "lora-model-uid"
+	backend := model.Spec.Backend
+	for i := range backend.Env {
+		if backend.Env[i].Name == env.RuntimePort {
+			backend.Env[i].Value = port
+		}
+	}
+	backend.LoraAdapters = []workload.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+	model, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Create(ctx, model, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	backend = model.Spec.Backend
+
+	newPod := func(name string, ready bool) *corev1.Pod {
+		status := corev1.ConditionFalse
+		if ready {
+			status = corev1.ConditionTrue
+		}
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: model.Namespace,
+				UID:       types.UID(name + "-uid"),
+				Labels: map[string]string{
+					workload.ModelServingNameLabelKey: utils.GetBackendResourceName(model.Name, backend.Name),
+					workload.EntryLabelKey:            "true",
+					utils.ModelNameLabelKey:           model.Name,
+					utils.OwnerUIDKey:                 string(model.UID),
+				},
+			},
+			Status: corev1.PodStatus{
+				Phase:             corev1.PodRunning,
+				PodIP:             "127.0.0.1",
+				Conditions:        []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+				ContainerStatuses: []corev1.ContainerStatus{{Name: "runtime"}},
+			},
+		}
+	}
+	pod := newPod("pod-0", true)
+	assert.NoError(t, controller.podsInformer.GetIndexer().Add(pod))
+	assert.NoError(t, controller.podsInformer.GetIndexer().Add(newPod("pod-1", false)))
+
+	// Load the adapter on the ready pod only.
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Equal(t, convert.GetLoraAdapterPath(backend, &backend.LoraAdapters[0]), runtime.bodies[0]["output_dir"])
+	assert.Equal(t, "hf://yard1/llama-2-7b-sql-lora-test", runtime.bodies[0]["source"])
+	assert.Equal(t, []workload.LoraAdapterStatus{{
+		Name:        "sql-lora",
+		BackendName: backend.Name,
+		ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test",
+		Pods:        []workload.LoraAdapterPodStatus{{Name: "pod-0", UID: "pod-0-uid", State: workload.LoraAdapterLoaded}},
+	}}, model.Status.LoraAdapters)
+	got, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, model.Status.LoraAdapters, got.Status.LoraAdapters)
+
+	// A loaded adapter is not loaded again.
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Empty(t, runtime.takeRequests())
+
+	// Reload the adapter when the pod restarts.
+	pod = pod.DeepCopy()
+	pod.Status.ContainerStatuses[0].RestartCount = 1
+	assert.NoError(t, controller.podsInformer.GetIndexer().Update(pod))
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Equal(t, int32(1), model.Status.LoraAdapters[0].Pods[0].RestartCount)
+
+	// Record the failure and retry the failed adapter.
+	backend.LoraAdapters = append(backend.LoraAdapters, workload.LoraAdapter{Name: "bad-lora", ArtifactURL: "s3://bucket/bad-lora"})
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/load_lora_adapter bad-lora"}, runtime.takeRequests())
+	assert.Equal(t, workload.LoraAdapterFailed, model.Status.LoraAdapters[1].Pods[0].State)
+	assert.Contains(t, model.Status.LoraAdapters[1].Pods[0].Message, "failed to download")
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/load_lora_adapter bad-lora"}, runtime.takeRequests())
+
+	// Keep the old adapter until it is unloaded, and only then load its replacement.
+	backend.LoraAdapters = []workload.LoraAdapter{{Name: "sql-lora", ArtifactURL: "s3://bucket/sql-lora-v2"}}
+	runtime.failingUnload = "sql-lora"
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/unload_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Len(t, model.Status.LoraAdapters, 1)
+	assert.Equal(t, "hf://yard1/llama-2-7b-sql-lora-test", model.Status.LoraAdapters[0].ArtifactURL)
+	assert.Equal(t, workload.LoraAdapterUnloadFailed, model.Status.LoraAdapters[0].Pods[0].State)
+	assert.Contains(t, model.Status.LoraAdapters[0].Pods[0].Message, "engine busy")
+
+	// Replace an adapter with a new artifact.
+	runtime.failingUnload = ""
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/unload_lora_adapter sql-lora", "/v1/load_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Len(t, model.Status.LoraAdapters, 1)
+	assert.Equal(t, "s3://bucket/sql-lora-v2", model.Status.LoraAdapters[0].ArtifactURL)
+
+	// Retry unloading the removed adapter until it succeeds.
+	backend.LoraAdapters = nil
+	runtime.failingUnload = "sql-lora"
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/unload_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Len(t, model.Status.LoraAdapters, 1)
+	assert.Equal(t, workload.LoraAdapterUnloadFailed, model.Status.LoraAdapters[0].Pods[0].State)
+	runtime.failingUnload = ""
+	assert.NoError(t, controller.syncLoraAdapters(ctx, model))
+	assert.Equal(t, []string{"/v1/unload_lora_adapter sql-lora"}, runtime.takeRequests())
+	assert.Empty(t, model.Status.LoraAdapters)
+}
+
+func TestUpdatePod(t *testing.T) {
+	controller := NewModelBoosterController(fake.NewClientset(), kthenafake.NewClientset())
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.UID = "lora-model-uid"
+	model.Spec.Backend.LoraAdapters = []workload.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+	assert.NoError(t, controller.modelsInformer.(cache.SharedIndexInformer).GetIndexer().Add(model))
+
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod-0",
+			Namespace: model.Namespace,
+			Labels: map[string]string{
+				workload.EntryLabelKey:  "true",
+				utils.ModelNameLabelKey: model.Name,
+				utils.OwnerUIDKey:       string(model.UID),
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	// invalid pods
+	controller.updatePod("invalid", oldPod)
+	controller.updatePod(oldPod, "invalid")
+	// pod not changed
+	controller.updatePod(oldPod, oldPod)
+	assert.Equal(t, 0, controller.workQueue.Len())
+
+	newPod := oldPod.DeepCopy()
+	newPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	controller.updatePod(oldPod, newPod)
+	assert.Equal(t, 1, controller.workQueue.Len())
+}
@@ -0,0 +1,192 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+type artifactState int
+
+const (
+	artifactPending artifactState = iota
+	artifactPreparing
+	artifactReady
+	artifactFailed
+)
+
+// syncModelArtifactCondition sets the ModelArtifactReady condition from the state of the model downloader and
+// verifier init containers on the serving pods. It counts the pods, the progress of each download is only in
+// the logs of its init container.
+func (mc *ModelBoosterController) syncModelArtifactCondition(ctx context.Context, model *workload.ModelBooster) error {
+	pods, err := mc.podsLister.Pods(model.Namespace).List(labels.SelectorFromSet(map[string]string{
+		utils.ModelNameLabelKey: model.Name,
+		utils.OwnerUIDKey:       string(model.UID),
+	}))
+	if err != nil {
+		return err
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+	var total, ready, preparing int
+	var failure string
+	for _, pod := range pods {
+		if _, isCacheWarmer := pod.Labels[utils.ModelCacheKeyLabelKey]; pod.DeletionTimestamp != nil || isCacheWarmer {
+			continue
+		}
+		total++
+		state, message := podArtifactState(model, pod)
+		switch state {
+		case artifactReady:
+			ready++
+		case artifactPreparing:
+			preparing++
+		case artifactFailed:
+			if failure == "" {
+				failure = fmt.Sprintf("pod %s: %s", pod.Name, message)
+			}
+		}
+	}
+
+	var condition metav1.Condition
+	switch {
+	case total == 0:
+		condition = newCondition(string(workload.ModelStatusConditionTypeArtifactReady), metav1.ConditionFalse,
+			ModelArtifactPendingReason, "Waiting for the serving pods to be created")
+	case failure != "":
+		condition = newCondition(string(workload.ModelStatusConditionTypeArtifactReady), metav1.ConditionFalse,
+			ModelArtifactFailedReason, "Failed to prepare the model artifact on "+failure)
+	case ready == total:
+		condition = newCondition(string(workload.ModelStatusConditionTypeArtifactReady), metav1.ConditionTrue,
+			ModelArtifactReadyReason, fmt.Sprintf("Model artifact is ready on %d pods", total))
+	default:
+		condition = newCondition(string(workload.ModelStatusConditionTypeArtifactReady), metav1.ConditionFalse,
+			ModelArtifactPreparingReason, fmt.Sprintf("Model artifact is ready on %d of %d pods, being downloaded or verified on %d", ready, total, preparing))
+	}
+	if !meta.SetStatusCondition(&model.Status.Conditions, condition) {
+		return nil
+	}
+	return mc.updateModelBoosterStatus(ctx, model)
+}
+
+// syncArtifactPVCs creates the PersistentVolumeClaims restoring the VolumeSnapshot artifacts of the backends, and
+// deletes the claims of the previous snapshots. A claim still used by a serving pod is only removed once the pod is.
+func (mc *ModelBoosterController) syncArtifactPVCs(ctx context.Context, model *workload.ModelBooster) error {
+	desired := sets.New[string]()
+	for _, backend := range utils.GetModelBackends(model) {
+		if backend.Artifact == nil || backend.Artifact.VolumeSnapshot == nil {
+			continue
+		}
+		pvc := convert.BuildArtifactPVC(model, backend)
+		desired.Insert(pvc.Name)
+		if _, err := mc.pvcsLister.PersistentVolumeClaims(model.Namespace).Get(pvc.Name); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		klog.V(4).Infof("Create model artifact PersistentVolumeClaim %s", klog.KObj(pvc))
+		_, err := mc.kubeClient.CoreV1().PersistentVolumeClaims(model.Namespace).Create(ctx, pvc, metav1.CreateOptions{})
+		if err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	pvcs, err := mc.pvcsLister.PersistentVolumeClaims(model.Namespace).List(labels.SelectorFromSet(map[string]string{
+		utils.ModelNameLabelKey: model.Name,
+	}))
+	if err != nil {
+		return err
+	}
+	for _, pvc := range pvcs {
+		if desired.Has(pvc.Name) || pvc.DeletionTimestamp != nil || !metav1.IsControlledBy(pvc, model) {
+			continue
+		}
+		klog.V(4).Infof("Delete model artifact PersistentVolumeClaim %s", klog.KObj(pvc))
+		err := mc.kubeClient.CoreV1().PersistentVolumeClaims(model.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// podArtifactState returns the state of the model artifact on the pod, and the failure reason if it failed.
+func podArtifactState(model *workload.ModelBooster, pod *corev1.Pod) (artifactState, string) {
+	names := map[string]bool{
+		convert.GetModelDownloaderName(model.Name): true,
+		convert.GetModelVerifierName(model.Name):   true,
+	}
+	hasArtifactContainer := false
+	for _, container := range pod.Spec.InitContainers {
+		if names[container.Name] {
+			hasArtifactContainer = true
+		}
+	}
+	if !hasArtifactContainer {
+		// The artifact is mounted directly, it is ready once the containers are created.
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Running != nil || status.State.Terminated != nil {
+				return artifactReady, ""
+			}
+		}
+		return artifactPending, ""
+	}
+
+	state := artifactReady
+	for _, status := range pod.Status.InitContainerStatuses {
+		if !names[status.Name] {
+			continue
+		}
+		switch {
+		case status.State.Terminated != nil && status.State.Terminated.ExitCode == 0:
+		case status.State.Terminated != nil:
+			return artifactFailed, terminationMessage(status.State.Terminated)
+		case status.State.Waiting != nil && status.LastTerminationState.Terminated != nil && status.LastTerminationState.Terminated.ExitCode != 0:
+			// The init container is restarted after a failure.
+			return artifactFailed, terminationMessage(status.LastTerminationState.Terminated)
+		case status.State.Running != nil:
+			state = artifactPreparing
+		default:
+			state = artifactPending
+		}
+	}
+	if len(pod.Status.InitContainerStatuses) == 0 {
+		return artifactPending, ""
+	}
+	return state, ""
+}
+
+func terminationMessage(state *corev1.ContainerStateTerminated) string {
+	if state.Message != "" {
+		return state.Message
+	}
+	return fmt.Sprintf("exit code %d, reason %s", state.ExitCode, state.Reason)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/convert"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPodArtifactState(t *testing.T) {
+	model := &workload.ModelBooster{ObjectMeta: metav1.ObjectMeta{Name: "llama"}}
+	downloader := convert.GetModelDownloaderName(model.Name)
+	newPod := func(initStatuses ...corev1.ContainerStatus) *corev1.Pod {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{InitContainers: []corev1.Container{{Name: downloader}}},
+		}
+		pod.Status.InitContainerStatuses = initStatuses
+		return pod
+	}
+	tests := []struct {
+		name        string
+		pod         *corev1.Pod
+		wantState   artifactState
+		wantMessage string
+	}{
+		{
+			name:      "not started",
+			pod:       newPod(),
+			wantState: artifactPending,
+		},
+		{
+			name: "waiting",
+			pod: newPod(corev1.ContainerStatus{Name: downloader, State: corev1.ContainerState{
+				Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"},
+			}}),
+			wantState: artifactPending,
+		},
+		{
+			name: "downloading",
+			pod: newPod(corev1.ContainerStatus{Name: downloader, State: corev1.ContainerState{
+				Running: &corev1.ContainerStateRunning{},
+			}}),
+			wantState: artifactPreparing,
+		},
+		{
+			name: "downloaded",
+			pod: newPod(corev1.ContainerStatus{Name: downloader, State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+			}}),
+			wantState: artifactReady,
+		},
+		{
+			name: "verification failed",
+			pod: newPod(corev1.ContainerStatus{Name: downloader, State: corev1.ContainerState{
+				Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "checksum mismatch: config.json"},
+			}}),
+			wantState:   artifactFailed,
+			wantMessage: "checksum mismatch: config.json",
+		},
+		{
+			name: "restarting after a failure",
+			pod: newPod(corev1.ContainerStatus{
+				Name:                 downloader,
+				State:                corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"}},
+			}),
+			wantState:   artifactFailed,
+			wantMessage: "exit code 1, reason Error",
+		},
+		{
+			name: "mounted artifact",
+			pod: &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}}}},
+			wantState: artifactReady,
+		},
+		{
+			name:      "mounting artifact",
+			pod:       &corev1.Pod{},
+			wantState: artifactPending,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, message := podArtifactState(model, tt.pod)
+			assert.Equal(t, tt.wantState, state)
+			assert.Equal(t, tt.wantMessage, message)
+		})
+	}
+}
+
+func TestSyncModelArtifactCondition(t *testing.T) {
+	ctx := context.Background()
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller := NewModelBoosterController(fake.NewClientset(), kthenaClient)
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.UID = "artifact-model-uid"
+	model, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Create(ctx, model, metav1.CreateOptions{})
+	assert.NoError(t, err)
+
+	getCondition := func() *metav1.Condition {
+		return meta.FindStatusCondition(model.Status.Conditions, string(workload.ModelStatusConditionTypeArtifactReady))
+	}
+	assert.NoError(t, controller.syncModelArtifactCondition(ctx, model))
+	assert.Equal(t, ModelArtifactPendingReason, getCondition().Reason)
+
+	downloader := convert.GetModelDownloaderName(model.Name)
+	newPod := func(name string, state corev1.ContainerState) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: model.Namespace,
+				Labels: map[string]string{
+					utils.ModelNameLabelKey: model.Name,
+					utils.OwnerUIDKey:       string(model.UID),
+				},
+			},
+			Spec: corev1.PodSpec{InitContainers: []corev1.Container{{Name: downloader}}},
+			Status: corev1.PodStatus{
+				InitContainerStatuses: []corev1.ContainerStatus{{Name: downloader, State: state}},
+			},
+		}
+	}
+	done := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}}
+	indexer := controller.podsInformer.GetIndexer()
+	assert.NoError(t, indexer.Add(newPod("pod-0", done)))
+	assert.NoError(t, indexer.Add(newPod("pod-1", corev1.ContainerState{Running: &corev1.ContainerStateRunning{}})))
+	assert.NoError(t, controller.syncModelArtifactCondition(ctx, model))
+	assert.Equal(t, metav1.ConditionFalse, getCondition().Status)
+	assert.Equal(t, ModelArtifactPreparingReason, getCondition().Reason)
+	assert.Equal(t, "Model artifact is ready on 1 of 2 pods, being downloaded or verified on 1", getCondition().Message)
+
+	assert.NoError(t, indexer.Update(newPod("pod-1", corev1.ContainerState{
+		Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, Message: "404 Client Error: Repository Not Found"},
+	})))
+	assert.NoError(t, controller.syncModelArtifactCondition(ctx, model))
+	assert.Equal(t, ModelArtifactFailedReason, getCondition().Reason)
+	assert.Equal(t, "Failed to prepare the model artifact on pod pod-1: 404 Client Error: Repository Not Found", getCondition().Message)
+
+	assert.NoError(t, indexer.Update(newPod("pod-1", done)))
+	assert.NoError(t, controller.syncModelArtifactCondition(ctx, model))
+	assert.Equal(t, metav1.ConditionTrue, getCondition().Status)
+	assert.Equal(t, ModelArtifactReadyReason, getCondition().Reason)
+	got, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(got.Status.Conditions, string(workload.ModelStatusConditionTypeArtifactReady)))
+}
+
+func TestSyncArtifactPVCs(t *testing.T) {
+	ctx := context.Background()
+	kubeClient := fake.NewClientset()
+	controller := NewModelBoosterController(kubeClient, kthenafake.NewSimpleClientset())
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/model.yaml")
+	model.UID = "artifact-model-uid"
+	backend := model.Spec.Backend
+	backend.ModelURI = ""
+	backend.Artifact = &workload.ModelArtifact{
+		VolumeSnapshot: &workload.VolumeSnapshotArtifactSource{SnapshotName: "llama-v1", Size: resource.MustParse("100Gi")},
+	}
+	indexer := controller.filterKubeInformerFactory.Core().V1().PersistentVolumeClaims().Informer().GetIndexer()
+	syncPVCs := func() []corev1.PersistentVolumeClaim {
+		assert.NoError(t, controller.syncArtifactPVCs(ctx, model))
+		pvcs, err := kubeClient.CoreV1().PersistentVolumeClaims(model.Namespace).List(ctx, metav1.ListOptions{})
+		assert.NoError(t, err)
+		// Feed the informer, as it would.
+		for i := range pvcs.Items {
+			assert.NoError(t, indexer.Update(&pvcs.Items[i]))
+		}
+		return pvcs.Items
+	}
+
+	pvcs := syncPVCs()
+	if assert.Len(t, pvcs, 1) {
+		assert.Equal(t, convert.GetArtifactPVCName(model, backend), pvcs[0].Name)
+		assert.Equal(t, "llama-v1", pvcs[0].Spec.DataSource.Name)
+	}
+	assert.Len(t, syncPVCs(), 1)
+
+	// The claim of the previous snapshot is replaced.
+	backend.Artifact.VolumeSnapshot.SnapshotName = "llama-v2"
+	pvcs = syncPVCs()
+	if assert.Len(t, pvcs, 1) {
+		assert.Equal(t, "llama-v2", pvcs[0].Spec.DataSource.Name)
+	}
+}
@@ -20,10 +20,11 @@ import (
 	"context"
 	"fmt"
 	"net/http"
-	"strings"
 	"time"
 
 	networkingv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -31,6 +32,8 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	appslisterv1 "k8s.io/client-go/listers/apps/v1"
+	batchlisterv1 "k8s.io/client-go/listers/batch/v1"
 	listerv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/workqueue"
@@ -43,7 +46,10 @@ import (
 	workloadLister "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
 	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/model-booster-controller/config"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/metrics"
 	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/runtime/lora"
 )
 
 const (
@@ -55,8 +61,8 @@ type ModelBoosterController struct {
 	kubeClient kubernetes.Interface
 	// client for custom resource
 	client clientset.Interface
-	// httpClient for HTTP requests to LoRA adapter APIs
-	httpClient *http.Client
+	// loraClient for HTTP requests to LoRA adapter APIs
+	loraClient *lora.Client
 
 	syncHandler                       func(ctx context.Context, miKey string) error
 	modelBoosterLister                workloadLister.ModelBoosterLister
@@ -73,16 +79,22 @@ type ModelBoosterController struct {
 	autoscalingPolicyBindingsInformer cache.SharedIndexInformer
 	podsLister                        listerv1.PodLister
 	podsInformer                      cache.SharedIndexInformer
+	nodesLister                       listerv1.NodeLister
+	daemonSetsLister                  appslisterv1.DaemonSetLister
+	jobsLister                        batchlisterv1.JobLister
+	pvcsLister                        listerv1.PersistentVolumeClaimLister
 	kubeInformerFactory               informers.SharedInformerFactory
+	filterKubeInformerFactory         informers.SharedInformerFactory
 	workQueue                         workqueue.TypedRateLimitingInterface[any]
-	// loraUpdateCache stores the previous model version for LoRA adapter comparison
-	// Key format: "namespace/name:generation" to avoid version conflicts
-	loraUpdateCache map[string]*workload.ModelBooster
+	// cacheKeyQueue holds the model cache keys whose node labels must be synced regardless of a ModelBooster,
+	// e.g. after the ModelBooster warming the cache is deleted.
+	cacheKeyQueue workqueue.TypedRateLimitingInterface[string]
 }
 
 func (mc *ModelBoosterController) Run(ctx context.Context, workers int) {
 	defer utilruntime.HandleCrash()
 	defer mc.workQueue.ShutDown()
+	defer mc.cacheKeyQueue.ShutDown()
 
 	// start informers
 	go mc.modelsInformer.RunWithContext(ctx)
@@ -93,8 +105,11 @@ func (mc *ModelBoosterController) Run(ctx context.Context, workers int) {
 	go mc.modelServersInformer.RunWithContext(ctx)
 	go mc.modelRoutesInformer.RunWithContext(ctx)
 
-	// start Kubernetes informer factory
-	go mc.kubeInformerFactory.Start(ctx.Done())
+	// start Kubernetes informer factories
+	mc.kubeInformerFactory.Start(ctx.Done())
+	mc.filterKubeInformerFactory.Start(ctx.Done())
+	mc.kubeInformerFactory.WaitForCacheSync(ctx.Done())
+	mc.filterKubeInformerFactory.WaitForCacheSync(ctx.Done())
 
 	cache.WaitForCacheSync(ctx.Done(),
 		mc.modelsInformer.HasSynced,
@@ -109,6 +124,7 @@ func (mc *ModelBoosterController) Run(ctx context.Context, workers int) {
 	klog.Info("start model controller")
 	for i := 0; i < workers; i++ {
 		go mc.worker(ctx)
+		go mc.cacheKeyWorker(ctx)
 	}
 	<-ctx.Done()
 	klog.Info("shut down model controller")
@@ -136,6 +152,28 @@ func (mc *ModelBoosterController) processNextWorkItem(ctx context.Context) bool
 	return true
 }
 
+func (mc *ModelBoosterController) cacheKeyWorker(ctx context.Context) {
+	for mc.processNextCacheKey(ctx) {
+	}
+}
+
+func (mc *ModelBoosterController) processNextCacheKey(ctx context.Context) bool {
+	cacheKey, quit := mc.cacheKeyQueue.Get()
+	if quit {
+		return false
+	}
+	defer mc.cacheKeyQueue.Done(cacheKey)
+
+	err := mc.syncModelCache(ctx, cacheKey)
+	if err == nil {
+		mc.cacheKeyQueue.Forget(cacheKey)
+		return true
+	}
+	utilruntime.HandleError(fmt.Errorf("sync model cache %q failed with %v", cacheKey, err))
+	mc.cacheKeyQueue.AddRateLimited(cacheKey)
+	return true
+}
+
 func (mc *ModelBoosterController) createModelBooster(obj any) {
 	model, ok := obj.(*workload.ModelBooster)
 	if !ok {
@@ -166,12 +204,8 @@ func (mc *ModelBoosterController) updateModelBooster(old any, new any) {
 		return
 	}
 
-	// When observed generation not equal to generation, reconcile model
-	if oldModel.Status.ObservedGeneration != newModel.Generation {
-		// Store the old model in cache with generation-specific key to avoid conflicts
-		cacheKey := fmt.Sprintf("%s/%s:%d", newModel.Namespace, newModel.Name, newModel.Generation)
-		mc.loraUpdateCache[cacheKey] = oldModel.DeepCopy()
-
+	// When observed generation not equal to generation, or a dry run is requested or ended, reconcile model
+	if oldModel.Status.ObservedGeneration != newModel.Generation || isDryRun(oldModel) != isDryRun(newModel) {
 		mc.enqueueModelBooster(newModel)
 	}
 }
@@ -183,6 +217,12 @@ func (mc *ModelBoosterController) deleteModelBooster(obj any) {
 		return
 	}
 	klog.V(4).Infof("Delete model: %s", klog.KObj(model))
+	metrics.DeleteModelBooster(model.Namespace, model.Name)
+	// The node labels of the model cache are removed once the cache warmers are gone, unless another
+	// ModelBooster still warms the same cache.
+	for _, status := range model.Status.CacheWarming {
+		mc.cacheKeyQueue.Add(status.CacheKey)
+	}
 }
 
 // reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -197,6 +237,10 @@ func (mc *ModelBoosterController) reconcile(ctx context.Context, namespaceAndNam
 		return client.IgnoreNotFound(err)
 	}
 	klog.InfoS("Start to process model", "namespace", namespace, "model name", model.Name, "model status", model.Status)
+	if isDryRun(model) {
+		return mc.syncDryRun(ctx, model)
+	}
+	meta.RemoveStatusCondition(&model.Status.Conditions, string(workload.ModelStatusConditionTypeDryRun))
 	if len(model.Status.Conditions) == 0 {
 		if err := mc.setModelInitCondition(ctx, model); err != nil {
 			return err
@@ -205,6 +249,10 @@ func (mc *ModelBoosterController) reconcile(ctx context.Context, namespaceAndNam
 	if err := mc.setModelProcessingCondition(ctx, model); err != nil {
 		return err
 	}
+	if err := mc.syncArtifactPVCs(ctx, model); err != nil {
+		mc.setModelFailedCondition(ctx, model, err)
+		return err
+	}
 	if err := mc.createOrUpdateModelServing(ctx, model); err != nil {
 		mc.setModelFailedCondition(ctx, model, err)
 		return err
@@ -221,6 +269,18 @@ func (mc *ModelBoosterController) reconcile(ctx context.Context, namespaceAndNam
 		mc.setModelFailedCondition(ctx, model, err)
 		return err
 	}
+	if err := mc.syncLoraAdapters(ctx, model); err != nil {
+		mc.setModelFailedCondition(ctx, model, err)
+		return err
+	}
+	if err := mc.syncModelArtifactCondition(ctx, model); err != nil {
+		mc.setModelFailedCondition(ctx, model, err)
+		return err
+	}
+	if err := mc.syncCacheWarming(ctx, model); err != nil {
+		mc.setModelFailedCondition(ctx, model, err)
+		return err
+	}
 	modelServingActive, err := mc.isModelServingActive(model)
 	if err != nil || !modelServingActive {
 		return err
@@ -238,9 +298,10 @@ func (mc *ModelBoosterController) isModelServingActive(model *workload.ModelBoos
 	if err != nil {
 		return false, err
 	}
-	// Ensure exactly one ModelServing exists for the single backend
-	if len(modelServings) != 1 {
-		klog.Infof("Number of ModelServings: %d, expected: %d", len(modelServings), 1)
+	// Ensure exactly one ModelServing exists for each backend
+	backendNum := len(utils.GetModelBackends(model))
+	if len(modelServings) != backendNum {
+		klog.Infof("Number of ModelServings: %d, expected: %d", len(modelServings), backendNum)
 		return false, fmt.Errorf("ModelServing number not equal to backend number")
 	}
 	// Check if all ModelServings are available
@@ -257,31 +318,16 @@ func (mc *ModelBoosterController) isModelServingActive(model *workload.ModelBoos
 // updateModelBoosterStatus updates model status.
 func (mc *ModelBoosterController) updateModelBoosterStatus(ctx context.Context, modelBooster *workload.ModelBooster) error {
 	modelBooster.Status.ObservedGeneration = modelBooster.Generation
-	if _, err := mc.client.WorkloadV1alpha1().ModelBoosters(modelBooster.Namespace).UpdateStatus(ctx, modelBooster, metav1.UpdateOptions{}); err != nil {
+	updated, err := mc.client.WorkloadV1alpha1().ModelBoosters(modelBooster.Namespace).UpdateStatus(ctx, modelBooster, metav1.UpdateOptions{})
+	if err != nil {
 		klog.Errorf("update modelBooster status failed: %v", err)
 		return err
 	}
-
-	// Clean up outdated cache entries for this modelBooster
-	mc.cleanupOutdatedLoraUpdateCache(modelBooster)
+	// Keep the resource version up to date, so that the status can be updated again in the same reconcile.
+	modelBooster.ResourceVersion = updated.ResourceVersion
 	return nil
 }
 
-// cleanupOutdatedLoraUpdateCache removes stale cache entries for the given modelBooster
-// Cache key format: "namespace/name:generation"
-func (mc *ModelBoosterController) cleanupOutdatedLoraUpdateCache(modelBooster *workload.ModelBooster) {
-	prefix := fmt.Sprintf("%s/%s:", modelBooster.Namespace, modelBooster.Name)
-	for key := range mc.loraUpdateCache {
-		if strings.HasPrefix(key, prefix) {
-			// Keep only the current generation entry, remove others
-			expectedKey := fmt.Sprintf("%s%d", prefix, modelBooster.Generation)
-			if key != expectedKey {
-				delete(mc.loraUpdateCache, key)
-			}
-		}
-	}
-}
-
 func NewModelBoosterController(kubeClient kubernetes.Interface, client clientset.Interface) *ModelBoosterController {
 	selector, err := labels.NewRequirement(utils.ManageBy, selection.Equals, []string{workload.GroupName})
 	if err != nil {
@@ -305,10 +351,21 @@ func NewModelBoosterController(kubeClient kubernetes.Interface, client clientset
 	autoscalingPoliciesInformer := filterInformerFactory.Workload().V1alpha1().AutoscalingPolicies()
 	autoscalingPolicyBindingsInformer := filterInformerFactory.Workload().V1alpha1().AutoscalingPolicyBindings()
 
-	// Initialize Kubernetes informer factory for pods
+	// Initialize Kubernetes informer factory for pods and nodes
 	kubeInformerFactory := informers.NewSharedInformerFactory(kubeClient, 0)
 	podsInformer := kubeInformerFactory.Core().V1().Pods().Informer()
 	podsLister := kubeInformerFactory.Core().V1().Pods().Lister()
+	nodesLister := kubeInformerFactory.Core().V1().Nodes().Lister()
+	// The cache warmers and the artifact claims are the only DaemonSets, Jobs and PersistentVolumeClaims managed by
+	// the controller.
+	filterKubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = selector.String()
+		}),
+	)
+	daemonSetsLister := filterKubeInformerFactory.Apps().V1().DaemonSets().Lister()
+	jobsLister := filterKubeInformerFactory.Batch().V1().Jobs().Lister()
+	pvcsLister := filterKubeInformerFactory.Core().V1().PersistentVolumeClaims().Lister()
 
 	// Create a shared HTTP client for LoRA adapter API calls
 	// This client will be reused across all HTTP requests, enabling connection pooling
@@ -324,7 +381,7 @@ func NewModelBoosterController(kubeClient kubernetes.Interface, client clientset
 	mc := &ModelBoosterController{
 		kubeClient:                        kubeClient,
 		client:                            client,
-		httpClient:                        httpClient,
+		loraClient:                        lora.NewClient(httpClient),
 		modelBoosterLister:                modelBoosterInformer.Lister(),
 		modelsInformer:                    modelBoosterInformer.Informer(),
 		modelServingLister:                modelServingInformer.Lister(),
@@ -339,11 +396,17 @@ func NewModelBoosterController(kubeClient kubernetes.Interface, client clientset
 		autoscalingPolicyBindingsInformer: autoscalingPolicyBindingsInformer.Informer(),
 		podsLister:                        podsLister,
 		podsInformer:                      podsInformer,
+		nodesLister:                       nodesLister,
+		daemonSetsLister:                  daemonSetsLister,
+		jobsLister:                        jobsLister,
+		pvcsLister:                        pvcsLister,
 		kubeInformerFactory:               kubeInformerFactory,
-		loraUpdateCache:                   make(map[string]*workload.ModelBooster),
+		filterKubeInformerFactory:         filterKubeInformerFactory,
 
 		workQueue: workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[any](),
 			workqueue.TypedRateLimitingQueueConfig[any]{}),
+		cacheKeyQueue: workqueue.NewTypedRateLimitingQueueWithConfig(workqueue.DefaultTypedControllerRateLimiter[string](),
+			workqueue.TypedRateLimitingQueueConfig[string]{}),
 	}
 	klog.Info("Set the ModelBooster event handler")
 	_, err = modelBoosterInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -377,6 +440,14 @@ func NewModelBoosterController(kubeClient kubernetes.Interface, client clientset
 		klog.Fatal("Unable to add model server event handler")
 		return nil
 	}
+	_, err = podsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: mc.updatePod,
+		DeleteFunc: mc.deletePod,
+	})
+	if err != nil {
+		klog.Fatal("Unable to add pod event handler")
+		return nil
+	}
 	mc.syncHandler = mc.reconcile
 	mc.loadConfigFromConfigMap()
 	return mc
@@ -427,6 +498,62 @@ func (mc *ModelBoosterController) triggerModel(old any, new any) {
 	}
 }
 
+// updatePod enqueues the ModelBooster when one of its serving pods makes progress on the model artifact, becomes
+// ready or restarts, so that the ModelArtifactReady condition is refreshed and the LoRA adapters are loaded on it.
+// The same applies to the cache warming pods, so that the warm nodes are labelled. When a serving pod is scheduled,
+// the model cache lookup is recorded.
+func (mc *ModelBoosterController) updatePod(old any, new any) {
+	newPod, ok := new.(*corev1.Pod)
+	if !ok {
+		klog.Error("failed to parse new Pod when updatePod")
+		return
+	}
+	oldPod, ok := old.(*corev1.Pod)
+	if !ok {
+		klog.Error("failed to parse old Pod when updatePod")
+		return
+	}
+	modelName, ok := newPod.Labels[utils.ModelNameLabelKey]
+	if !ok {
+		return
+	}
+	scheduled := oldPod.Spec.NodeName == "" && newPod.Spec.NodeName != ""
+	if !scheduled &&
+		icUtils.IsPodRunningAndReady(oldPod) == icUtils.IsPodRunningAndReady(newPod) &&
+		podRestartCount(oldPod) == podRestartCount(newPod) &&
+		equality.Semantic.DeepEqual(oldPod.Status.InitContainerStatuses, newPod.Status.InitContainerStatuses) {
+		return
+	}
+	model, err := mc.modelBoosterLister.ModelBoosters(newPod.Namespace).Get(modelName)
+	if err != nil || string(model.UID) != newPod.Labels[utils.OwnerUIDKey] {
+		return
+	}
+	if _, isCacheWarmer := newPod.Labels[utils.ModelCacheKeyLabelKey]; scheduled && !isCacheWarmer {
+		mc.recordModelCacheLookup(model, newPod)
+	}
+	mc.enqueueModelBooster(model)
+}
+
+// deletePod enqueues the model cache of a deleted cache warming pod, so that the label of its node is removed
+// even when the ModelBooster of the pod is gone.
+func (mc *ModelBoosterController) deletePod(obj any) {
+	pod, ok := obj.(*corev1.Pod)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			klog.Error("failed to parse Pod when deletePod")
+			return
+		}
+		if pod, ok = tombstone.Obj.(*corev1.Pod); !ok {
+			klog.Error("failed to parse Pod from tombstone when deletePod")
+			return
+		}
+	}
+	if cacheKey, ok := pod.Labels[utils.ModelCacheKeyLabelKey]; ok {
+		mc.cacheKeyQueue.Add(cacheKey)
+	}
+}
+
 // deleteModelServing is called when a ModelServing is deleted. It will reconcile the ModelBooster. Recreate model serving.
 func (mc *ModelBoosterController) deleteModelServing(obj any) {
 	modelServing, ok := obj.(*workload.ModelServing)
@@ -116,6 +116,62 @@ func TestReconcile(t *testing.T) {
 	}))
 }
 
+// TestReconcile_MultipleBackends checks that each backend gets its own ModelServing and ModelServer, and that the backends
+// are scaled together by one heterogeneous binding. Then the model is switched to a single backend.
+func TestReconcile_MultipleBackends(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kubeClient := fake.NewClientset()
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller := NewModelBoosterController(kubeClient, kthenaClient)
+	assert.NotNil(t, controller)
+	go controller.Run(ctx, 1)
+	model := loadYaml[workload.ModelBooster](t, "../convert/testdata/input/multi-backend-model.yaml")
+
+	// Case1: Create a model with two backends
+	_, err := kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Create(ctx, model, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.True(t, waitForCondition(func() bool {
+		binding, err := kthenaClient.WorkloadV1alpha1().AutoscalingPolicyBindings(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+		return err == nil && binding.Spec.HeterogeneousTarget != nil
+	}))
+	binding, err := kthenaClient.WorkloadV1alpha1().AutoscalingPolicyBindings(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, binding.Spec.HeterogeneousTarget.Params, 2)
+	assert.Equal(t, int32(100), binding.Spec.HeterogeneousTarget.Params[0].Cost)
+	assert.Equal(t, int32(60), binding.Spec.HeterogeneousTarget.Params[1].Cost)
+	modelServingList, err := kthenaClient.WorkloadV1alpha1().ModelServings(model.Namespace).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, modelServingList.Items, 2, "Expected 1 ModelServing per backend")
+	modelServers, err := kthenaClient.NetworkingV1alpha1().ModelServers(model.Namespace).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, modelServers.Items, 2, "Expected 1 ModelServer per backend")
+	route, err := kthenaClient.NetworkingV1alpha1().ModelRoutes(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, route.Spec.Rules[0].TargetModels, 2)
+
+	// Case2: Switch to a single backend, the ModelServing of the removed backend and the heterogeneous binding are deleted
+	model, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Get(ctx, model.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	model.Spec.Backend = &model.Spec.Backends[0]
+	model.Spec.Backends = nil
+	model.Generation += 1
+	_, err = kthenaClient.WorkloadV1alpha1().ModelBoosters(model.Namespace).Update(ctx, model, metav1.UpdateOptions{})
+	assert.NoError(t, err)
+	assert.True(t, waitForCondition(func() bool {
+		bindings, err := kthenaClient.WorkloadV1alpha1().AutoscalingPolicyBindings(model.Namespace).List(ctx, metav1.ListOptions{})
+		if err != nil || len(bindings.Items) != 1 {
+			return false
+		}
+		modelServings, err := kthenaClient.WorkloadV1alpha1().ModelServings(model.Namespace).List(ctx, metav1.ListOptions{})
+		return err == nil && len(modelServings.Items) == 1 && bindings.Items[0].Spec.HomogeneousTarget != nil
+	}))
+	policies, err := kthenaClient.WorkloadV1alpha1().AutoscalingPolicies(model.Namespace).List(ctx, metav1.ListOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, policies.Items, 1)
+	assert.Equal(t, model.Name+"-backend1", policies.Items[0].Name)
+}
+
 func TestReconcile_ReturnsError(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -143,7 +199,7 @@ func TestReconcile_ReturnsError(t *testing.T) {
 				Namespace: "default",
 			},
 			Spec: workload.ModelBoosterSpec{
-				Backend: workload.ModelBackend{
+				Backend: &workload.ModelBackend{
 					Name: "not-supported-backend-type",
 					Type: workload.ModelBackendTypeMindIEDisaggregated,
 				},
@@ -205,8 +261,6 @@ func TestTriggerModel(t *testing.T) {
 	assert.Equal(t, 0, controller.workQueue.Len())
 }
 
-// Removed tests for LoRA adapter changes as the current API defines a single backend without loraAdapters
-
 // loadYaml transfer yaml data into a struct of type T.
 // Used for test.
 func loadYaml[T any](t *testing.T, path string) *T {
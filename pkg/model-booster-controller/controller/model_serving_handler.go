@@ -34,41 +34,41 @@ func (mc *ModelBoosterController) createOrUpdateModelServing(ctx context.Context
 	if err != nil {
 		return err
 	}
-
-	modelServing, err := convert.BuildModelServing(model)
+	modelServings, err := convert.BuildModelServings(model)
 	if err != nil {
 		klog.Errorf("failed to build model serving for model %s: %v", model.Name, err)
 		return err
 	}
-
-	oldModelServing, err := mc.modelServingLister.ModelServings(modelServing.Namespace).Get(modelServing.Name)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			klog.V(4).Infof("Create ModelServing %s", modelServing.Name)
-			if _, err := mc.client.WorkloadV1alpha1().ModelServings(model.Namespace).Create(ctx, modelServing, metav1.CreateOptions{}); err != nil {
-				klog.Errorf("failed to create ModelServing %s: %v", klog.KObj(modelServing), err)
-				return err
+	modelServingsToKeep := make(map[string]struct{})
+	for _, modelServing := range modelServings {
+		modelServingsToKeep[modelServing.Name] = struct{}{}
+		oldModelServing, err := mc.modelServingLister.ModelServings(modelServing.Namespace).Get(modelServing.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				klog.V(4).Infof("Create ModelServing %s", modelServing.Name)
+				if _, err := mc.client.WorkloadV1alpha1().ModelServings(model.Namespace).Create(ctx, modelServing, metav1.CreateOptions{}); err != nil {
+					klog.Errorf("failed to create ModelServing %s: %v", klog.KObj(modelServing), err)
+					return err
+				}
+				continue
 			}
-		} else {
 			klog.Errorf("failed to get ModelServing %s: %v", klog.KObj(modelServing), err)
 			return err
 		}
-	} else {
-		if oldModelServing.Labels[utils.RevisionLabelKey] != modelServing.Labels[utils.RevisionLabelKey] {
-			modelServing.ResourceVersion = oldModelServing.ResourceVersion
-			if _, err := mc.client.WorkloadV1alpha1().ModelServings(model.Namespace).Update(ctx, modelServing, metav1.UpdateOptions{}); err != nil {
-				klog.Errorf("failed to update ModelServing %s: %v", klog.KObj(modelServing), err)
-				return err
-			}
-			klog.V(4).Infof("Updated ModelServing %s for model %s", modelServing.Name, model.Name)
-		} else {
+		if oldModelServing.Labels[utils.RevisionLabelKey] == modelServing.Labels[utils.RevisionLabelKey] {
 			klog.Infof("ModelServing %s of model %s does not need to update", modelServing.Name, model.Name)
+			continue
 		}
+		modelServing.ResourceVersion = oldModelServing.ResourceVersion
+		if _, err := mc.client.WorkloadV1alpha1().ModelServings(model.Namespace).Update(ctx, modelServing, metav1.UpdateOptions{}); err != nil {
+			klog.Errorf("failed to update ModelServing %s: %v", klog.KObj(modelServing), err)
+			return err
+		}
+		klog.V(4).Infof("Updated ModelServing %s for model %s", modelServing.Name, model.Name)
 	}
-
-	// Delete old ModelServings that are not the current one
+	// Delete old ModelServings whose backend no longer exists
 	for _, existingModelServing := range existingModelServings {
-		if existingModelServing.Name != modelServing.Name {
+		if _, ok := modelServingsToKeep[existingModelServing.Name]; !ok {
 			if err := mc.client.WorkloadV1alpha1().ModelServings(model.Namespace).Delete(ctx, existingModelServing.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
 				return err
 			}
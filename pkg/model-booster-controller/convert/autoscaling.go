@@ -24,6 +24,26 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// defaultCostExpansionRatePercent is the default of HeterogeneousTarget.CostExpansionRatePercent.
+const defaultCostExpansionRatePercent = 200
+
+// BuildAutoscaling builds the autoscaling policy and binding of the model, or returns nil if the model has no
+// autoscaling policy. A single backend gets its own binding, while multiple backends are scaled together by one
+// heterogeneous binding, weighted by the backend cost.
+func BuildAutoscaling(model *workload.ModelBooster) (*workload.AutoscalingPolicy, *workload.AutoscalingPolicyBinding) {
+	if model.Spec.AutoscalingPolicy == nil {
+		return nil, nil
+	}
+	backends := utils.GetModelBackends(model)
+	if len(backends) == 1 {
+		backend := backends[0]
+		return BuildAutoscalingPolicy(model.Spec.AutoscalingPolicy, model, backend.Name),
+			BuildScalingPolicyBinding(model, backend, utils.GetBackendResourceName(model.Name, backend.Name))
+	}
+	return BuildAutoscalingPolicy(model.Spec.AutoscalingPolicy, model, ""),
+		BuildHeterogeneousScalingPolicyBinding(model, backends, model.Name)
+}
+
 func BuildAutoscalingPolicy(autoscalingConfig *workload.AutoscalingPolicySpec, model *workload.ModelBooster, backendName string) *workload.AutoscalingPolicy {
 	return &workload.AutoscalingPolicy{
 		TypeMeta: metav1.TypeMeta{
@@ -45,21 +65,32 @@ func BuildAutoscalingPolicy(autoscalingConfig *workload.AutoscalingPolicySpec, m
 func BuildScalingPolicyBindingSpec(backend *workload.ModelBackend, name string) *workload.AutoscalingPolicyBindingSpec {
 	return &workload.AutoscalingPolicyBindingSpec{
 		HomogeneousTarget: &workload.HomogeneousTarget{
-			Target: workload.Target{
-				TargetRef: corev1.ObjectReference{
-					Name: name,
-					Kind: workload.ModelServingKind.Kind,
-				},
-				MetricEndpoint: workload.MetricEndpoint{
-					LabelSelector: &metav1.LabelSelector{
-						MatchLabels: map[string]string{
-							workload.RoleLabelKey: workload.ModelServingEntryPodLeaderLabel,
-						},
-					},
-				},
-			},
+			Target:      buildScalingTarget(name),
+			MinReplicas: backend.MinReplicas,
+			MaxReplicas: backend.MaxReplicas,
+		},
+		PolicyRef: corev1.LocalObjectReference{
+			Name: name,
+		},
+	}
+}
+
+// BuildHeterogeneousScalingPolicyBindingSpec builds a binding spec that scales the ModelServings of all the backends together.
+// The autoscaler prefers the backends with lower cost when splitting the replicas among them.
+func BuildHeterogeneousScalingPolicyBindingSpec(model *workload.ModelBooster, backends []*workload.ModelBackend, name string) *workload.AutoscalingPolicyBindingSpec {
+	params := make([]workload.HeterogeneousTargetParam, 0, len(backends))
+	for _, backend := range backends {
+		params = append(params, workload.HeterogeneousTargetParam{
+			Target:      buildScalingTarget(utils.GetBackendResourceName(model.Name, backend.Name)),
+			Cost:        backend.Cost,
 			MinReplicas: backend.MinReplicas,
 			MaxReplicas: backend.MaxReplicas,
+		})
+	}
+	return &workload.AutoscalingPolicyBindingSpec{
+		HeterogeneousTarget: &workload.HeterogeneousTarget{
+			Params:                   params,
+			CostExpansionRatePercent: defaultCostExpansionRatePercent,
 		},
 		PolicyRef: corev1.LocalObjectReference{
 			Name: name,
@@ -67,6 +98,23 @@ func BuildScalingPolicyBindingSpec(backend *workload.ModelBackend, name string)
 	}
 }
 
+// buildScalingTarget returns the scaling target of the ModelServing with the given name.
+func buildScalingTarget(name string) workload.Target {
+	return workload.Target{
+		TargetRef: corev1.ObjectReference{
+			Name: name,
+			Kind: workload.ModelServingKind.Kind,
+		},
+		MetricEndpoint: workload.MetricEndpoint{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					workload.RoleLabelKey: workload.ModelServingEntryPodLeaderLabel,
+				},
+			},
+		},
+	}
+}
+
 func BuildPolicyBindingMeta(spec *workload.AutoscalingPolicyBindingSpec, model *workload.ModelBooster, backendName string, name string) *metav1.ObjectMeta {
 	return &metav1.ObjectMeta{
 		Name:      name,
@@ -89,3 +137,16 @@ func BuildScalingPolicyBinding(model *workload.ModelBooster, backend *workload.M
 		Spec:       *spec,
 	}
 }
+
+// BuildHeterogeneousScalingPolicyBinding creates one binding for all the backends of the model.
+func BuildHeterogeneousScalingPolicyBinding(model *workload.ModelBooster, backends []*workload.ModelBackend, name string) *workload.AutoscalingPolicyBinding {
+	spec := BuildHeterogeneousScalingPolicyBindingSpec(model, backends, name)
+	return &workload.AutoscalingPolicyBinding{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: workload.AutoscalingPolicyBindingKind.GroupVersion().String(),
+			Kind:       workload.AutoscalingPolicyBindingKind.Kind,
+		},
+		ObjectMeta: *BuildPolicyBindingMeta(spec, model, "", name),
+		Spec:       *spec,
+	}
+}
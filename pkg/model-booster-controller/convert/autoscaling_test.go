@@ -42,12 +42,19 @@ func TestBuildScalingPolicyBinding(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			backend := tt.input.Spec.Backend
-			got := BuildScalingPolicyBinding(tt.input, &backend, utils.GetBackendResourceName(tt.input.Name, backend.Name))
+			got := BuildScalingPolicyBinding(tt.input, backend, utils.GetBackendResourceName(tt.input.Name, backend.Name))
 			assert.Equal(t, tt.expected, got)
 		})
 	}
 }
 
+func TestBuildHeterogeneousScalingPolicyBinding(t *testing.T) {
+	model := loadYaml[v1alpha1.ModelBooster](t, "testdata/input/multi-backend-model.yaml")
+	expected := loadYaml[v1alpha1.AutoscalingPolicyBinding](t, "testdata/expected/optimize-asp-binding.yaml")
+	got := BuildHeterogeneousScalingPolicyBinding(model, utils.GetModelBackends(model), model.Name)
+	assert.Equal(t, expected, got)
+}
+
 func TestBuildAutoscalingPolicy(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -59,6 +66,11 @@ func TestBuildAutoscalingPolicy(t *testing.T) {
 			input:    loadYaml[v1alpha1.ModelBooster](t, "testdata/input/model.yaml"),
 			expected: loadYaml[v1alpha1.AutoscalingPolicy](t, "testdata/expected/scaling-asp.yaml"),
 		},
+		{
+			name:     "multi-backend",
+			input:    loadYaml[v1alpha1.ModelBooster](t, "testdata/input/multi-backend-model.yaml"),
+			expected: loadYaml[v1alpha1.AutoscalingPolicy](t, "testdata/expected/optimize-asp.yaml"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
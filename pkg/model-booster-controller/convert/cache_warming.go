@@ -0,0 +1,174 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/plugins"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// GetModelCacheKey returns the key identifying the model files of the backend in its cache, or "" if the model
+// is not downloaded into a persistent cache.
+func GetModelCacheKey(backend *workload.ModelBackend) string {
+	source := getArtifactCacheSource(backend)
+	if source == "" || backend.CacheURI == "" {
+		return ""
+	}
+	return strings.TrimPrefix(GetMountPath(backend.CacheURI+"\n"+source), "/")
+}
+
+// GetModelCacheNodeLabelKey returns the label set on the nodes holding the model files of the backend.
+func GetModelCacheNodeLabelKey(backend *workload.ModelBackend) string {
+	return utils.ModelCacheNodeLabelPrefix + GetModelCacheKey(backend)
+}
+
+// GetCacheWarmerName returns the name of the DaemonSet or Job warming the cache of the backend.
+func GetCacheWarmerName(model *workload.ModelBooster, backend *workload.ModelBackend) string {
+	return utils.GetBackendResourceName(model.Name, backend.Name) + "-cache-warmer"
+}
+
+// IsNodeLocalCache returns true if the cache of the backend is on the nodes, so it is warmed on every node.
+func IsNodeLocalCache(backend *workload.ModelBackend) bool {
+	return strings.HasPrefix(backend.CacheURI, CacheURIPrefixHostPath)
+}
+
+// BuildCacheWarmerDaemonSet builds the DaemonSet downloading the model into the hostpath cache of every node of
+// the pool. Its pods become ready once the model is downloaded, and keep running so that the controller knows the
+// node is warm.
+func BuildCacheWarmerDaemonSet(model *workload.ModelBooster, backend *workload.ModelBackend) (*appsv1.DaemonSet, error) {
+	template, err := buildCacheWarmerPodTemplate(model, backend)
+	if err != nil {
+		return nil, err
+	}
+	template.Spec.NodeSelector = backend.CacheWarming.NodeSelector
+	template.Spec.Containers = []corev1.Container{{
+		Name:    "cache-warmer",
+		Image:   template.Spec.InitContainers[0].Image,
+		Command: []string{"sleep", "infinity"},
+	}}
+	return &appsv1.DaemonSet{
+		ObjectMeta: buildCacheWarmerMeta(model, backend, template),
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: getCacheWarmerSelector(model, backend)},
+			Template: *template,
+		},
+	}, nil
+}
+
+// BuildCacheWarmerJob builds the Job downloading the model once into the pvc cache shared by all the nodes.
+func BuildCacheWarmerJob(model *workload.ModelBooster, backend *workload.ModelBackend) (*batchv1.Job, error) {
+	template, err := buildCacheWarmerPodTemplate(model, backend)
+	if err != nil {
+		return nil, err
+	}
+	// The downloader runs as the main container, so the Job completes once the model is downloaded.
+	template.Spec.Containers = template.Spec.InitContainers
+	template.Spec.InitContainers = nil
+	template.Spec.RestartPolicy = corev1.RestartPolicyOnFailure
+	return &batchv1.Job{
+		ObjectMeta: buildCacheWarmerMeta(model, backend, template),
+		Spec: batchv1.JobSpec{
+			Template: *template,
+		},
+	}, nil
+}
+
+// BuildModelCacheAffinityPlugin builds the plugin making the serving pods of the backend prefer the warm nodes.
+// It returns nil if the cache of the backend is not warmed on the nodes.
+func BuildModelCacheAffinityPlugin(backend *workload.ModelBackend) (*workload.PluginSpec, error) {
+	if backend.CacheWarming == nil || !IsNodeLocalCache(backend) || GetModelCacheKey(backend) == "" {
+		return nil, nil
+	}
+	raw, err := json.Marshal(plugins.ModelCacheAffinityConfig{
+		NodeLabelKey: GetModelCacheNodeLabelKey(backend),
+		Weight:       backend.CacheWarming.AffinityWeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &workload.PluginSpec{
+		Name:   plugins.ModelCacheAffinityPluginName,
+		Type:   workload.PluginTypeBuiltIn,
+		Config: &apiextensionsv1.JSON{Raw: raw},
+	}, nil
+}
+
+func buildCacheWarmerPodTemplate(model *workload.ModelBooster, backend *workload.ModelBackend) (*corev1.PodTemplateSpec, error) {
+	if GetModelCacheKey(backend) == "" {
+		return nil, fmt.Errorf("cache warming of backend %s requires cacheURI and a model downloaded into the cache", backend.Name)
+	}
+	cacheVolume, err := buildCacheVolume(backend)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
+	}
+	volumes := []corev1.Volume{*cacheVolume}
+	for _, volume := range artifact.volumes {
+		volumes = append(volumes, *volume)
+	}
+	labels := getCacheWarmerSelector(model, backend)
+	labels[utils.ModelNameLabelKey] = model.Name
+	labels[utils.BackendNameLabelKey] = backend.Name
+	return &corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: labels},
+		Spec: corev1.PodSpec{
+			InitContainers:                artifact.initContainers,
+			Volumes:                       volumes,
+			Tolerations:                   backend.CacheWarming.Tolerations,
+			TerminationGracePeriodSeconds: ptr.To[int64](0),
+		},
+	}, nil
+}
+
+// buildCacheWarmerMeta builds the metadata of the cache warmer, labelled with the revision of its pod template.
+func buildCacheWarmerMeta(model *workload.ModelBooster, backend *workload.ModelBackend, template *corev1.PodTemplateSpec) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      GetCacheWarmerName(model, backend),
+		Namespace: model.Namespace,
+		Labels: map[string]string{
+			utils.ModelNameLabelKey:   model.Name,
+			utils.BackendNameLabelKey: backend.Name,
+			utils.ManageBy:            workload.GroupName,
+			utils.RevisionLabelKey:    icUtils.Revision(template),
+		},
+		OwnerReferences: []metav1.OwnerReference{utils.NewModelOwnerRef(model)},
+	}
+}
+
+// getCacheWarmerSelector returns the labels selecting the cache warming pods of the backend. The cache key changes
+// with the model revision, so the pods of a previous revision are not counted as warm.
+func getCacheWarmerSelector(model *workload.ModelBooster, backend *workload.ModelBackend) map[string]string {
+	return map[string]string{
+		utils.OwnerUIDKey:           string(model.UID),
+		utils.ModelCacheKeyLabelKey: GetModelCacheKey(backend),
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/plugins"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetModelCacheKey(t *testing.T) {
+	backend := &workload.ModelBackend{ModelURI: "s3://models/llama-2-7b", CacheURI: "hostpath:///models"}
+	key := GetModelCacheKey(backend)
+	assert.Len(t, key, 32)
+
+	other := backend.DeepCopy()
+	other.CacheURI = "pvc://models"
+	assert.NotEqual(t, key, GetModelCacheKey(other), "the same model in another cache has another key")
+
+	other = backend.DeepCopy()
+	other.ModelURI = ""
+	other.Artifact = &workload.ModelArtifact{ExistingPVC: &workload.ExistingPVCArtifactSource{ClaimName: "models"}}
+	assert.Empty(t, GetModelCacheKey(other), "mounted artifacts are not in the cache")
+
+	other = backend.DeepCopy()
+	other.CacheURI = ""
+	assert.Empty(t, GetModelCacheKey(other), "the emptyDir cache is not persistent")
+}
+
+func TestBuildCacheWarmerDaemonSet(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	backend.CacheWarming = &workload.CacheWarming{
+		NodeSelector: map[string]string{"node-pool": "ascend"},
+		Tolerations:  []corev1.Toleration{{Key: "ascend", Operator: corev1.TolerationOpExists}},
+	}
+	got, err := BuildCacheWarmerDaemonSet(model, backend)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-model-backend1-cache-warmer", got.Name)
+	cacheKey := GetModelCacheKey(backend)
+	assert.Equal(t, map[string]string{
+		utils.OwnerUIDKey:           string(model.UID),
+		utils.ModelCacheKeyLabelKey: cacheKey,
+	}, got.Spec.Selector.MatchLabels)
+	assert.Equal(t, model.Name, got.Spec.Template.Labels[utils.ModelNameLabelKey])
+
+	pod := got.Spec.Template.Spec
+	assert.Equal(t, backend.CacheWarming.NodeSelector, pod.NodeSelector)
+	assert.Equal(t, backend.CacheWarming.Tolerations, pod.Tolerations)
+	assert.Equal(t, "backend1-weights", pod.Volumes[0].Name)
+	// The warmer downloads the model to the same path as the serving pods.
+	assert.Len(t, pod.InitContainers, 1)
+	assert.Equal(t, []string{
+		"--source", backend.ModelURI,
+		"--output-dir", "/tmp/test" + GetMountPath(backend.ModelURI),
+	}, pod.InitContainers[0].Args)
+	assert.Equal(t, []string{"sleep", "infinity"}, pod.Containers[0].Command)
+
+	// Another model revision is warmed by another DaemonSet revision.
+	backend.ModelURI = "s3://aios_models/deepseek-ai/DeepSeek-V3.1"
+	updated, err := BuildCacheWarmerDaemonSet(model, backend)
+	assert.NoError(t, err)
+	assert.NotEqual(t, cacheKey, updated.Spec.Selector.MatchLabels[utils.ModelCacheKeyLabelKey])
+	assert.NotEqual(t, got.Labels[utils.RevisionLabelKey], updated.Labels[utils.RevisionLabelKey])
+}
+
+func TestBuildCacheWarmerJob(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	backend.CacheURI = "pvc://models"
+	backend.CacheWarming = &workload.CacheWarming{}
+	got, err := BuildCacheWarmerJob(model, backend)
+	assert.NoError(t, err)
+	pod := got.Spec.Template.Spec
+	assert.Empty(t, pod.InitContainers)
+	assert.Len(t, pod.Containers, 1)
+	assert.Equal(t, GetModelDownloaderName(model.Name), pod.Containers[0].Name)
+	assert.Equal(t, corev1.RestartPolicyOnFailure, pod.RestartPolicy)
+	assert.Equal(t, "/models", pod.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	backend.CacheURI = ""
+	_, err = BuildCacheWarmerJob(model, backend)
+	assert.ErrorContains(t, err, "cache warming of backend backend1 requires cacheURI")
+}
+
+func TestBuildModelServingWithCacheWarming(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	backend.CacheWarming = &workload.CacheWarming{AffinityWeight: 50}
+	got, err := BuildModelServing(model, backend)
+	assert.NoError(t, err)
+	assert.Len(t, got.Spec.Plugins, 1)
+	plugin := got.Spec.Plugins[0]
+	assert.Equal(t, plugins.ModelCacheAffinityPluginName, plugin.Name)
+	var config plugins.ModelCacheAffinityConfig
+	assert.NoError(t, json.Unmarshal(plugin.Config.Raw, &config))
+	assert.Equal(t, plugins.ModelCacheAffinityConfig{
+		NodeLabelKey: "workload.serving.volcano.sh/model-cache-" + GetModelCacheKey(backend),
+		Weight:       50,
+	}, config)
+
+	// A shared pvc cache is warm on all the nodes.
+	backend.CacheURI = "pvc://models"
+	got, err = BuildModelServing(model, backend)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Spec.Plugins)
+}
@@ -0,0 +1,240 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiffAction is the action the controller takes on an object to apply a ModelBooster.
+type DiffAction string
+
+const (
+	DiffActionCreate DiffAction = "Create"
+	DiffActionUpdate DiffAction = "Update"
+	DiffActionDelete DiffAction = "Delete"
+	DiffActionNone   DiffAction = "None"
+)
+
+// FieldChange is the change of a field of an object. Old is nil for an added field, and New for a removed one.
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old,omitempty"`
+	New  any    `json:"new,omitempty"`
+}
+
+// ObjectDiff is the change of an object derived from a ModelBooster.
+type ObjectDiff struct {
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	Action      DiffAction `json:"action"`
+	OldRevision string     `json:"oldRevision,omitempty"`
+	NewRevision string     `json:"newRevision,omitempty"`
+	// Rollout is true if the roles template of a ModelServing changes, which rolls out its serving groups. The
+	// replicas of the roles are ignored, like the ModelServing controller does.
+	Rollout bool          `json:"rollout,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// Diff compares the objects rendered from a ModelBooster with the existing objects of the ModelBooster.
+// Like the controller, an object is only updated if its revision label changes, and the existing objects
+// which are not rendered any more are deleted. The labels, annotations and spec of the updated objects are
+// compared field by field, so the fields defaulted by the API server are reported as removed.
+func Diff(desired, existing *RenderedObjects) ([]ObjectDiff, error) {
+	desiredObjects, existingObjects := desired.Objects(), existing.Objects()
+	var diffs []ObjectDiff
+	for _, gvk := range RenderedKinds {
+		existingByName := map[string]client.Object{}
+		for _, obj := range existingObjects[gvk] {
+			existingByName[obj.GetName()] = obj
+		}
+		for _, obj := range desiredObjects[gvk] {
+			diff := ObjectDiff{
+				Kind:        gvk.Kind,
+				Name:        obj.GetName(),
+				Action:      DiffActionCreate,
+				NewRevision: obj.GetLabels()[utils.RevisionLabelKey],
+			}
+			old, ok := existingByName[obj.GetName()]
+			delete(existingByName, obj.GetName())
+			if ok {
+				diff.OldRevision = old.GetLabels()[utils.RevisionLabelKey]
+				diff.Action = DiffActionNone
+				if diff.OldRevision != diff.NewRevision {
+					changes, err := diffFields(old, obj)
+					if err != nil {
+						return nil, err
+					}
+					diff.Action = DiffActionUpdate
+					diff.Rollout = gvk == workload.ModelServingKind && isRollout(old, obj)
+					diff.Changes = changes
+				}
+			}
+			diffs = append(diffs, diff)
+		}
+		var stale []string
+		for name := range existingByName {
+			stale = append(stale, name)
+		}
+		slices.Sort(stale)
+		for _, name := range stale {
+			diffs = append(diffs, ObjectDiff{
+				Kind:        gvk.Kind,
+				Name:        name,
+				Action:      DiffActionDelete,
+				OldRevision: existingByName[name].GetLabels()[utils.RevisionLabelKey],
+			})
+		}
+	}
+	return diffs, nil
+}
+
+// isRollout reports whether updating the ModelServing rolls it out, i.e. changes the revision of its roles template.
+func isRollout(oldObj, newObj client.Object) bool {
+	oldServing, ok := oldObj.(*workload.ModelServing)
+	if !ok {
+		return false
+	}
+	newServing, ok := newObj.(*workload.ModelServing)
+	if !ok {
+		return false
+	}
+	return templateRevision(oldServing) != templateRevision(newServing)
+}
+
+// templateRevision returns the revision the ModelServing controller rolls out for the ModelServing.
+func templateRevision(ms *workload.ModelServing) string {
+	return icUtils.Revision(icUtils.RemoveRoleReplicasForRevision(ms).Spec.Template.Roles)
+}
+
+// SummarizeDiff returns a one-line summary of the objects the diffs change.
+func SummarizeDiff(diffs []ObjectDiff) string {
+	var changes []string
+	for _, diff := range diffs {
+		if diff.Action == DiffActionNone {
+			continue
+		}
+		change := fmt.Sprintf("%s %s %s", diff.Action, diff.Kind, diff.Name)
+		if diff.Rollout {
+			change += " (rollout)"
+		}
+		changes = append(changes, change)
+	}
+	if len(changes) == 0 {
+		return "No changes"
+	}
+	return strings.Join(changes, "; ")
+}
+
+// diffFields returns the changed labels, annotations and spec fields between the old and new object.
+func diffFields(oldObj, newObj client.Object) ([]FieldChange, error) {
+	oldFields, err := comparedFields(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := comparedFields(newObj)
+	if err != nil {
+		return nil, err
+	}
+	var changes []FieldChange
+	diffValues("", oldFields, newFields, &changes)
+	return changes, nil
+}
+
+func comparedFields(obj client.Object) (map[string]any, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %v", obj.GetName(), err)
+	}
+	metadata := map[string]any{}
+	if labels := obj.GetLabels(); len(labels) > 0 {
+		metadata["labels"] = stringMap(labels)
+	}
+	if annotations := obj.GetAnnotations(); len(annotations) > 0 {
+		metadata["annotations"] = stringMap(annotations)
+	}
+	return map[string]any{"metadata": metadata, "spec": content["spec"]}, nil
+}
+
+func stringMap(m map[string]string) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// diffValues appends the changes between the old and new value at the path. Maps are compared by key
+// and lists by index.
+func diffValues(path string, oldValue, newValue any, changes *[]FieldChange) {
+	oldMap, oldIsMap := oldValue.(map[string]any)
+	newMap, newIsMap := newValue.(map[string]any)
+	if oldIsMap && newIsMap {
+		keys := make([]string, 0, len(oldMap)+len(newMap))
+		for k := range oldMap {
+			keys = append(keys, k)
+		}
+		for k := range newMap {
+			if _, ok := oldMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			diffValues(fieldPath(path, k), oldMap[k], newMap[k], changes)
+		}
+		return
+	}
+	oldList, oldIsList := oldValue.([]any)
+	newList, newIsList := newValue.([]any)
+	if oldIsList && newIsList {
+		for i := 0; i < max(len(oldList), len(newList)); i++ {
+			var oldItem, newItem any
+			if i < len(oldList) {
+				oldItem = oldList[i]
+			}
+			if i < len(newList) {
+				newItem = newList[i]
+			}
+			diffValues(fmt.Sprintf("%s[%d]", path, i), oldItem, newItem, changes)
+		}
+		return
+	}
+	if !reflect.DeepEqual(oldValue, newValue) {
+		*changes = append(*changes, FieldChange{Path: path, Old: oldValue, New: newValue})
+	}
+}
+
+// fieldPath appends the key to the path, quoting the keys which contain dots, such as label keys.
+func fieldPath(path, key string) string {
+	if strings.ContainsAny(key, "./") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
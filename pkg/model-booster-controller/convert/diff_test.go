@@ -0,0 +1,97 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+)
+
+func TestDiff(t *testing.T) {
+	model := loadYaml[v1alpha1.ModelBooster](t, "testdata/input/model.yaml")
+	existing, err := Render(model)
+	require.NoError(t, err)
+	// A stale ModelServing of a removed backend.
+	stale := existing.ModelServings[0].DeepCopy()
+	stale.Name = "test-model-removed"
+	existing.ModelServings = append(existing.ModelServings, stale)
+
+	// Nothing but the stale ModelServing changes.
+	desired, err := Render(model)
+	require.NoError(t, err)
+	diffs, err := Diff(desired, existing)
+	require.NoError(t, err)
+	assert.Equal(t, "Delete ModelServing test-model-removed", SummarizeDiff(diffs))
+
+	// Cost only changes the autoscaling binding, not the revision of the ModelServing.
+	changed := model.DeepCopy()
+	changed.Spec.Backend.Cost = 7
+	desired, err = Render(changed)
+	require.NoError(t, err)
+	diffs, err = Diff(desired, &RenderedObjects{ModelServings: existing.ModelServings[:1], AutoscalingPolicyBindings: existing.AutoscalingPolicyBindings})
+	require.NoError(t, err)
+	assert.Equal(t, "Create ModelServer test-model-backend1; Create ModelRoute test-model; Create AutoscalingPolicy test-model-backend1",
+		SummarizeDiff(diffs))
+
+	// The max replicas and the image change the revision of the ModelServing, which rolls it out.
+	changed = model.DeepCopy()
+	changed.Spec.Backend.MaxReplicas = 9
+	changed.Spec.Backend.Workers[0].Image = "vllm/vllm-openai:v2"
+	desired, err = Render(changed)
+	require.NoError(t, err)
+	diffs, err = Diff(desired, existing)
+	require.NoError(t, err)
+	assert.Equal(t, "Update ModelServing test-model-backend1 (rollout); Delete ModelServing test-model-removed; "+
+		"Update AutoscalingPolicyBinding test-model-backend1", SummarizeDiff(diffs))
+	serving := diffs[0]
+	assert.Equal(t, DiffActionUpdate, serving.Action)
+	assert.True(t, serving.Rollout)
+	assert.NotEqual(t, serving.OldRevision, serving.NewRevision)
+	assert.Contains(t, serving.Changes, FieldChange{
+		Path: `metadata.labels["workload.serving.volcano.sh/revision"]`,
+		Old:  serving.OldRevision,
+		New:  serving.NewRevision,
+	})
+	var imageChanged bool
+	for _, change := range serving.Changes {
+		if change.New == "vllm/vllm-openai:v2" {
+			imageChanged = true
+		}
+	}
+	assert.True(t, imageChanged, "changes: %v", serving.Changes)
+	binding := diffs[len(diffs)-1]
+	assert.Contains(t, binding.Changes, FieldChange{Path: "spec.homogeneousTarget.maxReplicas", Old: int64(model.Spec.Backend.MaxReplicas), New: int64(9)})
+
+	// An update which keeps the roles template, e.g. of the replicas, doesn't roll out the ModelServing.
+	desired, err = Render(model)
+	require.NoError(t, err)
+	scaled := existing.ModelServings[0].DeepCopy()
+	scaled.Labels[utils.RevisionLabelKey] = "scaled"
+	scaled.Spec.Replicas = ptr.To(*scaled.Spec.Replicas + 2)
+	scaled.Spec.Template.Roles[0].Replicas = ptr.To(*scaled.Spec.Template.Roles[0].Replicas + 1)
+	diffs, err = Diff(desired, &RenderedObjects{ModelServings: []*v1alpha1.ModelServing{scaled}})
+	require.NoError(t, err)
+	assert.Equal(t, DiffActionUpdate, diffs[0].Action)
+	assert.False(t, diffs[0].Rollout)
+}
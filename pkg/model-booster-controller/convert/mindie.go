@@ -0,0 +1,317 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/config"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/env"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MindIEEnginePort is the port MindIE serves requests on.
+	MindIEEnginePort = 1025
+	// MindIEManagementPort is the port MindIE serves the health probes on.
+	MindIEManagementPort = 1026
+	// MindIEMetricsPort is the port MindIE serves the Prometheus metrics on.
+	MindIEMetricsPort = 1027
+	// MindIEServiceHome is the install path of MindIE Service in the MindIE images.
+	MindIEServiceHome = "/usr/local/Ascend/mindie/latest/mindie-service"
+	// MindIEConfigOverridesEnv carries the settings merged into the MindIE config.json when the server starts.
+	MindIEConfigOverridesEnv = "MINDIE_CONFIG_OVERRIDES"
+)
+
+// mindieMergeConfigScript merges the JSON in $MINDIE_CONFIG_OVERRIDES into the given config.json.
+// Objects are merged recursively and the lists of objects, like ModelConfig, are merged by index.
+const mindieMergeConfigScript = `import json, os, sys
+def merge(dst, src):
+    for k, v in src.items():
+        if isinstance(v, dict) and isinstance(dst.get(k), dict):
+            merge(dst[k], v)
+        elif isinstance(v, list) and isinstance(dst.get(k), list) and all(isinstance(i, dict) for i in v):
+            for i, item in enumerate(v):
+                if i < len(dst[k]) and isinstance(dst[k][i], dict):
+                    merge(dst[k][i], item)
+                else:
+                    dst[k].append(item)
+        else:
+            dst[k] = v
+path = sys.argv[1]
+with open(path) as f:
+    conf = json.load(f)
+merge(conf, json.loads(os.environ["` + MindIEConfigOverridesEnv + `"]))
+with open(path, "w") as f:
+    json.dump(conf, f, indent=4)
+`
+
+// mindieConfigField is the location of a setting in the MindIE config.json.
+type mindieConfigField struct {
+	section []string
+	key     string
+}
+
+// MindIEConfigFields maps the vLLM engine args, which are accepted in ModelWorker.Config for all backends,
+// to the settings of the MindIE config.json. The sections ServerConfig, BackendConfig and LogConfig
+// of the config.json can also be given in ModelWorker.Config as they are.
+var MindIEConfigFields = map[string]mindieConfigField{
+	"served-model-name":      {section: []string{"BackendConfig", "ModelDeployConfig", "ModelConfig"}, key: "modelName"},
+	"trust-remote-code":      {section: []string{"BackendConfig", "ModelDeployConfig", "ModelConfig"}, key: "trustRemoteCode"},
+	"max-model-len":          {section: []string{"BackendConfig", "ModelDeployConfig"}, key: "maxSeqLen"},
+	"max-input-len":          {section: []string{"BackendConfig", "ModelDeployConfig"}, key: "maxInputTokenLen"},
+	"max-num-seqs":           {section: []string{"BackendConfig", "ScheduleConfig"}, key: "maxBatchSize"},
+	"max-num-batched-tokens": {section: []string{"BackendConfig", "ScheduleConfig"}, key: "maxPrefillTokens"},
+	"max-iter-times":         {section: []string{"BackendConfig", "ScheduleConfig"}, key: "maxIterTimes"},
+	"block-size":             {section: []string{"BackendConfig", "ScheduleConfig"}, key: "cacheBlockSize"},
+}
+
+var mindieConfigSections = []string{"ServerConfig", "BackendConfig", "LogConfig"}
+
+// buildMindIEModelServing handles MindIE backend creation.
+func buildMindIEModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
+	workersMap := mapWorkers(backend.Workers)
+	server := workersMap[workload.ModelWorkerTypeServer]
+	if server == nil {
+		return nil, fmt.Errorf("server worker not found in backend: %s", backend.Name)
+	}
+	if server.Pods > 1 {
+		return nil, fmt.Errorf("multi-node MindIE server is not supported, pods of backend %s must be 1", backend.Name)
+	}
+	cacheVolume, err := buildCacheVolume(backend)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
+	}
+	modelDownloadPath := artifact.path
+	overrides, err := buildMindIEConfigOverrides(model.Name, server, modelDownloadPath)
+	if err != nil {
+		return nil, err
+	}
+	engineEnv := buildEngineEnvVars(backend,
+		corev1.EnvVar{Name: "MIES_SERVICE_MONITOR_MODE", Value: "1"},
+		corev1.EnvVar{Name: MindIEConfigOverridesEnv, Value: overrides},
+	)
+	data := map[string]interface{}{
+		"MODEL_SERVING_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Name:      utils.GetBackendResourceName(model.Name, backend.Name),
+			Namespace: model.Namespace,
+			Labels:    utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: workload.GroupVersion.String(),
+					Kind:       workload.ModelKind.Kind,
+					Name:       model.Name,
+					UID:        model.UID,
+				},
+			},
+		},
+		"MODEL_NAME":       model.Name,
+		"BACKEND_REPLICAS": backend.MinReplicas, // todo: backend replicas
+		"ENGINE_ENV":       engineEnv,
+		"SERVER_REPLICAS":  server.Replicas,
+		"SERVER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+		},
+		"VOLUMES": append([]*corev1.Volume{
+			cacheVolume,
+			{
+				Name: dshm,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumMemory,
+					},
+				},
+			},
+		}, artifact.volumes...),
+		"VOLUME_MOUNTS": append([]corev1.VolumeMount{{
+			Name:      cacheVolume.Name,
+			MountPath: GetCachePath(backend.CacheURI),
+		}, {
+			Name:      dshm,
+			MountPath: "/dev/shm",
+		}}, artifact.volumeMounts...),
+		"INIT_CONTAINERS":             artifact.initContainers,
+		"MODEL_DOWNLOAD_ENVFROM":      backend.EnvFrom,
+		"MODEL_SERVING_RUNTIME_IMAGE": config.Config.RuntimeImage(),
+		"MODEL_SERVING_RUNTIME_PORT":  env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, 8100),
+		// MindIE serves the metrics on a dedicated port
+		"MODEL_SERVING_RUNTIME_URL":          env.GetEnvValueOrDefault[string](backend, env.RuntimeUrl, fmt.Sprintf("http://localhost:%d", MindIEMetricsPort)),
+		"MODEL_SERVING_RUNTIME_METRICS_PATH": env.GetEnvValueOrDefault[string](backend, env.RuntimeMetricsPath, "/metrics"),
+		"MODEL_SERVING_RUNTIME_ENGINE":       strings.ToLower(string(backend.Type)),
+		"MODEL_SERVING_RUNTIME_POD":          "$(POD_NAME).$(NAMESPACE)",
+		"ENGINE_SERVER_RESOURCES":            server.Resources,
+		"ENGINE_SERVER_IMAGE":                server.Image,
+		"ENGINE_SERVER_COMMAND":              buildMindIECommands(),
+		"SCHEDULER_NAME":                     backend.SchedulerName,
+	}
+	return loadModelServingTemplate(MindIETemplatePath, &data)
+}
+
+// buildMindIECommands constructs the command of a MindIE server, which applies the config overrides before starting.
+func buildMindIECommands() []string {
+	configPath := MindIEServiceHome + "/conf/config.json"
+	return []string{"bash", "-c", strings.Join([]string{
+		"source /usr/local/Ascend/ascend-toolkit/set_env.sh",
+		"source /usr/local/Ascend/nnal/atb/set_env.sh",
+		"source /usr/local/Ascend/mindie/set_env.sh",
+		fmt.Sprintf("python3 -c '%s' %s", mindieMergeConfigScript, configPath),
+		fmt.Sprintf("cd %s && ./bin/mindieservice_daemon", MindIEServiceHome),
+	}, " && ")}
+}
+
+// buildMindIEConfigOverrides builds the settings of the MindIE config.json from the worker config.
+func buildMindIEConfigOverrides(modelName string, worker *workload.ModelWorker, modelDownloadPath string) (string, error) {
+	worldSize := utils.GetDeviceNum(worker)
+	if worldSize < 1 {
+		worldSize = 1
+	}
+	modelConfig := map[string]interface{}{
+		"modelName":       modelName,
+		"modelWeightPath": modelDownloadPath,
+	}
+	overrides := map[string]interface{}{
+		"ServerConfig": map[string]interface{}{
+			"ipAddress":               "0.0.0.0",
+			"managementIpAddress":     "0.0.0.0",
+			"allowAllZeroIpListening": true,
+			"httpsEnabled":            false,
+			"port":                    MindIEEnginePort,
+			"managementPort":          MindIEManagementPort,
+			"metricsPort":             MindIEMetricsPort,
+		},
+		"BackendConfig": map[string]interface{}{
+			"ModelDeployConfig": map[string]interface{}{
+				"ModelConfig": []interface{}{modelConfig},
+			},
+			"ScheduleConfig": map[string]interface{}{},
+		},
+	}
+
+	configMap, err := parseMindIEWorkerConfig(&worker.Config)
+	if err != nil {
+		return "", err
+	}
+	keys := make([]string, 0, len(configMap))
+	for key := range configMap {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := configMap[key]
+		switch key {
+		case "tensor-parallel-size":
+			size, ok := value.(float64)
+			if !ok || size < 1 {
+				return "", fmt.Errorf("invalid MindIE config %s: %v", key, value)
+			}
+			worldSize = int64(size)
+			continue
+		case "trust-remote-code":
+			// vLLM flags without value are given as an empty string.
+			if s, ok := value.(string); ok && s == "" {
+				value = true
+			}
+		}
+		if field, ok := MindIEConfigFields[key]; ok {
+			setMindIEConfig(overrides, field, value)
+			continue
+		}
+		if !isMindIEConfigSection(key) {
+			return "", fmt.Errorf("not support MindIE config: %s", key)
+		}
+		section, ok := value.(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("invalid MindIE config %s: must be an object", key)
+		}
+		mergeMindIEConfig(overrides, key, section)
+	}
+
+	modelConfig["worldSize"] = worldSize
+	deviceIDs := make([]int64, 0, worldSize)
+	for i := int64(0); i < worldSize; i++ {
+		deviceIDs = append(deviceIDs, i)
+	}
+	overrides["BackendConfig"].(map[string]interface{})["npuDeviceIds"] = [][]int64{deviceIDs}
+
+	result, err := json.Marshal(overrides)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal MindIE config: %w", err)
+	}
+	return string(result), nil
+}
+
+func parseMindIEWorkerConfig(workerConfig *apiextensionsv1.JSON) (map[string]interface{}, error) {
+	configMap := map[string]interface{}{}
+	if workerConfig == nil || workerConfig.Raw == nil {
+		return configMap, nil
+	}
+	if err := json.Unmarshal(workerConfig.Raw, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return configMap, nil
+}
+
+func isMindIEConfigSection(key string) bool {
+	for _, section := range mindieConfigSections {
+		if section == key {
+			return true
+		}
+	}
+	return false
+}
+
+// setMindIEConfig sets a setting of the config.json. The first item of a list section, like ModelConfig, is used.
+func setMindIEConfig(overrides map[string]interface{}, field mindieConfigField, value interface{}) {
+	current := overrides
+	for _, name := range field.section {
+		switch next := current[name].(type) {
+		case map[string]interface{}:
+			current = next
+		case []interface{}:
+			current = next[0].(map[string]interface{})
+		default:
+			section := map[string]interface{}{}
+			current[name] = section
+			current = section
+		}
+	}
+	current[field.key] = value
+}
+
+// mergeMindIEConfig merges a section of the config.json given in the worker config into the overrides.
+func mergeMindIEConfig(dst map[string]interface{}, key string, value interface{}) {
+	src, ok := value.(map[string]interface{})
+	existing, isMap := dst[key].(map[string]interface{})
+	if !ok || !isMap {
+		dst[key] = value
+		return
+	}
+	for k, v := range src {
+		mergeMindIEConfig(existing, k, v)
+	}
+}
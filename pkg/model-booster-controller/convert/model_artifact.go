@@ -0,0 +1,274 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"fmt"
+	"path"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/config"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/env"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// ArtifactMountPath is where the OCI, existing PVC and VolumeSnapshot model artifacts are mounted in the serving pods.
+	ArtifactMountPath = "/model-artifact"
+	// sha256ManifestMountPath is where the SHA256 manifest of the model artifact is mounted in the init container.
+	sha256ManifestMountPath = "/etc/kthena/sha256-manifest"
+	sha256ManifestFile      = "SHA256SUMS"
+	// volumeSnapshotAPIGroup is the API group of the VolumeSnapshots restored into the artifact claims.
+	volumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+)
+
+// modelArtifact describes how the model files of a backend are provided to the serving pods.
+type modelArtifact struct {
+	// path is the directory of the model files in the engine containers.
+	path string
+	// volumes and volumeMounts are added to the serving pods besides the cache volume.
+	volumes      []*corev1.Volume
+	volumeMounts []corev1.VolumeMount
+	// initContainers download or verify the model files before the engine starts.
+	initContainers []corev1.Container
+}
+
+// GetModelDownloaderName returns the name of the init container downloading the model files.
+func GetModelDownloaderName(modelName string) string {
+	return modelName + "-model-downloader"
+}
+
+// GetModelVerifierName returns the name of the init container verifying the mounted model files.
+func GetModelVerifierName(modelName string) string {
+	return modelName + "-model-verifier"
+}
+
+// GetArtifactPVCName returns the name of the PersistentVolumeClaim restored from the VolumeSnapshot artifact of the
+// backend. The name changes with the snapshot source, since the source of a claim is immutable.
+func GetArtifactPVCName(model *workload.ModelBooster, backend *workload.ModelBackend) string {
+	return utils.GetBackendResourceName(model.Name, backend.Name) + "-artifact-" + icUtils.Revision(backend.Artifact.VolumeSnapshot)
+}
+
+// BuildArtifactPVC builds the PersistentVolumeClaim restoring the VolumeSnapshot artifact of the backend.
+func BuildArtifactPVC(model *workload.ModelBooster, backend *workload.ModelBackend) *corev1.PersistentVolumeClaim {
+	source := backend.Artifact.VolumeSnapshot
+	accessModes := source.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}
+	}
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetArtifactPVCName(model, backend),
+			Namespace: model.Namespace,
+			Labels: map[string]string{
+				utils.ModelNameLabelKey:   model.Name,
+				utils.BackendNameLabelKey: backend.Name,
+				utils.ManageBy:            workload.GroupName,
+			},
+			OwnerReferences: []metav1.OwnerReference{utils.NewModelOwnerRef(model)},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			StorageClassName: source.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: source.Size},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(volumeSnapshotAPIGroup),
+				Kind:     "VolumeSnapshot",
+				Name:     source.SnapshotName,
+			},
+		},
+	}
+}
+
+// buildModelArtifact builds the volumes and init containers providing the model files of the backend.
+// Backends with ModelURI download the model into the cache volume. Backends with Artifact download it into the
+// cache volume as well for Hugging Face and S3 sources, or mount it directly for OCI, existing PVC and VolumeSnapshot
+// sources.
+func buildModelArtifact(model *workload.ModelBooster, backend *workload.ModelBackend, cacheVolume *corev1.Volume) (*modelArtifact, error) {
+	artifact := backend.Artifact
+	if artifact == nil {
+		modelDownloadPath := GetCachePath(backend.CacheURI) + GetMountPath(backend.ModelURI)
+		return &modelArtifact{
+			path:           modelDownloadPath,
+			initContainers: buildModelDownloaderInitContainers(model, backend, cacheVolume, modelDownloadPath),
+		}, nil
+	}
+
+	result := &modelArtifact{}
+	var initContainer *corev1.Container
+	switch {
+	case artifact.HuggingFace != nil:
+		result.path = GetCachePath(backend.CacheURI) + GetMountPath(getArtifactCacheSource(backend))
+		initContainer = buildArtifactDownloader(model, backend, cacheVolume, "hf://"+artifact.HuggingFace.Repository, result.path)
+		initContainer.Env = append(initContainer.Env, buildHuggingFaceEnvVars(artifact.HuggingFace)...)
+	case artifact.S3 != nil:
+		result.path = GetCachePath(backend.CacheURI) + GetMountPath(artifact.S3.URI)
+		initContainer = buildArtifactDownloader(model, backend, cacheVolume, artifact.S3.URI, result.path)
+		if artifact.S3.Endpoint != "" {
+			initContainer.Env = append(initContainer.Env, corev1.EnvVar{Name: env.Endpoint, Value: artifact.S3.Endpoint})
+		}
+		if artifact.S3.CredentialsSecretRef != nil {
+			initContainer.EnvFrom = append(initContainer.EnvFrom, corev1.EnvFromSource{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: *artifact.S3.CredentialsSecretRef},
+			})
+		}
+	case artifact.OCI != nil:
+		result.path = path.Join(ArtifactMountPath, artifact.OCI.Path)
+		result.volumes = append(result.volumes, &corev1.Volume{
+			Name: getArtifactVolumeName(backend.Name),
+			VolumeSource: corev1.VolumeSource{
+				Image: &corev1.ImageVolumeSource{
+					Reference:  artifact.OCI.Image,
+					PullPolicy: artifact.OCI.PullPolicy,
+				},
+			},
+		})
+	case artifact.ExistingPVC != nil:
+		result.path = path.Join(ArtifactMountPath, artifact.ExistingPVC.Path)
+		result.volumes = append(result.volumes, &corev1.Volume{
+			Name: getArtifactVolumeName(backend.Name),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: artifact.ExistingPVC.ClaimName,
+					ReadOnly:  true,
+				},
+			},
+		})
+	case artifact.VolumeSnapshot != nil:
+		result.path = path.Join(ArtifactMountPath, artifact.VolumeSnapshot.Path)
+		result.volumes = append(result.volumes, &corev1.Volume{
+			Name: getArtifactVolumeName(backend.Name),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: GetArtifactPVCName(model, backend),
+					ReadOnly:  true,
+				},
+			},
+		})
+	default:
+		return nil, fmt.Errorf("no source set in the artifact of backend: %s", backend.Name)
+	}
+	if len(result.volumes) > 0 {
+		result.volumeMounts = append(result.volumeMounts, corev1.VolumeMount{
+			Name:      getArtifactVolumeName(backend.Name),
+			MountPath: ArtifactMountPath,
+			ReadOnly:  true,
+		})
+	}
+
+	if artifact.Verification != nil {
+		if initContainer == nil {
+			// The mounted artifacts are not downloaded, only verified.
+			initContainer = &corev1.Container{
+				Name:         GetModelVerifierName(model.Name),
+				Image:        config.Config.DownloaderImage(),
+				Args:         []string{"--verify-only", "--output-dir", result.path},
+				VolumeMounts: result.volumeMounts,
+			}
+		}
+		manifestVolume := &corev1.Volume{
+			Name: getSHA256ManifestVolumeName(backend.Name),
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: artifact.Verification.SHA256Manifest.LocalObjectReference,
+					Items:                []corev1.KeyToPath{{Key: artifact.Verification.SHA256Manifest.Key, Path: sha256ManifestFile}},
+				},
+			},
+		}
+		result.volumes = append(result.volumes, manifestVolume)
+		initContainer.Args = append(initContainer.Args, "--sha256-manifest", path.Join(sha256ManifestMountPath, sha256ManifestFile))
+		initContainer.VolumeMounts = append(initContainer.VolumeMounts, corev1.VolumeMount{
+			Name:      manifestVolume.Name,
+			MountPath: sha256ManifestMountPath,
+			ReadOnly:  true,
+		})
+	}
+	if initContainer != nil {
+		result.initContainers = []corev1.Container{*initContainer}
+	}
+	return result, nil
+}
+
+// getArtifactCacheSource returns the source identifying the model files of the backend in the cache, or "" if the
+// model files are mounted directly.
+func getArtifactCacheSource(backend *workload.ModelBackend) string {
+	artifact := backend.Artifact
+	switch {
+	case artifact == nil:
+		return backend.ModelURI
+	case artifact.HuggingFace != nil:
+		// Each revision is downloaded into its own directory, so that a pinned revision is never mixed with another.
+		source := "hf://" + artifact.HuggingFace.Repository
+		if artifact.HuggingFace.Revision != "" {
+			source += "@" + artifact.HuggingFace.Revision
+		}
+		return source
+	case artifact.S3 != nil:
+		return artifact.S3.URI
+	}
+	return ""
+}
+
+// buildArtifactDownloader builds the init container downloading the artifact from source into the cache volume.
+func buildArtifactDownloader(model *workload.ModelBooster, backend *workload.ModelBackend, cacheVolume *corev1.Volume,
+	source string, modelDownloadPath string) *corev1.Container {
+	return &corev1.Container{
+		Name:  GetModelDownloaderName(model.Name),
+		Image: config.Config.DownloaderImage(),
+		Args: []string{
+			"--source", source,
+			"--output-dir", modelDownloadPath,
+		},
+		// The typed fields take precedence over the variables from EnvFrom.
+		EnvFrom: append([]corev1.EnvFromSource{}, backend.EnvFrom...),
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      cacheVolume.Name,
+			MountPath: GetCachePath(backend.CacheURI),
+		}},
+	}
+}
+
+func buildHuggingFaceEnvVars(source *workload.HuggingFaceArtifactSource) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	if source.Revision != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: env.HfRevision, Value: source.Revision})
+	}
+	if source.Endpoint != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: env.HfEndpoint, Value: source.Endpoint})
+	}
+	if source.TokenSecretRef != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:      env.HfAuthToken,
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: source.TokenSecretRef},
+		})
+	}
+	return envVars
+}
+
+func getArtifactVolumeName(backendName string) string {
+	return backendName + "-artifact"
+}
+
+func getSHA256ManifestVolumeName(backendName string) string {
+	return backendName + "-sha256-manifest"
+}
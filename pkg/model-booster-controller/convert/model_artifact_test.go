@@ -0,0 +1,217 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+func TestBuildModelArtifact(t *testing.T) {
+	manifest := &workload.ArtifactVerification{
+		SHA256Manifest: corev1.ConfigMapKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "llama-checksums"},
+			Key:                  "sha256sums",
+		},
+	}
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	cacheVolume, err := buildCacheVolume(backend)
+	assert.NoError(t, err)
+	cachePath := GetCachePath(backend.CacheURI)
+
+	t.Run("model URI", func(t *testing.T) {
+		got, err := buildModelArtifact(model, backend, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, cachePath+GetMountPath(backend.ModelURI), got.path)
+		assert.Empty(t, got.volumes)
+		assert.Len(t, got.initContainers, 1)
+		assert.Equal(t, GetModelDownloaderName(model.Name), got.initContainers[0].Name)
+	})
+
+	t.Run("Hugging Face with pinned revision", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{
+			HuggingFace: &workload.HuggingFaceArtifactSource{
+				Repository: "meta-llama/Llama-2-7b",
+				Revision:   "01c7f73d771dfac7d292323805ebc428287df4f9",
+				TokenSecretRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "hf-secret"},
+					Key:                  "token",
+				},
+			},
+			Verification: manifest,
+		}
+		got, err := buildModelArtifact(model, b, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, cachePath+GetMountPath("hf://meta-llama/Llama-2-7b@01c7f73d771dfac7d292323805ebc428287df4f9"), got.path)
+		assert.Len(t, got.initContainers, 1)
+		downloader := got.initContainers[0]
+		assert.Equal(t, []string{
+			"--source", "hf://meta-llama/Llama-2-7b",
+			"--output-dir", got.path,
+			"--sha256-manifest", "/etc/kthena/sha256-manifest/SHA256SUMS",
+		}, downloader.Args)
+		assert.Equal(t, []corev1.EnvVar{
+			{Name: "HF_REVISION", Value: "01c7f73d771dfac7d292323805ebc428287df4f9"},
+			{Name: "HF_AUTH_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: b.Artifact.HuggingFace.TokenSecretRef}},
+		}, downloader.Env)
+		assert.Equal(t, []corev1.VolumeMount{
+			{Name: cacheVolume.Name, MountPath: cachePath},
+			{Name: "backend1-sha256-manifest", MountPath: "/etc/kthena/sha256-manifest", ReadOnly: true},
+		}, downloader.VolumeMounts)
+		assert.Equal(t, []*corev1.Volume{{
+			Name: "backend1-sha256-manifest",
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "llama-checksums"},
+				Items:                []corev1.KeyToPath{{Key: "sha256sums", Path: "SHA256SUMS"}},
+			}},
+		}}, got.volumes)
+		// Only the downloader needs the manifest.
+		assert.Empty(t, got.volumeMounts)
+	})
+
+	t.Run("S3 with credentials", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{
+			S3: &workload.S3ArtifactSource{
+				URI:                  "s3://models/llama-2-7b",
+				Endpoint:             "https://obs.test.com",
+				CredentialsSecretRef: &corev1.LocalObjectReference{Name: "s3-secret"},
+			},
+		}
+		got, err := buildModelArtifact(model, b, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, cachePath+GetMountPath("s3://models/llama-2-7b"), got.path)
+		downloader := got.initContainers[0]
+		assert.Equal(t, []corev1.EnvVar{{Name: "ENDPOINT", Value: "https://obs.test.com"}}, downloader.Env)
+		assert.Equal(t, corev1.EnvFromSource{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "s3-secret"}},
+		}, downloader.EnvFrom[len(downloader.EnvFrom)-1])
+		// The credentials are not leaked into the EnvFrom of the backend.
+		assert.Len(t, b.EnvFrom, len(downloader.EnvFrom)-1)
+	})
+
+	t.Run("OCI with verification", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{
+			OCI: &workload.OCIArtifactSource{
+				Image:      "registry.example.com/models/llama-2-7b@sha256:0123",
+				PullPolicy: corev1.PullIfNotPresent,
+				Path:       "/weights",
+			},
+			Verification: manifest,
+		}
+		got, err := buildModelArtifact(model, b, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, "/model-artifact/weights", got.path)
+		assert.Equal(t, &corev1.ImageVolumeSource{
+			Reference:  "registry.example.com/models/llama-2-7b@sha256:0123",
+			PullPolicy: corev1.PullIfNotPresent,
+		}, got.volumes[0].Image)
+		assert.Equal(t, []corev1.VolumeMount{{Name: "backend1-artifact", MountPath: "/model-artifact", ReadOnly: true}}, got.volumeMounts)
+		assert.Len(t, got.initContainers, 1)
+		verifier := got.initContainers[0]
+		assert.Equal(t, GetModelVerifierName(model.Name), verifier.Name)
+		assert.Equal(t, []string{
+			"--verify-only",
+			"--output-dir", "/model-artifact/weights",
+			"--sha256-manifest", "/etc/kthena/sha256-manifest/SHA256SUMS",
+		}, verifier.Args)
+		assert.Len(t, verifier.VolumeMounts, 2)
+	})
+
+	t.Run("existing PVC without verification", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{
+			ExistingPVC: &workload.ExistingPVCArtifactSource{ClaimName: "llama-models"},
+		}
+		got, err := buildModelArtifact(model, b, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, "/model-artifact", got.path)
+		assert.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "llama-models", ReadOnly: true},
+			got.volumes[0].PersistentVolumeClaim)
+		assert.Empty(t, got.initContainers)
+	})
+
+	t.Run("VolumeSnapshot", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{
+			VolumeSnapshot: &workload.VolumeSnapshotArtifactSource{SnapshotName: "llama-snapshot", Size: resource.MustParse("100Gi"), Path: "llama"},
+		}
+		got, err := buildModelArtifact(model, b, cacheVolume)
+		assert.NoError(t, err)
+		assert.Equal(t, "/model-artifact/llama", got.path)
+		assert.Equal(t, &corev1.PersistentVolumeClaimVolumeSource{ClaimName: GetArtifactPVCName(model, b), ReadOnly: true},
+			got.volumes[0].PersistentVolumeClaim)
+
+		pvc := BuildArtifactPVC(model, b)
+		assert.Equal(t, GetArtifactPVCName(model, b), pvc.Name)
+		assert.Equal(t, []corev1.PersistentVolumeAccessMode{corev1.ReadOnlyMany}, pvc.Spec.AccessModes)
+		assert.Equal(t, resource.MustParse("100Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+		assert.Equal(t, &corev1.TypedLocalObjectReference{
+			APIGroup: ptr.To("snapshot.storage.k8s.io"),
+			Kind:     "VolumeSnapshot",
+			Name:     "llama-snapshot",
+		}, pvc.Spec.DataSource)
+
+		// A new snapshot is restored into a new claim.
+		b.Artifact.VolumeSnapshot.SnapshotName = "llama-snapshot-v2"
+		assert.NotEqual(t, pvc.Name, GetArtifactPVCName(model, b))
+	})
+
+	t.Run("no source", func(t *testing.T) {
+		b := backend.DeepCopy()
+		b.ModelURI = ""
+		b.Artifact = &workload.ModelArtifact{Verification: manifest}
+		_, err := buildModelArtifact(model, b, cacheVolume)
+		assert.ErrorContains(t, err, "no source set in the artifact")
+	})
+}
+
+func TestBuildModelServingWithArtifact(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend
+	backend.ModelURI = ""
+	backend.Artifact = &workload.ModelArtifact{
+		ExistingPVC: &workload.ExistingPVCArtifactSource{ClaimName: "llama-models", Path: "llama-2-7b"},
+	}
+	got, err := BuildModelServing(model, backend)
+	assert.NoError(t, err)
+	pod := got.Spec.Template.Roles[0].EntryTemplate.Spec
+	assert.Empty(t, pod.InitContainers)
+	assert.Equal(t, "backend1-artifact", pod.Volumes[len(pod.Volumes)-1].Name)
+	var engine *corev1.Container
+	for i := range pod.Containers {
+		if pod.Containers[i].Image == backend.Workers[0].Image {
+			engine = &pod.Containers[i]
+		}
+	}
+	assert.NotNil(t, engine)
+	assert.Contains(t, engine.VolumeMounts, corev1.VolumeMount{Name: "backend1-artifact", MountPath: "/model-artifact", ReadOnly: true})
+	assert.Contains(t, engine.Command[len(engine.Command)-1], "--model /model-artifact/llama-2-7b ")
+}
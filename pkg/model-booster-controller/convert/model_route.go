@@ -17,11 +17,14 @@ limitations under the License.
 package convert
 
 import (
+	"slices"
+
 	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
 	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
 	icUtils "github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func BuildModelRoute(model *workload.ModelBooster) *networking.ModelRoute {
@@ -45,33 +48,84 @@ func BuildModelRoute(model *workload.ModelBooster) *networking.ModelRoute {
 			},
 		},
 		Spec: networking.ModelRouteSpec{
-			ModelName: model.Name,
-			Rules:     rules,
+			ModelName:    model.Name,
+			LoraAdapters: getLoraAdapterNames(model),
+			Rules:        rules,
 		},
 	}
 	route.Labels = utils.GetModelControllerLabels(model, "", icUtils.Revision(route.Spec))
 	return route
 }
 
+// getLoraAdapterNames returns the sorted names of the LoRA adapters declared on the backends of the model.
+func getLoraAdapterNames(model *workload.ModelBooster) []string {
+	var names []string
+	for _, backend := range utils.GetModelBackends(model) {
+		for _, adapter := range backend.LoraAdapters {
+			if !slices.Contains(names, adapter.Name) {
+				names = append(names, adapter.Name)
+			}
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
 // getRules generates routing rules based on the model's backend.
+// A LoRA adapter declared on only some of the backends gets a rule routing its requests to these backends.
 func getRules(model *workload.ModelBooster) []*networking.Rule {
-	targetModels := getTargetModels(model)
+	backends := utils.GetModelBackends(model)
 
 	var rules []*networking.Rule
+	for _, name := range getLoraAdapterNames(model) {
+		var adapterBackends []*workload.ModelBackend
+		for _, backend := range backends {
+			if slices.ContainsFunc(backend.LoraAdapters, func(adapter workload.LoraAdapter) bool { return adapter.Name == name }) {
+				adapterBackends = append(adapterBackends, backend)
+			}
+		}
+		if len(adapterBackends) == len(backends) {
+			continue
+		}
+		modelMatch := &networking.ModelMatch{}
+		if model.Spec.ModelMatch != nil {
+			modelMatch = model.Spec.ModelMatch.DeepCopy()
+		}
+		modelMatch.Body = &networking.BodyMatch{Model: ptr.To(name)}
+		rules = append(rules, &networking.Rule{
+			Name:         loraRouteRuleNamePrefix + name,
+			ModelMatch:   modelMatch,
+			TargetModels: getTargetModels(model, adapterBackends, len(backends) > 1),
+		})
+	}
 	rules = append(rules, &networking.Rule{
 		Name:         modelRouteRuleName,
 		ModelMatch:   model.Spec.ModelMatch,
-		TargetModels: targetModels,
+		TargetModels: getTargetModels(model, backends, len(backends) > 1),
 	})
 	return rules
 }
 
-// getTargetModels returns the target models.
-func getTargetModels(model *workload.ModelBooster) []*networking.TargetModel {
+// getTargetModels returns the target models, one for each backend.
+// With multiple backends, requests are split across them by the backend weight.
+// The weights are dropped if they are all zero, so that requests are split evenly.
+func getTargetModels(model *workload.ModelBooster, backends []*workload.ModelBackend, weighted bool) []*networking.TargetModel {
 	var targetModels []*networking.TargetModel
-	backend := model.Spec.Backend
-	targetModels = append(targetModels, &networking.TargetModel{
-		ModelServerName: utils.GetBackendResourceName(model.Name, backend.Name),
-	})
+	var totalWeight uint32
+	for _, backend := range backends {
+		targetModel := &networking.TargetModel{
+			ModelServerName: utils.GetBackendResourceName(model.Name, backend.Name),
+		}
+		if weighted && backend.Weight != nil {
+			targetModel.Weight = ptr.To(*backend.Weight)
+			totalWeight += *backend.Weight
+		}
+		targetModels = append(targetModels, targetModel)
+	}
+	if totalWeight == 0 {
+		for _, targetModel := range targetModels {
+			targetModel.Weight = nil
+		}
+	}
 	return targetModels
 }
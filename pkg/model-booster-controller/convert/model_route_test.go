@@ -22,6 +22,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
 	registry "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"k8s.io/utils/ptr"
 )
 
 func TestBuildModelRoute(t *testing.T) {
@@ -35,6 +36,11 @@ func TestBuildModelRoute(t *testing.T) {
 			input:    loadYaml[registry.ModelBooster](t, "testdata/input/model.yaml"),
 			expected: loadYaml[networking.ModelRoute](t, "testdata/expected/model-route.yaml"),
 		},
+		{
+			name:     "weighted multiple backends",
+			input:    loadYaml[registry.ModelBooster](t, "testdata/input/multi-backend-model.yaml"),
+			expected: loadYaml[networking.ModelRoute](t, "testdata/expected/multi-backend-model-route.yaml"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -43,3 +49,29 @@ func TestBuildModelRoute(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildModelRouteLoraAdapters(t *testing.T) {
+	model := loadYaml[registry.ModelBooster](t, "testdata/input/multi-backend-model.yaml")
+	model.Spec.Backends[0].LoraAdapters = []registry.LoraAdapter{
+		{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"},
+		{Name: "chat-lora", ArtifactURL: "hf://org/chat-lora"},
+	}
+	model.Spec.Backends[1].LoraAdapters = []registry.LoraAdapter{
+		{Name: "chat-lora", ArtifactURL: "hf://org/chat-lora"},
+	}
+
+	got := BuildModelRoute(model)
+	assert.Equal(t, []string{"chat-lora", "sql-lora"}, got.Spec.LoraAdapters)
+	// The adapter declared on one backend is only routed to it, the other requests are split by the weights.
+	assert.Len(t, got.Spec.Rules, 2)
+	assert.Equal(t, "lora-sql-lora", got.Spec.Rules[0].Name)
+	assert.Equal(t, "sql-lora", *got.Spec.Rules[0].ModelMatch.Body.Model)
+	assert.Equal(t, []*networking.TargetModel{{ModelServerName: "multi-backend-model-backend1", Weight: ptr.To[uint32](70)}}, got.Spec.Rules[0].TargetModels)
+	assert.Equal(t, "default", got.Spec.Rules[1].Name)
+	assert.Len(t, got.Spec.Rules[1].TargetModels, 2)
+
+	// Zero weights of the backends of an adapter are dropped to split its requests evenly.
+	model.Spec.Backends[0].Weight = ptr.To[uint32](0)
+	got = BuildModelRoute(model)
+	assert.Nil(t, got.Spec.Rules[0].TargetModels[0].Weight)
+}
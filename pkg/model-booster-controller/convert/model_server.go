@@ -38,16 +38,43 @@ var VLLMKvConnectorType = map[string]networking.KVConnectorType{
 // Each model backend will create one model server.
 func BuildModelServer(model *workload.ModelBooster) ([]*networking.ModelServer, error) {
 	var modelServers []*networking.ModelServer
-	var backend = model.Spec.Backend
+	for _, backend := range utils.GetModelBackends(model) {
+		modelServer, err := buildModelServer(model, *backend)
+		if err != nil {
+			return nil, err
+		}
+		modelServers = append(modelServers, modelServer)
+	}
+	return modelServers, nil
+}
+
+// buildModelServer creates the ModelServer for the given backend of the model.
+func buildModelServer(model *workload.ModelBooster, backend workload.ModelBackend) (*networking.ModelServer, error) {
 	var inferenceEngine networking.InferenceEngine
+	var workloadPort int32
 	switch backend.Type {
 	case workload.ModelBackendTypeVLLM, workload.ModelBackendTypeVLLMDisaggregated:
 		inferenceEngine = networking.VLLM
+		workloadPort = 8000 // todo: get port from config
+	case workload.ModelBackendTypeSGLang:
+		inferenceEngine = networking.SGLang
+		workloadPort = SGLangEnginePort
+	case workload.ModelBackendTypeMindIE:
+		inferenceEngine = networking.MindIE
+		workloadPort = MindIEEnginePort
 	default:
-		return nil, fmt.Errorf("not support %s backend yet, please use vLLM backend", backend.Type)
+		return nil, fmt.Errorf("not support %s backend yet, please use vLLM, SGLang or MindIE backend", backend.Type)
 	}
 	servedModelName := getServedModelName(model, backend)
 	pdGroup := getPdGroup(backend)
+	matchLabels := map[string]string{
+		utils.OwnerUIDKey:         string(model.UID),
+		utils.BackendNameLabelKey: backend.Name,
+	}
+	if backend.Type == workload.ModelBackendTypeSGLang && isDisaggregated(&backend) {
+		// Requests are sent to the SGLang router, which pairs the prefill and decode workers itself.
+		matchLabels[workload.RoleLabelKey] = SGLangRouterRoleName
+	}
 	kvConnector, err := getKvConnectorSpec(backend)
 	if err != nil {
 		return nil, err
@@ -68,13 +95,11 @@ func BuildModelServer(model *workload.ModelBooster) ([]*networking.ModelServer,
 			Model:           &servedModelName,
 			InferenceEngine: inferenceEngine,
 			WorkloadSelector: &networking.WorkloadSelector{
-				MatchLabels: map[string]string{
-					utils.OwnerUIDKey: string(model.UID),
-				},
-				PDGroup: pdGroup,
+				MatchLabels: matchLabels,
+				PDGroup:     pdGroup,
 			},
 			WorkloadPort: networking.WorkloadPort{
-				Port: 8000, // todo: get port from config
+				Port: workloadPort,
 			},
 			TrafficPolicy: &networking.TrafficPolicy{
 				Retry: &networking.Retry{
@@ -86,12 +111,14 @@ func BuildModelServer(model *workload.ModelBooster) ([]*networking.ModelServer,
 		},
 	}
 	modelServer.Labels = utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(modelServer.Spec))
-	modelServers = append(modelServers, &modelServer)
-
-	return modelServers, nil
+	return &modelServer, nil
 }
 
 func getKvConnectorSpec(backend workload.ModelBackend) (*networking.KVConnectorSpec, error) {
+	if backend.Type != workload.ModelBackendTypeVLLMDisaggregated {
+		// The KV cache transfer is handled by the engine itself, e.g. by the SGLang router.
+		return nil, nil
+	}
 	var connectorType *networking.KVConnectorType
 	foundConfig := false
 	for _, worker := range backend.Workers {
@@ -42,6 +42,29 @@ func TestBuildModelServer(t *testing.T) {
 			input:    loadYaml[registry.ModelBooster](t, "testdata/input/pd-disaggregated-model-npu.yaml"),
 			expected: []*networking.ModelServer{loadYaml[networking.ModelServer](t, "testdata/expected/pd-model-server.yaml")},
 		},
+		{
+			name:     "SGLang backend",
+			input:    loadYaml[registry.ModelBooster](t, "testdata/input/sglang-model.yaml"),
+			expected: []*networking.ModelServer{loadYaml[networking.ModelServer](t, "testdata/expected/sglang-model-server.yaml")},
+		},
+		{
+			name:     "SGLang PD disaggregation routed by the SGLang router",
+			input:    loadYaml[registry.ModelBooster](t, "testdata/input/sglang-pd-model.yaml"),
+			expected: []*networking.ModelServer{loadYaml[networking.ModelServer](t, "testdata/expected/sglang-pd-model-server.yaml")},
+		},
+		{
+			name:     "MindIE backend",
+			input:    loadYaml[registry.ModelBooster](t, "testdata/input/mindie-model.yaml"),
+			expected: []*networking.ModelServer{loadYaml[networking.ModelServer](t, "testdata/expected/mindie-model-server.yaml")},
+		},
+		{
+			name:  "multiple backends",
+			input: loadYaml[registry.ModelBooster](t, "testdata/input/multi-backend-model.yaml"),
+			expected: []*networking.ModelServer{
+				loadYaml[networking.ModelServer](t, "testdata/expected/multi-backend-model-server-backend1.yaml"),
+				loadYaml[networking.ModelServer](t, "testdata/expected/multi-backend-model-server-backend2.yaml"),
+			},
+		},
 		{
 			name: "invalid backend type",
 			input: &registry.ModelBooster{
@@ -50,7 +73,7 @@ func TestBuildModelServer(t *testing.T) {
 					Namespace: "default",
 				},
 				Spec: registry.ModelBoosterSpec{
-					Backend: registry.ModelBackend{
+					Backend: &registry.ModelBackend{
 						Name: "invalid",
 						Type: "InvalidType",
 					},
@@ -39,13 +39,17 @@ import (
 )
 
 const (
-	CacheURIPrefixPVC              = "pvc://"
-	CacheURIPrefixHostPath         = "hostpath://"
-	URIPrefixSeparator             = "://"
-	VllmTemplatePath               = "templates/vllm.yaml"
-	VllmDisaggregatedTemplatePath  = "templates/vllm-pd.yaml"
-	VllmMultiNodeServingScriptPath = "examples/online_serving/multi-node-serving.sh"
-	modelRouteRuleName             = "default"
+	CacheURIPrefixPVC               = "pvc://"
+	CacheURIPrefixHostPath          = "hostpath://"
+	URIPrefixSeparator              = "://"
+	VllmTemplatePath                = "templates/vllm.yaml"
+	VllmDisaggregatedTemplatePath   = "templates/vllm-pd.yaml"
+	VllmMultiNodeServingScriptPath  = "examples/online_serving/multi-node-serving.sh"
+	SGLangTemplatePath              = "templates/sglang.yaml"
+	SGLangDisaggregatedTemplatePath = "templates/sglang-pd.yaml"
+	MindIETemplatePath              = "templates/mindie.yaml"
+	modelRouteRuleName              = "default"
+	loraRouteRuleNamePrefix         = "lora-"
 	// /dev/shm is too small to support NCCL, we need a larger memory volume
 	dshm = "dshm"
 )
@@ -53,28 +57,54 @@ const (
 //go:embed templates/*
 var templateFS embed.FS
 
-// BuildModelServing creates a ModelServing object based on the model's backend.
-func BuildModelServing(model *workload.ModelBooster) (*workload.ModelServing, error) {
-	backend := &model.Spec.Backend
+// BuildModelServings creates one ModelServing object for each backend of the model.
+func BuildModelServings(model *workload.ModelBooster) ([]*workload.ModelServing, error) {
+	var servings []*workload.ModelServing
+	for _, backend := range utils.GetModelBackends(model) {
+		serving, err := BuildModelServing(model, backend)
+		if err != nil {
+			return nil, err
+		}
+		servings = append(servings, serving)
+	}
+	return servings, nil
+}
+
+// BuildModelServing creates a ModelServing object based on the given backend of the model.
+func BuildModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
 	var serving *workload.ModelServing
 	var err error
 	switch backend.Type {
 	case workload.ModelBackendTypeVLLM:
-		serving, err = buildVllmModelServing(model)
+		serving, err = buildVllmModelServing(model, backend)
 	case workload.ModelBackendTypeVLLMDisaggregated:
-		serving, err = buildVllmDisaggregatedModelServing(model)
+		serving, err = buildVllmDisaggregatedModelServing(model, backend)
+	case workload.ModelBackendTypeSGLang:
+		if isDisaggregated(backend) {
+			serving, err = buildSGLangDisaggregatedModelServing(model, backend)
+		} else {
+			serving, err = buildSGLangModelServing(model, backend)
+		}
+	case workload.ModelBackendTypeMindIE:
+		serving, err = buildMindIEModelServing(model, backend)
 	default:
 		return nil, fmt.Errorf("not support model backend type: %s", backend.Type)
 	}
 	if err != nil {
 		return nil, err
 	}
+	cachePlugin, err := BuildModelCacheAffinityPlugin(backend)
+	if err != nil {
+		return nil, err
+	}
+	if cachePlugin != nil {
+		serving.Spec.Plugins = append(serving.Spec.Plugins, *cachePlugin)
+	}
 	return serving, nil
 }
 
 // buildVllmDisaggregatedModelServing handles VLLM disaggregated backend creation.
-func buildVllmDisaggregatedModelServing(model *workload.ModelBooster) (*workload.ModelServing, error) {
-	backend := &model.Spec.Backend
+func buildVllmDisaggregatedModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
 	workersMap := mapWorkers(backend.Workers)
 	if workersMap[workload.ModelWorkerTypePrefill] == nil {
 		return nil, fmt.Errorf("prefill worker not found in backend")
@@ -86,38 +116,11 @@ func buildVllmDisaggregatedModelServing(model *workload.ModelBooster) (*workload
 	if err != nil {
 		return nil, err
 	}
-	modelDownloadPath := GetCachePath(backend.CacheURI) + GetMountPath(backend.ModelURI)
-
-	// Build an initial container list including model downloader container
-	var envVars []corev1.EnvVar
-	endpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.Endpoint, []corev1.EnvVar{
-		{Name: env.Endpoint},
-	})
-	if len(endpointEnvVars) > 0 && endpointEnvVars[0].Value != "" {
-		envVars = append(envVars, endpointEnvVars[0])
-	}
-	hfEndpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.HfEndpoint, []corev1.EnvVar{
-		{Name: env.HfEndpoint},
-	})
-	if len(hfEndpointEnvVars) > 0 && hfEndpointEnvVars[0].Value != "" {
-		envVars = append(envVars, hfEndpointEnvVars[0])
-	}
-	initContainers := []corev1.Container{
-		{
-			Name:  model.Name + "-model-downloader",
-			Image: config.Config.DownloaderImage(),
-			Args: []string{
-				"--source", backend.ModelURI,
-				"--output-dir", modelDownloadPath,
-			},
-			Env:     envVars,
-			EnvFrom: backend.EnvFrom,
-			VolumeMounts: []corev1.VolumeMount{{
-				Name:      cacheVolume.Name,
-				MountPath: GetCachePath(backend.CacheURI),
-			}},
-		},
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
 	}
+	modelDownloadPath := artifact.path
 
 	var preFillCommand []string
 	var decodeCommand []string
@@ -153,7 +156,7 @@ func buildVllmDisaggregatedModelServing(model *workload.ModelBooster) (*workload
 		"MODEL_SERVING_TEMPLATE_METADATA": &metav1.ObjectMeta{
 			Name:      utils.GetBackendResourceName(model.Name, backend.Name),
 			Namespace: model.Namespace,
-			Labels:    utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(backend)),
+			Labels:    utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: workload.GroupVersion.String(),
@@ -163,21 +166,23 @@ func buildVllmDisaggregatedModelServing(model *workload.ModelBooster) (*workload
 				},
 			},
 		},
-		"VOLUME_MOUNTS": []corev1.VolumeMount{{
+		"VOLUME_MOUNTS": append([]corev1.VolumeMount{{
 			Name:      cacheVolume.Name,
 			MountPath: GetCachePath(backend.CacheURI),
-		}},
-		"VOLUMES": []*corev1.Volume{
+		}}, artifact.volumeMounts...),
+		"RUNTIME_VOLUME_MOUNTS": buildRuntimeVolumeMounts(backend, cacheVolume),
+		"RUNTIME_ENVFROM":       buildRuntimeEnvFrom(backend),
+		"VOLUMES": append([]*corev1.Volume{
 			cacheVolume,
-		},
+		}, artifact.volumes...),
 		"MODEL_NAME":             model.Name,
 		"BACKEND_REPLICAS":       backend.MinReplicas, // todo: backend replicas
-		"INIT_CONTAINERS":        initContainers,
+		"INIT_CONTAINERS":        artifact.initContainers,
 		"MODEL_DOWNLOAD_ENVFROM": backend.EnvFrom,
 		"ENGINE_PREFILL_COMMAND": preFillCommand,
 		"ENGINE_DECODE_COMMAND":  decodeCommand,
 		"SERVER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
-			Labels: utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(backend)),
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
 		},
 		"MODEL_SERVING_RUNTIME_IMAGE":        config.Config.RuntimeImage(),
 		"MODEL_SERVING_RUNTIME_PORT":         env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, 8100),
@@ -199,8 +204,7 @@ func buildVllmDisaggregatedModelServing(model *workload.ModelBooster) (*workload
 }
 
 // buildVllmModelServing handles VLLM backend creation.
-func buildVllmModelServing(model *workload.ModelBooster) (*workload.ModelServing, error) {
-	backend := &model.Spec.Backend
+func buildVllmModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
 	workersMap := mapWorkers(backend.Workers)
 	if workersMap[workload.ModelWorkerTypeServer] == nil {
 		return nil, fmt.Errorf("server worker not found in backend: %s", backend.Name)
@@ -209,50 +213,23 @@ func buildVllmModelServing(model *workload.ModelBooster) (*workload.ModelServing
 	if err != nil {
 		return nil, err
 	}
-	modelDownloadPath := GetCachePath(backend.CacheURI) + GetMountPath(backend.ModelURI)
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
+	}
+	modelDownloadPath := artifact.path
 	// only one worker in such circumstance so get the first worker's config as commands
 	commands, err := buildCommands(&backend.Workers[0].Config, modelDownloadPath, workersMap)
 	if err != nil {
 		return nil, err
 	}
 
-	// Build an initial container list including model downloader container
-	var envVars []corev1.EnvVar
-	endpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.Endpoint, []corev1.EnvVar{
-		{Name: env.Endpoint},
-	})
-	if len(endpointEnvVars) > 0 && endpointEnvVars[0].Value != "" {
-		envVars = append(envVars, endpointEnvVars[0])
-	}
-	hfEndpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.HfEndpoint, []corev1.EnvVar{
-		{Name: env.HfEndpoint},
-	})
-	if len(hfEndpointEnvVars) > 0 && hfEndpointEnvVars[0].Value != "" {
-		envVars = append(envVars, hfEndpointEnvVars[0])
-	}
-	initContainers := []corev1.Container{
-		{
-			Name:  model.Name + "-model-downloader",
-			Image: config.Config.DownloaderImage(),
-			Args: []string{
-				"--source", backend.ModelURI,
-				"--output-dir", modelDownloadPath,
-			},
-			Env:     envVars,
-			EnvFrom: backend.EnvFrom,
-			VolumeMounts: []corev1.VolumeMount{{
-				Name:      cacheVolume.Name,
-				MountPath: GetCachePath(backend.CacheURI),
-			}},
-		},
-	}
-
 	engineEnv := buildEngineEnvVars(backend)
 	data := map[string]interface{}{
 		"MODEL_SERVING_TEMPLATE_METADATA": &metav1.ObjectMeta{
 			Name:      utils.GetBackendResourceName(model.Name, backend.Name),
 			Namespace: model.Namespace,
-			Labels:    utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(backend)),
+			Labels:    utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion: workload.GroupVersion.String(),
@@ -270,12 +247,12 @@ func buildVllmModelServing(model *workload.ModelBooster) (*workload.ModelServing
 		"WORKER_ENV":       backend.Env,
 		"SERVER_REPLICAS":  workersMap[workload.ModelWorkerTypeServer].Replicas,
 		"SERVER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
-			Labels: utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(backend)),
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
 		},
 		"SERVER_WORKER_TEMPLATE_METADATA": &metav1.ObjectMeta{
-			Labels: utils.GetModelControllerLabels(model, backend.Name, icUtils.Revision(backend)),
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
 		},
-		"VOLUMES": []*corev1.Volume{
+		"VOLUMES": append([]*corev1.Volume{
 			cacheVolume,
 			{
 				Name: dshm,
@@ -285,15 +262,16 @@ func buildVllmModelServing(model *workload.ModelBooster) (*workload.ModelServing
 					},
 				},
 			},
-		},
-		"VOLUME_MOUNTS": []corev1.VolumeMount{{
+		}, artifact.volumes...),
+		"VOLUME_MOUNTS": append([]corev1.VolumeMount{{
 			Name:      cacheVolume.Name,
 			MountPath: GetCachePath(backend.CacheURI),
 		}, {
 			Name:      dshm,
 			MountPath: "/dev/shm",
-		}},
-		"INIT_CONTAINERS":                    initContainers,
+		}}, artifact.volumeMounts...),
+		"RUNTIME_VOLUME_MOUNTS":              buildRuntimeVolumeMounts(backend, cacheVolume),
+		"INIT_CONTAINERS":                    artifact.initContainers,
 		"MODEL_DOWNLOAD_ENVFROM":             backend.EnvFrom,
 		"MODEL_SERVING_RUNTIME_IMAGE":        config.Config.RuntimeImage(),
 		"MODEL_SERVING_RUNTIME_PORT":         env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, 8100),
@@ -310,6 +288,74 @@ func buildVllmModelServing(model *workload.ModelBooster) (*workload.ModelServing
 	return loadModelServingTemplate(VllmTemplatePath, &data)
 }
 
+// buildRuntimeVolumeMounts returns the volume mounts of the runtime sidecar. The runtime downloads the LoRA adapters
+// into the cache, so it shares the cache volume with the engine when the backend declares adapters.
+func buildRuntimeVolumeMounts(backend *workload.ModelBackend, cacheVolume *corev1.Volume) []corev1.VolumeMount {
+	if len(backend.LoraAdapters) == 0 {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      cacheVolume.Name,
+		MountPath: GetCachePath(backend.CacheURI),
+	}}
+}
+
+// buildRuntimeEnvFrom returns the envFrom of a runtime sidecar which has no engine envFrom, with the credentials
+// to download the LoRA adapters when the backend declares adapters.
+func buildRuntimeEnvFrom(backend *workload.ModelBackend) []corev1.EnvFromSource {
+	if len(backend.LoraAdapters) == 0 {
+		return nil
+	}
+	return backend.EnvFrom
+}
+
+// backendRevision returns the revision of the ModelServing built from the backend.
+// Cost and Weight only affect scaling and routing, and LoraAdapters are loaded at runtime,
+// so changing them does not roll out the ModelServing. Only declaring the first adapter does, as the runtime
+// then mounts the cache.
+func backendRevision(backend *workload.ModelBackend) string {
+	b := backend.DeepCopy()
+	b.Cost = 0
+	b.Weight = nil
+	if len(b.LoraAdapters) > 0 {
+		b.LoraAdapters = []workload.LoraAdapter{{}}
+	}
+	return icUtils.Revision(b)
+}
+
+// buildModelDownloaderInitContainers builds the init container that downloads the model into the cache volume.
+func buildModelDownloaderInitContainers(model *workload.ModelBooster, backend *workload.ModelBackend, cacheVolume *corev1.Volume, modelDownloadPath string) []corev1.Container {
+	var envVars []corev1.EnvVar
+	endpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.Endpoint, []corev1.EnvVar{
+		{Name: env.Endpoint},
+	})
+	if len(endpointEnvVars) > 0 && endpointEnvVars[0].Value != "" {
+		envVars = append(envVars, endpointEnvVars[0])
+	}
+	hfEndpointEnvVars := env.GetEnvValueOrDefault[[]corev1.EnvVar](backend, env.HfEndpoint, []corev1.EnvVar{
+		{Name: env.HfEndpoint},
+	})
+	if len(hfEndpointEnvVars) > 0 && hfEndpointEnvVars[0].Value != "" {
+		envVars = append(envVars, hfEndpointEnvVars[0])
+	}
+	return []corev1.Container{
+		{
+			Name:  GetModelDownloaderName(model.Name),
+			Image: config.Config.DownloaderImage(),
+			Args: []string{
+				"--source", backend.ModelURI,
+				"--output-dir", modelDownloadPath,
+			},
+			Env:     envVars,
+			EnvFrom: backend.EnvFrom,
+			VolumeMounts: []corev1.VolumeMount{{
+				Name:      cacheVolume.Name,
+				MountPath: GetCachePath(backend.CacheURI),
+			}},
+		},
+	}
+}
+
 // mapWorkers creates a map of workers by type.
 func mapWorkers(workers []workload.ModelWorker) map[workload.ModelWorkerType]*workload.ModelWorker {
 	workersMap := make(map[workload.ModelWorkerType]*workload.ModelWorker, len(workers))
@@ -384,6 +430,11 @@ func getKvConnectorFromConfig(config *apiextensionsv1.JSON) string {
 	return ""
 }
 
+// GetLoraAdapterPath returns the path in the cache where the LoRA adapter of the backend is downloaded.
+func GetLoraAdapterPath(backend *workload.ModelBackend, adapter *workload.LoraAdapter) string {
+	return GetCachePath(backend.CacheURI) + GetMountPath(adapter.ArtifactURL)
+}
+
 // GetMountPath returns the mount path for the given ModelBackend in the format "/<backend.Name>".
 func GetMountPath(modelURI string) string {
 	h := md5.New()
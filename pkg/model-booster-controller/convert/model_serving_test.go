@@ -22,6 +22,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
 	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/utils/ptr"
 )
@@ -125,10 +126,61 @@ func TestCreateModelServingResources(t *testing.T) {
 			input:    loadYaml[workload.ModelBooster](t, "testdata/input/pd-disaggregated-model-mooncake.yaml"),
 			expected: loadYaml[workload.ModelServing](t, "testdata/expected/disaggregated-model-serving-mooncake.yaml"),
 		},
+		{
+			name:     "SGLang multi-node",
+			input:    loadYaml[workload.ModelBooster](t, "testdata/input/sglang-model.yaml"),
+			expected: loadYaml[workload.ModelServing](t, "testdata/expected/sglang-model-serving.yaml"),
+		},
+		{
+			name:     "SGLang PD disaggregation",
+			input:    loadYaml[workload.ModelBooster](t, "testdata/input/sglang-pd-model.yaml"),
+			expected: loadYaml[workload.ModelServing](t, "testdata/expected/sglang-pd-model-serving.yaml"),
+		},
+		{
+			name: "SGLang PD disaggregation multi-node",
+			input: func() *workload.ModelBooster {
+				model := loadYaml[workload.ModelBooster](t, "testdata/input/sglang-pd-model.yaml")
+				model.Spec.Backend.Workers[1].Pods = 2
+				return model
+			}(),
+			expectErrMsg: "multi-node SGLang prefill and decode workers are not supported",
+		},
+		{
+			name:     "MindIE",
+			input:    loadYaml[workload.ModelBooster](t, "testdata/input/mindie-model.yaml"),
+			expected: loadYaml[workload.ModelServing](t, "testdata/expected/mindie-model-serving.yaml"),
+		},
+		{
+			name: "MindIE multi-node",
+			input: func() *workload.ModelBooster {
+				model := loadYaml[workload.ModelBooster](t, "testdata/input/mindie-model.yaml")
+				model.Spec.Backend.Workers[0].Pods = 2
+				return model
+			}(),
+			expectErrMsg: "multi-node MindIE server is not supported",
+		},
+		{
+			name: "MindIE unknown config",
+			input: func() *workload.ModelBooster {
+				model := loadYaml[workload.ModelBooster](t, "testdata/input/mindie-model.yaml")
+				model.Spec.Backend.Workers[0].Config.Raw = []byte(`{"enforce-eager": ""}`)
+				return model
+			}(),
+			expectErrMsg: "not support MindIE config: enforce-eager",
+		},
+		{
+			name: "MindIE disaggregation",
+			input: func() *workload.ModelBooster {
+				model := loadYaml[workload.ModelBooster](t, "testdata/input/mindie-model.yaml")
+				model.Spec.Backend.Type = workload.ModelBackendTypeMindIEDisaggregated
+				return model
+			}(),
+			expectErrMsg: "not support model backend type: MindIEDisaggregated",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := BuildModelServing(tt.input)
+			got, err := BuildModelServing(tt.input, tt.input.Spec.Backend)
 			if tt.expectErrMsg != "" {
 				assert.Contains(t, err.Error(), tt.expectErrMsg)
 				return
@@ -142,6 +194,73 @@ func TestCreateModelServingResources(t *testing.T) {
 	}
 }
 
+func TestBuildModelServings(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/multi-backend-model.yaml")
+	got, err := BuildModelServings(model)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	for i, backend := range model.Spec.Backends {
+		assert.Equal(t, "multi-backend-model-"+backend.Name, got[i].Name)
+		assert.Equal(t, backend.Name, got[i].Labels[utils.BackendNameLabelKey])
+		assert.Equal(t, backend.MinReplicas, *got[i].Spec.Replicas)
+		var images []string
+		for _, container := range got[i].Spec.Template.Roles[0].EntryTemplate.Spec.Containers {
+			images = append(images, container.Image)
+		}
+		assert.Contains(t, images, backend.Workers[0].Image)
+	}
+}
+
+func TestBackendRevision(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/model.yaml")
+	backend := model.Spec.Backend.DeepCopy()
+	backend.Cost = 10
+	backend.Weight = ptr.To[uint32](50)
+	assert.Equal(t, backendRevision(model.Spec.Backend), backendRevision(backend))
+
+	// Declaring the first adapter mounts the cache into the runtime, but changing the adapters doesn't.
+	backend.LoraAdapters = []workload.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+	assert.NotEqual(t, backendRevision(model.Spec.Backend), backendRevision(backend))
+	withAdapter := backendRevision(backend)
+	backend.LoraAdapters = append(backend.LoraAdapters, workload.LoraAdapter{Name: "chat-lora", ArtifactURL: "hf://org/chat-lora"})
+	assert.Equal(t, withAdapter, backendRevision(backend))
+
+	backend.Workers[0].Image = "vllm-server:v2"
+	assert.NotEqual(t, withAdapter, backendRevision(backend))
+}
+
+func TestBuildModelServingRuntimeVolumeMounts(t *testing.T) {
+	model := loadYaml[workload.ModelBooster](t, "testdata/input/pd-disaggregated-model-npu.yaml")
+	getRuntimes := func(ms *workload.ModelServing) []corev1.Container {
+		var runtimes []corev1.Container
+		for _, role := range ms.Spec.Template.Roles {
+			for _, container := range role.EntryTemplate.Spec.Containers {
+				if container.Name == "runtime" {
+					runtimes = append(runtimes, container)
+				}
+			}
+		}
+		return runtimes
+	}
+
+	// The cache is only mounted into the runtime when the backend declares LoRA adapters.
+	ms, err := BuildModelServing(model, model.Spec.Backend)
+	assert.NoError(t, err)
+	for _, runtime := range getRuntimes(ms) {
+		assert.Empty(t, runtime.VolumeMounts)
+	}
+
+	model.Spec.Backend.LoraAdapters = []workload.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+	ms, err = BuildModelServing(model, model.Spec.Backend)
+	assert.NoError(t, err)
+	runtimes := getRuntimes(ms)
+	assert.Len(t, runtimes, 2)
+	for _, runtime := range runtimes {
+		assert.Len(t, runtime.VolumeMounts, 1)
+		assert.Equal(t, model.Spec.Backend.EnvFrom, runtime.EnvFrom)
+	}
+}
+
 func TestBuildCacheVolume(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -0,0 +1,150 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	networking "github.com/volcano-sh/kthena/pkg/apis/networking/v1alpha1"
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RenderedObjects are the objects derived from a ModelBooster, in the order the controller applies them.
+// They hold either the objects rendered from the spec of a ModelBooster, or the objects of a ModelBooster
+// found in a cluster.
+type RenderedObjects struct {
+	ModelServings             []*workload.ModelServing
+	ModelServers              []*networking.ModelServer
+	ModelRoutes               []*networking.ModelRoute
+	AutoscalingPolicies       []*workload.AutoscalingPolicy
+	AutoscalingPolicyBindings []*workload.AutoscalingPolicyBinding
+	CacheWarmerDaemonSets     []*appsv1.DaemonSet
+	CacheWarmerJobs           []*batchv1.Job
+}
+
+var (
+	modelServerKind = networking.SchemeGroupVersion.WithKind(networking.ModelServerKind)
+	modelRouteKind  = networking.SchemeGroupVersion.WithKind(networking.ModelRouteKind)
+	daemonSetKind   = appsv1.SchemeGroupVersion.WithKind("DaemonSet")
+	jobKind         = batchv1.SchemeGroupVersion.WithKind("Job")
+)
+
+// RenderedKinds are the kinds of the objects derived from a ModelBooster, in the order the controller applies them.
+var RenderedKinds = []schema.GroupVersionKind{
+	workload.ModelServingKind,
+	modelServerKind,
+	modelRouteKind,
+	workload.AutoscalingPolicyKind,
+	workload.AutoscalingPolicyBindingKind,
+	daemonSetKind,
+	jobKind,
+}
+
+// Render builds every object the model booster controller derives from the model, without a cluster.
+func Render(model *workload.ModelBooster) (*RenderedObjects, error) {
+	rendered := &RenderedObjects{}
+	var err error
+	if rendered.ModelServings, err = BuildModelServings(model); err != nil {
+		return nil, err
+	}
+	if rendered.ModelServers, err = BuildModelServer(model); err != nil {
+		return nil, err
+	}
+	rendered.ModelRoutes = []*networking.ModelRoute{BuildModelRoute(model)}
+	if asp, binding := BuildAutoscaling(model); asp != nil {
+		rendered.AutoscalingPolicies = []*workload.AutoscalingPolicy{asp}
+		rendered.AutoscalingPolicyBindings = []*workload.AutoscalingPolicyBinding{binding}
+	}
+	for _, backend := range utils.GetModelBackends(model) {
+		if backend.CacheWarming == nil {
+			continue
+		}
+		if IsNodeLocalCache(backend) {
+			daemonSet, err := BuildCacheWarmerDaemonSet(model, backend)
+			if err != nil {
+				return nil, err
+			}
+			rendered.CacheWarmerDaemonSets = append(rendered.CacheWarmerDaemonSets, daemonSet)
+			continue
+		}
+		job, err := BuildCacheWarmerJob(model, backend)
+		if err != nil {
+			return nil, err
+		}
+		rendered.CacheWarmerJobs = append(rendered.CacheWarmerJobs, job)
+	}
+	return rendered, nil
+}
+
+// Objects returns the objects grouped by kind, with their apiVersion and kind set.
+func (r *RenderedObjects) Objects() map[schema.GroupVersionKind][]client.Object {
+	objects := map[schema.GroupVersionKind][]client.Object{}
+	add := func(gvk schema.GroupVersionKind, obj client.Object) {
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+		objects[gvk] = append(objects[gvk], obj)
+	}
+	for _, obj := range r.ModelServings {
+		add(workload.ModelServingKind, obj)
+	}
+	for _, obj := range r.ModelServers {
+		add(modelServerKind, obj)
+	}
+	for _, obj := range r.ModelRoutes {
+		add(modelRouteKind, obj)
+	}
+	for _, obj := range r.AutoscalingPolicies {
+		add(workload.AutoscalingPolicyKind, obj)
+	}
+	for _, obj := range r.AutoscalingPolicyBindings {
+		add(workload.AutoscalingPolicyBindingKind, obj)
+	}
+	for _, obj := range r.CacheWarmerDaemonSets {
+		add(daemonSetKind, obj)
+	}
+	for _, obj := range r.CacheWarmerJobs {
+		add(jobKind, obj)
+	}
+	return objects
+}
+
+// List returns the objects in the order the controller applies them.
+func (r *RenderedObjects) List() []client.Object {
+	objects := r.Objects()
+	var list []client.Object
+	for _, gvk := range RenderedKinds {
+		list = append(list, objects[gvk]...)
+	}
+	return list
+}
+
+// SetDefaults sets the CRD defaults of the model fields the conversion depends on, for a model which was not
+// admitted by the API server, e.g. read from a file. The other defaults are only set by the API server.
+func SetDefaults(model *workload.ModelBooster) {
+	for _, backend := range utils.GetModelBackends(model) {
+		for i := range backend.Workers {
+			if backend.Workers[i].Type == "" {
+				backend.Workers[i].Type = workload.ModelWorkerTypeServer
+			}
+		}
+		if backend.CacheWarming != nil && backend.CacheWarming.AffinityWeight == 0 {
+			backend.CacheWarming.AffinityWeight = 100
+		}
+	}
+}
@@ -0,0 +1,57 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestRender(t *testing.T) {
+	model := loadYaml[v1alpha1.ModelBooster](t, "testdata/input/model.yaml")
+	rendered, err := Render(model)
+	require.NoError(t, err)
+
+	var kinds, names []string
+	for _, obj := range rendered.List() {
+		kinds = append(kinds, obj.GetObjectKind().GroupVersionKind().Kind)
+		names = append(names, obj.GetName())
+	}
+	assert.Equal(t, []string{"ModelServing", "ModelServer", "ModelRoute", "AutoscalingPolicy", "AutoscalingPolicyBinding"}, kinds)
+	assert.Equal(t, []string{"test-model-backend1", "test-model-backend1", "test-model", "test-model-backend1", "test-model-backend1"}, names)
+
+	model.Spec.AutoscalingPolicy = nil
+	rendered, err = Render(model)
+	require.NoError(t, err)
+	assert.Empty(t, rendered.AutoscalingPolicies)
+	assert.Empty(t, rendered.AutoscalingPolicyBindings)
+}
+
+func TestSetDefaults(t *testing.T) {
+	model := &v1alpha1.ModelBooster{Spec: v1alpha1.ModelBoosterSpec{Backend: &v1alpha1.ModelBackend{
+		Workers:      []v1alpha1.ModelWorker{{}, {Type: v1alpha1.ModelWorkerTypeDecode}},
+		CacheWarming: &v1alpha1.CacheWarming{},
+	}}}
+	SetDefaults(model)
+	assert.Equal(t, v1alpha1.ModelWorkerTypeServer, model.Spec.Backend.Workers[0].Type)
+	assert.Equal(t, v1alpha1.ModelWorkerTypeDecode, model.Spec.Backend.Workers[1].Type)
+	assert.Equal(t, int32(100), model.Spec.Backend.CacheWarming.AffinityWeight)
+}
@@ -0,0 +1,340 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package convert
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	workload "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/config"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/env"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SGLangEnginePort is the port SGLang serves requests and metrics on.
+	SGLangEnginePort = 30000
+	// SGLangBootstrapPort is the port the prefill workers exchange the KV cache metadata with the decode workers on.
+	SGLangBootstrapPort = 8998
+	// SGLangBootstrapPortAnnotation tells the SGLang router the bootstrap port of a prefill pod.
+	SGLangBootstrapPortAnnotation = "sglang.ai/bootstrap-port"
+	// SGLangRouterRoleName is the role running the SGLang router of a PD disaggregated backend.
+	SGLangRouterRoleName = "router"
+	sglangDistInitPort   = 5000
+	sglangTransferConfig = "disaggregation-transfer-backend"
+)
+
+// SGLangArgNames maps the vLLM engine args, which are accepted in ModelWorker.Config for all backends,
+// to the SGLang server args of the same meaning.
+var SGLangArgNames = map[string]string{
+	"tensor-parallel-size":   "tp-size",
+	"pipeline-parallel-size": "pp-size",
+	"data-parallel-size":     "dp-size",
+	"max-model-len":          "context-length",
+	"gpu-memory-utilization": "mem-fraction-static",
+	"max-num-seqs":           "max-running-requests",
+	"max-num-batched-tokens": "chunked-prefill-size",
+	"block-size":             "page-size",
+}
+
+// SGLangTransferBackends maps the vLLM KV connectors to the SGLang disaggregation transfer backends.
+var SGLangTransferBackends = map[string]string{
+	"MooncakeConnector": "mooncake",
+	"NixlConnector":     "nixl",
+}
+
+// isDisaggregated checks whether the backend runs separate prefill and decode workers.
+func isDisaggregated(backend *workload.ModelBackend) bool {
+	for _, worker := range backend.Workers {
+		if worker.Type == workload.ModelWorkerTypePrefill || worker.Type == workload.ModelWorkerTypeDecode {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSGLangModelServing handles SGLang backend creation.
+func buildSGLangModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
+	workersMap := mapWorkers(backend.Workers)
+	server := workersMap[workload.ModelWorkerTypeServer]
+	if server == nil {
+		return nil, fmt.Errorf("server worker not found in backend: %s", backend.Name)
+	}
+	cacheVolume, err := buildCacheVolume(backend)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
+	}
+	modelDownloadPath := artifact.path
+	commands, err := buildSGLangCommands(&server.Config, modelDownloadPath)
+	if err != nil {
+		return nil, err
+	}
+	if server.Pods > 1 {
+		// The entry pod is node 0 and the worker pods follow, all of them run the same command.
+		commands = append(commands,
+			"--nnodes", "$(GROUP_SIZE)",
+			"--node-rank", "$(WORKER_INDEX)",
+			"--dist-init-addr", fmt.Sprintf("$(ENTRY_ADDRESS):%d", sglangDistInitPort))
+	}
+	engineEnv := buildEngineEnvVars(backend)
+	data := map[string]interface{}{
+		"MODEL_SERVING_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Name:      utils.GetBackendResourceName(model.Name, backend.Name),
+			Namespace: model.Namespace,
+			Labels:    utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: workload.GroupVersion.String(),
+					Kind:       workload.ModelKind.Kind,
+					Name:       model.Name,
+					UID:        model.UID,
+				},
+			},
+		},
+		"MODEL_NAME":       model.Name,
+		"BACKEND_NAME":     strings.ToLower(backend.Name),
+		"BACKEND_REPLICAS": backend.MinReplicas, // todo: backend replicas
+		"BACKEND_TYPE":     strings.ToLower(string(backend.Type)),
+		"ENGINE_ENV":       engineEnv,
+		"SERVER_REPLICAS":  server.Replicas,
+		"SERVER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+		},
+		"SERVER_WORKER_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+		},
+		"VOLUMES": append([]*corev1.Volume{
+			cacheVolume,
+			{
+				Name: dshm,
+				VolumeSource: corev1.VolumeSource{
+					EmptyDir: &corev1.EmptyDirVolumeSource{
+						Medium: corev1.StorageMediumMemory,
+					},
+				},
+			},
+		}, artifact.volumes...),
+		"VOLUME_MOUNTS": append([]corev1.VolumeMount{{
+			Name:      cacheVolume.Name,
+			MountPath: GetCachePath(backend.CacheURI),
+		}, {
+			Name:      dshm,
+			MountPath: "/dev/shm",
+		}}, artifact.volumeMounts...),
+		"INIT_CONTAINERS":                    artifact.initContainers,
+		"MODEL_DOWNLOAD_ENVFROM":             backend.EnvFrom,
+		"MODEL_SERVING_RUNTIME_IMAGE":        config.Config.RuntimeImage(),
+		"MODEL_SERVING_RUNTIME_PORT":         env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, 8100),
+		"MODEL_SERVING_RUNTIME_URL":          env.GetEnvValueOrDefault[string](backend, env.RuntimeUrl, fmt.Sprintf("http://localhost:%d", SGLangEnginePort)),
+		"MODEL_SERVING_RUNTIME_METRICS_PATH": env.GetEnvValueOrDefault[string](backend, env.RuntimeMetricsPath, "/metrics"),
+		"MODEL_SERVING_RUNTIME_ENGINE":       strings.ToLower(string(backend.Type)),
+		"MODEL_SERVING_RUNTIME_POD":          "$(POD_NAME).$(NAMESPACE)",
+		"ENGINE_SERVER_RESOURCES":            server.Resources,
+		"ENGINE_SERVER_IMAGE":                server.Image,
+		"ENGINE_SERVER_COMMAND":              commands,
+		"WORKER_REPLICAS":                    server.Pods - 1,
+		"SCHEDULER_NAME":                     backend.SchedulerName,
+	}
+	return loadModelServingTemplate(SGLangTemplatePath, &data)
+}
+
+// buildSGLangDisaggregatedModelServing handles SGLang PD disaggregated backend creation.
+// The prefill and decode workers are paired by an SGLang router, which discovers them by their labels.
+func buildSGLangDisaggregatedModelServing(model *workload.ModelBooster, backend *workload.ModelBackend) (*workload.ModelServing, error) {
+	workersMap := mapWorkers(backend.Workers)
+	prefill := workersMap[workload.ModelWorkerTypePrefill]
+	if prefill == nil {
+		return nil, fmt.Errorf("prefill worker not found in backend")
+	}
+	decode := workersMap[workload.ModelWorkerTypeDecode]
+	if decode == nil {
+		return nil, fmt.Errorf("decode worker not found in backend")
+	}
+	if prefill.Pods > 1 || decode.Pods > 1 {
+		return nil, fmt.Errorf("multi-node SGLang prefill and decode workers are not supported, pods of backend %s must be 1", backend.Name)
+	}
+	cacheVolume, err := buildCacheVolume(backend)
+	if err != nil {
+		return nil, err
+	}
+	artifact, err := buildModelArtifact(model, backend, cacheVolume)
+	if err != nil {
+		return nil, err
+	}
+	modelDownloadPath := artifact.path
+
+	prefillCommand, err := buildSGLangCommands(&prefill.Config, modelDownloadPath)
+	if err != nil {
+		return nil, err
+	}
+	prefillCommand = append(prefillCommand, "--disaggregation-mode", string(workload.ModelWorkerTypePrefill),
+		"--disaggregation-bootstrap-port", strconv.Itoa(SGLangBootstrapPort))
+	prefillCommand = append(prefillCommand, sglangTransferBackendArgs(&prefill.Config)...)
+	decodeCommand, err := buildSGLangCommands(&decode.Config, modelDownloadPath)
+	if err != nil {
+		return nil, err
+	}
+	decodeCommand = append(decodeCommand, "--disaggregation-mode", string(workload.ModelWorkerTypeDecode))
+	decodeCommand = append(decodeCommand, sglangTransferBackendArgs(&decode.Config)...)
+
+	modelServingName := utils.GetBackendResourceName(model.Name, backend.Name)
+	routerCommand := []string{
+		"python3", "-m", "sglang_router.launch_router",
+		"--host", "0.0.0.0",
+		"--port", strconv.Itoa(SGLangEnginePort),
+		"--pd-disaggregation",
+		"--service-discovery",
+		"--service-discovery-namespace", "$(NAMESPACE)",
+		"--service-discovery-port", strconv.Itoa(SGLangEnginePort),
+		"--bootstrap-port-annotation", SGLangBootstrapPortAnnotation,
+		"--prefill-selector",
+		fmt.Sprintf("%s=%s", workload.ModelServingNameLabelKey, modelServingName),
+		fmt.Sprintf("%s=%s", workload.RoleLabelKey, workload.ModelWorkerTypePrefill),
+		"--decode-selector",
+		fmt.Sprintf("%s=%s", workload.ModelServingNameLabelKey, modelServingName),
+		fmt.Sprintf("%s=%s", workload.RoleLabelKey, workload.ModelWorkerTypeDecode),
+	}
+	engineEnv := buildEngineEnvVars(backend)
+	data := map[string]interface{}{
+		"MODEL_SERVING_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Name:      modelServingName,
+			Namespace: model.Namespace,
+			Labels:    utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: workload.GroupVersion.String(),
+					Kind:       workload.ModelKind.Kind,
+					Name:       model.Name,
+					UID:        model.UID,
+				},
+			},
+		},
+		"VOLUME_MOUNTS": append([]corev1.VolumeMount{{
+			Name:      cacheVolume.Name,
+			MountPath: GetCachePath(backend.CacheURI),
+		}}, artifact.volumeMounts...),
+		"VOLUMES": append([]*corev1.Volume{
+			cacheVolume,
+		}, artifact.volumes...),
+		"MODEL_NAME":             model.Name,
+		"BACKEND_REPLICAS":       backend.MinReplicas, // todo: backend replicas
+		"INIT_CONTAINERS":        artifact.initContainers,
+		"MODEL_DOWNLOAD_ENVFROM": backend.EnvFrom,
+		"ENGINE_PREFILL_COMMAND": prefillCommand,
+		"ENGINE_DECODE_COMMAND":  decodeCommand,
+		"ROUTER_COMMAND":         routerCommand,
+		"PREFILL_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+			Annotations: map[string]string{
+				SGLangBootstrapPortAnnotation: strconv.Itoa(SGLangBootstrapPort),
+			},
+		},
+		"SERVER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+		},
+		"ROUTER_ENTRY_TEMPLATE_METADATA": &metav1.ObjectMeta{
+			Labels: utils.GetModelControllerLabels(model, backend.Name, backendRevision(backend)),
+		},
+		"MODEL_SERVING_RUNTIME_IMAGE":        config.Config.RuntimeImage(),
+		"MODEL_SERVING_RUNTIME_PORT":         env.GetEnvValueOrDefault[int32](backend, env.RuntimePort, 8100),
+		"MODEL_SERVING_RUNTIME_URL":          env.GetEnvValueOrDefault[string](backend, env.RuntimeUrl, fmt.Sprintf("http://localhost:%d", SGLangEnginePort)),
+		"MODEL_SERVING_RUNTIME_METRICS_PATH": env.GetEnvValueOrDefault[string](backend, env.RuntimeMetricsPath, "/metrics"),
+		"ENGINE_PREFILL_ENV":                 engineEnv,
+		"ENGINE_DECODE_ENV":                  engineEnv,
+		"ROUTER_ENV": []corev1.EnvVar{{
+			Name: "NAMESPACE",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"},
+			},
+		}},
+		"MODEL_SERVING_RUNTIME_ENGINE": strings.ToLower(string(backend.Type)),
+		"MODEL_SERVING_RUNTIME_POD":    "$(POD_NAME).$(NAMESPACE)",
+		"PREFILL_REPLICAS":             prefill.Replicas,
+		"DECODE_REPLICAS":              decode.Replicas,
+		"PREFILL_BOOTSTRAP_PORT":       SGLangBootstrapPort,
+		"ENGINE_DECODE_RESOURCES":      decode.Resources,
+		"ENGINE_DECODE_IMAGE":          decode.Image,
+		"ENGINE_PREFILL_RESOURCES":     prefill.Resources,
+		"ENGINE_PREFILL_IMAGE":         prefill.Image,
+		"SCHEDULER_NAME":               backend.SchedulerName,
+	}
+	return loadModelServingTemplate(SGLangDisaggregatedTemplatePath, &data)
+}
+
+// buildSGLangCommands constructs the command of an SGLang server.
+func buildSGLangCommands(workerConfig *apiextensionsv1.JSON, modelDownloadPath string) ([]string, error) {
+	commands := []string{"python3", "-m", "sglang.launch_server", "--model-path", modelDownloadPath,
+		"--host", "0.0.0.0", "--port", strconv.Itoa(SGLangEnginePort), "--enable-metrics"}
+	args, err := convertSGLangArgs(workerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return append(commands, args...), nil
+}
+
+// convertSGLangArgs converts the worker config to SGLang server args, renaming the vLLM engine args.
+// The vLLM kv-transfer-config is dropped, it is translated by sglangTransferBackendArgs.
+func convertSGLangArgs(workerConfig *apiextensionsv1.JSON) ([]string, error) {
+	if workerConfig == nil || workerConfig.Raw == nil {
+		return []string{}, nil
+	}
+	var configMap map[string]interface{}
+	if err := json.Unmarshal(workerConfig.Raw, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	delete(configMap, "kv-transfer-config")
+	for vllmName, sglangName := range SGLangArgNames {
+		value, ok := configMap[vllmName]
+		if !ok {
+			continue
+		}
+		delete(configMap, vllmName)
+		if _, exists := configMap[sglangName]; !exists {
+			configMap[sglangName] = value
+		}
+	}
+	raw, err := json.Marshal(configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return utils.ConvertVLLMArgsFromJson(&apiextensionsv1.JSON{Raw: raw})
+}
+
+// sglangTransferBackendArgs returns the args selecting the KV transfer backend of a PD disaggregated SGLang worker.
+// The backend is derived from the vLLM kv-transfer-config unless it is set explicitly, and defaults to mooncake.
+func sglangTransferBackendArgs(workerConfig *apiextensionsv1.JSON) []string {
+	if workerConfig != nil && workerConfig.Raw != nil {
+		if value, err := utils.TryGetField(workerConfig.Raw, sglangTransferConfig); err == nil && value != nil {
+			return nil
+		}
+	}
+	transferBackend, ok := SGLangTransferBackends[getKvConnectorFromConfig(workerConfig)]
+	if !ok {
+		transferBackend = SGLangTransferBackends["MooncakeConnector"]
+	}
+	return []string{"--" + sglangTransferConfig, transferBackend}
+}
@@ -31,6 +31,8 @@ const (
 	RuntimeUrl         = "RUNTIME_URL"
 	RuntimeMetricsPath = "RUNTIME_METRICS_PATH"
 	HfEndpoint         = "HF_ENDPOINT"
+	HfRevision         = "HF_REVISION"
+	HfAuthToken        = "HF_AUTH_TOKEN"
 )
 
 // GetEnvValueOrDefault gets value of specific env, if env does not exist, return default value
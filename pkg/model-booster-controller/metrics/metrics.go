@@ -0,0 +1,62 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Label names
+	LabelNamespace = "namespace"
+	LabelModel     = "model"
+	LabelBackend   = "backend"
+	LabelResult    = "result"
+
+	// Model cache lookup result values
+	ResultHit  = "hit"
+	ResultMiss = "miss"
+)
+
+var (
+	// ModelCacheLookups counts the serving pods scheduled on a node, by whether the node already held the model
+	// in its cache.
+	ModelCacheLookups = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kthena_model_cache_lookups_total",
+			Help: "Total number of serving pods scheduled on a node, by whether the model was in the cache of the node",
+		},
+		[]string{LabelNamespace, LabelModel, LabelBackend, LabelResult},
+	)
+
+	// ModelCacheWarmNodes is the number of nodes holding the model of a backend in their cache.
+	ModelCacheWarmNodes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_model_cache_warm_nodes",
+			Help: "Number of nodes holding the model in their cache",
+		},
+		[]string{LabelNamespace, LabelModel, LabelBackend},
+	)
+)
+
+// DeleteModelBooster removes the metrics of a deleted ModelBooster.
+func DeleteModelBooster(namespace, name string) {
+	labels := prometheus.Labels{LabelNamespace: namespace, LabelModel: name}
+	ModelCacheLookups.DeletePartialMatch(labels)
+	ModelCacheWarmNodes.DeletePartialMatch(labels)
+}
@@ -58,6 +58,18 @@ func GetDeviceNum(worker *workloadv1alpha1.ModelWorker) int64 {
 	return sum
 }
 
+// GetModelBackends returns the backends of the model, either the single Backend or the list of Backends.
+func GetModelBackends(model *workloadv1alpha1.ModelBooster) []*workloadv1alpha1.ModelBackend {
+	if model.Spec.Backend != nil {
+		return []*workloadv1alpha1.ModelBackend{model.Spec.Backend}
+	}
+	backends := make([]*workloadv1alpha1.ModelBackend, 0, len(model.Spec.Backends))
+	for i := range model.Spec.Backends {
+		backends = append(backends, &model.Spec.Backends[i])
+	}
+	return backends
+}
+
 func NewModelOwnerRef(model *workloadv1alpha1.ModelBooster) metav1.OwnerReference {
 	return metav1.OwnerReference{
 		APIVersion:         workloadv1alpha1.GroupVersion.String(),
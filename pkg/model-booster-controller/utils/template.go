@@ -33,6 +33,14 @@ const (
 	ManageBy            = workload.GroupName + "/managed-by"
 	RevisionLabelKey    = workload.GroupName + "/revision"
 	OwnerUIDKey         = workload.GroupName + "/model-uid"
+	// ModelCacheKeyLabelKey labels the cache warming pods with the cache key of the model they download.
+	ModelCacheKeyLabelKey = workload.GroupName + "/model-cache-key"
+	// ModelCacheNodeLabelPrefix is the prefix of the label set on the nodes holding a model in their cache,
+	// followed by the cache key.
+	ModelCacheNodeLabelPrefix = workload.GroupName + "/model-cache-"
+	// DryRunAnnotationKey set to "true" on a ModelBooster makes the controller only report the changes it would
+	// apply to the derived objects in the DryRun condition, without applying them.
+	DryRunAnnotationKey = workload.GroupName + "/dry-run"
 )
 
 func ReplaceEmbeddedPlaceholders(s string, values *map[string]interface{}) (string, error) {
@@ -25,8 +25,10 @@ import (
 	clientset "github.com/volcano-sh/kthena/client-go/clientset/versioned"
 	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 )
@@ -91,6 +93,7 @@ func (v *AutoscalingBindingValidator) validateAutoscalingBinding(asp_binding *wo
 	allErrs = append(allErrs, validateOptimizeAndScalingPolicyExistence(asp_binding)...)
 	allErrs = append(allErrs, v.validateAutoscalingPolicyExistence(ctx, asp_binding)...)
 	allErrs = append(allErrs, validateBindingTargetKind(asp_binding)...)
+	allErrs = append(allErrs, validateCoupledTarget(asp_binding)...)
 
 	if len(allErrs) > 0 {
 		// Convert field errors to a formatted multi-line error message
@@ -119,12 +122,44 @@ func (v *AutoscalingBindingValidator) validateAutoscalingPolicyExistence(ctx con
 
 func validateOptimizeAndScalingPolicyExistence(asp_binding *workloadv1alpha1.AutoscalingPolicyBinding) field.ErrorList {
 	var allErrs field.ErrorList
-	if asp_binding.Spec.HeterogeneousTarget == nil && asp_binding.Spec.HomogeneousTarget == nil {
-		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("homogeneousTarget"), "spec.homogeneousTarget should be set if spec.heterogeneousTarget does not exist"))
+	if asp_binding.Spec.HeterogeneousTarget == nil && asp_binding.Spec.HomogeneousTarget == nil && asp_binding.Spec.CoupledTarget == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("homogeneousTarget"), "spec.homogeneousTarget should be set if spec.heterogeneousTarget and spec.coupledTarget do not exist"))
 	}
 	if asp_binding.Spec.HeterogeneousTarget != nil && asp_binding.Spec.HomogeneousTarget != nil {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("homogeneousTarget"), "both spec.heterogeneousTarget and spec.homogeneousTarget can not be set at the same time"))
 	}
+	if asp_binding.Spec.CoupledTarget != nil && (asp_binding.Spec.HeterogeneousTarget != nil || asp_binding.Spec.HomogeneousTarget != nil) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("coupledTarget"), "spec.coupledTarget can not be set with spec.heterogeneousTarget or spec.homogeneousTarget"))
+	}
+	return allErrs
+}
+
+// validateCoupledTarget validates that the coupled target references the roles of a ModelServing, and that the
+// roles set a token type with a Learned ratio.
+func validateCoupledTarget(asp_binding *workloadv1alpha1.AutoscalingPolicyBinding) field.ErrorList {
+	var allErrs field.ErrorList
+	coupledTarget := asp_binding.Spec.CoupledTarget
+	if coupledTarget == nil {
+		return allErrs
+	}
+	path := field.NewPath("spec").Child("coupledTarget")
+	if coupledTarget.Target.TargetRef.Kind != "" && coupledTarget.Target.TargetRef.Kind != workloadv1alpha1.ModelServingKind.Kind {
+		allErrs = append(allErrs, field.Invalid(path.Child("targetRef").Child("kind"), coupledTarget.Target.TargetRef.Kind, fmt.Sprintf("coupledTarget.targetRef.kind must be ModelServing, but got %s", coupledTarget.Target.TargetRef.Kind)))
+	}
+	if coupledTarget.Target.TargetRef.Name == "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("targetRef").Child("name"), coupledTarget.Target.TargetRef.Name, "coupledTarget.targetRef.name must be set, but got empty"))
+	}
+	if coupledTarget.Target.SubTarget != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("targetRef").Child("subTarget"), coupledTarget.Target.SubTarget, "coupledTarget.targetRef.subTarget must be empty, the roles are set by coupledTarget.roles"))
+	}
+	for idx, role := range coupledTarget.Roles {
+		if role.MinReplicas > role.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(path.Child("roles").Index(idx).Child("minReplicas"), role.MinReplicas, fmt.Sprintf("coupledTarget.roles[].minReplicas must not be greater than maxReplicas %d", role.MaxReplicas)))
+		}
+		if coupledTarget.Ratio == workloadv1alpha1.CoupledRatioLearned && role.TokenType == "" {
+			allErrs = append(allErrs, field.Required(path.Child("roles").Index(idx).Child("tokenType"), "coupledTarget.roles[].tokenType must be set with a Learned ratio"))
+		}
+	}
 	return allErrs
 }
 
@@ -133,7 +168,7 @@ func validateBindingTargetKind(asp_binding *workloadv1alpha1.AutoscalingPolicyBi
 	if asp_binding.Spec.HeterogeneousTarget != nil {
 		for idx, param := range asp_binding.Spec.HeterogeneousTarget.Params {
 			if param.Target.TargetRef.Kind != "" && param.Target.TargetRef.Kind != workloadv1alpha1.ModelServingKind.Kind {
-				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("heterogeneousTarget").Child("params").Index(idx).Child("targetRef").Child("kind"), param.Target.TargetRef.Kind, fmt.Sprintf("heterogeneousTarget.params[].targetRef.kind must be ModelServing, but got %s", param.Target.TargetRef.Kind)))
+				allErrs = append(allErrs, validateScaleTargetRef(&param.Target.TargetRef, field.NewPath("spec").Child("heterogeneousTarget").Child("params").Index(idx).Child("targetRef"))...)
 			}
 			if param.Target.SubTarget != nil {
 				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("heterogeneousTarget").Child("params").Index(idx).Child("targetRef").Child("subTarget"), param.Target.SubTarget, fmt.Sprintf("heterogeneousTarget.params[].targetRef.subTarget must be empty, but got %s", param.Target.SubTarget)))
@@ -157,9 +192,27 @@ func validateBindingTargetKind(asp_binding *workloadv1alpha1.AutoscalingPolicyBi
 				}
 			}
 		default:
-			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("homogeneousTarget").Child("targetRef").Child("kind"), asp_binding.Spec.HomogeneousTarget.Target.TargetRef.Kind, fmt.Sprintf("homogeneousTarget.targetRef.kind must be ModelServing, but got %s", asp_binding.Spec.HomogeneousTarget.Target.TargetRef.Kind)))
+			allErrs = append(allErrs, validateScaleTargetRef(&asp_binding.Spec.HomogeneousTarget.Target.TargetRef, field.NewPath("spec").Child("homogeneousTarget").Child("targetRef"))...)
+			if asp_binding.Spec.HomogeneousTarget.Target.SubTarget != nil {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("homogeneousTarget").Child("targetRef").Child("subTarget"), asp_binding.Spec.HomogeneousTarget.Target.SubTarget, fmt.Sprintf("homogeneousTarget.targetRef.subTarget must be empty for kind %s", asp_binding.Spec.HomogeneousTarget.Target.TargetRef.Kind)))
+			}
 		}
 	}
 
 	return allErrs
 }
+
+// validateScaleTargetRef validates the reference of a target other than ModelServing, which is scaled through its
+// scale subresource and so must be resolvable from its apiVersion and kind.
+func validateScaleTargetRef(targetRef *corev1.ObjectReference, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if targetRef.APIVersion == "" {
+		allErrs = append(allErrs, field.Required(path.Child("apiVersion"), fmt.Sprintf("targetRef.apiVersion must be set for kind %s", targetRef.Kind)))
+	} else if _, err := schema.ParseGroupVersion(targetRef.APIVersion); err != nil {
+		allErrs = append(allErrs, field.Invalid(path.Child("apiVersion"), targetRef.APIVersion, err.Error()))
+	}
+	if targetRef.Name == "" {
+		allErrs = append(allErrs, field.Invalid(path.Child("name"), targetRef.Name, "targetRef.name must be set, but got empty"))
+	}
+	return allErrs
+}
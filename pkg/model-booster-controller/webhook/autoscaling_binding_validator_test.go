@@ -58,7 +58,7 @@ func TestValidateAutoscalingBinding(t *testing.T) {
 					HomogeneousTarget:   nil,
 				},
 			},
-			expected: []string{"  - spec.homogeneousTarget: Required value: spec.homogeneousTarget should be set if spec.heterogeneousTarget does not exist"},
+			expected: []string{"  - spec.homogeneousTarget: Required value: spec.homogeneousTarget should be set if spec.heterogeneousTarget and spec.coupledTarget do not exist"},
 		},
 		{
 			name: "optimizer and scaling config both are not nil",
@@ -194,14 +194,13 @@ func TestValidateBindingTargetKind_HeterogeneousValidRole(t *testing.T) {
 }
 
 func TestValidateBindingTargetKind_HeterogeneousInvalid(t *testing.T) {
-	invalidKind := "Deployment"
 	asp := &v1alpha1.AutoscalingPolicyBinding{
 		Spec: v1alpha1.AutoscalingPolicyBindingSpec{
 			HeterogeneousTarget: &v1alpha1.HeterogeneousTarget{
 				Params: []v1alpha1.HeterogeneousTargetParam{
 					{
 						Target: v1alpha1.Target{
-							TargetRef: corev1.ObjectReference{Kind: invalidKind},
+							TargetRef: corev1.ObjectReference{Kind: "Deployment", Name: "target-name"},
 						},
 						MinReplicas: 0,
 						MaxReplicas: 1,
@@ -214,10 +213,10 @@ func TestValidateBindingTargetKind_HeterogeneousInvalid(t *testing.T) {
 	if len(errs) != 1 {
 		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
 	}
-	if errs[0].Type != field.ErrorTypeInvalid {
-		t.Fatalf("expected invalid error type, got %v", errs[0].Type)
+	if errs[0].Type != field.ErrorTypeRequired {
+		t.Fatalf("expected required error type, got %v", errs[0].Type)
 	}
-	if errs[0].Field != "spec.heterogeneousTarget.params[0].targetRef.kind" {
+	if errs[0].Field != "spec.heterogeneousTarget.params[0].targetRef.apiVersion" {
 		t.Fatalf("unexpected field path: %s", errs[0].Field)
 	}
 }
@@ -242,13 +241,34 @@ func TestValidateBindingTargetKind_HomogeneousValidModelServing(t *testing.T) {
 	}
 }
 
+func TestValidateBindingTargetKind_HomogeneousValidDeployment(t *testing.T) {
+	asp := &v1alpha1.AutoscalingPolicyBinding{
+		Spec: v1alpha1.AutoscalingPolicyBindingSpec{
+			HomogeneousTarget: &v1alpha1.HomogeneousTarget{
+				Target: v1alpha1.Target{
+					TargetRef: corev1.ObjectReference{
+						APIVersion: "apps/v1",
+						Kind:       "Deployment",
+						Name:       "target-name"},
+				},
+				MinReplicas: 0,
+				MaxReplicas: 1,
+			},
+		},
+	}
+	errs := validateBindingTargetKind(asp)
+	if len(errs) != 0 {
+		t.Fatalf("expected no errors, got %d: %v", len(errs), errs)
+	}
+}
+
 func TestValidateBindingTargetKind_HomogeneousInvalid(t *testing.T) {
-	invalidKind := "Unknown"
 	asp := &v1alpha1.AutoscalingPolicyBinding{
 		Spec: v1alpha1.AutoscalingPolicyBindingSpec{
 			HomogeneousTarget: &v1alpha1.HomogeneousTarget{
 				Target: v1alpha1.Target{
-					TargetRef: corev1.ObjectReference{Kind: invalidKind},
+					TargetRef: corev1.ObjectReference{APIVersion: "apps/v1", Kind: "Deployment"},
+					SubTarget: &v1alpha1.SubTarget{Kind: "Role", Name: "prefill"},
 				},
 				MinReplicas: 0,
 				MaxReplicas: 1,
@@ -256,13 +276,46 @@ func TestValidateBindingTargetKind_HomogeneousInvalid(t *testing.T) {
 		},
 	}
 	errs := validateBindingTargetKind(asp)
-	if len(errs) != 1 {
-		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
 	}
 	if errs[0].Type != field.ErrorTypeInvalid {
 		t.Fatalf("expected invalid error type, got %v", errs[0].Type)
 	}
-	if errs[0].Field != "spec.homogeneousTarget.targetRef.kind" {
+	if errs[0].Field != "spec.homogeneousTarget.targetRef.name" {
 		t.Fatalf("unexpected field path: %s", errs[0].Field)
 	}
+	if errs[1].Field != "spec.homogeneousTarget.targetRef.subTarget" {
+		t.Fatalf("unexpected field path: %s", errs[1].Field)
+	}
+}
+
+func TestValidateCoupledTarget(t *testing.T) {
+	asp := &v1alpha1.AutoscalingPolicyBinding{
+		Spec: v1alpha1.AutoscalingPolicyBindingSpec{
+			CoupledTarget: &v1alpha1.CoupledTarget{
+				Target: v1alpha1.Target{
+					TargetRef: corev1.ObjectReference{Kind: v1alpha1.ModelServingKind.Kind, Name: "pd"},
+				},
+				Roles: []v1alpha1.CoupledRole{
+					{Name: "prefill", TokenType: v1alpha1.CoupledTokenInput, MinReplicas: 1, MaxReplicas: 4},
+					{Name: "decode", MinReplicas: 5, MaxReplicas: 4},
+				},
+				Ratio: v1alpha1.CoupledRatioLearned,
+			},
+		},
+	}
+	assert.Empty(t, validateOptimizeAndScalingPolicyExistence(asp))
+	errs := validateCoupledTarget(asp)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %d: %v", len(errs), errs)
+	}
+	assert.Equal(t, "spec.coupledTarget.roles[1].minReplicas", errs[0].Field)
+	assert.Equal(t, "spec.coupledTarget.roles[1].tokenType", errs[1].Field)
+
+	asp.Spec.HomogeneousTarget = &v1alpha1.HomogeneousTarget{}
+	errs = validateOptimizeAndScalingPolicyExistence(asp)
+	if len(errs) != 1 || errs[0].Field != "spec.coupledTarget" {
+		t.Fatalf("expected a forbidden error on spec.coupledTarget, got %v", errs)
+	}
 }
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"time"
 
+	jsonpatchapply "github.com/evanphx/json-patch/v5"
 	registryv1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"gomodules.xyz/jsonpatch/v2"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -143,6 +144,32 @@ func createPolicyBatch(policy *registryv1.AutoscalingPolicy) []jsonpatch.Operati
 	return patch
 }
 
+// DefaultAutoscalingPolicy sets the defaults of the mutating webhook on an AutoscalingPolicy, e.g. for a policy
+// used offline by the simulator. The defaults the API server sets from the CRD schema are not applied.
+func DefaultAutoscalingPolicy(policy *registryv1.AutoscalingPolicy) error {
+	operations := createPolicyBatch(policy)
+	if len(operations) == 0 {
+		return nil
+	}
+	patchBytes, err := createPolicyPatchBytes(operations)
+	if err != nil {
+		return err
+	}
+	patch, err := jsonpatchapply.DecodePatch(patchBytes)
+	if err != nil {
+		return fmt.Errorf("failed to decode patch: %v", err)
+	}
+	original, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal policy: %v", err)
+	}
+	patched, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("failed to apply patch: %v", err)
+	}
+	return json.Unmarshal(patched, policy)
+}
+
 func createPolicyPatchBytes(patch []jsonpatch.Operation) ([]byte, error) {
 	patchBytes, err := json.Marshal(patch)
 	if err != nil {
@@ -205,6 +205,31 @@ func TestMutateAutoscalingPolicy_NoChangesNeeded(t *testing.T) {
 	assert.Len(t, patch, 0)
 }
 
+// TestDefaultAutoscalingPolicy tests the defaults applied to a policy used offline
+func TestDefaultAutoscalingPolicy(t *testing.T) {
+	policy := &registryv1.AutoscalingPolicy{
+		Spec: registryv1.AutoscalingPolicySpec{
+			Behavior: registryv1.AutoscalingPolicyBehavior{
+				ScaleDown: registryv1.AutoscalingPolicyStablePolicy{
+					Instances: ptr.To(int32(1)),
+				},
+			},
+		},
+	}
+
+	require.NoError(t, DefaultAutoscalingPolicy(policy))
+
+	assert.Equal(t, ptr.To(int32(1)), policy.Spec.Behavior.ScaleDown.Instances)
+	assert.Equal(t, time.Minute*5, policy.Spec.Behavior.ScaleDown.StabilizationWindow.Duration)
+	assert.Equal(t, ptr.To(int32(4)), policy.Spec.Behavior.ScaleUp.StablePolicy.Instances)
+	assert.Equal(t, ptr.To(int32(200)), policy.Spec.Behavior.ScaleUp.PanicPolicy.PanicThresholdPercent)
+
+	// A policy without defaults to set is left unchanged.
+	defaulted := policy.DeepCopy()
+	require.NoError(t, DefaultAutoscalingPolicy(defaulted))
+	assert.Equal(t, policy, defaulted)
+}
+
 // TestCreatePolicyPatch tests the patch creation functionality
 func TestCreatePolicyPatch(t *testing.T) {
 	// Test creating patch from operations
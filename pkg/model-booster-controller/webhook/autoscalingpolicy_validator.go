@@ -21,14 +21,19 @@ import (
 	"math"
 	"net/http"
 	"strings"
+	"time"
 
 	registryv1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/autoscaler/algorithm"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 )
 
+// maxSeasonIntervals is the maximum number of intervals of the season of a predictive policy.
+const maxSeasonIntervals = 10080
+
 // AutoscalingPolicyValidator handles validation of AutoscalingPolicy resources
 type AutoscalingPolicyValidator struct {
 }
@@ -94,6 +99,12 @@ func (v *AutoscalingPolicyValidator) validateAutoscalingPolicy(policy *registryv
 	// Validate scale up behavior
 	allErrs = append(allErrs, v.validateScaleUpBehavior(policy)...)
 
+	// Validate schedules
+	allErrs = append(allErrs, v.validateSchedules(policy)...)
+
+	// Validate predictive policy
+	allErrs = append(allErrs, v.validatePredictivePolicy(policy)...)
+
 	if len(allErrs) > 0 {
 		var messages []string
 		for _, err := range allErrs {
@@ -231,3 +242,74 @@ func (v *AutoscalingPolicyValidator) validatePanicPolicy(policy *registryv1.Auto
 
 	return allErrs
 }
+
+// validateSchedules validates the cron expressions, time zones and durations of the schedules
+func (v *AutoscalingPolicyValidator) validateSchedules(policy *registryv1.AutoscalingPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, schedule := range policy.Spec.Schedules {
+		schedulePath := field.NewPath("spec").Child("schedules").Index(i)
+
+		if _, err := algorithm.ParseCronSchedule(schedule.Schedule); err != nil {
+			allErrs = append(allErrs, field.Invalid(schedulePath.Child("schedule"), schedule.Schedule, err.Error()))
+		}
+
+		if schedule.TimeZone != "" {
+			if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+				allErrs = append(allErrs, field.Invalid(schedulePath.Child("timeZone"), schedule.TimeZone, "unknown time zone"))
+			}
+		}
+
+		if schedule.Duration.Duration <= 0 || schedule.Duration.Duration > algorithm.MaxScheduleDuration {
+			allErrs = append(allErrs, field.Invalid(
+				schedulePath.Child("duration"),
+				schedule.Duration,
+				"schedule duration must be greater than 0 and at most 7 days",
+			))
+		}
+	}
+
+	return allErrs
+}
+
+// validatePredictivePolicy validates the season, interval and lead time of the predictive policy
+func (v *AutoscalingPolicyValidator) validatePredictivePolicy(policy *registryv1.AutoscalingPolicy) field.ErrorList {
+	var allErrs field.ErrorList
+	predictive := policy.Spec.Predictive
+	if predictive == nil {
+		return allErrs
+	}
+	predictivePath := field.NewPath("spec").Child("predictive")
+
+	season, interval := 24*time.Hour, 5*time.Minute
+	if predictive.Season != nil {
+		season = predictive.Season.Duration
+	}
+	if predictive.Interval != nil {
+		interval = predictive.Interval.Duration
+	}
+
+	if interval < time.Minute {
+		allErrs = append(allErrs, field.Invalid(
+			predictivePath.Child("interval"),
+			predictive.Interval,
+			"predictive interval must be at least 1 minute",
+		))
+	} else if season < interval || season%interval != 0 || season/interval > maxSeasonIntervals {
+		allErrs = append(allErrs, field.Invalid(
+			predictivePath.Child("season"),
+			predictive.Season,
+			fmt.Sprintf("predictive season must be a multiple of the interval with at most %d intervals", maxSeasonIntervals),
+		))
+	}
+
+	if predictive.LeadTime != nil && (predictive.LeadTime.Duration < 0 || predictive.LeadTime.Duration > season) {
+		allErrs = append(allErrs, field.Invalid(
+			predictivePath.Child("leadTime"),
+			predictive.LeadTime,
+			"predictive lead time must be between 0 and the season",
+		))
+	}
+
+	return allErrs
+}
@@ -165,6 +165,73 @@ func TestValidateAutoscalingPolicy_NoErrors(t *testing.T) {
 	assert.Empty(t, errorMsg)
 }
 
+func TestValidateAutoscalingPolicy_SchedulesAndPredictive(t *testing.T) {
+	validator := NewAutoscalingPolicyValidator()
+	newPolicy := func(schedules []registryv1.AutoscalingSchedule, predictive *registryv1.PredictivePolicy) *registryv1.AutoscalingPolicy {
+		return &registryv1.AutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-policy", Namespace: "default"},
+			Spec: registryv1.AutoscalingPolicySpec{
+				Metrics:    []registryv1.AutoscalingPolicyMetric{{MetricName: "cpu", TargetValue: resource.MustParse("80")}},
+				Schedules:  schedules,
+				Predictive: predictive,
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		schedules  []registryv1.AutoscalingSchedule
+		predictive *registryv1.PredictivePolicy
+		wantErrs   []string
+	}{
+		{
+			name: "valid schedule and predictive policy",
+			schedules: []registryv1.AutoscalingSchedule{
+				{Name: "business-hours", Schedule: "0 8 * * MON-FRI", Duration: metav1.Duration{Duration: 10 * time.Hour}, TimeZone: "Europe/Paris", MinReplicas: 4},
+			},
+			predictive: &registryv1.PredictivePolicy{
+				Season:   &metav1.Duration{Duration: 24 * time.Hour},
+				Interval: &metav1.Duration{Duration: 5 * time.Minute},
+				LeadTime: &metav1.Duration{Duration: 10 * time.Minute},
+			},
+		},
+		{
+			name: "invalid schedule",
+			schedules: []registryv1.AutoscalingSchedule{
+				{Name: "invalid", Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: 8 * 24 * time.Hour}, TimeZone: "Mars/Olympus", MinReplicas: 1},
+			},
+			wantErrs: []string{"spec.schedules[0].schedule", "spec.schedules[0].timeZone", "spec.schedules[0].duration"},
+		},
+		{
+			name: "season not a multiple of the interval",
+			predictive: &registryv1.PredictivePolicy{
+				Season:   &metav1.Duration{Duration: 24 * time.Hour},
+				Interval: &metav1.Duration{Duration: 7 * time.Minute},
+			},
+			wantErrs: []string{"spec.predictive.season"},
+		},
+		{
+			name: "interval too short and lead time longer than the season",
+			predictive: &registryv1.PredictivePolicy{
+				Season:   &metav1.Duration{Duration: time.Hour},
+				Interval: &metav1.Duration{Duration: 30 * time.Second},
+				LeadTime: &metav1.Duration{Duration: 2 * time.Hour},
+			},
+			wantErrs: []string{"spec.predictive.interval", "spec.predictive.leadTime"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allowed, errorMsg := validator.validateAutoscalingPolicy(newPolicy(tt.schedules, tt.predictive))
+			assert.Equal(t, len(tt.wantErrs) == 0, allowed, errorMsg)
+			for _, wantErr := range tt.wantErrs {
+				assert.Contains(t, errorMsg, wantErr)
+			}
+		})
+	}
+}
+
 func TestAutoscalingPolicyValidator_Handle_ValidPolicy(t *testing.T) {
 	validator := NewAutoscalingPolicyValidator()
 
@@ -32,7 +32,7 @@ func TestCreatePatchNoChanges(t *testing.T) {
 			Namespace: "default",
 		},
 		Spec: v1alpha1.ModelBoosterSpec{
-			Backend: v1alpha1.ModelBackend{
+			Backend: &v1alpha1.ModelBackend{
 				Name:        "backend1",
 				Type:        "vLLM",
 				ModelURI:    "hf://test/model",
@@ -19,11 +19,14 @@ package webhook
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	registryv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-booster-controller/utils"
 	admissionv1 "k8s.io/api/admission/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 )
@@ -81,6 +84,10 @@ func (v *ModelValidator) validateModel(model *registryv1alpha1.ModelBooster) (bo
 	allErrs = append(allErrs, validateWorkerImages(model)...)
 	allErrs = append(allErrs, validateAutoScalingPolicyScope(model)...)
 	allErrs = append(allErrs, validateBackendWorkerTypes(model)...)
+	allErrs = append(allErrs, validateBackendWeights(model)...)
+	allErrs = append(allErrs, validateLoraAdapters(model)...)
+	allErrs = append(allErrs, validateModelArtifacts(model)...)
+	allErrs = append(allErrs, validateCacheWarming(model)...)
 
 	if len(allErrs) > 0 {
 		// Convert field errors to a formatted multi-line error message
@@ -93,12 +100,31 @@ func (v *ModelValidator) validateModel(model *registryv1alpha1.ModelBooster) (bo
 	return true, ""
 }
 
+// backendPath returns the field path of the i-th backend returned by utils.GetModelBackends.
+func backendPath(model *registryv1alpha1.ModelBooster, i int) *field.Path {
+	if model.Spec.Backend != nil {
+		return field.NewPath("spec").Child("backend")
+	}
+	return field.NewPath("spec").Child("backends").Index(i)
+}
+
 func validateBackendWorkerTypes(model *registryv1alpha1.ModelBooster) field.ErrorList {
 	var allErrs field.ErrorList
-	backendPath := field.NewPath("spec").Child("backend")
-	backend := model.Spec.Backend
+	for i, backend := range utils.GetModelBackends(model) {
+		allErrs = append(allErrs, validateWorkerTypes(backendPath(model, i), backend)...)
+	}
+	return allErrs
+}
+
+func validateWorkerTypes(backendPath *field.Path, backend *registryv1alpha1.ModelBackend) field.ErrorList {
+	var allErrs field.ErrorList
 	workers := backend.Workers
 
+	// SGLang -> either exactly one 'server' worker, or single-node 'prefill' and 'decode' workers for PD disaggregation
+	if backend.Type == registryv1alpha1.ModelBackendTypeSGLang && isPDDisaggregated(workers) {
+		return validateSGLangPDWorkers(backendPath, workers)
+	}
+
 	if backend.Type == registryv1alpha1.ModelBackendTypeVLLM ||
 		backend.Type == registryv1alpha1.ModelBackendTypeSGLang ||
 		backend.Type == registryv1alpha1.ModelBackendTypeMindIE {
@@ -114,19 +140,17 @@ func validateBackendWorkerTypes(model *registryv1alpha1.ModelBooster) field.Erro
 				workers[0].Type,
 				fmt.Sprintf("If backend type is '%s', the worker type must be 'server'", backend.Type),
 			))
+		} else if backend.Type == registryv1alpha1.ModelBackendTypeMindIE && workers[0].Pods > 1 {
+			allErrs = append(allErrs, field.Invalid(
+				backendPath.Child("workers").Index(0).Child("pods"),
+				workers[0].Pods,
+				"multi-node MindIE server is not supported, pods must be 1",
+			))
 		}
 	}
 
 	if backend.Type == registryv1alpha1.ModelBackendTypeVLLMDisaggregated {
-		for j, w := range workers {
-			if w.Type != registryv1alpha1.ModelWorkerTypePrefill && w.Type != registryv1alpha1.ModelWorkerTypeDecode {
-				allErrs = append(allErrs, field.Invalid(
-					backendPath.Child("workers").Index(j).Child("type"),
-					w.Type,
-					"If backend type is 'vLLMDisaggregated', all workers must be type 'prefill' or 'decode'",
-				))
-			}
-		}
+		allErrs = append(allErrs, validatePDWorkerTypes(backendPath, workers, "If backend type is 'vLLMDisaggregated', all workers must be type 'prefill' or 'decode'")...)
 	}
 
 	// Rule 3: MindIEDisaggregated -> all workers must be 'prefill', 'decode', 'controller', or 'coordinator'
@@ -150,40 +174,205 @@ func validateBackendWorkerTypes(model *registryv1alpha1.ModelBooster) field.Erro
 	return allErrs
 }
 
+// isPDDisaggregated checks whether any worker is a prefill or decode worker.
+func isPDDisaggregated(workers []registryv1alpha1.ModelWorker) bool {
+	for _, w := range workers {
+		if w.Type == registryv1alpha1.ModelWorkerTypePrefill || w.Type == registryv1alpha1.ModelWorkerTypeDecode {
+			return true
+		}
+	}
+	return false
+}
+
+// validatePDWorkerTypes checks that all workers are prefill or decode workers.
+func validatePDWorkerTypes(backendPath *field.Path, workers []registryv1alpha1.ModelWorker, message string) field.ErrorList {
+	var allErrs field.ErrorList
+	for j, w := range workers {
+		if w.Type != registryv1alpha1.ModelWorkerTypePrefill && w.Type != registryv1alpha1.ModelWorkerTypeDecode {
+			allErrs = append(allErrs, field.Invalid(
+				backendPath.Child("workers").Index(j).Child("type"),
+				w.Type,
+				message,
+			))
+		}
+	}
+	return allErrs
+}
+
+// validateSGLangPDWorkers checks that the workers of an SGLang PD disaggregated backend are a prefill and a decode
+// worker, each of them running on a single node.
+func validateSGLangPDWorkers(backendPath *field.Path, workers []registryv1alpha1.ModelWorker) field.ErrorList {
+	allErrs := validatePDWorkerTypes(backendPath, workers, "If backend type is 'SGLang' with prefill or decode workers, all workers must be type 'prefill' or 'decode'")
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+	found := map[registryv1alpha1.ModelWorkerType]bool{}
+	for j, w := range workers {
+		found[w.Type] = true
+		if w.Pods > 1 {
+			allErrs = append(allErrs, field.Invalid(
+				backendPath.Child("workers").Index(j).Child("pods"),
+				w.Pods,
+				"multi-node SGLang prefill and decode workers are not supported, pods must be 1",
+			))
+		}
+	}
+	if !found[registryv1alpha1.ModelWorkerTypePrefill] || !found[registryv1alpha1.ModelWorkerTypeDecode] {
+		allErrs = append(allErrs, field.Required(backendPath.Child("workers"), "both a 'prefill' and a 'decode' worker are required"))
+	}
+	return allErrs
+}
+
 func validateBackendReplicaBounds(model *registryv1alpha1.ModelBooster) field.ErrorList {
 	var allErrs field.ErrorList
-	path := field.NewPath("spec").Child("backend")
 	const maxTotalReplicas = 1000000
-	backend := model.Spec.Backend
-	if backend.MinReplicas > backend.MaxReplicas {
+	var totalMaxReplicas int64
+	for i, backend := range utils.GetModelBackends(model) {
+		if backend.MinReplicas > backend.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(
+				backendPath(model, i).Child("minReplicas"),
+				backend.MinReplicas,
+				"minReplicas cannot be greater than maxReplicas",
+			))
+		}
+		totalMaxReplicas += int64(backend.MaxReplicas)
+	}
+
+	if totalMaxReplicas > maxTotalReplicas {
+		path := field.NewPath("spec").Child("backend")
+		if model.Spec.Backend == nil {
+			path = field.NewPath("spec").Child("backends")
+		}
 		allErrs = append(allErrs, field.Invalid(
-			path.Child("minReplicas"),
-			backend.MinReplicas,
-			"minReplicas cannot be greater than maxReplicas",
+			path,
+			totalMaxReplicas,
+			fmt.Sprintf("sum of maxReplicas across all backends (%d) cannot exceed %d", totalMaxReplicas, maxTotalReplicas),
 		))
 	}
+	return allErrs
+}
 
-	if backend.MaxReplicas > maxTotalReplicas {
+// validateBackendWeights checks that the weight is set on either all or none of the backends,
+// as the router can't split requests between weighted and unweighted targets.
+func validateBackendWeights(model *registryv1alpha1.ModelBooster) field.ErrorList {
+	var allErrs field.ErrorList
+	backends := utils.GetModelBackends(model)
+	if len(backends) < 2 {
+		return allErrs
+	}
+	weighted := backends[0].Weight != nil
+	var totalWeight uint32
+	for i, backend := range backends {
+		if (backend.Weight != nil) != weighted {
+			allErrs = append(allErrs, field.Invalid(
+				backendPath(model, i).Child("weight"),
+				backend.Weight,
+				"weight must be set on either all or none of the backends",
+			))
+		}
+		if backend.Weight != nil {
+			totalWeight += *backend.Weight
+		}
+	}
+	if weighted && len(allErrs) == 0 && totalWeight == 0 {
 		allErrs = append(allErrs, field.Invalid(
-			path,
-			backend.MaxReplicas,
-			fmt.Sprintf("sum of maxReplicas across all backends (%d) cannot exceed %d", backend.MaxReplicas, maxTotalReplicas),
+			backendPath(model, 0).Child("weight"),
+			backends[0].Weight,
+			"the weights of the backends must not all be zero",
 		))
 	}
 	return allErrs
 }
 
+// maxRouteLoraAdapters is the maximum number of LoRA adapters of a ModelRoute.
+const maxRouteLoraAdapters = 10
+
+// validateLoraAdapters checks that LoRA adapters are only set on the backends which can load them at runtime, and
+// that the ModelRoute of the model can route all of them.
+func validateLoraAdapters(model *registryv1alpha1.ModelBooster) field.ErrorList {
+	var allErrs field.ErrorList
+	names := sets.New[string]()
+	for i, backend := range utils.GetModelBackends(model) {
+		if len(backend.LoraAdapters) == 0 {
+			continue
+		}
+		routable := names.Len() <= maxRouteLoraAdapters
+		for _, adapter := range backend.LoraAdapters {
+			names.Insert(adapter.Name)
+		}
+		if routable && names.Len() > maxRouteLoraAdapters {
+			allErrs = append(allErrs, field.TooMany(backendPath(model, i).Child("loraAdapters"), names.Len(), maxRouteLoraAdapters))
+		}
+		if backend.Type != registryv1alpha1.ModelBackendTypeVLLM && backend.Type != registryv1alpha1.ModelBackendTypeVLLMDisaggregated {
+			allErrs = append(allErrs, field.Forbidden(
+				backendPath(model, i).Child("loraAdapters"),
+				fmt.Sprintf("LoRA adapters are not supported by %s backend", backend.Type),
+			))
+		}
+	}
+	return allErrs
+}
+
+// validateModelArtifacts checks the image of OCI artifacts, and that the model path of mounted artifacts stays
+// inside the volume.
+func validateModelArtifacts(model *registryv1alpha1.ModelBooster) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, backend := range utils.GetModelBackends(model) {
+		artifact := backend.Artifact
+		if artifact == nil {
+			continue
+		}
+		artifactPath := backendPath(model, i).Child("artifact")
+		var modelPath string
+		switch {
+		case artifact.OCI != nil:
+			if err := validateImageField(artifact.OCI.Image); err != nil {
+				allErrs = append(allErrs, field.Invalid(artifactPath.Child("oci", "image"), artifact.OCI.Image,
+					fmt.Sprintf("invalid image reference: %v", err)))
+			}
+			modelPath, artifactPath = artifact.OCI.Path, artifactPath.Child("oci", "path")
+		case artifact.ExistingPVC != nil:
+			modelPath, artifactPath = artifact.ExistingPVC.Path, artifactPath.Child("existingPVC", "path")
+		case artifact.VolumeSnapshot != nil:
+			modelPath, artifactPath = artifact.VolumeSnapshot.Path, artifactPath.Child("volumeSnapshot", "path")
+		}
+		if slices.Contains(strings.Split(modelPath, "/"), "..") {
+			allErrs = append(allErrs, field.Invalid(artifactPath, modelPath, "must not contain '..'"))
+		}
+	}
+	return allErrs
+}
+
+// validateCacheWarming checks that the backends warming their cache download the model into a cache.
+func validateCacheWarming(model *registryv1alpha1.ModelBooster) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, backend := range utils.GetModelBackends(model) {
+		if backend.CacheWarming == nil {
+			continue
+		}
+		warmingPath := backendPath(model, i).Child("cacheWarming")
+		if backend.CacheURI == "" {
+			allErrs = append(allErrs, field.Forbidden(warmingPath, "cache warming requires cacheURI"))
+		}
+		if backend.Artifact != nil && (backend.Artifact.OCI != nil || backend.Artifact.ExistingPVC != nil || backend.Artifact.VolumeSnapshot != nil) {
+			allErrs = append(allErrs, field.Forbidden(warmingPath, "cache warming is not supported by mounted OCI, existing PVC and VolumeSnapshot artifacts"))
+		}
+	}
+	return allErrs
+}
+
 func validateWorkerImages(model *registryv1alpha1.ModelBooster) field.ErrorList {
 	var allErrs field.ErrorList
-	backend := model.Spec.Backend
-	for j, worker := range backend.Workers {
-		if worker.Image != "" {
-			if err := validateImageField(worker.Image); err != nil {
-				allErrs = append(allErrs, field.Invalid(
-					field.NewPath("spec").Child("backend").Child("workers").Index(j).Child("image"),
-					worker.Image,
-					fmt.Sprintf("invalid container image reference: %v", err),
-				))
+	for i, backend := range utils.GetModelBackends(model) {
+		for j, worker := range backend.Workers {
+			if worker.Image != "" {
+				if err := validateImageField(worker.Image); err != nil {
+					allErrs = append(allErrs, field.Invalid(
+						backendPath(model, i).Child("workers").Index(j).Child("image"),
+						worker.Image,
+						fmt.Sprintf("invalid container image reference: %v", err),
+					))
+				}
 			}
 		}
 	}
@@ -220,23 +409,24 @@ func validateAutoScalingPolicyScope(model *registryv1alpha1.ModelBooster) field.
 	var allErrs field.ErrorList
 
 	modelAutoScalingEmpty := spec.AutoscalingPolicy == nil
-	backend := spec.Backend
 
-	if modelAutoScalingEmpty {
-		if backend.MinReplicas != backend.MaxReplicas {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("backend"),
-				fmt.Sprintf("minReplicas=%d, maxReplicas=%d", backend.MinReplicas, backend.MaxReplicas),
-				"minReplicas and maxReplicas must be equal and > 0 when no autoscaling is set",
-			))
-		}
-	} else {
-		if backend.MinReplicas < 0 {
-			allErrs = append(allErrs, field.Invalid(
-				field.NewPath("spec").Child("backend").Child("minReplicas"),
-				backend.MinReplicas,
-				"minReplicas must be >= 0 when model-level autoscaling is set",
-			))
+	for i, backend := range utils.GetModelBackends(model) {
+		if modelAutoScalingEmpty {
+			if backend.MinReplicas != backend.MaxReplicas {
+				allErrs = append(allErrs, field.Invalid(
+					backendPath(model, i),
+					fmt.Sprintf("minReplicas=%d, maxReplicas=%d", backend.MinReplicas, backend.MaxReplicas),
+					"minReplicas and maxReplicas must be equal and > 0 when no autoscaling is set",
+				))
+			}
+		} else {
+			if backend.MinReplicas < 0 {
+				allErrs = append(allErrs, field.Invalid(
+					backendPath(model, i).Child("minReplicas"),
+					backend.MinReplicas,
+					"minReplicas must be >= 0 when model-level autoscaling is set",
+				))
+			}
 		}
 	}
 	return allErrs
@@ -17,12 +17,15 @@ limitations under the License.
 package webhook
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	registryv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateModel_ErrorFormatting(t *testing.T) {
@@ -36,7 +39,7 @@ func TestValidateModel_ErrorFormatting(t *testing.T) {
 		},
 		Spec: registryv1alpha1.ModelBoosterSpec{
 			// This will trigger validation errors for autoscaling-related fields
-			Backend: registryv1alpha1.ModelBackend{
+			Backend: &registryv1alpha1.ModelBackend{
 				Name:        "backend1",
 				Type:        registryv1alpha1.ModelBackendTypeVLLM,
 				MinReplicas: 1,
@@ -92,7 +95,7 @@ func TestValidateModel_NoErrors(t *testing.T) {
 			Namespace: "default",
 		},
 		Spec: registryv1alpha1.ModelBoosterSpec{
-			Backend: registryv1alpha1.ModelBackend{
+			Backend: &registryv1alpha1.ModelBackend{
 				Name:        "backend1",
 				Type:        registryv1alpha1.ModelBackendTypeVLLM,
 				MinReplicas: 1,
@@ -114,3 +117,245 @@ func TestValidateModel_NoErrors(t *testing.T) {
 	assert.True(t, valid)
 	assert.Empty(t, errorMsg)
 }
+
+func TestValidateBackendWorkerTypes_SGLangAndMindIE(t *testing.T) {
+	newModel := func(backendType registryv1alpha1.ModelBackendType, pods int32, workerTypes ...registryv1alpha1.ModelWorkerType) *registryv1alpha1.ModelBooster {
+		model := &registryv1alpha1.ModelBooster{
+			Spec: registryv1alpha1.ModelBoosterSpec{
+				Backend: &registryv1alpha1.ModelBackend{
+					Name: "backend1",
+					Type: backendType,
+				},
+			},
+		}
+		for _, workerType := range workerTypes {
+			model.Spec.Backend.Workers = append(model.Spec.Backend.Workers, registryv1alpha1.ModelWorker{Type: workerType, Pods: 1})
+		}
+		// Only the first worker runs on several pods.
+		model.Spec.Backend.Workers[0].Pods = pods
+		return model
+	}
+
+	tests := []struct {
+		name        string
+		backendType registryv1alpha1.ModelBackendType
+		pods        int32
+		workerTypes []registryv1alpha1.ModelWorkerType
+		expectErr   string
+	}{
+		{
+			name:        "server worker",
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypeServer},
+		},
+		{
+			name:        "prefill and decode workers",
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypePrefill, registryv1alpha1.ModelWorkerTypeDecode},
+		},
+		{
+			name:        "prefill worker without decode worker",
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypePrefill},
+			expectErr:   "both a 'prefill' and a 'decode' worker are required",
+		},
+		{
+			name:        "server worker mixed with prefill worker",
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypePrefill, registryv1alpha1.ModelWorkerTypeServer},
+			expectErr:   "all workers must be type 'prefill' or 'decode'",
+		},
+		{
+			name:        "multi-node server worker",
+			pods:        2,
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypeServer},
+		},
+		{
+			name:        "multi-node prefill and decode workers",
+			pods:        2,
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypePrefill, registryv1alpha1.ModelWorkerTypeDecode},
+			expectErr:   "multi-node SGLang prefill and decode workers are not supported",
+		},
+		{
+			name:        "multi-node MindIE server worker",
+			backendType: registryv1alpha1.ModelBackendTypeMindIE,
+			pods:        2,
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypeServer},
+			expectErr:   "multi-node MindIE server is not supported",
+		},
+		{
+			name:        "vLLMDisaggregated prefill worker without decode worker",
+			backendType: registryv1alpha1.ModelBackendTypeVLLMDisaggregated,
+			workerTypes: []registryv1alpha1.ModelWorkerType{registryv1alpha1.ModelWorkerTypePrefill},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backendType := tt.backendType
+			if backendType == "" {
+				backendType = registryv1alpha1.ModelBackendTypeSGLang
+			}
+			pods := tt.pods
+			if pods == 0 {
+				pods = 1
+			}
+			errs := validateBackendWorkerTypes(newModel(backendType, pods, tt.workerTypes...))
+			if tt.expectErr == "" {
+				assert.Empty(t, errs)
+				return
+			}
+			assert.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), tt.expectErr)
+		})
+	}
+}
+
+func TestValidateModel_MultipleBackends(t *testing.T) {
+	newBackend := func(name string, weight *uint32) registryv1alpha1.ModelBackend {
+		return registryv1alpha1.ModelBackend{
+			Name:        name,
+			Type:        registryv1alpha1.ModelBackendTypeVLLM,
+			MinReplicas: 1,
+			MaxReplicas: 1,
+			Weight:      weight,
+			Workers:     []registryv1alpha1.ModelWorker{{Type: registryv1alpha1.ModelWorkerTypeServer, Image: "vllm:latest"}},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		backends  []registryv1alpha1.ModelBackend
+		expectErr string
+	}{
+		{
+			name:     "weighted backends",
+			backends: []registryv1alpha1.ModelBackend{newBackend("gpu", ptr.To[uint32](70)), newBackend("npu", ptr.To[uint32](30))},
+		},
+		{
+			name:     "unweighted backends",
+			backends: []registryv1alpha1.ModelBackend{newBackend("gpu", nil), newBackend("npu", nil)},
+		},
+		{
+			name:      "weight set on part of the backends",
+			backends:  []registryv1alpha1.ModelBackend{newBackend("gpu", ptr.To[uint32](70)), newBackend("npu", nil)},
+			expectErr: "spec.backends[1].weight: Invalid value",
+		},
+		{
+			name:      "zero weights",
+			backends:  []registryv1alpha1.ModelBackend{newBackend("gpu", ptr.To[uint32](0)), newBackend("npu", ptr.To[uint32](0))},
+			expectErr: "the weights of the backends must not all be zero",
+		},
+		{
+			name: "invalid worker of the second backend",
+			backends: func() []registryv1alpha1.ModelBackend {
+				npu := newBackend("npu", nil)
+				npu.Workers[0].Type = registryv1alpha1.ModelWorkerTypePrefill
+				return []registryv1alpha1.ModelBackend{newBackend("gpu", nil), npu}
+			}(),
+			expectErr: "spec.backends[1].workers[0].type: Invalid value",
+		},
+		{
+			name: "maxReplicas summed across backends",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu, npu := newBackend("gpu", nil), newBackend("npu", nil)
+				gpu.MinReplicas, gpu.MaxReplicas = 600000, 600000
+				npu.MinReplicas, npu.MaxReplicas = 600000, 600000
+				return []registryv1alpha1.ModelBackend{gpu, npu}
+			}(),
+			expectErr: "sum of maxReplicas across all backends (1200000) cannot exceed 1000000",
+		},
+		{
+			name: "LoRA adapters on vLLM backend",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu := newBackend("gpu", nil)
+				gpu.LoraAdapters = []registryv1alpha1.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+				return []registryv1alpha1.ModelBackend{gpu, newBackend("npu", nil)}
+			}(),
+		},
+		{
+			name: "LoRA adapters on SGLang backend",
+			backends: func() []registryv1alpha1.ModelBackend {
+				npu := newBackend("npu", nil)
+				npu.Type = registryv1alpha1.ModelBackendTypeSGLang
+				npu.LoraAdapters = []registryv1alpha1.LoraAdapter{{Name: "sql-lora", ArtifactURL: "hf://yard1/llama-2-7b-sql-lora-test"}}
+				return []registryv1alpha1.ModelBackend{newBackend("gpu", nil), npu}
+			}(),
+			expectErr: "spec.backends[1].loraAdapters: Forbidden: LoRA adapters are not supported by SGLang backend",
+		},
+		{
+			name: "too many LoRA adapters to route",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu, npu := newBackend("gpu", nil), newBackend("npu", nil)
+				for i := 0; i < 6; i++ {
+					gpu.LoraAdapters = append(gpu.LoraAdapters, registryv1alpha1.LoraAdapter{Name: fmt.Sprintf("gpu-lora-%d", i), ArtifactURL: "hf://org/lora"})
+					npu.LoraAdapters = append(npu.LoraAdapters, registryv1alpha1.LoraAdapter{Name: fmt.Sprintf("npu-lora-%d", i), ArtifactURL: "hf://org/lora"})
+				}
+				return []registryv1alpha1.ModelBackend{gpu, npu}
+			}(),
+			expectErr: "spec.backends[1].loraAdapters: Too many: 12: must have at most 10 items",
+		},
+		{
+			name: "OCI artifact",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu := newBackend("gpu", nil)
+				gpu.Artifact = &registryv1alpha1.ModelArtifact{
+					OCI: &registryv1alpha1.OCIArtifactSource{Image: "registry.example.com/models/llama:v1", Path: "weights/llama"},
+				}
+				return []registryv1alpha1.ModelBackend{gpu, newBackend("npu", nil)}
+			}(),
+		},
+		{
+			name: "existing PVC artifact path outside of the volume",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu := newBackend("gpu", nil)
+				gpu.Artifact = &registryv1alpha1.ModelArtifact{
+					ExistingPVC: &registryv1alpha1.ExistingPVCArtifactSource{ClaimName: "models", Path: "llama/../../etc"},
+				}
+				return []registryv1alpha1.ModelBackend{gpu, newBackend("npu", nil)}
+			}(),
+			expectErr: "spec.backends[0].artifact.existingPVC.path: Invalid value: \"llama/../../etc\": must not contain '..'",
+		},
+		{
+			name: "VolumeSnapshot artifact with cache warming",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu := newBackend("gpu", nil)
+				gpu.CacheURI = "hostpath://models"
+				gpu.CacheWarming = &registryv1alpha1.CacheWarming{}
+				gpu.Artifact = &registryv1alpha1.ModelArtifact{
+					VolumeSnapshot: &registryv1alpha1.VolumeSnapshotArtifactSource{SnapshotName: "llama", Size: resource.MustParse("100Gi")},
+				}
+				return []registryv1alpha1.ModelBackend{gpu, newBackend("npu", nil)}
+			}(),
+			expectErr: "spec.backends[0].cacheWarming: Forbidden: cache warming is not supported by mounted OCI, existing PVC and VolumeSnapshot artifacts",
+		},
+		{
+			name: "cache warming with hostpath cache",
+			backends: func() []registryv1alpha1.ModelBackend {
+				gpu := newBackend("gpu", nil)
+				gpu.CacheURI = "hostpath://models"
+				gpu.CacheWarming = &registryv1alpha1.CacheWarming{NodeSelector: map[string]string{"pool": "gpu"}}
+				return []registryv1alpha1.ModelBackend{gpu, newBackend("npu", nil)}
+			}(),
+		},
+		{
+			name: "cache warming without cache",
+			backends: func() []registryv1alpha1.ModelBackend {
+				npu := newBackend("npu", nil)
+				npu.CacheWarming = &registryv1alpha1.CacheWarming{}
+				return []registryv1alpha1.ModelBackend{newBackend("gpu", nil), npu}
+			}(),
+			expectErr: "spec.backends[1].cacheWarming: Forbidden: cache warming requires cacheURI",
+		},
+	}
+	validator := NewModelValidator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			model := &registryv1alpha1.ModelBooster{
+				Spec: registryv1alpha1.ModelBoosterSpec{Backends: tt.backends},
+			}
+			valid, msg := validator.validateModel(model)
+			if tt.expectErr == "" {
+				assert.True(t, valid, msg)
+				return
+			}
+			assert.False(t, valid)
+			assert.Contains(t, msg, tt.expectErr)
+		})
+	}
+}
@@ -39,6 +39,7 @@ const (
 	PriorityServingGroupDeleting = 0
 	PriorityServingGroupScaling  = 0
 	PriorityServingGroupNotFound = 0
+	PriorityServingGroupFailed   = 0
 	PriorityServingGroupRunning  = 1
 
 	// Role priorities:
@@ -49,11 +50,12 @@ const (
 )
 
 // ServingGroupWithScore stores serving group deletion priority information
-// Priority order: Status (primary) > Deletion cost (secondary) > Index (tertiary)
+// Priority order: Status (primary) > Outdated revision (secondary) > Deletion cost (tertiary) > Index (quaternary)
 type ServingGroupWithScore struct {
 	Name         string
-	Priority     int // Status priority from getServingGroupStatusPriority()
-	DeletionCost int // Higher cost = more protected (lower deletion priority)
+	Priority     int  // Status priority from getServingGroupStatusPriority()
+	Outdated     bool // Outdated groups are deleted before updated ones, e.g. while a surge rolling update is in progress
+	DeletionCost int  // Higher cost = more protected (lower deletion priority)
 	Index        int
 }
 
@@ -80,6 +82,8 @@ func getServingGroupStatusPriority(status datastore.ServingGroupStatus) int {
 		return PriorityServingGroupScaling
 	case datastore.ServingGroupNotFound:
 		return PriorityServingGroupNotFound
+	case datastore.ServingGroupFailed:
+		return PriorityServingGroupFailed
 	default:
 		// Unknown status - treat as highest deletion priority (safety first)
 		return 0
@@ -152,14 +156,21 @@ func (c *ModelServingController) calculateRoleScore(ms *workloadv1alpha1.ModelSe
 	}
 }
 
-// calculateServingGroupScore calculates priority information for serving group scale-down with two-level sorting:
+// calculateServingGroupScore calculates priority information for serving group scale-down with three-level sorting:
 // 1. Status readiness (primary): Not-ready groups should be deleted first
-// 2. Pod deletion cost (secondary): Among groups with same status, lower cost = delete first
+// 2. Revision (secondary): Among groups with same status, groups not at the update revision are deleted first,
+// so that scaling down during a surge rolling update never removes the freshly surged groups
+// 3. Pod deletion cost (tertiary): Among groups with same status and revision, lower cost = delete first
 func (c *ModelServingController) calculateServingGroupScore(ms *workloadv1alpha1.ModelServing, groupName string) ServingGroupWithScore {
 	// Get serving group status from store
 	groupStatus := c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName)
 	priority := getServingGroupStatusPriority(groupStatus)
 
+	outdated := false
+	if revision, ok := c.store.GetServingGroupRevision(utils.GetNamespaceName(ms), groupName); ok && ms.Status.UpdateRevision != "" {
+		outdated = revision != ms.Status.UpdateRevision
+	}
+
 	// Get pod deletion cost as secondary factor
 	groupNameValue := fmt.Sprintf("%s/%s", ms.Namespace, groupName)
 	pods, err := c.getPodsByIndex(GroupNameKey, groupNameValue)
@@ -168,6 +179,7 @@ func (c *ModelServingController) calculateServingGroupScore(ms *workloadv1alpha1
 		return ServingGroupWithScore{
 			Name:         groupName,
 			Priority:     priority,
+			Outdated:     outdated,
 			DeletionCost: 0, // Default to 0 on error
 			Index:        index,
 		}
@@ -184,6 +196,7 @@ func (c *ModelServingController) calculateServingGroupScore(ms *workloadv1alpha1
 	return ServingGroupWithScore{
 		Name:         groupName,
 		Priority:     priority,
+		Outdated:     outdated,
 		DeletionCost: deletionCost,
 		Index:        index,
 	}
@@ -19,21 +19,32 @@ package controller
 import (
 	"context"
 	"fmt"
+	"maps"
 	"reflect"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	listerv1 "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 	lwsclientset "sigs.k8s.io/lws/client-go/clientset/versioned"
+	lwsscheme "sigs.k8s.io/lws/client-go/clientset/versioned/scheme"
 	lwsinformers "sigs.k8s.io/lws/client-go/informers/externalversions"
 	lwslisters "sigs.k8s.io/lws/client-go/listers/leaderworkerset/v1"
 
@@ -41,6 +52,7 @@ import (
 	kthenainformers "github.com/volcano-sh/kthena/client-go/informers/externalversions"
 	kthenalisters "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
 	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
 )
 
 func InitializeLWSController(
@@ -63,8 +75,14 @@ func InitializeLWSController(
 
 	lwsInformerFactory := lwsinformers.NewSharedInformerFactory(lwsClient, 0)
 	kthenaInformerFactory := kthenainformers.NewSharedInformerFactory(kthenaClient, 0)
-
-	controller, err := NewLWSController(kubeClient, kthenaClient, lwsClient, lwsInformerFactory, kthenaInformerFactory)
+	// The only services of the controller are the subdomain services of the LeaderWorkerSets.
+	kubeInformerFactory := informers.NewSharedInformerFactoryWithOptions(kubeClient, 0,
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.LabelSelector = lwsv1.SetNameLabelKey
+		}),
+	)
+
+	controller, err := NewLWSController(kubeClient, kthenaClient, lwsClient, lwsInformerFactory, kthenaInformerFactory, kubeInformerFactory)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create LWS controller: %v", err)
 	}
@@ -83,6 +101,10 @@ type LWSController struct {
 	lwsSynced             cache.InformerSynced
 	modelServingLister    kthenalisters.ModelServingLister
 	modelServingSynced    cache.InformerSynced
+	kubeInformerFactory   informers.SharedInformerFactory
+	serviceLister         listerv1.ServiceLister
+	serviceSynced         cache.InformerSynced
+	recorder              record.EventRecorder
 
 	workqueue workqueue.TypedRateLimitingInterface[string]
 }
@@ -93,9 +115,21 @@ func NewLWSController(
 	lwsClient lwsclientset.Interface,
 	lwsInformer lwsinformers.SharedInformerFactory,
 	kthenaInformer kthenainformers.SharedInformerFactory,
+	kubeInformer informers.SharedInformerFactory,
 ) (*LWSController, error) {
 	lwsInformerInstance := lwsInformer.Leaderworkerset().V1().LeaderWorkerSets()
 	modelServingInformerInstance := kthenaInformer.Workload().V1alpha1().ModelServings()
+	serviceInformerInstance := kubeInformer.Core().V1().Services()
+
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartStructuredLogging(0)
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{
+		Interface: kubeClient.CoreV1().Events(""),
+	})
+	recorder := eventBroadcaster.NewRecorder(
+		lwsscheme.Scheme,
+		corev1.EventSource{Component: "lws-controller"},
+	)
 
 	c := &LWSController{
 		kubeClient:            kubeClient,
@@ -107,6 +141,10 @@ func NewLWSController(
 		lwsSynced:             lwsInformerInstance.Informer().HasSynced,
 		modelServingLister:    modelServingInformerInstance.Lister(),
 		modelServingSynced:    modelServingInformerInstance.Informer().HasSynced,
+		kubeInformerFactory:   kubeInformer,
+		serviceLister:         serviceInformerInstance.Lister(),
+		serviceSynced:         serviceInformerInstance.Informer().HasSynced,
+		recorder:              recorder,
 		workqueue: workqueue.NewTypedRateLimitingQueueWithConfig(
 			workqueue.DefaultTypedControllerRateLimiter[string](),
 			workqueue.TypedRateLimitingQueueConfig[string]{Name: "LeaderWorkerSets"},
@@ -140,6 +178,13 @@ func NewLWSController(
 		return nil, err
 	}
 
+	_, err = serviceInformerInstance.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: c.handleObject,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	return c, nil
 }
 
@@ -151,9 +196,10 @@ func (c *LWSController) Run(ctx context.Context, workers int) error {
 
 	c.lwsInformerFactory.Start(ctx.Done())
 	c.kthenaInformerFactory.Start(ctx.Done())
+	c.kubeInformerFactory.Start(ctx.Done())
 
 	klog.Info("Waiting for informer caches to sync")
-	if ok := cache.WaitForCacheSync(ctx.Done(), c.lwsSynced, c.modelServingSynced); !ok {
+	if ok := cache.WaitForCacheSync(ctx.Done(), c.lwsSynced, c.modelServingSynced, c.serviceSynced); !ok {
 		return fmt.Errorf("failed to wait for caches to sync")
 	}
 
@@ -214,10 +260,20 @@ func (c *LWSController) syncHandler(ctx context.Context, key string) error {
 		return err
 	}
 
+	desiredMs, err := c.constructModelServing(lws)
+	if err != nil {
+		// The LeaderWorkerSet is requeued when it is updated.
+		return c.recordUnsupportedLWS(ctx, lws, err)
+	}
+
+	if err := c.syncSubdomainService(ctx, lws); err != nil {
+		return err
+	}
+
 	msName := lws.Name
 	ms, err := c.modelServingLister.ModelServings(namespace).Get(msName)
 	if errors.IsNotFound(err) {
-		ms = c.constructModelServing(lws)
+		ms = desiredMs
 		_, err = c.kthenaClient.WorkloadV1alpha1().ModelServings(namespace).Create(ctx, ms, metav1.CreateOptions{})
 		if err != nil {
 			return err
@@ -225,7 +281,6 @@ func (c *LWSController) syncHandler(ctx context.Context, key string) error {
 	} else if err != nil {
 		return err
 	} else {
-		desiredMs := c.constructModelServing(lws)
 		if !reflect.DeepEqual(ms.Spec, desiredMs.Spec) {
 			msCopy := ms.DeepCopy()
 			msCopy.Spec = desiredMs.Spec
@@ -291,51 +346,77 @@ func (c *LWSController) handleObject(obj interface{}) {
 	}
 }
 
-func (c *LWSController) constructModelServing(lws *lwsv1.LeaderWorkerSet) *workloadv1alpha1.ModelServing {
+// lwsRoleName is the name of the role the groups of a LeaderWorkerSet are mapped to.
+const lwsRoleName = "default"
+
+// lwsUnsupportedReason is the reason of the Progressing condition of a LeaderWorkerSet which cannot be translated
+// to a ModelServing.
+const lwsUnsupportedReason = "UnsupportedSpec"
+
+func (c *LWSController) constructModelServing(lws *lwsv1.LeaderWorkerSet) (*workloadv1alpha1.ModelServing, error) {
+	// The leader and the workers of a ServingGroup are created together, so the workers cannot be delayed
+	// until the leader is ready.
+	if lws.Spec.StartupPolicy == lwsv1.LeaderReadyStartupPolicy {
+		return nil, fmt.Errorf("startup policy %s is not supported", lwsv1.LeaderReadyStartupPolicy)
+	}
+	// The pods of a ServingGroup don't have a subdomain of their own.
+	if policy := lwsSubdomainPolicy(lws); policy != lwsv1.SubdomainShared {
+		return nil, fmt.Errorf("subdomain policy %s is not supported", policy)
+	}
+
 	replicas := int32(1)
 	if lws.Spec.Replicas != nil {
 		replicas = *lws.Spec.Replicas
 	}
 
-	convertTemplate := func(src corev1.PodTemplateSpec) workloadv1alpha1.PodTemplateSpec {
+	lwsTemplate := lws.Spec.LeaderWorkerTemplate
+	// The subgroups are not scheduled by ModelServing, their annotations are only set for the workloads reading them.
+	var annotations, leaderAnnotations map[string]string
+	if policy := lwsTemplate.SubGroupPolicy; policy != nil && policy.SubGroupSize != nil {
+		annotations = map[string]string{lwsv1.SubGroupSizeAnnotationKey: strconv.Itoa(int(*policy.SubGroupSize))}
+		policyType := lwsv1.SubGroupPolicyTypeLeaderWorker
+		if policy.Type != nil {
+			policyType = *policy.Type
+		}
+		leaderAnnotations = map[string]string{lwsv1.SubGroupPolicyTypeAnnotationKey: string(policyType)}
+	}
+
+	convertTemplate := func(src corev1.PodTemplateSpec, extraAnnotations ...map[string]string) workloadv1alpha1.PodTemplateSpec {
+		spec := *src.Spec.DeepCopy()
+		// Like LeaderWorkerSet, all pods are reachable at <pod>.<lws>, through the subdomain service.
+		spec.Subdomain = lws.Name
+		addExclusiveTopologyAffinity(&spec, lws.Annotations[lwsv1.ExclusiveKeyAnnotationKey])
 		return workloadv1alpha1.PodTemplateSpec{
 			Metadata: &workloadv1alpha1.Metadata{
 				Labels:      src.ObjectMeta.Labels,
-				Annotations: src.ObjectMeta.Annotations,
+				Annotations: mergeStringMaps(src.ObjectMeta.Annotations, extraAnnotations...),
 			},
-			Spec: src.Spec,
+			Spec: spec,
 		}
 	}
 
-	convertTemplatePtr := func(src *corev1.PodTemplateSpec) *workloadv1alpha1.PodTemplateSpec {
-		if src == nil {
-			return nil
-		}
-		t := convertTemplate(*src)
-		return &t
-	}
-
 	workerSize := int32(1)
-	if lws.Spec.LeaderWorkerTemplate.Size != nil {
-		workerSize = *lws.Spec.LeaderWorkerTemplate.Size
+	if lwsTemplate.Size != nil {
+		workerSize = *lwsTemplate.Size
 	}
 	workerReplicas := max(workerSize-1, 0)
 
 	roleReplicas := int32(1)
 
 	var leaderTemplate corev1.PodTemplateSpec
-	if lws.Spec.LeaderWorkerTemplate.LeaderTemplate != nil {
-		leaderTemplate = *lws.Spec.LeaderWorkerTemplate.LeaderTemplate
+	if lwsTemplate.LeaderTemplate != nil {
+		leaderTemplate = *lwsTemplate.LeaderTemplate
 	} else {
-		leaderTemplate = lws.Spec.LeaderWorkerTemplate.WorkerTemplate
+		leaderTemplate = lwsTemplate.WorkerTemplate
 	}
+	workerTemplate := convertTemplate(lwsTemplate.WorkerTemplate, annotations)
 
 	role := workloadv1alpha1.Role{
-		Name:           "default",
+		Name:           lwsRoleName,
 		Replicas:       &roleReplicas,
-		EntryTemplate:  convertTemplate(leaderTemplate),
+		EntryTemplate:  convertTemplate(leaderTemplate, annotations, leaderAnnotations),
 		WorkerReplicas: workerReplicas,
-		WorkerTemplate: convertTemplatePtr(&lws.Spec.LeaderWorkerTemplate.WorkerTemplate),
+		WorkerTemplate: &workerTemplate,
 	}
 
 	ms := &workloadv1alpha1.ModelServing{
@@ -351,16 +432,182 @@ func (c *LWSController) constructModelServing(lws *lwsv1.LeaderWorkerSet) *workl
 			Template: workloadv1alpha1.ServingGroup{
 				Roles: []workloadv1alpha1.Role{role},
 			},
+			RolloutStrategy: convertRolloutStrategy(lws.Spec.RolloutStrategy),
+			RecoveryPolicy:  convertRestartPolicy(lwsTemplate.RestartPolicy),
 		},
 	}
-	return ms
+	return ms, nil
 }
 
-func (c *LWSController) updateLWSStatus(ctx context.Context, lws *lwsv1.LeaderWorkerSet, ms *workloadv1alpha1.ModelServing) error {
-	newStatus := lws.Status.DeepCopy()
-	newStatus.Replicas = ms.Status.Replicas
-	newStatus.ReadyReplicas = ms.Status.AvailableReplicas
+// convertRolloutStrategy maps the rolling update of a LeaderWorkerSet to the ServingGroup rolling update of the
+// ModelServing. It returns nil for the default rolling update, one group at a time, which is also the default of
+// ModelServing.
+func convertRolloutStrategy(strategy lwsv1.RolloutStrategy) *workloadv1alpha1.RolloutStrategy {
+	config := strategy.RollingUpdateConfiguration
+	if config == nil {
+		return nil
+	}
+	maxUnavailable := config.MaxUnavailable
+	maxSurge := config.MaxSurge
+	if maxUnavailable.String() == "0" && maxSurge.String() == "0" {
+		// LeaderWorkerSet defaults maxUnavailable to 1, and both cannot be 0.
+		maxUnavailable = intstr.FromInt32(1)
+	}
+	if maxUnavailable.String() == "1" && maxSurge.String() == "0" && ptr.Deref(config.Partition, 0) == 0 {
+		return nil
+	}
+	rollingUpdate := &workloadv1alpha1.RollingUpdateConfiguration{
+		MaxUnavailable: &maxUnavailable,
+		Partition:      config.Partition,
+	}
+	if maxSurge.String() != "0" {
+		rollingUpdate.MaxSurge = &maxSurge
+	}
+	return &workloadv1alpha1.RolloutStrategy{
+		Type:                       workloadv1alpha1.ServingGroupRollingUpdate,
+		PodUpdatePolicy:            workloadv1alpha1.RecreatePodUpdatePolicy,
+		RollingUpdateConfiguration: rollingUpdate,
+	}
+}
+
+// convertRestartPolicy maps the restart policy of a LeaderWorkerSet to the recovery policy of the ModelServing,
+// or returns an empty policy if it is not set. A group of a LeaderWorkerSet is a ServingGroup, so
+// RecreateGroupOnPodRestart recreates the ServingGroup.
+func convertRestartPolicy(policy lwsv1.RestartPolicyType) workloadv1alpha1.RecoveryPolicy {
+	switch policy {
+	case "":
+		return ""
+	case lwsv1.NoneRestartPolicy, lwsv1.DeprecatedDefaultRestartPolicy:
+		return workloadv1alpha1.NoneRestartPolicy
+	default:
+		return workloadv1alpha1.ServingGroupRecreate
+	}
+}
+
+// addExclusiveTopologyAffinity places each ServingGroup exclusively within one domain of the topology key, like the
+// exclusive topology annotation of a LeaderWorkerSet: the pods of a ServingGroup are placed with each other, and
+// away from the pods of the other ServingGroups.
+func addExclusiveTopologyAffinity(spec *corev1.PodSpec, topologyKey string) {
+	if topologyKey == "" {
+		return
+	}
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.PodAffinity == nil {
+		spec.Affinity.PodAffinity = &corev1.PodAffinity{}
+	}
+	if spec.Affinity.PodAntiAffinity == nil {
+		spec.Affinity.PodAntiAffinity = &corev1.PodAntiAffinity{}
+	}
+	groupSelector := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{
+			Key:      workloadv1alpha1.GroupNameLabelKey,
+			Operator: metav1.LabelSelectorOpExists,
+		}},
+	}
+	spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+			LabelSelector:  groupSelector,
+			MatchLabelKeys: []string{workloadv1alpha1.GroupNameLabelKey},
+			TopologyKey:    topologyKey,
+		})
+	spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+		spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, corev1.PodAffinityTerm{
+			LabelSelector:     groupSelector,
+			MismatchLabelKeys: []string{workloadv1alpha1.GroupNameLabelKey},
+			TopologyKey:       topologyKey,
+		})
+}
+
+// lwsSubdomainPolicy returns the subdomain policy of the LeaderWorkerSet, Shared by default.
+func lwsSubdomainPolicy(lws *lwsv1.LeaderWorkerSet) lwsv1.SubdomainPolicy {
+	if lws.Spec.NetworkConfig == nil || lws.Spec.NetworkConfig.SubdomainPolicy == nil {
+		return lwsv1.SubdomainShared
+	}
+	return *lws.Spec.NetworkConfig.SubdomainPolicy
+}
+
+// syncSubdomainService creates the headless service of the shared subdomain of the pods of the LeaderWorkerSet.
+func (c *LWSController) syncSubdomainService(ctx context.Context, lws *lwsv1.LeaderWorkerSet) error {
+	if _, err := c.serviceLister.Services(lws.Namespace).Get(lws.Name); err == nil || !errors.IsNotFound(err) {
+		return err
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      lws.Name,
+			Namespace: lws.Namespace,
+			Labels:    map[string]string{lwsv1.SetNameLabelKey: lws.Name},
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(lws, lwsv1.GroupVersion.WithKind("LeaderWorkerSet")),
+			},
+		},
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                "None", // defines service as headless
+			Selector:                 map[string]string{workloadv1alpha1.ModelServingNameLabelKey: lws.Name},
+			PublishNotReadyAddresses: true,
+		},
+	}
+	klog.V(4).Infof("Creating subdomain service %s", klog.KObj(service))
+	if _, err := c.kubeClient.CoreV1().Services(lws.Namespace).Create(ctx, service, metav1.CreateOptions{}); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create subdomain service of lws %s: %v", klog.KObj(lws), err)
+	}
+	return nil
+}
 
+// recordUnsupportedLWS sets the Progressing condition of a LeaderWorkerSet which cannot be translated to a
+// ModelServing to False, and emits a warning event when the failure is first observed for its spec.
+func (c *LWSController) recordUnsupportedLWS(ctx context.Context, lws *lwsv1.LeaderWorkerSet, err error) error {
+	cond := unsupportedLWSCondition(lws, err)
+	if cond == nil {
+		return nil
+	}
+	lwsCopy := lws.DeepCopy()
+	meta.SetStatusCondition(&lwsCopy.Status.Conditions, *cond)
+	if _, err := c.lwsClient.LeaderworkersetV1().LeaderWorkerSets(lws.Namespace).UpdateStatus(ctx, lwsCopy, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+	klog.Warningf("Cannot convert lws %s to a ModelServing: %v", klog.KObj(lws), err)
+	c.recorder.Event(lws, corev1.EventTypeWarning, lwsUnsupportedReason, cond.Message)
+	return nil
+}
+
+// unsupportedLWSCondition returns the Progressing condition of a LeaderWorkerSet which cannot be translated to a
+// ModelServing, or nil if the LeaderWorkerSet already has it for its spec.
+func unsupportedLWSCondition(lws *lwsv1.LeaderWorkerSet, err error) *metav1.Condition {
+	cond := &metav1.Condition{
+		Type:               string(lwsv1.LeaderWorkerSetProgressing),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: lws.Generation,
+		Reason:             lwsUnsupportedReason,
+		Message:            fmt.Sprintf("Cannot be translated to a ModelServing: %v", err),
+	}
+	if prev := meta.FindStatusCondition(lws.Status.Conditions, cond.Type); prev != nil && prev.Status == cond.Status &&
+		prev.Reason == cond.Reason && prev.Message == cond.Message && prev.ObservedGeneration == cond.ObservedGeneration {
+		return nil
+	}
+	return cond
+}
+
+// mergeStringMaps returns the union of src and the extra maps, the later maps taking precedence.
+// src is returned as is if there is nothing to add, so that the templates are unchanged.
+func mergeStringMaps(src map[string]string, extra ...map[string]string) map[string]string {
+	merged := src
+	for _, m := range extra {
+		if len(m) == 0 {
+			continue
+		}
+		merged = maps.Clone(merged)
+		if merged == nil {
+			merged = map[string]string{}
+		}
+		maps.Copy(merged, m)
+	}
+	return merged
+}
+
+func (c *LWSController) updateLWSStatus(ctx context.Context, lws *lwsv1.LeaderWorkerSet, ms *workloadv1alpha1.ModelServing) error {
+	newStatus := buildLWSStatus(lws, ms)
 	if !reflect.DeepEqual(lws.Status, *newStatus) {
 		lwsCopy := lws.DeepCopy()
 		lwsCopy.Status = *newStatus
@@ -370,6 +617,34 @@ func (c *LWSController) updateLWSStatus(ctx context.Context, lws *lwsv1.LeaderWo
 	return nil
 }
 
+// buildLWSStatus maps the status of the ModelServing back to the status of the LeaderWorkerSet.
+func buildLWSStatus(lws *lwsv1.LeaderWorkerSet, ms *workloadv1alpha1.ModelServing) *lwsv1.LeaderWorkerSetStatus {
+	newStatus := lws.Status.DeepCopy()
+	newStatus.Replicas = ms.Status.Replicas
+	newStatus.ReadyReplicas = ms.Status.AvailableReplicas
+	newStatus.UpdatedReplicas = ms.Status.UpdatedReplicas
+	// Like LeaderWorkerSet, the HPA only looks up the leader pods.
+	newStatus.HPAPodSelector = labels.SelectorFromSet(map[string]string{
+		workloadv1alpha1.ModelServingNameLabelKey: ms.Name,
+		workloadv1alpha1.EntryLabelKey:            utils.Entry,
+	}).String()
+	// The failure to translate the LeaderWorkerSet has been fixed, since a ModelServing is in sync with it.
+	if cond := meta.FindStatusCondition(newStatus.Conditions, string(lwsv1.LeaderWorkerSetProgressing)); cond != nil && cond.Reason == lwsUnsupportedReason {
+		meta.RemoveStatusCondition(&newStatus.Conditions, cond.Type)
+	}
+	// The ModelServing conditions have the same types and semantics as the LeaderWorkerSet conditions.
+	for _, condition := range ms.Status.Conditions {
+		meta.SetStatusCondition(&newStatus.Conditions, metav1.Condition{
+			Type:               condition.Type,
+			Status:             condition.Status,
+			ObservedGeneration: lws.Generation,
+			Reason:             condition.Reason,
+			Message:            condition.Message,
+		})
+	}
+	return newStatus
+}
+
 func ResourceExists(client kubernetes.Interface, groupVersion string, kind string) (bool, error) {
 	resources, err := client.Discovery().ServerResourcesForGroupVersion(groupVersion)
 	if err != nil {
@@ -17,11 +17,16 @@ limitations under the License.
 package controller
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/utils/ptr"
 	lwsv1 "sigs.k8s.io/lws/api/leaderworkerset/v1"
 
@@ -334,7 +339,8 @@ func TestConstructModelServing(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := c.constructModelServing(tt.lws)
+			got, err := c.constructModelServing(tt.lws)
+			assert.NoError(t, err)
 
 			// Verify ObjectMeta
 			assert.Equal(t, tt.expected.Name, got.Name)
@@ -368,7 +374,186 @@ func TestConstructModelServing(t *testing.T) {
 			if expectedRole.EntryTemplate.Metadata != nil {
 				assert.Equal(t, expectedRole.EntryTemplate.Metadata.Labels, role.EntryTemplate.Metadata.Labels)
 				assert.Equal(t, expectedRole.EntryTemplate.Metadata.Annotations, role.EntryTemplate.Metadata.Annotations)
+				assert.Equal(t, expectedRole.WorkerTemplate.Metadata.Labels, role.WorkerTemplate.Metadata.Labels)
 			}
 		})
 	}
 }
+
+func TestConstructModelServingPolicies(t *testing.T) {
+	lws := &lwsv1.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-lws-policies",
+			Namespace:   "default",
+			Annotations: map[string]string{lwsv1.ExclusiveKeyAnnotationKey: "cloud.google.com/gke-nodepool"},
+		},
+		Spec: lwsv1.LeaderWorkerSetSpec{
+			Replicas: ptr.To[int32](4),
+			LeaderWorkerTemplate: lwsv1.LeaderWorkerTemplate{
+				Size:          ptr.To[int32](5),
+				RestartPolicy: lwsv1.NoneRestartPolicy,
+				SubGroupPolicy: &lwsv1.SubGroupPolicy{
+					Type:         ptr.To(lwsv1.SubGroupPolicyTypeLeaderExcluded),
+					SubGroupSize: ptr.To[int32](2),
+				},
+				WorkerTemplate: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "worker", Image: "nginx"}}},
+				},
+			},
+			RolloutStrategy: lwsv1.RolloutStrategy{
+				Type: lwsv1.RollingUpdateStrategyType,
+				RollingUpdateConfiguration: &lwsv1.RollingUpdateConfiguration{
+					MaxUnavailable: intstr.FromString("50%"),
+					MaxSurge:       intstr.FromInt32(1),
+					Partition:      ptr.To[int32](2),
+				},
+			},
+			StartupPolicy: lwsv1.LeaderCreatedStartupPolicy,
+		},
+	}
+
+	got, err := (&LWSController{}).constructModelServing(lws)
+	assert.NoError(t, err)
+	assert.Equal(t, &workloadv1alpha1.RolloutStrategy{
+		Type:            workloadv1alpha1.ServingGroupRollingUpdate,
+		PodUpdatePolicy: workloadv1alpha1.RecreatePodUpdatePolicy,
+		RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+			MaxUnavailable: ptr.To(intstr.FromString("50%")),
+			MaxSurge:       ptr.To(intstr.FromInt32(1)),
+			Partition:      ptr.To[int32](2),
+		},
+	}, got.Spec.RolloutStrategy)
+	assert.Equal(t, workloadv1alpha1.NoneRestartPolicy, got.Spec.RecoveryPolicy)
+	assert.Nil(t, got.Spec.Template.GangPolicy)
+
+	role := got.Spec.Template.Roles[0]
+	// The pods of a ServingGroup are placed together and away from the other ServingGroups, in the topology domain.
+	for _, spec := range []corev1.PodSpec{role.EntryTemplate.Spec, role.WorkerTemplate.Spec} {
+		assert.Equal(t, "test-lws-policies", spec.Subdomain)
+		affinity := spec.Affinity.PodAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		antiAffinity := spec.Affinity.PodAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+		if assert.Len(t, affinity, 1) && assert.Len(t, antiAffinity, 1) {
+			assert.Equal(t, "cloud.google.com/gke-nodepool", affinity[0].TopologyKey)
+			assert.Equal(t, []string{workloadv1alpha1.GroupNameLabelKey}, affinity[0].MatchLabelKeys)
+			assert.Equal(t, "cloud.google.com/gke-nodepool", antiAffinity[0].TopologyKey)
+			assert.Equal(t, []string{workloadv1alpha1.GroupNameLabelKey}, antiAffinity[0].MismatchLabelKeys)
+		}
+	}
+	// The template of the LeaderWorkerSet is not modified.
+	assert.Nil(t, lws.Spec.LeaderWorkerTemplate.WorkerTemplate.Spec.Affinity)
+	assert.Equal(t, map[string]string{
+		lwsv1.SubGroupSizeAnnotationKey:       "2",
+		lwsv1.SubGroupPolicyTypeAnnotationKey: string(lwsv1.SubGroupPolicyTypeLeaderExcluded),
+	}, role.EntryTemplate.Metadata.Annotations)
+	assert.Equal(t, map[string]string{lwsv1.SubGroupSizeAnnotationKey: "2"}, role.WorkerTemplate.Metadata.Annotations)
+
+	// The policies are left to the defaults of ModelServing when they are not set, or set to the defaults of
+	// LeaderWorkerSet which are also the defaults of ModelServing.
+	lws.Spec.LeaderWorkerTemplate.RestartPolicy = ""
+	lws.Spec.LeaderWorkerTemplate.SubGroupPolicy = nil
+	lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &lwsv1.RollingUpdateConfiguration{}
+	got, err = (&LWSController{}).constructModelServing(lws)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Spec.RecoveryPolicy)
+	assert.Nil(t, got.Spec.RolloutStrategy)
+	assert.Nil(t, got.Spec.Template.Roles[0].EntryTemplate.Metadata.Annotations)
+	assert.Nil(t, got.Spec.Template.Roles[0].WorkerTemplate.Metadata.Annotations)
+
+	lws.Spec.LeaderWorkerTemplate.RestartPolicy = lwsv1.RecreateGroupOnPodRestart
+	lws.Spec.RolloutStrategy.RollingUpdateConfiguration = &lwsv1.RollingUpdateConfiguration{MaxSurge: intstr.FromInt32(2)}
+	got, err = (&LWSController{}).constructModelServing(lws)
+	assert.NoError(t, err)
+	assert.Equal(t, workloadv1alpha1.ServingGroupRecreate, got.Spec.RecoveryPolicy)
+	assert.Equal(t, ptr.To(intstr.FromInt32(0)), got.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxUnavailable)
+	assert.Equal(t, ptr.To(intstr.FromInt32(2)), got.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge)
+
+	// The pods of a ServingGroup don't have a subdomain of their own.
+	lws.Spec.NetworkConfig = &lwsv1.NetworkConfig{SubdomainPolicy: ptr.To(lwsv1.SubdomainUniquePerReplica)}
+	_, err = (&LWSController{}).constructModelServing(lws)
+	assert.Error(t, err)
+
+	// The workers cannot wait for the leader to be ready.
+	lws.Spec.NetworkConfig = nil
+	lws.Spec.StartupPolicy = lwsv1.LeaderReadyStartupPolicy
+	_, err = (&LWSController{}).constructModelServing(lws)
+	assert.Error(t, err)
+}
+
+func TestUnsupportedLWSCondition(t *testing.T) {
+	lws := &lwsv1.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-lws", Namespace: "default", Generation: 2},
+		Spec:       lwsv1.LeaderWorkerSetSpec{StartupPolicy: lwsv1.LeaderReadyStartupPolicy},
+	}
+	_, err := (&LWSController{}).constructModelServing(lws)
+	condition := unsupportedLWSCondition(lws, err)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, string(lwsv1.LeaderWorkerSetProgressing), condition.Type)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, lwsUnsupportedReason, condition.Reason)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+	}
+
+	// The failure is recorded once for the spec.
+	meta.SetStatusCondition(&lws.Status.Conditions, *condition)
+	assert.Nil(t, unsupportedLWSCondition(lws, err))
+	lws.Generation = 3
+	assert.NotNil(t, unsupportedLWSCondition(lws, err))
+
+	// The condition is cleared once the LeaderWorkerSet is translated.
+	status := buildLWSStatus(lws, &workloadv1alpha1.ModelServing{})
+	assert.Nil(t, meta.FindStatusCondition(status.Conditions, string(lwsv1.LeaderWorkerSetProgressing)))
+}
+
+func TestSyncSubdomainService(t *testing.T) {
+	ctx := context.Background()
+	lws := &lwsv1.LeaderWorkerSet{ObjectMeta: metav1.ObjectMeta{Name: "test-lws", Namespace: "default", UID: "test-uid"}}
+	kubeClient := kubefake.NewSimpleClientset()
+	c := &LWSController{
+		kubeClient:    kubeClient,
+		serviceLister: informers.NewSharedInformerFactory(kubeClient, 0).Core().V1().Services().Lister(),
+	}
+
+	assert.NoError(t, c.syncSubdomainService(ctx, lws))
+	service, err := kubeClient.CoreV1().Services("default").Get(ctx, lws.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, corev1.ClusterIPNone, service.Spec.ClusterIP)
+	assert.Equal(t, map[string]string{workloadv1alpha1.ModelServingNameLabelKey: lws.Name}, service.Spec.Selector)
+	assert.Equal(t, lws.Name, metav1.GetControllerOf(service).Name)
+
+	// The service is only created once.
+	assert.NoError(t, c.syncSubdomainService(ctx, lws))
+}
+
+func TestBuildLWSStatus(t *testing.T) {
+	lws := &lwsv1.LeaderWorkerSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-lws", Namespace: "default", Generation: 3},
+	}
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-lws", Namespace: "default"},
+		Status: workloadv1alpha1.ModelServingStatus{
+			Replicas:          3,
+			AvailableReplicas: 2,
+			UpdatedReplicas:   1,
+			Conditions: []metav1.Condition{{
+				Type:    string(workloadv1alpha1.ModelServingUpdateInProgress),
+				Status:  metav1.ConditionTrue,
+				Reason:  "UpdateInProgress",
+				Message: "Some groups have been updated",
+			}},
+		},
+	}
+
+	got := buildLWSStatus(lws, ms)
+	assert.Equal(t, int32(3), got.Replicas)
+	assert.Equal(t, int32(2), got.ReadyReplicas)
+	assert.Equal(t, int32(1), got.UpdatedReplicas)
+	assert.Equal(t, "modelserving.volcano.sh/entry=true,modelserving.volcano.sh/name=test-lws", got.HPAPodSelector)
+	condition := meta.FindStatusCondition(got.Conditions, string(lwsv1.LeaderWorkerSetUpdateInProgress))
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, int64(3), condition.ObservedGeneration)
+
+	// The transition time of an unchanged condition is kept.
+	lws.Status = *got
+	assert.Equal(t, condition.LastTransitionTime, meta.FindStatusCondition(buildLWSStatus(lws, ms).Conditions, condition.Type).LastTransitionTime)
+}
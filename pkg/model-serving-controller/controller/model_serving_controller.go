@@ -28,6 +28,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apiextClientSet "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -52,6 +53,7 @@ import (
 	listerv1alpha1 "github.com/volcano-sh/kthena/client-go/listers/workload/v1alpha1"
 	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/metrics"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/plugins"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/podgroupmanager"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
@@ -79,12 +81,19 @@ type ModelServingController struct {
 	modelServingsInformer cache.SharedIndexInformer
 
 	// nolint
-	workqueue       workqueue.RateLimitingInterface
-	store           datastore.Store
-	graceMap        sync.Map // key: errorPod.namespace/errorPod.name, value:time
-	initialSync     bool     // indicates whether the initial sync has been completed
-	pluginsRegistry *plugins.Registry
-	recorder        record.EventRecorder
+	workqueue      workqueue.RateLimitingInterface
+	store          datastore.Store
+	graceMap       sync.Map // key: errorPod.namespace/errorPod.name, value: restart grace deadline
+	readyHooks     sync.Map // key: pod UID, set once the OnPodReady hooks succeeded for the current ready transition
+	pluginChains   sync.Map // key: modelServing.namespace/modelServing.name, value: *pluginChainEntry
+	updateFailures sync.Map // key: modelServing.namespace/modelServing.name/revision, value: *atomic.Int32
+	// restartBudgets is the restart budget state of the ServingGroups,
+	// key: modelServing.namespace/modelServing.name/servingGroup.name
+	restartBudgets     map[string]*servingGroupRestarts
+	restartBudgetsLock sync.Mutex
+	initialSync        bool // indicates whether the initial sync has been completed
+	pluginsRegistry    *plugins.Registry
+	recorder           record.EventRecorder
 }
 
 func NewModelServingController(kubeClientSet kubernetes.Interface, modelServingClient clientset.Interface, volcanoClient volcano.Interface, apiextClient apiextClientSet.Interface) (*ModelServingController, error) {
@@ -293,6 +302,9 @@ func (c *ModelServingController) deleteModelServing(obj interface{}) {
 		Namespace: ms.Namespace,
 		Name:      ms.Name,
 	})
+	metrics.DeleteModelServing(ms.Namespace, ms.Name)
+	c.clearRestartBudgets(ms)
+	c.pluginChains.Delete(utils.GetNamespaceName(ms))
 	// ControllerRevisions will be automatically deleted via OwnerReference when ModelServing is deleted
 }
 
@@ -334,12 +346,19 @@ func (c *ModelServingController) updatePod(_, newObj interface{}) {
 		if err != nil {
 			klog.Errorf("handle running pod failed: %v", err)
 		}
-	case utils.IsPodFailed(newPod) || utils.ContainerRestarted(newPod):
+		if c.hasPendingPodReadyHooks(ms, newPod) {
+			// The hooks may call external endpoints, so they run in the sync of the ModelServing.
+			c.enqueueModelServing(ms)
+		}
+	case utils.IsPodFailed(newPod) || utils.ContainerRestartedByFailure(newPod):
+		c.readyHooks.Delete(newPod.UID)
 		err = c.handleErrorPod(ms, servingGroupName, newPod)
 		if err != nil {
 			klog.Errorf("handle error pod failed: %v", err)
 		}
 	default:
+		// The OnPodReady hooks fire again when the pod becomes ready again.
+		c.readyHooks.Delete(newPod.UID)
 		if !c.initialSync {
 			c.store.AddServingGroupAndRole(types.NamespacedName{
 				Namespace: ms.Namespace,
@@ -365,6 +384,10 @@ func (c *ModelServingController) deletePod(obj interface{}) {
 		}
 	}
 
+	// The restart grace period of the pod, if any, ends with the pod.
+	c.graceMap.Delete(utils.GetNamespaceName(pod))
+	c.readyHooks.Delete(pod.UID)
+
 	ms, servingGroupName, roleName, roleID := c.getModelServingAndResourceDetails(pod)
 	// ms is nil means the modelserving is deleted
 	// delete the pod
@@ -524,6 +547,21 @@ func (c *ModelServingController) syncModelServing(ctx context.Context, key strin
 		return err
 	}
 
+	if rolledBack, err := c.manageRollback(ctx, ms); err != nil {
+		return fmt.Errorf("cannot manage rollback: %v", err)
+	} else if rolledBack {
+		// The spec update triggers a new sync with the rolled back roles.
+		return nil
+	}
+
+	if err := c.manageFailedServingGroups(ctx, ms); err != nil {
+		return fmt.Errorf("cannot manage failed ServingGroups: %v", err)
+	}
+
+	if err := c.manageRestartGracePeriods(ctx, ms); err != nil {
+		return fmt.Errorf("cannot manage restart grace periods: %v", err)
+	}
+
 	// only fields in roles can be modified in rolling updates.
 	// and only modifying the role.replicas field will not affect the revision.
 	copy := utils.RemoveRoleReplicasForRevision(ms)
@@ -548,6 +586,10 @@ func (c *ModelServingController) syncModelServing(ctx context.Context, key strin
 		return fmt.Errorf("failed to update status of ms %s/%s: %v", namespace, name, err)
 	}
 
+	if err := c.managePodReadyHooks(ctx, ms); err != nil {
+		return fmt.Errorf("cannot run OnPodReady hooks: %v", err)
+	}
+
 	return nil
 }
 
@@ -610,6 +652,9 @@ func (c *ModelServingController) manageServingGroupReplicas(ctx context.Context,
 	}
 	expectedCount := int(*ms.Spec.Replicas)
 	curReplicas := len(servingGroupList)
+	// ServingGroups surged above the desired replicas are owned by the rolling update,
+	// they are removed by manageServingGroupRollingUpdate once their replacements are running.
+	surgeLimit := expectedCount + c.getSurgeAllowance(ms, servingGroupList, newRevision)
 
 	// Determine whether it is a scale-up or scale-down scenario
 	if curReplicas < expectedCount {
@@ -623,8 +668,8 @@ func (c *ModelServingController) manageServingGroupReplicas(ctx context.Context,
 			return fmt.Errorf("failed to scale up ServingGroups: %v", err)
 		}
 	} else {
-		if curReplicas > expectedCount {
-			if err := c.scaleDownServingGroups(ctx, ms, servingGroupList, expectedCount); err != nil {
+		if curReplicas > surgeLimit {
+			if err := c.scaleDownServingGroups(ctx, ms, servingGroupList, surgeLimit); err != nil {
 				return fmt.Errorf("failed to scale down ServingGroups: %v", err)
 			}
 		}
@@ -667,23 +712,6 @@ func (c *ModelServingController) scaleUpServingGroups(ctx context.Context, ms *w
 		_, maxOrdinal = utils.GetParentNameAndOrdinal(servingGroupList[len(servingGroupList)-1].Name)
 	}
 
-	// Helper function to create a ServingGroup
-	createServingGroup := func(ordinal int, revision string, roles []workloadv1alpha1.Role) error {
-		groupName := utils.GenerateServingGroupName(ms.Name, ordinal)
-		// Ensure a PodGroup exists for the new ServingGroup when gang scheduling is enabled.
-		if err := c.createOrUpdatePodGroupByServingGroup(ctx, ms, groupName); err != nil {
-			return err
-		}
-		klog.V(4).Infof("Creating ServingGroup %s at ordinal %d with revision %s", groupName, ordinal, revision)
-		// Create pods for ServingGroup using the provided roles template
-		if err := c.CreatePodsForServingGroup(ctx, ms, ordinal, revision, roles); err != nil {
-			return fmt.Errorf("create Serving group failed: %v", err)
-		}
-		// Insert new ServingGroup to global storage
-		c.store.AddServingGroup(utils.GetNamespaceName(ms), ordinal, revision)
-		return nil
-	}
-
 	if partition > 0 {
 		// When partition is set, fill missing ordinals in [0, partition) using CurrentRevision
 		for ordinal := 0; ordinal < partition && ordinal < expectedCount; ordinal++ {
@@ -718,7 +746,7 @@ func (c *ModelServingController) scaleUpServingGroups(ctx context.Context, ms *w
 				klog.Infof("Creating missing ServingGroup at ordinal %d with revision %s using ms.Spec.Template.Roles (partition=%d, first startup)", ordinal, revisionToUse, partition)
 			}
 
-			if err := createServingGroup(ordinal, revisionToUse, rolesToUse); err != nil {
+			if err := c.createServingGroup(ctx, ms, ordinal, revisionToUse, rolesToUse); err != nil {
 				return err
 			}
 			// Update existingOrdinals and maxOrdinal
@@ -746,7 +774,7 @@ func (c *ModelServingController) scaleUpServingGroups(ctx context.Context, ms *w
 		// Create new ServingGroups with increasing indices
 		for i := startingIndex; i < startingIndex+toCreate; i++ {
 			// For newly created ServingGroups (ordinal >= partition), always use current template
-			if err := createServingGroup(i, newRevision, ms.Spec.Template.Roles); err != nil {
+			if err := c.createServingGroup(ctx, ms, i, newRevision, ms.Spec.Template.Roles); err != nil {
 				return err
 			}
 		}
@@ -755,15 +783,36 @@ func (c *ModelServingController) scaleUpServingGroups(ctx context.Context, ms *w
 	return nil
 }
 
+// createServingGroup creates a ServingGroup at the given ordinal using the provided roles template.
+func (c *ModelServingController) createServingGroup(ctx context.Context, ms *workloadv1alpha1.ModelServing, ordinal int, revision string, roles []workloadv1alpha1.Role) error {
+	groupName := utils.GenerateServingGroupName(ms.Name, ordinal)
+	// Ensure a PodGroup exists for the new ServingGroup when gang scheduling is enabled.
+	if err := c.createOrUpdatePodGroupByServingGroup(ctx, ms, groupName); err != nil {
+		return err
+	}
+	klog.V(4).Infof("Creating ServingGroup %s at ordinal %d with revision %s", groupName, ordinal, revision)
+	// Create pods for ServingGroup using the provided roles template
+	if err := c.CreatePodsForServingGroup(ctx, ms, ordinal, revision, roles); err != nil {
+		return fmt.Errorf("create Serving group failed: %v", err)
+	}
+	// Insert new ServingGroup to global storage
+	c.store.AddServingGroup(utils.GetNamespaceName(ms), ordinal, revision)
+	return nil
+}
+
 func (c *ModelServingController) manageRole(ctx context.Context, ms *workloadv1alpha1.ModelServing, newRevision string) error {
 	servingGroupList, err := c.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
 	if err != nil && !errors.Is(err, datastore.ErrServingGroupNotFound) {
 		return fmt.Errorf("cannot get ServingGroup of modelServing: %s from map: %v", ms.GetName(), err)
 	}
 	for _, servingGroup := range servingGroupList {
-		if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), servingGroup.Name) == datastore.ServingGroupDeleting {
+		switch c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), servingGroup.Name) {
+		case datastore.ServingGroupDeleting:
 			// Deleting ServingGroup will be recreated after the deletion is complete, so there is no need to scale the roles
 			continue
+		case datastore.ServingGroupFailed:
+			// Failed ServingGroup is left as is for inspection
+			continue
 		}
 		_, servingGroupOrdinal := utils.GetParentNameAndOrdinal(servingGroup.Name)
 		for _, targetRole := range ms.Spec.Template.Roles {
@@ -1014,6 +1063,17 @@ func (c *ModelServingController) manageServingGroupRollingUpdate(ctx context.Con
 	if err != nil {
 		return fmt.Errorf("failed to calculate maxUnavailable: %v", err)
 	}
+	if isRoleRollingUpdate(ms) {
+		return c.manageRoleRollingUpdate(ctx, ms, revision, maxUnavailable)
+	}
+
+	maxSurge, err := utils.GetMaxSurge(ms)
+	if err != nil {
+		return fmt.Errorf("failed to calculate maxSurge: %v", err)
+	}
+	if maxSurge > 0 {
+		return c.manageServingGroupSurgeUpdate(ctx, ms, revision, maxSurge, maxUnavailable)
+	}
 
 	servingGroupList, err := c.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
 	if err != nil {
@@ -1059,6 +1119,21 @@ func (c *ModelServingController) manageServingGroupRollingUpdate(ctx context.Con
 		return nil
 	}
 
+	// Update outdated groups in place when possible, they count against maxScaleDown as they are unavailable until ready
+	if isInPlaceUpdateEnabled(ms) {
+		var inPlaceCount int
+		notRunningOutdatedGroups, inPlaceCount, err = c.updateServingGroupsInPlace(ctx, ms, revision, maxScaleDown, notRunningOutdatedGroups)
+		if err != nil {
+			return err
+		}
+		maxScaleDown -= inPlaceCount
+		runningOutdatedGroups, inPlaceCount, err = c.updateServingGroupsInPlace(ctx, ms, revision, maxScaleDown, runningOutdatedGroups)
+		if err != nil {
+			return err
+		}
+		maxScaleDown -= inPlaceCount
+	}
+
 	// Delete outdated groups respecting the maxUnavailable constraint
 	updateCount, err := c.deleteOutdatedServingGroups(ctx, ms, maxScaleDown, notRunningOutdatedGroups, runningOutdatedGroups)
 	if err != nil {
@@ -1071,6 +1146,157 @@ func (c *ModelServingController) manageServingGroupRollingUpdate(ctx context.Con
 	return nil
 }
 
+// manageServingGroupSurgeUpdate performs a rolling update that creates up to maxSurge new-revision ServingGroups
+// above spec.replicas before deleting the outdated ones, so that capacity is kept while the new groups load.
+// Surge ServingGroups reuse free ordinals in [partition, replicas) when possible and otherwise take ordinals after
+// the current max ordinal. Once all ServingGroups are updated, the ones left outside [0, replicas) are replaced in
+// the same way, which compacts the ordinals without ever reducing the number of available ServingGroups.
+func (c *ModelServingController) manageServingGroupSurgeUpdate(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision string, maxSurge, maxUnavailable int) error {
+	servingGroupList, err := c.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
+	if err != nil {
+		return fmt.Errorf("cannot get ServingGroupList from store, err:%v", err)
+	}
+
+	replaceable, compacting := c.getSurgeReplaceableGroups(ms, servingGroupList, revision)
+	if len(replaceable) == 0 {
+		return nil
+	}
+
+	replicas := int(*ms.Spec.Replicas)
+	total := len(servingGroupList)
+	// Never surge more ServingGroups than there are groups left to replace.
+	toCreate := min(replicas+maxSurge-total, len(replaceable)-(total-replicas))
+	if toCreate > 0 {
+		ordinals := c.getSurgeOrdinals(ms, servingGroupList, toCreate, !compacting)
+		if len(ordinals) > 0 {
+			if _, err := utils.CreateControllerRevision(ctx, c.kubeClientSet, ms, revision, ms.Spec.Template.Roles); err != nil {
+				klog.Warningf("Failed to create ControllerRevision for new revision %s: %v", revision, err)
+			}
+		}
+		for _, ordinal := range ordinals {
+			klog.V(2).Infof("Surging ServingGroup %s with revision %s (compacting=%v)", utils.GenerateServingGroupName(ms.Name, ordinal), revision, compacting)
+			if err := c.createServingGroup(ctx, ms, ordinal, revision, ms.Spec.Template.Roles); err != nil {
+				return err
+			}
+		}
+		servingGroupList, err = c.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
+		if err != nil {
+			return fmt.Errorf("cannot get ServingGroupList from store, err:%v", err)
+		}
+	}
+
+	replaceableNames := make(map[string]struct{}, len(replaceable))
+	var notRunningReplaceable, runningReplaceable []datastore.ServingGroup
+	for _, sg := range replaceable {
+		replaceableNames[sg.Name] = struct{}{}
+		if sg.Status == datastore.ServingGroupRunning {
+			runningReplaceable = append(runningReplaceable, sg)
+		} else {
+			notRunningReplaceable = append(notRunningReplaceable, sg)
+		}
+	}
+
+	newServingGroupUnavailableCount := 0
+	for _, sg := range servingGroupList {
+		if _, ok := replaceableNames[sg.Name]; ok {
+			continue
+		}
+		if sg.Revision == revision && sg.Status != datastore.ServingGroupRunning {
+			newServingGroupUnavailableCount++
+		}
+	}
+
+	// Compaction only moves already updated ServingGroups around, it must not reduce availability.
+	minAvailable := replicas - maxUnavailable
+	if compacting {
+		minAvailable = replicas
+	}
+	maxScaleDown := len(servingGroupList) - minAvailable - newServingGroupUnavailableCount
+	if maxScaleDown <= 0 {
+		klog.V(4).Infof("No ServingGroups can be replaced for ModelServing %s/%s: maxScaleDown=%d",
+			ms.Namespace, ms.Name, maxScaleDown)
+		return nil
+	}
+
+	updateCount, err := c.deleteOutdatedServingGroups(ctx, ms, maxScaleDown, notRunningReplaceable, runningReplaceable)
+	if err != nil {
+		return err
+	}
+	if updateCount > 0 {
+		klog.V(4).Infof("Deleted %d replaced ServingGroups for ModelServing %s (compacting=%v)", updateCount, ms.Name, compacting)
+	}
+	return nil
+}
+
+// getSurgeReplaceableGroups returns the ServingGroups a surge rolling update still has to replace.
+// These are the outdated ServingGroups after the partition, or, once all of them are updated,
+// the ServingGroups whose ordinals lie outside [0, replicas). The second return value reports the latter case.
+func (c *ModelServingController) getSurgeReplaceableGroups(ms *workloadv1alpha1.ModelServing, servingGroupList []datastore.ServingGroup, revision string) ([]datastore.ServingGroup, bool) {
+	partition := c.getPartition(ms)
+	if partition >= len(servingGroupList) {
+		return nil, false
+	}
+
+	var outdated []datastore.ServingGroup
+	for _, sg := range servingGroupList[partition:] {
+		if sg.Status != datastore.ServingGroupDeleting && sg.Revision != revision {
+			outdated = append(outdated, sg)
+		}
+	}
+	if len(outdated) > 0 {
+		return outdated, false
+	}
+
+	replicas := int(*ms.Spec.Replicas)
+	var outOfRange []datastore.ServingGroup
+	for _, sg := range servingGroupList {
+		_, ordinal := utils.GetParentNameAndOrdinal(sg.Name)
+		if sg.Status != datastore.ServingGroupDeleting && ordinal >= replicas {
+			outOfRange = append(outOfRange, sg)
+		}
+	}
+	return outOfRange, len(outOfRange) > 0
+}
+
+// getSurgeAllowance returns the number of ServingGroups tolerated above spec.replicas
+// because a surge rolling update is in progress.
+func (c *ModelServingController) getSurgeAllowance(ms *workloadv1alpha1.ModelServing, servingGroupList []datastore.ServingGroup, revision string) int {
+	maxSurge, err := utils.GetMaxSurge(ms)
+	if err != nil || maxSurge == 0 || isRoleRollingUpdate(ms) {
+		return 0
+	}
+	if replaceable, _ := c.getSurgeReplaceableGroups(ms, servingGroupList, revision); len(replaceable) == 0 {
+		return 0
+	}
+	return maxSurge
+}
+
+// getSurgeOrdinals returns up to count ordinals for new surge ServingGroups. Free ordinals in
+// [partition, replicas) are used first. Ordinals after the current max ordinal are only used when allowOutOfRange is set.
+func (c *ModelServingController) getSurgeOrdinals(ms *workloadv1alpha1.ModelServing, servingGroupList []datastore.ServingGroup, count int, allowOutOfRange bool) []int {
+	existingOrdinals := make(map[int]bool, len(servingGroupList))
+	maxOrdinal := -1
+	for _, sg := range servingGroupList {
+		_, ordinal := utils.GetParentNameAndOrdinal(sg.Name)
+		existingOrdinals[ordinal] = true
+		maxOrdinal = max(maxOrdinal, ordinal)
+	}
+
+	ordinals := make([]int, 0, count)
+	for ordinal := c.getPartition(ms); ordinal < int(*ms.Spec.Replicas) && len(ordinals) < count; ordinal++ {
+		if !existingOrdinals[ordinal] {
+			ordinals = append(ordinals, ordinal)
+		}
+	}
+	if !allowOutOfRange {
+		return ordinals
+	}
+	for ordinal := maxOrdinal + 1; len(ordinals) < count; ordinal++ {
+		ordinals = append(ordinals, ordinal)
+	}
+	return ordinals
+}
+
 // deleteOutdatedServingGroups deletes outdated ServingGroups respecting partition and maxScaleDown constraints
 // It prioritizes deleting not-running outdated groups first, then running outdated groups
 func (c *ModelServingController) deleteOutdatedServingGroups(
@@ -1109,24 +1335,67 @@ func (c *ModelServingController) deleteOutdatedServingGroups(
 	return updateCount, nil
 }
 
-func (c *ModelServingController) handleReadyPod(ms *workloadv1alpha1.ModelServing, servingGroupName string, newPod *corev1.Pod) error {
-	chain, err := c.buildPluginChain(ms)
-	if err != nil {
-		return fmt.Errorf("build plugin chain: %w", err)
+// hasPendingPodReadyHooks reports whether the OnPodReady hooks of the plugins have not run yet for the current
+// ready transition of the pod.
+func (c *ModelServingController) hasPendingPodReadyHooks(ms *workloadv1alpha1.ModelServing, pod *corev1.Pod) bool {
+	if len(ms.Spec.Plugins) == 0 {
+		return false
 	}
-	if chain != nil {
-		if err := chain.OnPodReady(context.Background(), &plugins.HookRequest{
+	_, done := c.readyHooks.Load(pod.UID)
+	return !done
+}
+
+// runPodReadyHooks runs the OnPodReady hooks of the plugins once per ready transition of the pod.
+// A failure is reported as an event and returned, so that the ModelServing is requeued to retry the hooks.
+// It does not block the status of the ServingGroup.
+func (c *ModelServingController) runPodReadyHooks(ctx context.Context, ms *workloadv1alpha1.ModelServing, servingGroupName string, pod *corev1.Pod) error {
+	if !c.hasPendingPodReadyHooks(ms, pod) {
+		return nil
+	}
+	chain, err := c.buildPluginChain(ms)
+	if err == nil {
+		err = chain.OnPodReady(ctx, &plugins.HookRequest{
 			ModelServing: ms,
 			ServingGroup: servingGroupName,
-			RoleName:     utils.GetRoleName(newPod),
-			RoleID:       utils.GetRoleID(newPod),
-			IsEntry:      newPod.Labels[workloadv1alpha1.EntryLabelKey] == utils.Entry,
-			Pod:          newPod,
-		}); err != nil {
-			return err
+			RoleName:     utils.GetRoleName(pod),
+			RoleID:       utils.GetRoleID(pod),
+			IsEntry:      pod.Labels[workloadv1alpha1.EntryLabelKey] == utils.Entry,
+			Pod:          pod,
+		})
+	}
+	if err != nil {
+		if c.recorder != nil {
+			c.recorder.Eventf(ms, corev1.EventTypeWarning, "PluginOnPodReadyFailed", "OnPodReady hooks of pod %s failed: %v", pod.Name, err)
 		}
+		return fmt.Errorf("OnPodReady hooks of pod %s/%s failed: %v", pod.Namespace, pod.Name, err)
 	}
+	c.readyHooks.Store(pod.UID, struct{}{})
+	return nil
+}
 
+// managePodReadyHooks runs the pending OnPodReady hooks of the ready pods of the ModelServing.
+func (c *ModelServingController) managePodReadyHooks(ctx context.Context, ms *workloadv1alpha1.ModelServing) error {
+	if len(ms.Spec.Plugins) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(map[string]string{workloadv1alpha1.ModelServingNameLabelKey: ms.Name})
+	pods, err := c.podsLister.Pods(ms.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !utils.IsOwnedByModelServingWithUID(pod, ms.UID) || !utils.IsPodRunningAndReady(pod) {
+			continue
+		}
+		if err := c.runPodReadyHooks(ctx, ms, pod.Labels[workloadv1alpha1.GroupNameLabelKey], pod); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (c *ModelServingController) handleReadyPod(ms *workloadv1alpha1.ModelServing, servingGroupName string, newPod *corev1.Pod) error {
 	// Add the running pod to the global storage and try to update the ServingGroup status
 	roleName := utils.GetRoleName(newPod)
 	roleID := utils.GetRoleID(newPod)
@@ -1175,13 +1444,21 @@ func (c *ModelServingController) handleReadyPod(ms *workloadv1alpha1.ModelServin
 func (c *ModelServingController) handleErrorPod(ms *workloadv1alpha1.ModelServing, servingGroupName string, errPod *corev1.Pod) error {
 	// pod is already in the grace period and does not need to be processed for the time being.
 	_, exists := c.graceMap.Load(utils.GetNamespaceName(errPod))
-	now := time.Now()
 	if exists {
 		klog.V(4).Infof("Pod %v failed, waiting for grace time", utils.GetNamespaceName(errPod))
 		return nil
 	}
+	deadline, resumed, err := c.startRestartGracePeriod(ms, errPod)
+	if err != nil {
+		return err
+	}
 	// add pod to the grace period map
-	c.graceMap.Store(utils.GetNamespaceName(errPod), now)
+	c.graceMap.Store(utils.GetNamespaceName(errPod), deadline)
+	if resumed {
+		klog.V(4).Infof("Pod %v failed, resuming grace time until %v", utils.GetNamespaceName(errPod), deadline)
+	} else {
+		c.recordUpdateFailure(ms, errPod)
+	}
 	c.store.DeleteRunningPodFromServingGroup(types.NamespacedName{
 		Namespace: ms.Namespace,
 		Name:      ms.Name,
@@ -1212,57 +1489,25 @@ func (c *ModelServingController) handleErrorPod(ms *workloadv1alpha1.ModelServin
 		}
 		klog.V(2).Infof("update ServingGroup %s to processing when pod fails", servingGroupName)
 	}
-	// Wait for the grace period before processing
-	go c.handlePodAfterGraceTime(ms, errPod)
-	// ServingGroup status may change, needs reconcile
+	// ServingGroup status may change, needs reconcile.
+	// The pod is rebuilt by the reconcile after the grace period if it has not recovered.
 	c.enqueueModelServing(ms)
+	c.enqueueModelServingAfter(ms, time.Until(deadline))
 	return nil
 }
 
-func (c *ModelServingController) handlePodAfterGraceTime(ms *workloadv1alpha1.ModelServing, errPod *corev1.Pod) {
-	if ms.Spec.Template.RestartGracePeriodSeconds != nil && *ms.Spec.Template.RestartGracePeriodSeconds > 0 {
-		// Wait for the grace period before making a decision
-		time.Sleep(time.Duration(*ms.Spec.Template.RestartGracePeriodSeconds) * time.Second)
-		klog.V(4).Infof("%s after grace time", errPod.Name)
-		defer c.graceMap.Delete(utils.GetNamespaceName(errPod))
-
-		newPod, err := c.podsLister.Pods(ms.Namespace).Get(errPod.Name)
-		if err != nil {
-			if apierrors.IsNotFound(err) {
-				klog.V(4).Infof("pod %s has been deleted after grace time", errPod.Name)
-			} else {
-				klog.Errorf("cannot get pod %s after grace time, err: %v", errPod.Name, err)
-			}
-			return
-		}
-
-		if !utils.IsPodRunningAndReady(newPod) {
-			// pod has not recovered after the grace period, needs to be rebuilt
-			// After this pod has been deleted, we will rebuild the ServingGroup in deletePod function
-			err = c.kubeClientSet.CoreV1().Pods(ms.Namespace).Delete(context.TODO(), newPod.Name, metav1.DeleteOptions{})
-			if err != nil {
-				klog.Errorf("cannot delete pod %s after grace time, err: %v", newPod.Name, err)
-				return
-			}
-			klog.V(2).Infof("%s been deleted after grace time", errPod.Name)
-		}
-	} else {
-		// grace period is not set or the grace period is 0, the deletion will be executed immediately.
-		defer c.graceMap.Delete(utils.GetNamespaceName(errPod))
-
-		err := c.kubeClientSet.CoreV1().Pods(ms.Namespace).Delete(context.TODO(), errPod.Name, metav1.DeleteOptions{})
-		if err != nil {
-			klog.Errorf("cannot delete pod %s when it error, err: %v", errPod.Name, err)
-			return
-		}
-		klog.V(2).Infof("%s been deleted without grace time", errPod.Name)
-	}
-}
-
 func (c *ModelServingController) handleDeletedPod(ms *workloadv1alpha1.ModelServing, servingGroupName string, pod *corev1.Pod) error {
-	// pod is deleted due to failure or other reasons and needs to be rebuilt according to the RecoveryPolicy
-	switch ms.Spec.RecoveryPolicy {
+	if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), servingGroupName) == datastore.ServingGroupFailed {
+		// Failed ServingGroup has exhausted its restart budget and is not recovered anymore.
+		return nil
+	}
+	// pod is deleted due to failure or other reasons and needs to be rebuilt according to the RecoveryPolicy of its role
+	roleName := utils.GetRoleName(pod)
+	switch recoveryPolicyOfRole(ms, roleName) {
 	case workloadv1alpha1.ServingGroupRecreate:
+		if !c.recordRecreation(ms, servingGroupName, "ServingGroup") {
+			return nil
+		}
 		// Rebuild the entire ServingGroup directly
 		if err := c.deleteServingGroup(context.TODO(), ms, servingGroupName); err != nil {
 			klog.Errorf("failed to delete ServingGroup %s: %v", servingGroupName, err)
@@ -1274,7 +1519,11 @@ func (c *ModelServingController) handleDeletedPod(ms *workloadv1alpha1.ModelServ
 				klog.Errorf("failed to delete ServingGroup %s: %v", servingGroupName, err)
 			}
 			return nil
-		} else if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), servingGroupName) == datastore.ServingGroupRunning {
+		}
+		if !c.recordRecreation(ms, servingGroupName, fmt.Sprintf("role %s/%s", roleName, utils.GetRoleID(pod))) {
+			return nil
+		}
+		if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), servingGroupName) == datastore.ServingGroupRunning {
 			// If the ServingGroup status is running when the pod fails, we need to set it to creating
 			err := c.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), servingGroupName, datastore.ServingGroupCreating)
 			klog.V(4).Infof("Setting ServingGroup %s/%s status to Creating when pod deleted for recreating", ms.GetName(), servingGroupName)
@@ -1282,7 +1531,10 @@ func (c *ModelServingController) handleDeletedPod(ms *workloadv1alpha1.ModelServ
 				return fmt.Errorf("failed to set ServingGroup %s status: %v", servingGroupName, err)
 			}
 		}
-		c.DeleteRole(context.Background(), ms, servingGroupName, utils.GetRoleName(pod), utils.GetRoleID(pod))
+		c.DeleteRole(context.Background(), ms, servingGroupName, roleName, utils.GetRoleID(pod))
+	case workloadv1alpha1.NoneRestartPolicy:
+		// The pod is recreated alone by manageRole.
+		c.recordRecreation(ms, servingGroupName, fmt.Sprintf("pod %s", pod.Name))
 	}
 	return nil
 }
@@ -1334,7 +1586,8 @@ func (c *ModelServingController) checkRoleReady(ms *workloadv1alpha1.ModelServin
 	// Count running and ready pods
 	runningPods := 0
 	for _, pod := range pods {
-		if utils.IsPodRunningAndReady(pod) {
+		// A pod updated in place is not ready until its containers run the new image.
+		if utils.IsPodRunningAndReady(pod) && utils.IsInPlaceUpdateCompleted(pod) {
 			runningPods++
 		}
 	}
@@ -1601,7 +1854,7 @@ func (c *ModelServingController) UpdateModelServingStatus(ms *workloadv1alpha1.M
 			return err
 		}
 
-		available, updated, current := 0, 0, 0
+		available, updated, current, updatedAvailable := 0, 0, 0, 0
 		progressingGroups, updatedGroups, currentGroups := []int{}, []int{}, []int{}
 		// Track revision counts to determine the most common non-updated revision (CurrentRevision)
 		revisionCount := make(map[string]int)
@@ -1632,6 +1885,9 @@ func (c *ModelServingController) UpdateModelServingStatus(ms *workloadv1alpha1.M
 			if groups[index].Revision == revision {
 				updated = updated + 1
 				updatedGroups = append(updatedGroups, index)
+				if groups[index].Status == datastore.ServingGroupRunning {
+					updatedAvailable = updatedAvailable + 1
+				}
 			} else {
 				current = current + 1
 				currentGroups = append(currentGroups, index)
@@ -1718,11 +1974,23 @@ func (c *ModelServingController) UpdateModelServingStatus(ms *workloadv1alpha1.M
 			copy.Status.ObservedGeneration = latestMS.Generation
 		}
 
+		copy.Status.ServingGroupRecoveries = c.servingGroupRecoveries(latestMS, groups)
+
+		failures := c.updateRolloutProgress(latestMS, copy, rolloutProgress{
+			current:          current,
+			updated:          updated,
+			updatedAvailable: updatedAvailable,
+		})
+		if !apiequality.Semantic.DeepEqual(latestMS.Status, copy.Status) {
+			shouldUpdate = true
+		}
+
 		if shouldUpdate {
 			_, err := c.modelServingClient.WorkloadV1alpha1().ModelServings(copy.GetNamespace()).UpdateStatus(context.TODO(), copy, metav1.UpdateOptions{})
 			if err != nil {
 				return err
 			}
+			c.commitUpdateFailures(copy, copy.Status.UpdateRevision, failures)
 			// Clean up old revisions only after roles have been updated (revision status changed)
 			if revisionUpdated {
 				if cleanupErr := utils.CleanupOldControllerRevisions(context.TODO(), c.kubeClientSet, copy); cleanupErr != nil {
@@ -1743,9 +2011,10 @@ func (c *ModelServingController) getPartition(ms *workloadv1alpha1.ModelServing)
 	return 0
 }
 
-// scaleDownServingGroups scales down the ServingGroups to the expected count with two-level priority-based selection:
+// scaleDownServingGroups scales down the ServingGroups to the expected count with three-level priority-based selection:
 // 1. Primary: Not-ready groups (Creating, NotFound) are deleted first
-// 2. Secondary: Among groups with same status, lower deletion cost = delete first
+// 2. Secondary: Among groups with same status, groups not at the update revision are deleted first
+// 3. Tertiary: Among groups with same status and revision, lower deletion cost = delete first
 // When partition is set, the first N replicas (where N = partition) are protected.
 // Non-protected replicas (after the first N) are deleted first, then protected replicas if needed.
 func (c *ModelServingController) scaleDownServingGroups(ctx context.Context, ms *workloadv1alpha1.ModelServing, servingGroupList []datastore.ServingGroup, expectedCount int) error {
@@ -1780,12 +2049,20 @@ func (c *ModelServingController) scaleDownServingGroups(ctx context.Context, ms
 			return cmp.Compare(a.Priority, b.Priority) // Ascending: lower priority (not-ready) first
 		}
 
-		// Secondary: Among groups with same priority, lower deletion cost comes first
+		// Secondary: Among groups with same priority, outdated groups come first
+		if a.Outdated != b.Outdated {
+			if a.Outdated {
+				return -1
+			}
+			return 1
+		}
+
+		// Tertiary: Among groups with same priority and revision, lower deletion cost comes first
 		if a.DeletionCost != b.DeletionCost {
 			return cmp.Compare(a.DeletionCost, b.DeletionCost) // Ascending: lower cost first
 		}
 
-		// Tertiary: Higher index comes first (backward compatibility)
+		// Quaternary: Higher index comes first (backward compatibility)
 		return cmp.Compare(b.Index, a.Index) // Descending: higher indices first
 	}
 
@@ -1886,6 +2163,14 @@ func (c *ModelServingController) manageHeadlessService(ctx context.Context, ms *
 	return nil
 }
 
+// pluginChainEntry is the plugin chain built for a generation of a ModelServing.
+type pluginChainEntry struct {
+	uid        types.UID
+	generation int64
+	chain      *plugins.Chain
+}
+
+// buildPluginChain returns the plugin chain of the ModelServing. The chain is built once per generation.
 func (c *ModelServingController) buildPluginChain(ms *workloadv1alpha1.ModelServing) (*plugins.Chain, error) {
 	if ms == nil || len(ms.Spec.Plugins) == 0 {
 		return nil, nil
@@ -1893,7 +2178,18 @@ func (c *ModelServingController) buildPluginChain(ms *workloadv1alpha1.ModelServ
 	if c.pluginsRegistry == nil {
 		return nil, fmt.Errorf("plugin registry is not initialized")
 	}
-	return plugins.NewChain(c.pluginsRegistry, ms.Spec.Plugins)
+	key := utils.GetNamespaceName(ms)
+	if value, ok := c.pluginChains.Load(key); ok {
+		if cached := value.(*pluginChainEntry); cached.uid == ms.UID && cached.generation == ms.Generation {
+			return cached.chain, nil
+		}
+	}
+	chain, err := plugins.NewChain(c.pluginsRegistry, ms.Spec.Plugins)
+	if err != nil {
+		return nil, err
+	}
+	c.pluginChains.Store(key, &pluginChainEntry{uid: ms.UID, generation: ms.Generation, chain: chain})
+	return chain, nil
 }
 
 func (c *ModelServingController) CreatePodsForServingGroup(ctx context.Context, ms *workloadv1alpha1.ModelServing, servingGroupIndex int, revision string, roles []workloadv1alpha1.Role) error {
@@ -1916,8 +2212,6 @@ func (c *ModelServingController) CreatePodsForServingGroup(ctx context.Context,
 
 func (c *ModelServingController) CreatePodsByRole(ctx context.Context, role workloadv1alpha1.Role, ms *workloadv1alpha1.ModelServing, roleIndex int, servingGroupOrdinal int, revision string) error {
 	servingGroupName := utils.GenerateServingGroupName(ms.Name, servingGroupOrdinal)
-	// TODO(hzxuzhonghu): build the plugin chain only once per ModelServing
-	// This is not critical now, so we leave it for future optimization.
 	chain, err := c.buildPluginChain(ms)
 	if err != nil {
 		return fmt.Errorf("build plugin chain: %w", err)
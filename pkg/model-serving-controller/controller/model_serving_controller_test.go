@@ -33,10 +33,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/informers"
 	kubefake "k8s.io/client-go/kubernetes/fake"
+	listerv1 "k8s.io/client-go/listers/core/v1"
 	kubetesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/ptr"
 	schedulingv1beta1 "volcano.sh/apis/pkg/apis/scheduling/v1beta1"
@@ -47,6 +50,7 @@ import (
 	informersv1alpha1 "github.com/volcano-sh/kthena/client-go/informers/externalversions"
 	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/plugins"
 	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
 )
 
@@ -6242,3 +6246,303 @@ func TestDeleteOutdatedServingGroups(t *testing.T) {
 		})
 	}
 }
+
+func TestManageServingGroupSurgeUpdate(t *testing.T) {
+	type groupState struct {
+		ordinal  int
+		revision string
+		status   datastore.ServingGroupStatus
+	}
+	tests := []struct {
+		name              string
+		existing          []groupState
+		expectedRevisions map[int]string // ordinal -> revision of the remaining ServingGroups
+	}{
+		{
+			name: "surge a new ServingGroup outside the ordinal range",
+			existing: []groupState{
+				{0, "v1", datastore.ServingGroupRunning},
+				{1, "v1", datastore.ServingGroupRunning},
+				{2, "v1", datastore.ServingGroupRunning},
+			},
+			expectedRevisions: map[int]string{0: "v1", 1: "v1", 2: "v1", 3: "v2"},
+		},
+		{
+			name: "keep outdated ServingGroups while the surge is not running",
+			existing: []groupState{
+				{0, "v1", datastore.ServingGroupRunning},
+				{1, "v1", datastore.ServingGroupRunning},
+				{2, "v1", datastore.ServingGroupRunning},
+				{3, "v2", datastore.ServingGroupCreating},
+			},
+			expectedRevisions: map[int]string{0: "v1", 1: "v1", 2: "v1", 3: "v2"},
+		},
+		{
+			name: "delete an outdated ServingGroup once the surge is running",
+			existing: []groupState{
+				{0, "v1", datastore.ServingGroupRunning},
+				{1, "v1", datastore.ServingGroupRunning},
+				{2, "v1", datastore.ServingGroupRunning},
+				{3, "v2", datastore.ServingGroupRunning},
+			},
+			expectedRevisions: map[int]string{0: "v1", 1: "v1", 3: "v2"},
+		},
+		{
+			name: "reuse the free ordinal for the next surge",
+			existing: []groupState{
+				{0, "v1", datastore.ServingGroupRunning},
+				{1, "v1", datastore.ServingGroupRunning},
+				{3, "v2", datastore.ServingGroupRunning},
+			},
+			expectedRevisions: map[int]string{0: "v1", 1: "v1", 2: "v2", 3: "v2"},
+		},
+		{
+			name: "compact ordinals by surging into the free ordinal",
+			existing: []groupState{
+				{1, "v2", datastore.ServingGroupRunning},
+				{2, "v2", datastore.ServingGroupRunning},
+				{3, "v2", datastore.ServingGroupRunning},
+			},
+			expectedRevisions: map[int]string{0: "v2", 1: "v2", 2: "v2", 3: "v2"},
+		},
+		{
+			name: "delete the out of range ServingGroup once compaction replacement is running",
+			existing: []groupState{
+				{0, "v2", datastore.ServingGroupRunning},
+				{1, "v2", datastore.ServingGroupRunning},
+				{2, "v2", datastore.ServingGroupRunning},
+				{3, "v2", datastore.ServingGroupRunning},
+			},
+			expectedRevisions: map[int]string{0: "v2", 1: "v2", 2: "v2"},
+		},
+	}
+
+	for idx, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			kthenaClient := kthenafake.NewSimpleClientset()
+			volcanoClient := volcanofake.NewSimpleClientset()
+			apiextClient := apiextfake.NewSimpleClientset()
+
+			controller, err := NewModelServingController(kubeClient, kthenaClient, volcanoClient, apiextClient)
+			assert.NoError(t, err)
+
+			ms := &workloadv1alpha1.ModelServing{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      fmt.Sprintf("test-surge-%d", idx),
+				},
+				Spec: workloadv1alpha1.ModelServingSpec{
+					Replicas:      ptr.To[int32](3),
+					SchedulerName: "volcano",
+					Template: workloadv1alpha1.ServingGroup{
+						Roles: []workloadv1alpha1.Role{
+							{
+								Name:     "prefill",
+								Replicas: ptr.To[int32](1),
+								EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+									Spec: corev1.PodSpec{
+										Containers: []corev1.Container{
+											{
+												Name:  "prefill-container",
+												Image: "test-image:latest",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+					RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+						Type: workloadv1alpha1.ServingGroupRollingUpdate,
+						RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+							MaxUnavailable: ptr.To(intstr.FromInt(0)),
+							MaxSurge:       ptr.To(intstr.FromInt(1)),
+						},
+					},
+					RecoveryPolicy: workloadv1alpha1.RoleRecreate,
+				},
+			}
+
+			for _, g := range tt.existing {
+				controller.store.AddServingGroup(utils.GetNamespaceName(ms), g.ordinal, g.revision)
+				err := controller.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), utils.GenerateServingGroupName(ms.Name, g.ordinal), g.status)
+				assert.NoError(t, err)
+			}
+
+			err = controller.manageServingGroupRollingUpdate(context.Background(), ms, "v2")
+			assert.NoError(t, err)
+
+			groups, err := controller.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
+			assert.NoError(t, err)
+			actual := make(map[int]string, len(groups))
+			for _, g := range groups {
+				_, ordinal := utils.GetParentNameAndOrdinal(g.Name)
+				actual[ordinal] = g.Revision
+			}
+			assert.Equal(t, tt.expectedRevisions, actual)
+		})
+	}
+}
+
+func TestGetSurgeAllowance(t *testing.T) {
+	controller := &ModelServingController{}
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test"},
+		Spec: workloadv1alpha1.ModelServingSpec{
+			Replicas: ptr.To[int32](2),
+			RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+				RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+					MaxSurge: ptr.To(intstr.FromInt(1)),
+				},
+			},
+		},
+	}
+
+	updated := []datastore.ServingGroup{
+		{Name: "test-0", Revision: "v2", Status: datastore.ServingGroupRunning},
+		{Name: "test-1", Revision: "v2", Status: datastore.ServingGroupRunning},
+	}
+	assert.Equal(t, 0, controller.getSurgeAllowance(ms, updated, "v2"))
+
+	rollingOut := []datastore.ServingGroup{
+		{Name: "test-0", Revision: "v1", Status: datastore.ServingGroupRunning},
+		{Name: "test-1", Revision: "v1", Status: datastore.ServingGroupRunning},
+		{Name: "test-2", Revision: "v2", Status: datastore.ServingGroupCreating},
+	}
+	assert.Equal(t, 1, controller.getSurgeAllowance(ms, rollingOut, "v2"))
+
+	compacting := []datastore.ServingGroup{
+		{Name: "test-1", Revision: "v2", Status: datastore.ServingGroupRunning},
+		{Name: "test-2", Revision: "v2", Status: datastore.ServingGroupRunning},
+	}
+	assert.Equal(t, 1, controller.getSurgeAllowance(ms, compacting, "v2"))
+}
+
+func TestScaleDownServingGroupsPrefersOutdated(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kthenaClient := kthenafake.NewSimpleClientset()
+	volcanoClient := volcanofake.NewSimpleClientset()
+	apiextClient := apiextfake.NewSimpleClientset()
+
+	controller, err := NewModelServingController(kubeClient, kthenaClient, volcanoClient, apiextClient)
+	assert.NoError(t, err)
+
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-scaledown-outdated",
+		},
+		Spec: workloadv1alpha1.ModelServingSpec{
+			Replicas: ptr.To[int32](2),
+		},
+		Status: workloadv1alpha1.ModelServingStatus{
+			UpdateRevision: "v2",
+		},
+	}
+
+	var existingGroups []datastore.ServingGroup
+	for ordinal, revision := range []string{"v1", "v1", "v2", "v2"} {
+		controller.store.AddServingGroup(utils.GetNamespaceName(ms), ordinal, revision)
+		existingGroups = append(existingGroups, datastore.ServingGroup{
+			Name:     utils.GenerateServingGroupName(ms.Name, ordinal),
+			Revision: revision,
+		})
+	}
+
+	err = controller.scaleDownServingGroups(context.Background(), ms, existingGroups, 2)
+	assert.NoError(t, err)
+
+	groups, err := controller.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
+	assert.NoError(t, err)
+	var remaining []string
+	for _, g := range groups {
+		remaining = append(remaining, g.Name)
+	}
+	assert.Equal(t, []string{"test-scaledown-outdated-2", "test-scaledown-outdated-3"}, remaining)
+}
+
+type readyCountingPlugin struct {
+	calls *int
+	err   error
+}
+
+func (p *readyCountingPlugin) Name() string { return "ready-counter" }
+
+func (p *readyCountingPlugin) OnPodCreate(_ context.Context, _ *plugins.HookRequest) error {
+	return nil
+}
+
+func (p *readyCountingPlugin) OnPodReady(_ context.Context, _ *plugins.HookRequest) error {
+	*p.calls++
+	return p.err
+}
+
+// TestManagePodReadyHooks tests that the OnPodReady hooks of the ready pods fire once per ready transition
+// in the sync of the ModelServing, and that a failure is returned so that the ModelServing is requeued.
+func TestManagePodReadyHooks(t *testing.T) {
+	calls, builds := 0, 0
+	plugin := &readyCountingPlugin{calls: &calls, err: fmt.Errorf("registry unavailable")}
+	registry := plugins.NewRegistry()
+	registry.Register(plugin.Name(), func(workloadv1alpha1.PluginSpec) (plugins.Plugin, error) {
+		builds++
+		return plugin, nil
+	})
+	recorder := record.NewFakeRecorder(10)
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	controller := &ModelServingController{
+		pluginsRegistry: registry,
+		recorder:        recorder,
+		podsLister:      listerv1.NewPodLister(indexer),
+	}
+
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ms", UID: "ms-uid", Generation: 1},
+		Spec: workloadv1alpha1.ModelServingSpec{
+			Plugins: []workloadv1alpha1.PluginSpec{{Name: plugin.Name(), Type: workloadv1alpha1.PluginTypeBuiltIn}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "ms-0-prefill-0-0",
+			UID:       "pod-uid",
+			Labels: map[string]string{
+				workloadv1alpha1.ModelServingNameLabelKey: ms.Name,
+				workloadv1alpha1.GroupNameLabelKey:        "ms-0",
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: workloadv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "ModelServing",
+				Name:       ms.Name,
+				UID:        ms.UID,
+				Controller: ptr.To(true),
+			}},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+	assert.NoError(t, indexer.Add(pod))
+
+	// A failure emits an event and is returned to requeue the ModelServing.
+	assert.Error(t, controller.managePodReadyHooks(context.Background(), ms))
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, <-recorder.Events, "PluginOnPodReadyFailed")
+	assert.True(t, controller.hasPendingPodReadyHooks(ms, pod))
+
+	plugin.err = nil
+	assert.NoError(t, controller.managePodReadyHooks(context.Background(), ms))
+	assert.NoError(t, controller.managePodReadyHooks(context.Background(), ms))
+	assert.Equal(t, 2, calls, "hooks must fire once after success")
+	assert.False(t, controller.hasPendingPodReadyHooks(ms, pod))
+	assert.Equal(t, 1, builds, "the plugin chain must be built once per generation")
+
+	// The hooks fire again once the pod becomes ready again.
+	controller.readyHooks.Delete(pod.UID)
+	ms.Generation = 2
+	assert.NoError(t, controller.managePodReadyHooks(context.Background(), ms))
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2, builds, "the plugin chain must be rebuilt for a new generation")
+}
@@ -0,0 +1,276 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+const defaultRestartBudgetWindow = time.Hour
+
+// servingGroupRestarts is the restart budget state of a ServingGroup.
+type servingGroupRestarts struct {
+	revision string
+	times    []time.Time
+	failed   bool
+}
+
+// restartBudgetKey returns the key of the restart budget state of a ServingGroup.
+func restartBudgetKey(ms *workloadv1alpha1.ModelServing, groupName string) string {
+	return fmt.Sprintf("%s/%s/%s", ms.Namespace, ms.Name, groupName)
+}
+
+// restartBudgetWindow returns the window the recreations are counted in.
+func restartBudgetWindow(budget *workloadv1alpha1.RestartBudget) time.Duration {
+	if budget.WindowSeconds <= 0 {
+		return defaultRestartBudgetWindow
+	}
+	return time.Duration(budget.WindowSeconds) * time.Second
+}
+
+// recoveryPolicyOfRole returns the RecoveryPolicy of the role, which defaults to the RecoveryPolicy of the ModelServing.
+func recoveryPolicyOfRole(ms *workloadv1alpha1.ModelServing, roleName string) workloadv1alpha1.RecoveryPolicy {
+	for _, role := range ms.Spec.Template.Roles {
+		if role.Name == roleName && role.RecoveryPolicy != nil {
+			return *role.RecoveryPolicy
+		}
+	}
+	return ms.Spec.RecoveryPolicy
+}
+
+// getServingGroupRestarts returns the restart budget state of the ServingGroup at the given revision.
+// The state is initialized from the ModelServing status, so that it survives controller restarts, and is reset
+// when the ServingGroup is updated to another revision. The caller must hold restartBudgetsLock.
+func (c *ModelServingController) getServingGroupRestarts(ms *workloadv1alpha1.ModelServing, groupName, revision string) *servingGroupRestarts {
+	if c.restartBudgets == nil {
+		c.restartBudgets = make(map[string]*servingGroupRestarts)
+	}
+	key := restartBudgetKey(ms, groupName)
+	restarts, ok := c.restartBudgets[key]
+	if !ok {
+		restarts = &servingGroupRestarts{revision: revision}
+		for _, status := range ms.Status.ServingGroupRecoveries {
+			if status.Name != groupName {
+				continue
+			}
+			restarts.revision = status.Revision
+			restarts.failed = status.Failed
+			for _, t := range status.RecreationTimes {
+				restarts.times = append(restarts.times, t.Time)
+			}
+		}
+		c.restartBudgets[key] = restarts
+	}
+	if revision != "" && restarts.revision != revision {
+		*restarts = servingGroupRestarts{revision: revision}
+	}
+	return restarts
+}
+
+// pruneRecreations drops the recreations that are out of the budget window.
+func (r *servingGroupRestarts) pruneRecreations(window time.Duration, now time.Time) {
+	kept := r.times[:0]
+	for _, t := range r.times {
+		if now.Sub(t) < window {
+			kept = append(kept, t)
+		}
+	}
+	r.times = kept
+}
+
+// restartBudgetExhausted checks whether the ServingGroup may not be recreated anymore.
+func (c *ModelServingController) restartBudgetExhausted(ms *workloadv1alpha1.ModelServing, groupName string) bool {
+	budget := ms.Spec.RestartBudget
+	if budget == nil {
+		return false
+	}
+	if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName) == datastore.ServingGroupFailed {
+		return true
+	}
+	revision, _ := c.store.GetServingGroupRevision(utils.GetNamespaceName(ms), groupName)
+
+	c.restartBudgetsLock.Lock()
+	defer c.restartBudgetsLock.Unlock()
+	restarts := c.getServingGroupRestarts(ms, groupName, revision)
+	restarts.pruneRecreations(restartBudgetWindow(budget), time.Now())
+	return len(restarts.times) >= int(budget.MaxRecreations)
+}
+
+// recordRecreation counts a recreation of the ServingGroup, or of one of its roles or pods, against the restart
+// budget. It returns false when the budget is exhausted, in which case the ServingGroup is marked Failed and must
+// not be recovered.
+func (c *ModelServingController) recordRecreation(ms *workloadv1alpha1.ModelServing, groupName, target string) bool {
+	budget := ms.Spec.RestartBudget
+	if budget == nil {
+		return true
+	}
+	if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName) == datastore.ServingGroupFailed {
+		return false
+	}
+	revision, _ := c.store.GetServingGroupRevision(utils.GetNamespaceName(ms), groupName)
+
+	c.restartBudgetsLock.Lock()
+	restarts := c.getServingGroupRestarts(ms, groupName, revision)
+	// Truncate to seconds to match the precision of the status.
+	now := time.Now().Truncate(time.Second)
+	window := restartBudgetWindow(budget)
+	restarts.pruneRecreations(window, now)
+	exhausted := len(restarts.times) >= int(budget.MaxRecreations)
+	if !exhausted {
+		restarts.times = append(restarts.times, now)
+	}
+	c.restartBudgetsLock.Unlock()
+
+	if !exhausted {
+		klog.V(2).Infof("Recreating %s of ServingGroup %s/%s (%d/%d recreations within %v)", target, ms.Namespace, groupName, len(restarts.times), budget.MaxRecreations, window)
+		return true
+	}
+	c.markServingGroupFailed(ms, groupName)
+	return false
+}
+
+// markServingGroupFailed marks a ServingGroup that exhausted its restart budget as Failed.
+func (c *ModelServingController) markServingGroupFailed(ms *workloadv1alpha1.ModelServing, groupName string) {
+	budget := ms.Spec.RestartBudget
+	revision, _ := c.store.GetServingGroupRevision(utils.GetNamespaceName(ms), groupName)
+	c.restartBudgetsLock.Lock()
+	c.getServingGroupRestarts(ms, groupName, revision).failed = true
+	c.restartBudgetsLock.Unlock()
+
+	if err := c.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), groupName, datastore.ServingGroupFailed); err != nil {
+		klog.Errorf("failed to set ServingGroup %s status to Failed: %v", groupName, err)
+	}
+	message := fmt.Sprintf("ServingGroup %s has been recreated %d times within %v, it is marked Failed and is no longer recovered",
+		groupName, budget.MaxRecreations, restartBudgetWindow(budget))
+	klog.Warningf("ModelServing %s/%s: %s", ms.Namespace, ms.Name, message)
+	c.emitEvent(ms, corev1.EventTypeWarning, "RestartBudgetExceeded", message)
+	c.enqueueModelServing(ms)
+}
+
+// manageFailedServingGroups restores the Failed status of the ServingGroups recorded in the ModelServing status,
+// e.g. after a controller restart, and recreates the Failed ServingGroups whose pods have all been deleted,
+// which is how a Failed ServingGroup is retried after inspection.
+func (c *ModelServingController) manageFailedServingGroups(ctx context.Context, ms *workloadv1alpha1.ModelServing) error {
+	if ms.Spec.RestartBudget == nil {
+		return nil
+	}
+	msName := utils.GetNamespaceName(ms)
+	for _, status := range ms.Status.ServingGroupRecoveries {
+		if !status.Failed {
+			continue
+		}
+		groupStatus := c.store.GetServingGroupStatus(msName, status.Name)
+		revision, _ := c.store.GetServingGroupRevision(msName, status.Name)
+		if groupStatus == datastore.ServingGroupNotFound || groupStatus == datastore.ServingGroupDeleting ||
+			groupStatus == datastore.ServingGroupFailed || groupStatus == datastore.ServingGroupRunning ||
+			revision != status.Revision {
+			continue
+		}
+		c.restartBudgetsLock.Lock()
+		failed := c.getServingGroupRestarts(ms, status.Name, revision).failed
+		c.restartBudgetsLock.Unlock()
+		if !failed {
+			continue
+		}
+		if err := c.store.UpdateServingGroupStatus(msName, status.Name, datastore.ServingGroupFailed); err != nil {
+			return err
+		}
+		klog.V(2).Infof("Restored Failed status of ServingGroup %s/%s", ms.Namespace, status.Name)
+	}
+
+	groups, err := c.store.GetServingGroupByModelServing(msName)
+	if err != nil {
+		return nil
+	}
+	for _, group := range groups {
+		if group.Status != datastore.ServingGroupFailed {
+			continue
+		}
+		pods, err := c.getPodsByIndex(GroupNameKey, fmt.Sprintf("%s/%s", ms.Namespace, group.Name))
+		if err != nil {
+			return err
+		}
+		if len(pods) > 0 {
+			continue
+		}
+		klog.V(2).Infof("All pods of Failed ServingGroup %s/%s have been deleted, recreating it", ms.Namespace, group.Name)
+		c.restartBudgetsLock.Lock()
+		delete(c.restartBudgets, restartBudgetKey(ms, group.Name))
+		c.restartBudgetsLock.Unlock()
+		if err := c.deleteServingGroup(ctx, ms, group.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// servingGroupRecoveries returns the restart budget state of the ServingGroups to be recorded in the ModelServing status.
+func (c *ModelServingController) servingGroupRecoveries(ms *workloadv1alpha1.ModelServing, groups []datastore.ServingGroup) []workloadv1alpha1.ServingGroupRecoveryStatus {
+	budget := ms.Spec.RestartBudget
+	if budget == nil {
+		return nil
+	}
+	now := time.Now()
+
+	c.restartBudgetsLock.Lock()
+	defer c.restartBudgetsLock.Unlock()
+	var recoveries []workloadv1alpha1.ServingGroupRecoveryStatus
+	for _, group := range groups {
+		restarts := c.getServingGroupRestarts(ms, group.Name, group.Revision)
+		restarts.pruneRecreations(restartBudgetWindow(budget), now)
+		if restarts.failed && group.Status != datastore.ServingGroupFailed && group.Status != datastore.ServingGroupDeleting {
+			// The ServingGroup became ready again.
+			restarts.failed = false
+		}
+		if len(restarts.times) == 0 && !restarts.failed {
+			continue
+		}
+		status := workloadv1alpha1.ServingGroupRecoveryStatus{
+			Name:     group.Name,
+			Revision: restarts.revision,
+			Failed:   restarts.failed,
+		}
+		for _, t := range restarts.times {
+			status.RecreationTimes = append(status.RecreationTimes, metav1.NewTime(t))
+		}
+		recoveries = append(recoveries, status)
+	}
+	return recoveries
+}
+
+// clearRestartBudgets drops the restart budget state of a deleted ModelServing.
+func (c *ModelServingController) clearRestartBudgets(ms *workloadv1alpha1.ModelServing) {
+	prefix := fmt.Sprintf("%s/%s/", ms.Namespace, ms.Name)
+	c.restartBudgetsLock.Lock()
+	defer c.restartBudgetsLock.Unlock()
+	for key := range c.restartBudgets {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.restartBudgets, key)
+		}
+	}
+}
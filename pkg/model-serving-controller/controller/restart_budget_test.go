@@ -0,0 +1,185 @@
+/*
+Copyright The Volcano Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+func newRestartBudgetTestController(t *testing.T) *ModelServingController {
+	controller, err := NewModelServingController(kubefake.NewSimpleClientset(), kthenafake.NewSimpleClientset(), volcanofake.NewSimpleClientset(), apiextfake.NewSimpleClientset())
+	assert.NoError(t, err)
+	return controller
+}
+
+// newRestartBudgetTestModelServing returns a ModelServing whose prefill role recreates the whole ServingGroup,
+// while the decode role follows the RoleRecreate policy of the ModelServing.
+func newRestartBudgetTestModelServing() *workloadv1alpha1.ModelServing {
+	return &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "test-restart-budget",
+			UID:       "test-restart-budget-uid",
+		},
+		Spec: workloadv1alpha1.ModelServingSpec{
+			Replicas:      ptr.To[int32](2),
+			SchedulerName: "volcano",
+			Template: workloadv1alpha1.ServingGroup{
+				Roles: []workloadv1alpha1.Role{
+					{
+						Name:           "prefill",
+						Replicas:       ptr.To[int32](1),
+						RecoveryPolicy: ptr.To(workloadv1alpha1.ServingGroupRecreate),
+						EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "prefill-container", Image: "prefill:v1"}},
+							},
+						},
+					},
+					{
+						Name:     "decode",
+						Replicas: ptr.To[int32](1),
+						EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+							Spec: corev1.PodSpec{
+								Containers: []corev1.Container{{Name: "decode-container", Image: "decode:v1"}},
+							},
+						},
+					},
+				},
+			},
+			RecoveryPolicy: workloadv1alpha1.RoleRecreate,
+			RestartBudget:  &workloadv1alpha1.RestartBudget{MaxRecreations: 2, WindowSeconds: 600},
+		},
+	}
+}
+
+func TestRecoveryPolicyOfRole(t *testing.T) {
+	ms := newRestartBudgetTestModelServing()
+	assert.Equal(t, workloadv1alpha1.ServingGroupRecreate, recoveryPolicyOfRole(ms, "prefill"))
+	assert.Equal(t, workloadv1alpha1.RoleRecreate, recoveryPolicyOfRole(ms, "decode"))
+	assert.Equal(t, workloadv1alpha1.RoleRecreate, recoveryPolicyOfRole(ms, "unknown"))
+}
+
+func TestHandleDeletedPodWithRoleRecoveryPolicy(t *testing.T) {
+	ms := newRestartBudgetTestModelServing()
+	ms.Spec.RestartBudget = nil
+	controller := newRestartBudgetTestController(t)
+	groupName := utils.GenerateServingGroupName(ms.Name, 0)
+	for _, role := range ms.Spec.Template.Roles {
+		controller.store.AddRole(utils.GetNamespaceName(ms), groupName, role.Name, utils.GenerateRoleID(role.Name, 0), "v1")
+	}
+
+	decodePod := utils.GenerateEntryPod(*ms.Spec.Template.Roles[1].DeepCopy(), ms, groupName, 0, "v1")
+	assert.NoError(t, controller.handleDeletedPod(ms, groupName, decodePod))
+	assert.Equal(t, datastore.RoleDeleting, controller.store.GetRoleStatus(utils.GetNamespaceName(ms), groupName, "decode", "decode-0"))
+	assert.NotEqual(t, datastore.ServingGroupDeleting, controller.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName))
+
+	prefillPod := utils.GenerateEntryPod(*ms.Spec.Template.Roles[0].DeepCopy(), ms, groupName, 0, "v1")
+	assert.NoError(t, controller.handleDeletedPod(ms, groupName, prefillPod))
+	// The ServingGroup has no pods left, so it is deleted at once.
+	assert.Equal(t, datastore.ServingGroupNotFound, controller.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName))
+}
+
+func TestRestartBudget(t *testing.T) {
+	ms := newRestartBudgetTestModelServing()
+	controller := newRestartBudgetTestController(t)
+	msName := utils.GetNamespaceName(ms)
+	groupName := utils.GenerateServingGroupName(ms.Name, 0)
+	controller.store.AddServingGroup(msName, 0, "v1")
+	controller.store.AddRole(msName, groupName, "decode", "decode-0", "v1")
+
+	assert.True(t, controller.recordRecreation(ms, groupName, "role decode/decode-0"))
+	assert.True(t, controller.recordRecreation(ms, groupName, "role decode/decode-0"))
+	assert.True(t, controller.restartBudgetExhausted(ms, groupName))
+	assert.False(t, controller.recordRecreation(ms, groupName, "role decode/decode-0"))
+	assert.Equal(t, datastore.ServingGroupFailed, controller.store.GetServingGroupStatus(msName, groupName))
+
+	groups, err := controller.store.GetServingGroupByModelServing(msName)
+	assert.NoError(t, err)
+	recoveries := controller.servingGroupRecoveries(ms, groups)
+	assert.Len(t, recoveries, 1)
+	assert.Equal(t, groupName, recoveries[0].Name)
+	assert.Equal(t, "v1", recoveries[0].Revision)
+	assert.True(t, recoveries[0].Failed)
+	assert.Len(t, recoveries[0].RecreationTimes, 2)
+
+	// A Failed ServingGroup is not recovered and is left for inspection.
+	decodePod := utils.GenerateEntryPod(*ms.Spec.Template.Roles[1].DeepCopy(), ms, groupName, 0, "v1")
+	assert.NoError(t, controller.handleDeletedPod(ms, groupName, decodePod))
+	assert.NotEqual(t, datastore.RoleDeleting, controller.store.GetRoleStatus(msName, groupName, "decode", "decode-0"))
+
+	t.Run("restore the budget from status", func(t *testing.T) {
+		restarted := newRestartBudgetTestController(t)
+		ms := ms.DeepCopy()
+		ms.Status.ServingGroupRecoveries = recoveries
+		restarted.store.AddServingGroup(msName, 0, "v1")
+		restarted.store.AddRole(msName, groupName, "decode", "decode-0", "v1")
+		pod := utils.GenerateEntryPod(*ms.Spec.Template.Roles[1].DeepCopy(), ms, groupName, 0, "v1")
+		assert.NoError(t, restarted.podsInformer.GetIndexer().Add(pod))
+
+		assert.NoError(t, restarted.manageFailedServingGroups(context.Background(), ms))
+		assert.Equal(t, datastore.ServingGroupFailed, restarted.store.GetServingGroupStatus(msName, groupName))
+	})
+
+	t.Run("recreate a Failed ServingGroup without pods", func(t *testing.T) {
+		assert.NoError(t, controller.manageFailedServingGroups(context.Background(), ms))
+		assert.Equal(t, datastore.ServingGroupNotFound, controller.store.GetServingGroupStatus(msName, groupName))
+
+		controller.store.AddServingGroup(msName, 0, "v1")
+		assert.True(t, controller.recordRecreation(ms, groupName, "role decode/decode-0"), "the budget should be reset")
+	})
+
+	t.Run("reset the budget when the ServingGroup is updated", func(t *testing.T) {
+		controller := newRestartBudgetTestController(t)
+		ms := ms.DeepCopy()
+		ms.Status.ServingGroupRecoveries = []workloadv1alpha1.ServingGroupRecoveryStatus{{
+			Name:            groupName,
+			Revision:        "v1",
+			RecreationTimes: []metav1.Time{metav1.Now(), metav1.Now()},
+		}}
+		controller.store.AddServingGroup(msName, 0, "v2")
+		assert.False(t, controller.restartBudgetExhausted(ms, groupName))
+	})
+
+	t.Run("recreations out of the window are not counted", func(t *testing.T) {
+		controller := newRestartBudgetTestController(t)
+		ms := ms.DeepCopy()
+		old := metav1.NewTime(time.Now().Add(-time.Hour))
+		ms.Status.ServingGroupRecoveries = []workloadv1alpha1.ServingGroupRecoveryStatus{{
+			Name:            groupName,
+			Revision:        "v1",
+			RecreationTimes: []metav1.Time{old, old},
+		}}
+		controller.store.AddServingGroup(msName, 0, "v1")
+		assert.False(t, controller.restartBudgetExhausted(ms, groupName))
+		groups, err := controller.store.GetServingGroupByModelServing(msName)
+		assert.NoError(t, err)
+		assert.Empty(t, controller.servingGroupRecoveries(ms, groups))
+	})
+}
@@ -0,0 +1,158 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/metrics"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+// restartGraceDeadline returns the end of the restart grace period recorded on the pod.
+func restartGraceDeadline(pod *corev1.Pod) (time.Time, bool) {
+	value, ok := pod.Annotations[workloadv1alpha1.RestartGraceDeadlineAnnotationKey]
+	if !ok {
+		return time.Time{}, false
+	}
+	deadline, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		klog.Warningf("Invalid restart grace deadline %q of pod %s/%s: %v", value, pod.Namespace, pod.Name, err)
+		return time.Time{}, false
+	}
+	return deadline, true
+}
+
+// startRestartGracePeriod starts the restart grace period of a failed pod and returns its deadline.
+// The deadline is recorded on the pod, so that the grace period survives controller restarts. A pod that
+// already records a deadline, e.g. when the controller has failed over, resumes its grace period.
+func (c *ModelServingController) startRestartGracePeriod(ms *workloadv1alpha1.ModelServing, pod *corev1.Pod) (time.Time, bool, error) {
+	if deadline, ok := restartGraceDeadline(pod); ok {
+		return deadline, true, nil
+	}
+
+	var grace time.Duration
+	if ms.Spec.Template.RestartGracePeriodSeconds != nil && *ms.Spec.Template.RestartGracePeriodSeconds > 0 {
+		grace = time.Duration(*ms.Spec.Template.RestartGracePeriodSeconds) * time.Second
+	}
+	// Truncate to seconds to match the precision of the annotation.
+	deadline := time.Now().Add(grace).Truncate(time.Second)
+	if grace == 0 {
+		// The pod is rebuilt immediately, no need to record the deadline.
+		return deadline, false, nil
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, workloadv1alpha1.RestartGraceDeadlineAnnotationKey, deadline.Format(time.RFC3339))
+	_, err := c.kubeClientSet.CoreV1().Pods(pod.Namespace).Patch(context.TODO(), pod.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to record restart grace deadline of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	}
+	return deadline, false, nil
+}
+
+// manageRestartGracePeriods rebuilds the failed pods of the ModelServing whose restart grace period has expired
+// without recovering, and requeues the ModelServing for the earliest pending deadline.
+func (c *ModelServingController) manageRestartGracePeriods(ctx context.Context, ms *workloadv1alpha1.ModelServing) error {
+	selector := labels.SelectorFromSet(map[string]string{workloadv1alpha1.ModelServingNameLabelKey: ms.Name})
+	pods, err := c.podsLister.Pods(ms.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	inGrace := 0
+	var nextDeadline time.Time
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || !utils.IsOwnedByModelServingWithUID(pod, ms.UID) {
+			continue
+		}
+		deadline, ok := restartGraceDeadline(pod)
+		if value, exists := c.graceMap.Load(utils.GetNamespaceName(pod)); exists {
+			deadline, ok = value.(time.Time), true
+		}
+		if !ok {
+			continue
+		}
+
+		if now.Before(deadline) {
+			inGrace++
+			if nextDeadline.IsZero() || deadline.Before(nextDeadline) {
+				nextDeadline = deadline
+			}
+			continue
+		}
+
+		if err := c.expireRestartGracePeriod(ctx, ms, pod); err != nil {
+			return err
+		}
+	}
+
+	metrics.PodsInRestartGracePeriod.WithLabelValues(ms.Namespace, ms.Name).Set(float64(inGrace))
+	if !nextDeadline.IsZero() {
+		c.enqueueModelServingAfter(ms, time.Until(nextDeadline))
+	}
+	return nil
+}
+
+// expireRestartGracePeriod ends the restart grace period of the pod. The pod is deleted if it has not recovered,
+// and is then rebuilt according to the RecoveryPolicy when its deletion is observed. The pod is kept if the restart
+// budget of its ServingGroup is exhausted.
+func (c *ModelServingController) expireRestartGracePeriod(ctx context.Context, ms *workloadv1alpha1.ModelServing, pod *corev1.Pod) error {
+	if utils.IsPodRunningAndReady(pod) {
+		if _, ok := pod.Annotations[workloadv1alpha1.RestartGraceDeadlineAnnotationKey]; ok {
+			patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:null}}}`, workloadv1alpha1.RestartGraceDeadlineAnnotationKey)
+			_, err := c.kubeClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to clear restart grace deadline of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			}
+		}
+		c.graceMap.Delete(utils.GetNamespaceName(pod))
+		metrics.RestartGracePeriodsExpired.WithLabelValues(ms.Namespace, ms.Name, metrics.ResultRecovered).Inc()
+		klog.V(4).Infof("Pod %s/%s recovered within the restart grace period", pod.Namespace, pod.Name)
+		return nil
+	}
+
+	groupName := pod.Labels[workloadv1alpha1.GroupNameLabelKey]
+	if c.restartBudgetExhausted(ms, groupName) {
+		// The pod is kept for inspection, as the ServingGroup cannot be recovered anymore.
+		if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName) != datastore.ServingGroupFailed {
+			c.markServingGroupFailed(ms, groupName)
+		}
+		c.graceMap.Delete(utils.GetNamespaceName(pod))
+		return nil
+	}
+
+	// The grace map entry is removed once the pod deletion is observed.
+	err := c.kubeClientSet.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("cannot delete pod %s/%s after restart grace period: %v", pod.Namespace, pod.Name, err)
+	}
+	metrics.RestartGracePeriodsExpired.WithLabelValues(ms.Namespace, ms.Name, metrics.ResultRebuilt).Inc()
+	klog.V(2).Infof("Pod %s/%s has been deleted after restart grace period", pod.Namespace, pod.Name)
+	return nil
+}
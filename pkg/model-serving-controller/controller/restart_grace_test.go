@@ -0,0 +1,175 @@
+/*
+Copyright The Volcano Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/metrics"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+func newRestartGraceTestPod(ms *workloadv1alpha1.ModelServing, name string, ready bool, deadline *time.Time) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ms.Namespace,
+			Name:      name,
+			Labels: map[string]string{
+				workloadv1alpha1.ModelServingNameLabelKey: ms.Name,
+				workloadv1alpha1.GroupNameLabelKey:        utils.GenerateServingGroupName(ms.Name, 0),
+			},
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion: workloadv1alpha1.GroupVersion.String(),
+					Kind:       "ModelServing",
+					Name:       ms.Name,
+					UID:        ms.UID,
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			Phase: corev1.PodFailed,
+		},
+	}
+	if ready {
+		pod.Status.Phase = corev1.PodRunning
+		pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+	}
+	if deadline != nil {
+		pod.Annotations = map[string]string{workloadv1alpha1.RestartGraceDeadlineAnnotationKey: deadline.Format(time.RFC3339)}
+	}
+	return pod
+}
+
+func newRestartGraceTestController(t *testing.T) (*ModelServingController, *kubefake.Clientset) {
+	kubeClient := kubefake.NewSimpleClientset()
+	controller, err := NewModelServingController(kubeClient, kthenafake.NewSimpleClientset(), volcanofake.NewSimpleClientset(), apiextfake.NewSimpleClientset())
+	assert.NoError(t, err)
+	return controller, kubeClient
+}
+
+func TestStartRestartGracePeriod(t *testing.T) {
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-grace", UID: "test-grace-uid"},
+	}
+
+	t.Run("record the deadline on the pod", func(t *testing.T) {
+		controller, kubeClient := newRestartGraceTestController(t)
+		ms := ms.DeepCopy()
+		ms.Spec.Template.RestartGracePeriodSeconds = ptr.To[int64](60)
+		pod := newRestartGraceTestPod(ms, "pod", false, nil)
+		_, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+
+		deadline, resumed, err := controller.startRestartGracePeriod(ms, pod)
+		assert.NoError(t, err)
+		assert.False(t, resumed)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 2*time.Second)
+
+		got, err := kubeClient.CoreV1().Pods(pod.Namespace).Get(context.Background(), pod.Name, metav1.GetOptions{})
+		assert.NoError(t, err)
+		recorded, ok := restartGraceDeadline(got)
+		assert.True(t, ok)
+		assert.True(t, deadline.Equal(recorded))
+	})
+
+	t.Run("resume the deadline recorded on the pod", func(t *testing.T) {
+		controller, _ := newRestartGraceTestController(t)
+		ms := ms.DeepCopy()
+		ms.Spec.Template.RestartGracePeriodSeconds = ptr.To[int64](60)
+		recorded := time.Now().Add(30 * time.Second).Truncate(time.Second)
+		pod := newRestartGraceTestPod(ms, "pod", false, &recorded)
+
+		deadline, resumed, err := controller.startRestartGracePeriod(ms, pod)
+		assert.NoError(t, err)
+		assert.True(t, resumed)
+		assert.True(t, recorded.Equal(deadline))
+	})
+
+	t.Run("no grace period", func(t *testing.T) {
+		controller, kubeClient := newRestartGraceTestController(t)
+		pod := newRestartGraceTestPod(ms, "pod", false, nil)
+
+		deadline, resumed, err := controller.startRestartGracePeriod(ms, pod)
+		assert.NoError(t, err)
+		assert.False(t, resumed)
+		assert.False(t, deadline.After(time.Now()))
+		// The pod is not patched, so it does not need to exist.
+		assert.Empty(t, kubeClient.Actions())
+	})
+}
+
+func TestManageRestartGracePeriods(t *testing.T) {
+	controller, kubeClient := newRestartGraceTestController(t)
+	ms := &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-grace-periods", UID: "test-grace-periods-uid"},
+	}
+
+	past := time.Now().Add(-time.Minute)
+	future := time.Now().Add(time.Hour)
+	pods := []*corev1.Pod{
+		newRestartGraceTestPod(ms, "expired-failed", false, &past),
+		newRestartGraceTestPod(ms, "expired-recovered", true, &past),
+		newRestartGraceTestPod(ms, "in-grace", false, &future),
+		newRestartGraceTestPod(ms, "healthy", true, nil),
+	}
+	for _, pod := range pods {
+		_, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+		assert.NoError(t, err)
+		assert.NoError(t, controller.podsInformer.GetIndexer().Add(pod))
+	}
+	// A pod without annotation, e.g. with no restart grace period, is tracked in the grace map only.
+	noGrace := newRestartGraceTestPod(ms, "no-grace", false, nil)
+	_, err := kubeClient.CoreV1().Pods(noGrace.Namespace).Create(context.Background(), noGrace, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, controller.podsInformer.GetIndexer().Add(noGrace))
+	controller.graceMap.Store(utils.GetNamespaceName(noGrace), time.Now().Add(-time.Second))
+
+	assert.NoError(t, controller.manageRestartGracePeriods(context.Background(), ms))
+
+	for _, name := range []string{"expired-failed", "no-grace"} {
+		_, err = kubeClient.CoreV1().Pods(ms.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err), "pod %s should be deleted", name)
+	}
+
+	recovered, err := kubeClient.CoreV1().Pods(ms.Namespace).Get(context.Background(), "expired-recovered", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.NotContains(t, recovered.Annotations, workloadv1alpha1.RestartGraceDeadlineAnnotationKey)
+
+	for _, name := range []string{"in-grace", "healthy"} {
+		_, err = kubeClient.CoreV1().Pods(ms.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+		assert.NoError(t, err, "pod %s should be kept", name)
+	}
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.PodsInRestartGracePeriod.WithLabelValues(ms.Namespace, ms.Name)))
+	assert.Equal(t, float64(2), testutil.ToFloat64(metrics.RestartGracePeriodsExpired.WithLabelValues(ms.Namespace, ms.Name, metrics.ResultRebuilt)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(metrics.RestartGracePeriodsExpired.WithLabelValues(ms.Namespace, ms.Name, metrics.ResultRecovered)))
+
+	metrics.DeleteModelServing(ms.Namespace, ms.Name)
+	assert.False(t, metrics.PodsInRestartGracePeriod.DeleteLabelValues(ms.Namespace, ms.Name), "metrics of the ModelServing should be deleted")
+}
@@ -0,0 +1,402 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+// outdatedRole is a role replica of a ServingGroup whose pods are not created from the current role template.
+type outdatedRole struct {
+	roleName string
+	roleID   string
+	pods     []*corev1.Pod
+	// removed reports that the role is no longer part of the ModelServing template.
+	removed bool
+}
+
+func isRoleRollingUpdate(ms *workloadv1alpha1.ModelServing) bool {
+	return ms.Spec.RolloutStrategy != nil && ms.Spec.RolloutStrategy.Type == workloadv1alpha1.RoleRollingUpdate
+}
+
+func isInPlaceUpdateEnabled(ms *workloadv1alpha1.ModelServing) bool {
+	return ms.Spec.RolloutStrategy != nil && ms.Spec.RolloutStrategy.PodUpdatePolicy == workloadv1alpha1.InPlaceIfPossiblePodUpdatePolicy
+}
+
+// podRoleRevision returns the revision of the role template a pod is created from. Pods created before the role
+// revision was recorded are created from the roles template of their ServingGroup revision, and have no revision
+// when that template is unknown.
+func (c *ModelServingController) podRoleRevision(ctx context.Context, ms *workloadv1alpha1.ModelServing, pod *corev1.Pod, roleName string, revisionRoles map[string][]workloadv1alpha1.Role) string {
+	if roleRevision, ok := pod.Labels[workloadv1alpha1.RoleRevisionLabelKey]; ok {
+		return roleRevision
+	}
+	roles, ok := c.getRevisionRoles(ctx, ms, utils.ObjectRevision(pod), revisionRoles)
+	if !ok {
+		return ""
+	}
+	index := slices.IndexFunc(roles, func(r workloadv1alpha1.Role) bool { return r.Name == roleName })
+	if index < 0 {
+		return ""
+	}
+	return utils.RoleRevision(roles[index])
+}
+
+// getOutdatedRoles returns the role replicas of the ServingGroup that have to be updated to the current roles template,
+// including the replicas of the roles removed from the template. Role replicas being deleted are skipped.
+func (c *ModelServingController) getOutdatedRoles(ctx context.Context, ms *workloadv1alpha1.ModelServing, groupName string, revisionRoles map[string][]workloadv1alpha1.Role) ([]outdatedRole, error) {
+	var outdated []outdatedRole
+	templateRoles := make(map[string]struct{}, len(ms.Spec.Template.Roles))
+	for _, role := range ms.Spec.Template.Roles {
+		templateRoles[role.Name] = struct{}{}
+		roleRevision := utils.RoleRevision(role)
+		roleList, err := c.store.GetRoleList(utils.GetNamespaceName(ms), groupName, role.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range roleList {
+			if r.Status == datastore.RoleDeleting {
+				continue
+			}
+			pods, err := c.getPodsByIndex(RoleIDKey, fmt.Sprintf("%s/%s/%s/%s", ms.Namespace, groupName, role.Name, r.Name))
+			if err != nil {
+				return nil, err
+			}
+			for _, pod := range pods {
+				if c.podRoleRevision(ctx, ms, pod, role.Name, revisionRoles) != roleRevision {
+					outdated = append(outdated, outdatedRole{roleName: role.Name, roleID: r.Name, pods: pods})
+					break
+				}
+			}
+		}
+	}
+
+	// The roles removed from the template are only left in the pods of the ServingGroup.
+	pods, err := c.getPodsByIndex(GroupNameKey, fmt.Sprintf("%s/%s", ms.Namespace, groupName))
+	if err != nil {
+		return nil, err
+	}
+	removed := map[string]*outdatedRole{}
+	for _, pod := range pods {
+		roleName, roleID := utils.GetRoleName(pod), utils.GetRoleID(pod)
+		if _, ok := templateRoles[roleName]; ok || pod.DeletionTimestamp != nil {
+			continue
+		}
+		if c.store.GetRoleStatus(utils.GetNamespaceName(ms), groupName, roleName, roleID) == datastore.RoleDeleting {
+			continue
+		}
+		key := roleName + "/" + roleID
+		if _, ok := removed[key]; !ok {
+			removed[key] = &outdatedRole{roleName: roleName, roleID: roleID, removed: true}
+		}
+		removed[key].pods = append(removed[key].pods, pod)
+	}
+	keys := make([]string, 0, len(removed))
+	for key := range removed {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		outdated = append(outdated, *removed[key])
+	}
+	return outdated, nil
+}
+
+// getRevisionRoles returns the roles template of a revision from its ControllerRevision.
+// The templates are cached in revisionRoles for the duration of a sync.
+func (c *ModelServingController) getRevisionRoles(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision string, revisionRoles map[string][]workloadv1alpha1.Role) ([]workloadv1alpha1.Role, bool) {
+	if roles, ok := revisionRoles[revision]; ok {
+		return roles, roles != nil
+	}
+	revisionRoles[revision] = nil
+	cr, err := utils.GetControllerRevision(ctx, c.kubeClientSet, ms, revision)
+	if err != nil {
+		klog.Warningf("Failed to get ControllerRevision of revision %s for ModelServing %s/%s: %v", revision, ms.Namespace, ms.Name, err)
+		return nil, false
+	}
+	if cr == nil {
+		return nil, false
+	}
+	roles, err := utils.GetRolesFromControllerRevision(cr)
+	if err != nil {
+		klog.Warningf("Failed to get roles from ControllerRevision %s/%s: %v", cr.Namespace, cr.Name, err)
+		return nil, false
+	}
+	revisionRoles[revision] = roles
+	return roles, true
+}
+
+// getInPlaceRoleTemplates returns the old and new templates of an outdated role if its pods can be updated in place.
+// All the pods of the role must have been created from the same revision, whose template is known.
+func (c *ModelServingController) getInPlaceRoleTemplates(ctx context.Context, ms *workloadv1alpha1.ModelServing, role outdatedRole, revisionRoles map[string][]workloadv1alpha1.Role) (workloadv1alpha1.Role, workloadv1alpha1.Role, bool) {
+	if role.removed || len(role.pods) == 0 {
+		return workloadv1alpha1.Role{}, workloadv1alpha1.Role{}, false
+	}
+	podRevision := utils.ObjectRevision(role.pods[0])
+	for _, pod := range role.pods {
+		if utils.ObjectRevision(pod) != podRevision || pod.DeletionTimestamp != nil {
+			return workloadv1alpha1.Role{}, workloadv1alpha1.Role{}, false
+		}
+	}
+
+	oldRoles, ok := c.getRevisionRoles(ctx, ms, podRevision, revisionRoles)
+	if !ok {
+		return workloadv1alpha1.Role{}, workloadv1alpha1.Role{}, false
+	}
+	oldIndex := slices.IndexFunc(oldRoles, func(r workloadv1alpha1.Role) bool { return r.Name == role.roleName })
+	newIndex := slices.IndexFunc(ms.Spec.Template.Roles, func(r workloadv1alpha1.Role) bool { return r.Name == role.roleName })
+	if oldIndex < 0 || newIndex < 0 {
+		return workloadv1alpha1.Role{}, workloadv1alpha1.Role{}, false
+	}
+	oldRole, newRole := oldRoles[oldIndex], ms.Spec.Template.Roles[newIndex]
+	if !utils.CanUpdateRoleInPlace(oldRole, newRole) {
+		return workloadv1alpha1.Role{}, workloadv1alpha1.Role{}, false
+	}
+	return oldRole, newRole, true
+}
+
+// updateRoleInPlace patches the pods of a role replica to the new role template. Kubelet restarts the containers
+// whose image changed, and the role is Running again once all of them run the new image.
+func (c *ModelServingController) updateRoleInPlace(ctx context.Context, ms *workloadv1alpha1.ModelServing, groupName string, role outdatedRole, oldRole, newRole workloadv1alpha1.Role, revision string) error {
+	roleRevision := utils.RoleRevision(newRole)
+	for _, pod := range role.pods {
+		oldTemplate, newTemplate := oldRole.EntryTemplate, newRole.EntryTemplate
+		if pod.Labels[workloadv1alpha1.EntryLabelKey] != utils.Entry {
+			oldTemplate, newTemplate = *oldRole.WorkerTemplate, *newRole.WorkerTemplate
+		}
+		updated := utils.GenerateInPlaceUpdatedPod(pod, oldTemplate, newTemplate, revision, roleRevision)
+
+		original, err := json.Marshal(pod)
+		if err != nil {
+			return err
+		}
+		modified, err := json.Marshal(updated)
+		if err != nil {
+			return err
+		}
+		patch, err := strategicpatch.CreateTwoWayMergePatch(original, modified, corev1.Pod{})
+		if err != nil {
+			return fmt.Errorf("failed to create in-place update patch of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+		_, err = c.kubeClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update pod %s/%s in place: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	if err := c.store.UpdateRoleStatus(utils.GetNamespaceName(ms), groupName, role.roleName, role.roleID, datastore.RoleCreating); err != nil {
+		return err
+	}
+	klog.V(2).Infof("Role %s/%s in ServingGroup %s is updated in place to revision %s", role.roleName, role.roleID, groupName, revision)
+	message := fmt.Sprintf("Role %s/%s in ServingGroup %s is updated in place", role.roleName, role.roleID, groupName)
+	c.emitRoleStatusEvent(ms, corev1.EventTypeNormal, "RoleInPlaceUpdate", message)
+	return nil
+}
+
+// promoteServingGroup records that the ServingGroup runs the given revision once all its roles are updated,
+// relabeling the pods of the roles whose template did not change.
+func (c *ModelServingController) promoteServingGroup(ctx context.Context, ms *workloadv1alpha1.ModelServing, groupName, revision string) error {
+	pods, err := c.getPodsByIndex(GroupNameKey, fmt.Sprintf("%s/%s", ms.Namespace, groupName))
+	if err != nil {
+		return err
+	}
+	patch := fmt.Sprintf(`{"metadata":{"labels":{%q:%q}}}`, workloadv1alpha1.RevisionLabelKey, revision)
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil || utils.ObjectRevision(pod) == revision {
+			continue
+		}
+		_, err := c.kubeClientSet.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to update revision of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	if err := c.store.UpdateServingGroupRevision(utils.GetNamespaceName(ms), groupName, revision); err != nil {
+		return err
+	}
+	klog.V(2).Infof("ServingGroup %s is updated to revision %s", groupName, revision)
+	return nil
+}
+
+// setServingGroupUpdating marks a running ServingGroup whose roles are being updated as Creating,
+// so that it is counted as unavailable until the updated roles are ready.
+func (c *ModelServingController) setServingGroupUpdating(ms *workloadv1alpha1.ModelServing, groupName string) error {
+	if c.store.GetServingGroupStatus(utils.GetNamespaceName(ms), groupName) != datastore.ServingGroupRunning {
+		return nil
+	}
+	return c.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), groupName, datastore.ServingGroupCreating)
+}
+
+// updateServingGroupRoles updates the outdated roles of a ServingGroup. Roles are updated in place when allowed
+// and possible, and are otherwise deleted and recreated with the current template by manageRole.
+func (c *ModelServingController) updateServingGroupRoles(ctx context.Context, ms *workloadv1alpha1.ModelServing, groupName string, outdated []outdatedRole, revision string, revisionRoles map[string][]workloadv1alpha1.Role) error {
+	if err := c.setServingGroupUpdating(ms, groupName); err != nil {
+		return err
+	}
+	for _, role := range outdated {
+		if isInPlaceUpdateEnabled(ms) {
+			if oldRole, newRole, ok := c.getInPlaceRoleTemplates(ctx, ms, role, revisionRoles); ok {
+				if err := c.updateRoleInPlace(ctx, ms, groupName, role, oldRole, newRole, revision); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		klog.V(2).Infof("Role %s/%s in ServingGroup %s will be recreated for update", role.roleName, role.roleID, groupName)
+		c.DeleteRole(ctx, ms, groupName, role.roleName, role.roleID)
+	}
+	return nil
+}
+
+// manageRoleRollingUpdate performs a rolling update that only replaces the roles whose template changed, keeping
+// the other roles of the ServingGroups running. ServingGroups are updated from the largest ordinal down to the
+// partition, and no more than maxUnavailable ServingGroups are unavailable at a time.
+func (c *ModelServingController) manageRoleRollingUpdate(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision string, maxUnavailable int) error {
+	servingGroupList, err := c.store.GetServingGroupByModelServing(utils.GetNamespaceName(ms))
+	if err != nil {
+		return fmt.Errorf("cannot get ServingGroupList from store, err:%v", err)
+	}
+	partition := c.getPartition(ms)
+	if partition >= len(servingGroupList) {
+		return nil
+	}
+
+	available := 0
+	for _, sg := range servingGroupList {
+		if sg.Status == datastore.ServingGroupRunning {
+			available++
+		}
+	}
+	budget := available - (int(*ms.Spec.Replicas) - maxUnavailable)
+
+	revisionRoles := map[string][]workloadv1alpha1.Role{}
+	revisionRecorded := false
+	updateCount := 0
+	for i := len(servingGroupList) - 1; i >= partition; i-- {
+		sg := servingGroupList[i]
+		if sg.Status == datastore.ServingGroupDeleting {
+			continue
+		}
+		outdated, err := c.getOutdatedRoles(ctx, ms, sg.Name, revisionRoles)
+		if err != nil {
+			return err
+		}
+		if len(outdated) == 0 {
+			if sg.Revision != revision {
+				if err := c.promoteServingGroup(ctx, ms, sg.Name, revision); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if sg.Status == datastore.ServingGroupRunning {
+			if budget <= 0 {
+				continue
+			}
+			budget--
+		}
+		if sg.Status == datastore.ServingGroupFailed {
+			// The roles of a Failed ServingGroup are not recreated, so the ServingGroup is replaced.
+			klog.V(2).Infof("Failed ServingGroup %s will be terminated for update", sg.Name)
+			if err := c.deleteServingGroup(ctx, ms, sg.Name); err != nil {
+				return err
+			}
+			updateCount++
+			continue
+		}
+
+		if !revisionRecorded {
+			// The template of the revision is needed to update the roles in place or to roll back.
+			if _, err := utils.CreateControllerRevision(ctx, c.kubeClientSet, ms, revision, ms.Spec.Template.Roles); err != nil {
+				klog.Warningf("Failed to create ControllerRevision for new revision %s: %v", revision, err)
+			}
+			revisionRecorded = true
+		}
+		if err := c.updateServingGroupRoles(ctx, ms, sg.Name, outdated, revision, revisionRoles); err != nil {
+			return err
+		}
+		updateCount++
+	}
+
+	if updateCount > 0 {
+		klog.V(4).Infof("Updated roles of %d ServingGroups for ModelServing %s (partition=%d)", updateCount, ms.Name, partition)
+	}
+	return nil
+}
+
+// updateServingGroupsInPlace updates up to limit outdated ServingGroups in place, from the largest ordinal down.
+// A ServingGroup is only updated in place if all its outdated roles can be. The ServingGroups that are not updated
+// are returned, keeping their order.
+func (c *ModelServingController) updateServingGroupsInPlace(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision string, limit int, groups []datastore.ServingGroup) ([]datastore.ServingGroup, int, error) {
+	revisionRoles := map[string][]workloadv1alpha1.Role{}
+	updated := map[string]struct{}{}
+	for i := len(groups) - 1; i >= 0 && len(updated) < limit; i-- {
+		sg := groups[i]
+		if sg.Status == datastore.ServingGroupFailed {
+			// A Failed ServingGroup is replaced.
+			continue
+		}
+		outdated, err := c.getOutdatedRoles(ctx, ms, sg.Name, revisionRoles)
+		if err != nil {
+			return groups, len(updated), err
+		}
+		inPlace := true
+		for _, role := range outdated {
+			if _, _, ok := c.getInPlaceRoleTemplates(ctx, ms, role, revisionRoles); !ok {
+				inPlace = false
+				break
+			}
+		}
+		if !inPlace {
+			continue
+		}
+
+		if len(updated) == 0 {
+			if _, err := utils.CreateControllerRevision(ctx, c.kubeClientSet, ms, revision, ms.Spec.Template.Roles); err != nil {
+				klog.Warningf("Failed to create ControllerRevision for new revision %s: %v", revision, err)
+			}
+		}
+		klog.V(2).Infof("ServingGroup %s will be updated in place (status=%s)", sg.Name, sg.Status)
+		if err := c.updateServingGroupRoles(ctx, ms, sg.Name, outdated, revision, revisionRoles); err != nil {
+			return groups, len(updated), err
+		}
+		if err := c.promoteServingGroup(ctx, ms, sg.Name, revision); err != nil {
+			return groups, len(updated), err
+		}
+		updated[sg.Name] = struct{}{}
+	}
+
+	remaining := make([]datastore.ServingGroup, 0, len(groups)-len(updated))
+	for _, sg := range groups {
+		if _, ok := updated[sg.Name]; !ok {
+			remaining = append(remaining, sg)
+		}
+	}
+	return remaining, len(updated), nil
+}
@@ -0,0 +1,170 @@
+/*
+Copyright The Volcano Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+func newRoleRollingUpdateTestRoles(prefillImage, decodeImage string) []workloadv1alpha1.Role {
+	newRole := func(name, image string) workloadv1alpha1.Role {
+		return workloadv1alpha1.Role{
+			Name:     name,
+			Replicas: ptr.To[int32](1),
+			EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: name + "-container", Image: image}},
+				},
+			},
+		}
+	}
+	return []workloadv1alpha1.Role{newRole("prefill", prefillImage), newRole("decode", decodeImage)}
+}
+
+// setupRoleRollingUpdateTest creates two running ServingGroups with the old roles and updates the ModelServing
+// template to the new roles.
+func setupRoleRollingUpdateTest(t *testing.T, strategy workloadv1alpha1.RolloutStrategyType, policy workloadv1alpha1.PodUpdatePolicyType,
+	oldRoles, newRoles []workloadv1alpha1.Role) (*ModelServingController, *kubefake.Clientset, *workloadv1alpha1.ModelServing) {
+	controller, kubeClient := newRestartGraceTestController(t)
+	ms := newRollbackTestModelServing("test-role-update", oldRoles)
+	ms.UID = "test-role-update-uid"
+	ms.Spec.RolloutStrategy = &workloadv1alpha1.RolloutStrategy{Type: strategy, PodUpdatePolicy: policy}
+
+	oldRevision := rolesRevision(oldRoles)
+	_, err := utils.CreateControllerRevision(context.Background(), kubeClient, ms, oldRevision, oldRoles)
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		groupName := utils.GenerateServingGroupName(ms.Name, i)
+		controller.store.AddServingGroup(utils.GetNamespaceName(ms), i, oldRevision)
+		for _, role := range oldRoles {
+			roleID := utils.GenerateRoleID(role.Name, 0)
+			controller.store.AddRole(utils.GetNamespaceName(ms), groupName, role.Name, roleID, oldRevision)
+			assert.NoError(t, controller.store.UpdateRoleStatus(utils.GetNamespaceName(ms), groupName, role.Name, roleID, datastore.RoleRunning))
+
+			pod := utils.GenerateEntryPod(*role.DeepCopy(), ms, groupName, 0, oldRevision)
+			_, err := kubeClient.CoreV1().Pods(pod.Namespace).Create(context.Background(), pod, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			assert.NoError(t, controller.podsInformer.GetIndexer().Add(pod))
+		}
+		assert.NoError(t, controller.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), groupName, datastore.ServingGroupRunning))
+	}
+
+	ms.Spec.Template.Roles = newRoles
+	return controller, kubeClient, ms
+}
+
+func getRoleRollingUpdateTestPod(t *testing.T, kubeClient *kubefake.Clientset, ms *workloadv1alpha1.ModelServing, group int, role string) *corev1.Pod {
+	name := utils.GeneratePodName(utils.GenerateServingGroupName(ms.Name, group), utils.GenerateRoleID(role, 0), 0)
+	pod, err := kubeClient.CoreV1().Pods(ms.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	return pod
+}
+
+func TestManageRoleRollingUpdate(t *testing.T) {
+	oldRoles := newRoleRollingUpdateTestRoles("prefill:v1", "decode:v1")
+	newRoles := newRoleRollingUpdateTestRoles("prefill:v2", "decode:v1")
+	newRevision := rolesRevision(newRoles)
+
+	t.Run("recreate only the changed role", func(t *testing.T) {
+		controller, _, ms := setupRoleRollingUpdateTest(t, workloadv1alpha1.RoleRollingUpdate, workloadv1alpha1.RecreatePodUpdatePolicy, oldRoles, newRoles)
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, newRevision))
+
+		msName := utils.GetNamespaceName(ms)
+		group1 := utils.GenerateServingGroupName(ms.Name, 1)
+		assert.Equal(t, datastore.RoleDeleting, controller.store.GetRoleStatus(msName, group1, "prefill", "prefill-0"))
+		assert.Equal(t, datastore.RoleRunning, controller.store.GetRoleStatus(msName, group1, "decode", "decode-0"))
+		assert.Equal(t, datastore.ServingGroupCreating, controller.store.GetServingGroupStatus(msName, group1))
+
+		// maxUnavailable is 1, so the other ServingGroup is kept.
+		group0 := utils.GenerateServingGroupName(ms.Name, 0)
+		assert.Equal(t, datastore.RoleRunning, controller.store.GetRoleStatus(msName, group0, "prefill", "prefill-0"))
+		assert.Equal(t, datastore.ServingGroupRunning, controller.store.GetServingGroupStatus(msName, group0))
+	})
+
+	t.Run("pods without role revision are created from the ServingGroup revision", func(t *testing.T) {
+		controller, _, ms := setupRoleRollingUpdateTest(t, workloadv1alpha1.RoleRollingUpdate, workloadv1alpha1.RecreatePodUpdatePolicy, oldRoles, newRoles)
+		for _, obj := range controller.podsInformer.GetIndexer().List() {
+			pod := obj.(*corev1.Pod).DeepCopy()
+			delete(pod.Labels, workloadv1alpha1.RoleRevisionLabelKey)
+			assert.NoError(t, controller.podsInformer.GetIndexer().Update(pod))
+		}
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, newRevision))
+
+		msName := utils.GetNamespaceName(ms)
+		group1 := utils.GenerateServingGroupName(ms.Name, 1)
+		assert.Equal(t, datastore.RoleDeleting, controller.store.GetRoleStatus(msName, group1, "prefill", "prefill-0"))
+		assert.Equal(t, datastore.RoleRunning, controller.store.GetRoleStatus(msName, group1, "decode", "decode-0"))
+	})
+
+	t.Run("update the changed role in place", func(t *testing.T) {
+		controller, kubeClient, ms := setupRoleRollingUpdateTest(t, workloadv1alpha1.RoleRollingUpdate, workloadv1alpha1.InPlaceIfPossiblePodUpdatePolicy, oldRoles, newRoles)
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, newRevision))
+
+		msName := utils.GetNamespaceName(ms)
+		group1 := utils.GenerateServingGroupName(ms.Name, 1)
+		prefill := getRoleRollingUpdateTestPod(t, kubeClient, ms, 1, "prefill")
+		assert.Equal(t, "prefill:v2", prefill.Spec.Containers[0].Image)
+		assert.Equal(t, newRevision, utils.ObjectRevision(prefill))
+		assert.Equal(t, utils.RoleRevision(newRoles[0]), prefill.Labels[workloadv1alpha1.RoleRevisionLabelKey])
+		assert.Contains(t, prefill.Annotations, workloadv1alpha1.InPlaceUpdateStateAnnotationKey)
+		assert.Equal(t, datastore.RoleCreating, controller.store.GetRoleStatus(msName, group1, "prefill", "prefill-0"))
+		assert.Equal(t, datastore.RoleRunning, controller.store.GetRoleStatus(msName, group1, "decode", "decode-0"))
+		assert.Equal(t, "prefill:v1", getRoleRollingUpdateTestPod(t, kubeClient, ms, 0, "prefill").Spec.Containers[0].Image)
+
+		// Once the pods are observed updated, the ServingGroup is promoted to the new revision.
+		assert.NoError(t, controller.podsInformer.GetIndexer().Update(prefill))
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, newRevision))
+		revision, _ := controller.store.GetServingGroupRevision(msName, group1)
+		assert.Equal(t, newRevision, revision)
+		assert.Equal(t, newRevision, utils.ObjectRevision(getRoleRollingUpdateTestPod(t, kubeClient, ms, 1, "decode")))
+		// The updated ServingGroup is not ready yet, so the other one is kept.
+		assert.Equal(t, "prefill:v1", getRoleRollingUpdateTestPod(t, kubeClient, ms, 0, "prefill").Spec.Containers[0].Image)
+	})
+
+	t.Run("recreate the role when in-place update is not possible", func(t *testing.T) {
+		resized := newRoleRollingUpdateTestRoles("prefill:v1", "decode:v1")
+		resized[0].WorkerReplicas = 1
+		resized[0].WorkerTemplate = resized[0].EntryTemplate.DeepCopy()
+		controller, _, ms := setupRoleRollingUpdateTest(t, workloadv1alpha1.RoleRollingUpdate, workloadv1alpha1.InPlaceIfPossiblePodUpdatePolicy, oldRoles, resized)
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, rolesRevision(resized)))
+
+		group1 := utils.GenerateServingGroupName(ms.Name, 1)
+		assert.Equal(t, datastore.RoleDeleting, controller.store.GetRoleStatus(utils.GetNamespaceName(ms), group1, "prefill", "prefill-0"))
+	})
+
+	t.Run("update the ServingGroup in place", func(t *testing.T) {
+		controller, kubeClient, ms := setupRoleRollingUpdateTest(t, workloadv1alpha1.ServingGroupRollingUpdate, workloadv1alpha1.InPlaceIfPossiblePodUpdatePolicy, oldRoles, newRoles)
+		assert.NoError(t, controller.manageServingGroupRollingUpdate(context.Background(), ms, newRevision))
+
+		msName := utils.GetNamespaceName(ms)
+		group1 := utils.GenerateServingGroupName(ms.Name, 1)
+		assert.Equal(t, datastore.ServingGroupCreating, controller.store.GetServingGroupStatus(msName, group1))
+		revision, _ := controller.store.GetServingGroupRevision(msName, group1)
+		assert.Equal(t, newRevision, revision)
+		assert.Equal(t, "prefill:v2", getRoleRollingUpdateTestPod(t, kubeClient, ms, 1, "prefill").Spec.Containers[0].Image)
+		assert.Equal(t, newRevision, utils.ObjectRevision(getRoleRollingUpdateTestPod(t, kubeClient, ms, 1, "decode")))
+		assert.Equal(t, "prefill:v1", getRoleRollingUpdateTestPod(t, kubeClient, ms, 0, "prefill").Spec.Containers[0].Image)
+	})
+}
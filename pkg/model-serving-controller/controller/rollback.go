@@ -0,0 +1,307 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+// rolloutProgress summarizes the ServingGroups of a ModelServing for rolling update progress tracking.
+type rolloutProgress struct {
+	// current is the number of ServingGroups not at the update revision.
+	current int
+	// updated is the number of ServingGroups at the update revision.
+	updated int
+	// updatedAvailable is the number of running ServingGroups at the update revision.
+	updatedAvailable int
+}
+
+// updateFailuresKey returns the key of the pending pod failure counter of a ModelServing revision.
+func updateFailuresKey(ms *workloadv1alpha1.ModelServing, revision string) string {
+	return fmt.Sprintf("%s/%s/%s", ms.Namespace, ms.Name, revision)
+}
+
+// recordUpdateFailure counts a pod failure against the rolling update when the pod belongs to the update revision.
+// The pending count is persisted into ModelServing status by UpdateModelServingStatus.
+func (c *ModelServingController) recordUpdateFailure(ms *workloadv1alpha1.ModelServing, pod *corev1.Pod) {
+	revision := utils.ObjectRevision(pod)
+	if revision == "" || revision != ms.Status.UpdateRevision || ms.Status.CurrentRevision == ms.Status.UpdateRevision {
+		return
+	}
+	counter, _ := c.updateFailures.LoadOrStore(updateFailuresKey(ms, revision), &atomic.Int32{})
+	counter.(*atomic.Int32).Add(1)
+	klog.V(4).Infof("Recorded failure of pod %s/%s for update revision %s", pod.Namespace, pod.Name, revision)
+}
+
+// pendingUpdateFailures returns the pod failures of the revision that are not persisted in status yet.
+func (c *ModelServingController) pendingUpdateFailures(ms *workloadv1alpha1.ModelServing, revision string) int32 {
+	counter, ok := c.updateFailures.Load(updateFailuresKey(ms, revision))
+	if !ok {
+		return 0
+	}
+	return counter.(*atomic.Int32).Load()
+}
+
+// commitUpdateFailures drops the pod failures that have been persisted in status.
+func (c *ModelServingController) commitUpdateFailures(ms *workloadv1alpha1.ModelServing, revision string, persisted int32) {
+	if counter, ok := c.updateFailures.Load(updateFailuresKey(ms, revision)); ok {
+		counter.(*atomic.Int32).Add(-persisted)
+	}
+}
+
+// updateRolloutProgress records the progress of the rolling update in the status of newMS and sets the Progressing
+// condition to False when the rolling update failed, either because ProgressDeadlineSeconds elapsed without progress
+// or because the pods of the update revision failed more often than RollbackPolicy.FailureThreshold.
+// It returns the number of pending pod failures that have been added to the status.
+func (c *ModelServingController) updateRolloutProgress(oldMS, newMS *workloadv1alpha1.ModelServing, progress rolloutProgress) int32 {
+	status := &newMS.Status
+	removeStaleRollbackFailedCondition(newMS)
+	if oldMS.Status.UpdateRevision != status.UpdateRevision {
+		// A new rolling update starts, the previous one is not tracked anymore.
+		c.updateFailures.Delete(updateFailuresKey(oldMS, oldMS.Status.UpdateRevision))
+		status.UpdateFailures = 0
+		status.LastProgressTime = nil
+	}
+
+	inProgress := status.CurrentRevision != status.UpdateRevision &&
+		(progress.current > c.getPartition(newMS) || progress.updatedAvailable < progress.updated)
+	if !inProgress {
+		c.updateFailures.Delete(updateFailuresKey(newMS, status.UpdateRevision))
+		status.UpdateFailures = 0
+		status.LastProgressTime = nil
+		removeRolloutFailedCondition(newMS)
+		return 0
+	}
+
+	pending := c.pendingUpdateFailures(newMS, status.UpdateRevision)
+	status.UpdateFailures += pending
+
+	now := metav1.Now()
+	if status.LastProgressTime == nil ||
+		status.UpdatedReplicas > oldMS.Status.UpdatedReplicas ||
+		status.AvailableReplicas > oldMS.Status.AvailableReplicas {
+		status.LastProgressTime = &now
+	}
+
+	reason, message := rolloutFailure(newMS, now.Time)
+	if reason == "" {
+		removeRolloutFailedCondition(newMS)
+		if newMS.Spec.ProgressDeadlineSeconds != nil {
+			// Check the deadline again once it elapses.
+			deadline := status.LastProgressTime.Add(time.Duration(*newMS.Spec.ProgressDeadlineSeconds) * time.Second)
+			c.enqueueModelServingAfter(newMS, time.Until(deadline)+enqueueAfter)
+		}
+		return pending
+	}
+
+	cond := metav1.Condition{
+		Type:               string(workloadv1alpha1.ModelServingProgressing),
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: newMS.Generation,
+		LastTransitionTime: now,
+		Reason:             reason,
+		Message:            message,
+	}
+	if prev := meta.FindStatusCondition(oldMS.Status.Conditions, cond.Type); prev != nil &&
+		prev.Status == cond.Status && prev.Reason == cond.Reason && prev.ObservedGeneration == cond.ObservedGeneration {
+		// Keep the condition stable while the failure persists.
+		cond = *prev
+	} else {
+		klog.Warningf("Rolling update of ModelServing %s/%s to revision %s failed: %s", newMS.Namespace, newMS.Name, status.UpdateRevision, message)
+		c.emitEvent(oldMS, corev1.EventTypeWarning, reason, message)
+	}
+	meta.SetStatusCondition(&status.Conditions, cond)
+	return pending
+}
+
+// rolloutFailure returns the reason and message if the rolling update of ms has failed at the given time.
+func rolloutFailure(ms *workloadv1alpha1.ModelServing, now time.Time) (string, string) {
+	status := ms.Status
+	if policy := ms.Spec.RolloutStrategy; policy != nil && policy.RollbackPolicy != nil && policy.RollbackPolicy.FailureThreshold != nil {
+		if threshold := *policy.RollbackPolicy.FailureThreshold; status.UpdateFailures >= threshold {
+			return workloadv1alpha1.FailureThresholdExceededReason,
+				fmt.Sprintf("%d pod failures observed for revision %s, exceeding the failure threshold %d", status.UpdateFailures, status.UpdateRevision, threshold)
+		}
+	}
+	if ms.Spec.ProgressDeadlineSeconds != nil && status.LastProgressTime != nil {
+		deadline := time.Duration(*ms.Spec.ProgressDeadlineSeconds) * time.Second
+		if now.Sub(status.LastProgressTime.Time) >= deadline {
+			return workloadv1alpha1.ProgressDeadlineExceededReason,
+				fmt.Sprintf("rolling update to revision %s has not made progress for %v", status.UpdateRevision, deadline)
+		}
+	}
+	return "", ""
+}
+
+// isRolloutFailedCondition reports whether the condition marks a failed rolling update.
+func isRolloutFailedCondition(cond *metav1.Condition) bool {
+	return cond != nil && cond.Type == string(workloadv1alpha1.ModelServingProgressing) && cond.Status == metav1.ConditionFalse &&
+		(cond.Reason == workloadv1alpha1.ProgressDeadlineExceededReason || cond.Reason == workloadv1alpha1.FailureThresholdExceededReason)
+}
+
+// removeRolloutFailedCondition removes the Progressing condition left by a failed rolling update.
+func removeRolloutFailedCondition(ms *workloadv1alpha1.ModelServing) {
+	if isRolloutFailedCondition(meta.FindStatusCondition(ms.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))) {
+		meta.RemoveStatusCondition(&ms.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))
+	}
+}
+
+// manageRollback rolls the roles template back to a previous revision. The rollback is either explicitly requested
+// through the RollbackToAnnotationKey annotation, or performed automatically when the rolling update failed and
+// RollbackPolicy.AutoRollback is enabled. It returns true if the roles template has been rolled back, in which case
+// the update event triggers the next sync.
+func (c *ModelServingController) manageRollback(ctx context.Context, ms *workloadv1alpha1.ModelServing) (bool, error) {
+	if target, ok := ms.Annotations[workloadv1alpha1.RollbackToAnnotationKey]; ok {
+		return c.rollbackModelServing(ctx, ms, target, "RollbackRequested")
+	}
+
+	if ms.Spec.RolloutStrategy == nil || ms.Spec.RolloutStrategy.RollbackPolicy == nil || !ms.Spec.RolloutStrategy.RollbackPolicy.AutoRollback {
+		return false, nil
+	}
+	cond := meta.FindStatusCondition(ms.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))
+	// The condition must be observed for the current spec, otherwise it belongs to a previous rolling update.
+	if !isRolloutFailedCondition(cond) || cond.ObservedGeneration != ms.Generation {
+		return false, nil
+	}
+	if ms.Status.CurrentRevision == "" || ms.Status.CurrentRevision == ms.Status.UpdateRevision {
+		return false, nil
+	}
+	if rolesRevision(ms.Spec.Template.Roles) == ms.Status.CurrentRevision {
+		return false, nil
+	}
+	if rollbackFailed(ms, ms.Status.CurrentRevision) {
+		// The failure has already been recorded for the current spec.
+		return false, nil
+	}
+	return c.rollbackModelServing(ctx, ms, ms.Status.CurrentRevision, cond.Reason)
+}
+
+// rollbackModelServing replaces the roles template of the ModelServing with the one recorded in the ControllerRevision
+// of the target revision. The replicas of the current roles are kept, since they may be managed by an autoscaler.
+// It returns true if the roles template has changed.
+func (c *ModelServingController) rollbackModelServing(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision, reason string) (bool, error) {
+	cr, err := utils.GetControllerRevision(ctx, c.kubeClientSet, ms, revision)
+	if err != nil {
+		return false, fmt.Errorf("failed to get ControllerRevision of revision %s: %v", revision, err)
+	}
+
+	msCopy := ms.DeepCopy()
+	_, requested := msCopy.Annotations[workloadv1alpha1.RollbackToAnnotationKey]
+	delete(msCopy.Annotations, workloadv1alpha1.RollbackToAnnotationKey)
+	changed := false
+	if cr != nil {
+		roles, err := utils.GetRolesFromControllerRevision(cr)
+		if err != nil {
+			return false, fmt.Errorf("failed to get roles of revision %s: %v", revision, err)
+		}
+		for i := range roles {
+			for _, role := range ms.Spec.Template.Roles {
+				if role.Name == roles[i].Name && role.Replicas != nil {
+					roles[i].Replicas = role.Replicas
+				}
+			}
+		}
+		changed = !apiequality.Semantic.DeepEqual(roles, ms.Spec.Template.Roles)
+		msCopy.Spec.Template.Roles = roles
+	}
+
+	if changed || requested {
+		updated, err := c.modelServingClient.WorkloadV1alpha1().ModelServings(ms.Namespace).Update(ctx, msCopy, metav1.UpdateOptions{})
+		if err != nil {
+			return false, fmt.Errorf("failed to roll back ModelServing %s/%s to revision %s: %v", ms.Namespace, ms.Name, revision, err)
+		}
+		ms = updated
+	}
+	if cr == nil {
+		return false, c.recordRollbackFailure(ctx, ms, revision)
+	}
+	if changed {
+		klog.V(2).Infof("Rolled back ModelServing %s/%s to revision %s (%s)", ms.Namespace, ms.Name, revision, reason)
+		c.emitEvent(ms, corev1.EventTypeNormal, "RolledBack", fmt.Sprintf("Rolled back roles template to revision %s: %s", revision, reason))
+	}
+	return changed, nil
+}
+
+// rollbackFailureMessage returns the message of the RollbackFailed condition for the revision.
+func rollbackFailureMessage(revision string) string {
+	return fmt.Sprintf("Revision %s to roll back to is not found", revision)
+}
+
+// rollbackFailed reports whether the rollback to the revision has already failed for the current spec of ms.
+func rollbackFailed(ms *workloadv1alpha1.ModelServing, revision string) bool {
+	cond := meta.FindStatusCondition(ms.Status.Conditions, string(workloadv1alpha1.ModelServingRollbackFailed))
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration == ms.Generation &&
+		cond.Message == rollbackFailureMessage(revision)
+}
+
+// recordRollbackFailure sets the RollbackFailed condition of the ModelServing and emits a warning event,
+// unless the failure has already been recorded for the current spec.
+func (c *ModelServingController) recordRollbackFailure(ctx context.Context, ms *workloadv1alpha1.ModelServing, revision string) error {
+	if rollbackFailed(ms, revision) {
+		return nil
+	}
+	message := rollbackFailureMessage(revision)
+	msCopy := ms.DeepCopy()
+	meta.SetStatusCondition(&msCopy.Status.Conditions, metav1.Condition{
+		Type:               string(workloadv1alpha1.ModelServingRollbackFailed),
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: ms.Generation,
+		LastTransitionTime: metav1.Now(),
+		Reason:             workloadv1alpha1.RollbackRevisionNotFoundReason,
+		Message:            message,
+	})
+	if _, err := c.modelServingClient.WorkloadV1alpha1().ModelServings(ms.Namespace).UpdateStatus(ctx, msCopy, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to record rollback failure of ModelServing %s/%s: %v", ms.Namespace, ms.Name, err)
+	}
+	klog.Warningf("Failed to roll back ModelServing %s/%s: %s", ms.Namespace, ms.Name, message)
+	c.emitEvent(ms, corev1.EventTypeWarning, workloadv1alpha1.RollbackRevisionNotFoundReason, message)
+	return nil
+}
+
+// removeStaleRollbackFailedCondition removes the RollbackFailed condition recorded for a previous spec.
+func removeStaleRollbackFailedCondition(ms *workloadv1alpha1.ModelServing) {
+	if cond := meta.FindStatusCondition(ms.Status.Conditions, string(workloadv1alpha1.ModelServingRollbackFailed)); cond != nil && cond.ObservedGeneration != ms.Generation {
+		meta.RemoveStatusCondition(&ms.Status.Conditions, string(workloadv1alpha1.ModelServingRollbackFailed))
+	}
+}
+
+// rolesRevision returns the revision of the roles template, ignoring the role replicas.
+func rolesRevision(roles []workloadv1alpha1.Role) string {
+	ms := &workloadv1alpha1.ModelServing{}
+	ms.Spec.Template.Roles = roles
+	return utils.Revision(utils.RemoveRoleReplicasForRevision(ms).Spec.Template.Roles)
+}
+
+// emitEvent emits a Kubernetes Event for the ModelServing, it is no-op when recorder is not initialized.
+func (c *ModelServingController) emitEvent(ms *workloadv1alpha1.ModelServing, eventType, reason, message string) {
+	if c == nil || c.recorder == nil || ms == nil {
+		return
+	}
+	c.recorder.Event(ms, eventType, reason, message)
+}
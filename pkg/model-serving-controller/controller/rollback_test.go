@@ -0,0 +1,324 @@
+/*
+Copyright The Volcano Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apiextfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+	volcanofake "volcano.sh/apis/pkg/client/clientset/versioned/fake"
+
+	kthenafake "github.com/volcano-sh/kthena/client-go/clientset/versioned/fake"
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/datastore"
+	"github.com/volcano-sh/kthena/pkg/model-serving-controller/utils"
+)
+
+func newRollbackTestRoles(image string, replicas int32) []workloadv1alpha1.Role {
+	return []workloadv1alpha1.Role{
+		{
+			Name:     "prefill",
+			Replicas: ptr.To(replicas),
+			EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "prefill-container",
+							Image: image,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newRollbackTestModelServing(name string, roles []workloadv1alpha1.Role) *workloadv1alpha1.ModelServing {
+	return &workloadv1alpha1.ModelServing{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       name,
+			Generation: 2,
+		},
+		Spec: workloadv1alpha1.ModelServingSpec{
+			Replicas:      ptr.To[int32](2),
+			SchedulerName: "volcano",
+			Template: workloadv1alpha1.ServingGroup{
+				Roles: roles,
+			},
+			RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+				Type: workloadv1alpha1.ServingGroupRollingUpdate,
+			},
+		},
+	}
+}
+
+func TestRolloutFailure(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name             string
+		deadline         *int32
+		threshold        *int32
+		failures         int32
+		lastProgressTime time.Time
+		expectedReason   string
+	}{
+		{
+			name:             "no deadline and no threshold",
+			failures:         10,
+			lastProgressTime: now.Add(-time.Hour),
+		},
+		{
+			name:             "deadline not exceeded",
+			deadline:         ptr.To[int32](600),
+			lastProgressTime: now.Add(-time.Minute),
+		},
+		{
+			name:             "deadline exceeded",
+			deadline:         ptr.To[int32](60),
+			lastProgressTime: now.Add(-2 * time.Minute),
+			expectedReason:   workloadv1alpha1.ProgressDeadlineExceededReason,
+		},
+		{
+			name:             "failure threshold not reached",
+			threshold:        ptr.To[int32](3),
+			failures:         2,
+			lastProgressTime: now,
+		},
+		{
+			name:             "failure threshold reached",
+			threshold:        ptr.To[int32](3),
+			failures:         3,
+			lastProgressTime: now,
+			expectedReason:   workloadv1alpha1.FailureThresholdExceededReason,
+		},
+		{
+			name:             "failure threshold takes precedence over deadline",
+			deadline:         ptr.To[int32](60),
+			threshold:        ptr.To[int32](1),
+			failures:         1,
+			lastProgressTime: now.Add(-2 * time.Minute),
+			expectedReason:   workloadv1alpha1.FailureThresholdExceededReason,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ms := newRollbackTestModelServing("test", newRollbackTestRoles("image:v1", 1))
+			ms.Spec.ProgressDeadlineSeconds = tt.deadline
+			ms.Spec.RolloutStrategy.RollbackPolicy = &workloadv1alpha1.RollbackPolicy{FailureThreshold: tt.threshold}
+			ms.Status.UpdateFailures = tt.failures
+			ms.Status.LastProgressTime = &metav1.Time{Time: tt.lastProgressTime}
+
+			reason, _ := rolloutFailure(ms, now)
+			assert.Equal(t, tt.expectedReason, reason)
+		})
+	}
+}
+
+func TestUpdateModelServingStatusRolloutProgress(t *testing.T) {
+	kubeClient := kubefake.NewSimpleClientset()
+	kthenaClient := kthenafake.NewSimpleClientset()
+	controller, err := NewModelServingController(kubeClient, kthenaClient, volcanofake.NewSimpleClientset(), apiextfake.NewSimpleClientset())
+	assert.NoError(t, err)
+
+	ms := newRollbackTestModelServing("test-rollout-progress", newRollbackTestRoles("image:v2", 1))
+	ms.Spec.RolloutStrategy.RollbackPolicy = &workloadv1alpha1.RollbackPolicy{FailureThreshold: ptr.To[int32](2)}
+	ms.Status.CurrentRevision = "v1"
+	ms.Status.UpdateRevision = "v2"
+	_, err = kthenaClient.WorkloadV1alpha1().ModelServings("default").Create(context.Background(), ms, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	assert.NoError(t, controller.modelServingsInformer.GetIndexer().Add(ms))
+
+	controller.store.AddServingGroup(utils.GetNamespaceName(ms), 0, "v1")
+	controller.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), utils.GenerateServingGroupName(ms.Name, 0), datastore.ServingGroupRunning)
+	controller.store.AddServingGroup(utils.GetNamespaceName(ms), 1, "v2")
+
+	// Failures of pods of other revisions are not counted.
+	oldPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "old", Labels: map[string]string{workloadv1alpha1.RevisionLabelKey: "v1"}}}
+	newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "new", Labels: map[string]string{workloadv1alpha1.RevisionLabelKey: "v2"}}}
+	controller.recordUpdateFailure(ms, oldPod)
+	controller.recordUpdateFailure(ms, newPod)
+
+	assert.NoError(t, controller.UpdateModelServingStatus(ms, "v2"))
+	updated, err := kthenaClient.WorkloadV1alpha1().ModelServings("default").Get(context.Background(), ms.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), updated.Status.UpdateFailures)
+	assert.NotNil(t, updated.Status.LastProgressTime)
+	assert.False(t, isRolloutFailedCondition(meta.FindStatusCondition(updated.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))))
+	assert.Equal(t, int32(0), controller.pendingUpdateFailures(ms, "v2"))
+
+	// The second failure reaches the threshold.
+	assert.NoError(t, controller.modelServingsInformer.GetIndexer().Update(updated))
+	controller.recordUpdateFailure(updated, newPod)
+	assert.NoError(t, controller.UpdateModelServingStatus(updated, "v2"))
+	updated, err = kthenaClient.WorkloadV1alpha1().ModelServings("default").Get(context.Background(), ms.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), updated.Status.UpdateFailures)
+	cond := meta.FindStatusCondition(updated.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))
+	assert.True(t, isRolloutFailedCondition(cond))
+	assert.Equal(t, workloadv1alpha1.FailureThresholdExceededReason, cond.Reason)
+	assert.Equal(t, ms.Generation, cond.ObservedGeneration)
+
+	// Once all ServingGroups are updated and available, the rollout is not tracked anymore.
+	assert.NoError(t, controller.modelServingsInformer.GetIndexer().Update(updated))
+	controller.store.DeleteServingGroup(utils.GetNamespaceName(ms), utils.GenerateServingGroupName(ms.Name, 0))
+	controller.store.UpdateServingGroupStatus(utils.GetNamespaceName(ms), utils.GenerateServingGroupName(ms.Name, 1), datastore.ServingGroupRunning)
+	assert.NoError(t, controller.UpdateModelServingStatus(updated, "v2"))
+	updated, err = kthenaClient.WorkloadV1alpha1().ModelServings("default").Get(context.Background(), ms.Name, metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), updated.Status.UpdateFailures)
+	assert.Nil(t, updated.Status.LastProgressTime)
+	assert.False(t, isRolloutFailedCondition(meta.FindStatusCondition(updated.Status.Conditions, string(workloadv1alpha1.ModelServingProgressing))))
+}
+
+func TestManageRollback(t *testing.T) {
+	oldRoles := newRollbackTestRoles("image:v1", 1)
+	newRoles := newRollbackTestRoles("image:v2", 3)
+	oldRevision := rolesRevision(oldRoles)
+	newRevision := rolesRevision(newRoles)
+
+	failedCondition := metav1.Condition{
+		Type:               string(workloadv1alpha1.ModelServingProgressing),
+		Status:             metav1.ConditionFalse,
+		Reason:             workloadv1alpha1.ProgressDeadlineExceededReason,
+		ObservedGeneration: 2,
+		LastTransitionTime: metav1.Now(),
+	}
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		autoRollback    bool
+		conditions      []metav1.Condition
+		storeRevision   bool
+		expectedUpdated bool
+		expectedImage   string
+		// expectedRollbackFailed is whether the RollbackFailed condition is recorded.
+		expectedRollbackFailed bool
+	}{
+		{
+			name:            "explicit rollback to a named revision",
+			annotations:     map[string]string{workloadv1alpha1.RollbackToAnnotationKey: oldRevision},
+			storeRevision:   true,
+			expectedUpdated: true,
+			expectedImage:   "image:v1",
+		},
+		{
+			name:                   "explicit rollback to an unknown revision drops the annotation",
+			annotations:            map[string]string{workloadv1alpha1.RollbackToAnnotationKey: "unknown"},
+			storeRevision:          true,
+			expectedImage:          "image:v2",
+			expectedRollbackFailed: true,
+		},
+		{
+			name:            "automatic rollback of a failed rollout",
+			autoRollback:    true,
+			conditions:      []metav1.Condition{failedCondition},
+			storeRevision:   true,
+			expectedUpdated: true,
+			expectedImage:   "image:v1",
+		},
+		{
+			name:                   "automatic rollback to a revision without ControllerRevision",
+			autoRollback:           true,
+			conditions:             []metav1.Condition{failedCondition},
+			expectedImage:          "image:v2",
+			expectedRollbackFailed: true,
+		},
+		{
+			name:          "failed rollout without automatic rollback",
+			conditions:    []metav1.Condition{failedCondition},
+			storeRevision: true,
+			expectedImage: "image:v2",
+		},
+		{
+			name:         "failure observed for a previous generation",
+			autoRollback: true,
+			conditions: []metav1.Condition{func() metav1.Condition {
+				cond := failedCondition
+				cond.ObservedGeneration = 1
+				return cond
+			}()},
+			storeRevision: true,
+			expectedImage: "image:v2",
+		},
+		{
+			name:          "rollout in progress",
+			autoRollback:  true,
+			storeRevision: true,
+			expectedImage: "image:v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kubeClient := kubefake.NewSimpleClientset()
+			kthenaClient := kthenafake.NewSimpleClientset()
+			controller, err := NewModelServingController(kubeClient, kthenaClient, volcanofake.NewSimpleClientset(), apiextfake.NewSimpleClientset())
+			assert.NoError(t, err)
+
+			ms := newRollbackTestModelServing("test-rollback", newRoles)
+			ms.Annotations = tt.annotations
+			ms.Spec.RolloutStrategy.RollbackPolicy = &workloadv1alpha1.RollbackPolicy{AutoRollback: tt.autoRollback}
+			ms.Status.CurrentRevision = oldRevision
+			ms.Status.UpdateRevision = newRevision
+			ms.Status.Conditions = tt.conditions
+			_, err = kthenaClient.WorkloadV1alpha1().ModelServings("default").Create(context.Background(), ms, metav1.CreateOptions{})
+			assert.NoError(t, err)
+			if tt.storeRevision {
+				_, err = utils.CreateControllerRevision(context.Background(), kubeClient, ms, oldRevision, oldRoles)
+				assert.NoError(t, err)
+			}
+
+			rolledBack, err := controller.manageRollback(context.Background(), ms)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedUpdated, rolledBack)
+
+			got, err := kthenaClient.WorkloadV1alpha1().ModelServings("default").Get(context.Background(), ms.Name, metav1.GetOptions{})
+			assert.NoError(t, err)
+			assert.NotContains(t, got.Annotations, workloadv1alpha1.RollbackToAnnotationKey)
+			assert.Equal(t, tt.expectedImage, got.Spec.Template.Roles[0].EntryTemplate.Spec.Containers[0].Image)
+			// The role replicas are not rolled back.
+			assert.Equal(t, int32(3), *got.Spec.Template.Roles[0].Replicas)
+
+			cond := meta.FindStatusCondition(got.Status.Conditions, string(workloadv1alpha1.ModelServingRollbackFailed))
+			if !tt.expectedRollbackFailed {
+				assert.Nil(t, cond)
+				return
+			}
+			if assert.NotNil(t, cond) {
+				assert.Equal(t, metav1.ConditionTrue, cond.Status)
+				assert.Equal(t, workloadv1alpha1.RollbackRevisionNotFoundReason, cond.Reason)
+			}
+
+			// The failure is recorded once, the next syncs go on without updating the ModelServing again.
+			kthenaClient.ClearActions()
+			rolledBack, err = controller.manageRollback(context.Background(), got)
+			assert.NoError(t, err)
+			assert.False(t, rolledBack)
+			for _, action := range kthenaClient.Actions() {
+				assert.NotEqual(t, "update", action.GetVerb())
+			}
+		})
+	}
+}
@@ -46,6 +46,8 @@ type Store interface {
 	AddServingGroupAndRole(modelServingName types.NamespacedName, servingGroupName, revision, roleName, roleID string)
 	DeleteRunningPodFromServingGroup(modelServingName types.NamespacedName, groupName string, pod string)
 	UpdateServingGroupStatus(modelServingName types.NamespacedName, groupName string, Status ServingGroupStatus) error
+	// UpdateServingGroupRevision updates the revision of the ServingGroup and of all its roles
+	UpdateServingGroupRevision(modelServingName types.NamespacedName, groupName, revision string) error
 }
 
 type store struct {
@@ -78,6 +80,9 @@ const (
 	ServingGroupDeleting ServingGroupStatus = "Deleting"
 	ServingGroupScaling  ServingGroupStatus = "Scaling"
 	ServingGroupNotFound ServingGroupStatus = "NotFound"
+	// ServingGroupFailed is the status of a ServingGroup that exhausted its restart budget.
+	// It is no longer recovered, so that it can be inspected.
+	ServingGroupFailed ServingGroupStatus = "Failed"
 )
 
 type RoleStatus string
@@ -442,3 +447,26 @@ func (s *store) UpdateServingGroupStatus(modelServingName types.NamespacedName,
 	}
 	return nil
 }
+
+// UpdateServingGroupRevision updates the revision of the ServingGroup and of all its roles,
+// which is used when all the roles of the ServingGroup have been updated to the revision.
+func (s *store) UpdateServingGroupRevision(modelServingName types.NamespacedName, groupName, revision string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	groups, ok := s.servingGroup[modelServingName]
+	if !ok {
+		return fmt.Errorf("failed to find modelServing %s", modelServingName.Namespace+"/"+modelServingName.Name)
+	}
+	group, ok := groups[groupName]
+	if !ok {
+		return fmt.Errorf("failed to find ServingGroup %s in modelServing %s", groupName, modelServingName.Namespace+"/"+modelServingName.Name)
+	}
+	group.Revision = revision
+	for _, roles := range group.roles {
+		for _, role := range roles {
+			role.Revision = revision
+		}
+	}
+	return nil
+}
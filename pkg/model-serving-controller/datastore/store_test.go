@@ -376,3 +376,40 @@ func TestGetRoleListSortingByIndex(t *testing.T) {
 		assert.Equal(t, expectedNames[i], role.Name, "Roles should be sorted by index, not by name")
 	}
 }
+
+func TestUpdateServingGroupRevision(t *testing.T) {
+	key := types.NamespacedName{Namespace: "ns1", Name: "model1"}
+
+	s := &store{
+		mutex: sync.RWMutex{},
+		servingGroup: map[types.NamespacedName]map[string]*ServingGroup{
+			key: {
+				"group0": &ServingGroup{
+					Name:     "group0",
+					Revision: "v1",
+					roles: map[string]map[string]*Role{
+						"prefill": {
+							"prefill-0": &Role{Name: "prefill-0", Revision: "v1"},
+						},
+						"decode": {
+							"decode-0": &Role{Name: "decode-0", Revision: "v2"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	err := s.UpdateServingGroupRevision(key, "group0", "v2")
+	assert.NoError(t, err)
+	group := s.servingGroup[key]["group0"]
+	assert.Equal(t, "v2", group.Revision)
+	assert.Equal(t, "v2", group.roles["prefill"]["prefill-0"].Revision)
+	assert.Equal(t, "v2", group.roles["decode"]["decode-0"].Revision)
+
+	err = s.UpdateServingGroupRevision(types.NamespacedName{Namespace: "ns2", Name: "model2"}, "group0", "v2")
+	assert.Error(t, err)
+
+	err = s.UpdateServingGroupRevision(key, "nonexistgroup", "v2")
+	assert.Error(t, err)
+}
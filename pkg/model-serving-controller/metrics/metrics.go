@@ -0,0 +1,62 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	// Label names
+	LabelNamespace    = "namespace"
+	LabelModelServing = "model_serving"
+	LabelResult       = "result"
+
+	// Restart grace period result values
+	ResultRecovered = "recovered"
+	ResultRebuilt   = "rebuilt"
+)
+
+var (
+	// PodsInRestartGracePeriod is the number of failed pods of a ModelServing waiting for the end of
+	// their restart grace period.
+	PodsInRestartGracePeriod = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "kthena_model_serving_pods_in_restart_grace_period",
+			Help: "Number of failed pods waiting for the end of their restart grace period",
+		},
+		[]string{LabelNamespace, LabelModelServing},
+	)
+
+	// RestartGracePeriodsExpired counts the restart grace periods that expired, by whether the pod
+	// recovered or was rebuilt.
+	RestartGracePeriodsExpired = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kthena_model_serving_restart_grace_periods_expired_total",
+			Help: "Total number of expired restart grace periods of failed pods",
+		},
+		[]string{LabelNamespace, LabelModelServing, LabelResult},
+	)
+)
+
+// DeleteModelServing removes the metrics of a deleted ModelServing.
+func DeleteModelServing(namespace, name string) {
+	labels := prometheus.Labels{LabelNamespace: namespace, LabelModelServing: name}
+	PodsInRestartGracePeriod.DeletePartialMatch(labels)
+	RestartGracePeriodsExpired.DeletePartialMatch(labels)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+const (
+	DeviceTopologyEnvPluginName = "device-topology-env"
+
+	// DevicesPerPodEnv is the number of accelerator devices allocated to the pod.
+	DevicesPerPodEnv = "KTHENA_DEVICES_PER_POD"
+	// WorldSizeEnv is the number of accelerator devices of the whole group.
+	WorldSizeEnv = "KTHENA_WORLD_SIZE"
+	// RankOffsetEnv is the global rank of the first device of the pod within the group.
+	RankOffsetEnv = "KTHENA_RANK_OFFSET"
+)
+
+// deviceVendor describes the resources and communication library env of an accelerator vendor.
+type deviceVendor struct {
+	resources []corev1.ResourceName
+	// socketEnvs are set to the network interface used by the collective communication library.
+	socketEnvs []string
+}
+
+var deviceVendors = []deviceVendor{
+	{
+		resources:  []corev1.ResourceName{"nvidia.com/gpu"},
+		socketEnvs: []string{"NCCL_SOCKET_IFNAME", "GLOO_SOCKET_IFNAME"},
+	},
+	{
+		resources:  []corev1.ResourceName{"huawei.com/ascend-1980", "huawei.com/Ascend910", "huawei.com/Ascend310P"},
+		socketEnvs: []string{"HCCL_SOCKET_IFNAME", "GLOO_SOCKET_IFNAME"},
+	},
+}
+
+// DeviceTopologyEnvConfig is the config of the device topology env plugin.
+type DeviceTopologyEnvConfig struct {
+	// SocketInterface is the network interface used by NCCL/HCCL, e.g. eth0. Not set if empty.
+	SocketInterface string `json:"socketInterface,omitempty"`
+}
+
+// DeviceTopologyEnvPlugin injects the device count, world size and rank offset of the pod into the containers
+// requesting GPU/NPU devices, derived from their resource limits and the group size and worker index of the pod.
+type DeviceTopologyEnvPlugin struct {
+	name string
+	cfg  DeviceTopologyEnvConfig
+}
+
+func init() {
+	DefaultRegistry.Register(DeviceTopologyEnvPluginName, NewDeviceTopologyEnvPlugin)
+}
+
+// NewDeviceTopologyEnvPlugin constructs the device topology env plugin from PluginSpec config.
+func NewDeviceTopologyEnvPlugin(spec workloadv1alpha1.PluginSpec) (Plugin, error) {
+	cfg := DeviceTopologyEnvConfig{}
+	if err := DecodeJSON(spec.Config, &cfg); err != nil {
+		return nil, err
+	}
+	return &DeviceTopologyEnvPlugin{name: spec.Name, cfg: cfg}, nil
+}
+
+func (p *DeviceTopologyEnvPlugin) Name() string { return p.name }
+
+// OnPodCreate adds the topology env to the containers requesting devices. Env already set is kept.
+func (p *DeviceTopologyEnvPlugin) OnPodCreate(_ context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	for i := range req.Pod.Spec.Containers {
+		container := &req.Pod.Spec.Containers[i]
+		vendor, devices := containerDevices(container)
+		if devices == 0 {
+			continue
+		}
+		groupSize := envInt(container, workloadv1alpha1.GroupSizeEnv, 1)
+		workerIndex := envInt(container, workloadv1alpha1.WorkerIndexEnv, 0)
+		setEnvIfAbsent(container, DevicesPerPodEnv, strconv.FormatInt(devices, 10))
+		setEnvIfAbsent(container, WorldSizeEnv, strconv.FormatInt(devices*groupSize, 10))
+		setEnvIfAbsent(container, RankOffsetEnv, strconv.FormatInt(devices*workerIndex, 10))
+		if p.cfg.SocketInterface != "" {
+			for _, env := range vendor.socketEnvs {
+				setEnvIfAbsent(container, env, p.cfg.SocketInterface)
+			}
+		}
+	}
+	return nil
+}
+
+// OnPodReady is a no-op for the device topology env plugin.
+func (p *DeviceTopologyEnvPlugin) OnPodReady(_ context.Context, _ *HookRequest) error {
+	return nil
+}
+
+// containerDevices returns the vendor and number of the devices in the limits of the container.
+func containerDevices(container *corev1.Container) (deviceVendor, int64) {
+	for _, vendor := range deviceVendors {
+		for _, resource := range vendor.resources {
+			if quantity, ok := container.Resources.Limits[resource]; ok && quantity.Value() > 0 {
+				return vendor, quantity.Value()
+			}
+		}
+	}
+	return deviceVendor{}, 0
+}
+
+// envInt returns the integer value of the env of the container, or def if it is not set or invalid.
+func envInt(container *corev1.Container, name string, def int64) int64 {
+	for _, env := range container.Env {
+		if env.Name != name {
+			continue
+		}
+		if v, err := strconv.ParseInt(env.Value, 10, 64); err == nil {
+			return v
+		}
+		return def
+	}
+	return def
+}
+
+func setEnvIfAbsent(container *corev1.Container, name, value string) {
+	for _, env := range container.Env {
+		if env.Name == name {
+			return
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestDeviceTopologyEnvPluginOnPodCreate(t *testing.T) {
+	plugin, err := NewDeviceTopologyEnvPlugin(workloadv1alpha1.PluginSpec{
+		Name:   DeviceTopologyEnvPluginName,
+		Config: &apiextensionsv1.JSON{Raw: []byte(`{"socketInterface":"eth0"}`)},
+	})
+	if err != nil {
+		t.Fatalf("new plugin: %v", err)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{
+		{
+			Name: "engine",
+			Env: []corev1.EnvVar{
+				{Name: workloadv1alpha1.GroupSizeEnv, Value: "2"},
+				{Name: workloadv1alpha1.WorkerIndexEnv, Value: "1"},
+				{Name: "HCCL_SOCKET_IFNAME", Value: "bond0"},
+			},
+			Resources: corev1.ResourceRequirements{Limits: corev1.ResourceList{
+				"huawei.com/ascend-1980": resource.MustParse("8"),
+			}},
+		},
+		{Name: "runtime"},
+	}}}
+	if err := plugin.OnPodCreate(context.Background(), &HookRequest{Pod: pod}); err != nil {
+		t.Fatalf("on create: %v", err)
+	}
+
+	got := map[string]string{}
+	for _, env := range pod.Spec.Containers[0].Env {
+		got[env.Name] = env.Value
+	}
+	want := map[string]string{
+		DevicesPerPodEnv:     "8",
+		WorldSizeEnv:         "16",
+		RankOffsetEnv:        "8",
+		"HCCL_SOCKET_IFNAME": "bond0",
+		"GLOO_SOCKET_IFNAME": "eth0",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("expected %s=%s, got %q", k, v, got[k])
+		}
+	}
+	if _, ok := got["NCCL_SOCKET_IFNAME"]; ok {
+		t.Fatalf("NCCL env must not be set on NPU pods")
+	}
+	if len(pod.Spec.Containers[1].Env) != 0 {
+		t.Fatalf("containers without devices must not be mutated: %v", pod.Spec.Containers[1].Env)
+	}
+}
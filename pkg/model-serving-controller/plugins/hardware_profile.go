@@ -0,0 +1,158 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+const (
+	HardwareProfilePluginName = "hardware-profile"
+
+	// gpuProductLabel is set on the nodes by the NVIDIA GPU feature discovery.
+	gpuProductLabel = "nvidia.com/gpu.product"
+	// acceleratorLabel is set on the Ascend nodes by the Ascend device plugin.
+	acceleratorLabel = "accelerator"
+)
+
+// hardwareProfiles are the node selector requirements of the well-known hardware profiles.
+var hardwareProfiles = map[string][]corev1.NodeSelectorRequirement{
+	"nvidia-a100": {{
+		Key:      gpuProductLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"NVIDIA-A100-SXM4-40GB", "NVIDIA-A100-SXM4-80GB", "NVIDIA-A100-PCIE-40GB", "NVIDIA-A100-80GB-PCIe"},
+	}},
+	"nvidia-h100": {{
+		Key:      gpuProductLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"NVIDIA-H100-80GB-HBM3", "NVIDIA-H100-PCIe", "NVIDIA-H100-NVL"},
+	}},
+	"nvidia-l40s": {{
+		Key:      gpuProductLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"NVIDIA-L40S"},
+	}},
+	"ascend-910b": {{
+		Key:      acceleratorLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"huawei-Ascend910"},
+	}},
+	"ascend-310p": {{
+		Key:      acceleratorLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{"huawei-Ascend310P"},
+	}},
+}
+
+// HardwareProfileConfig is the config of the hardware profile plugin.
+type HardwareProfileConfig struct {
+	// Profile is the name of a well-known hardware profile: nvidia-a100, nvidia-h100, nvidia-l40s, ascend-910b or
+	// ascend-310p.
+	Profile string `json:"profile,omitempty"`
+	// MatchExpressions are additional node selector requirements of the profile.
+	MatchExpressions []corev1.NodeSelectorRequirement `json:"matchExpressions,omitempty"`
+	// Required makes the pods only run on the nodes of the profile. Otherwise the nodes are preferred.
+	Required bool `json:"required,omitempty"`
+	// Weight is the weight of the preferred node affinity, in the range 1-100. Defaults to 100.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// HardwareProfilePlugin adds a node affinity to the nodes of a hardware profile.
+type HardwareProfilePlugin struct {
+	name         string
+	cfg          HardwareProfileConfig
+	requirements []corev1.NodeSelectorRequirement
+}
+
+func init() {
+	DefaultRegistry.Register(HardwareProfilePluginName, NewHardwareProfilePlugin)
+}
+
+// NewHardwareProfilePlugin constructs the hardware profile plugin from PluginSpec config.
+func NewHardwareProfilePlugin(spec workloadv1alpha1.PluginSpec) (Plugin, error) {
+	cfg := HardwareProfileConfig{}
+	if err := DecodeJSON(spec.Config, &cfg); err != nil {
+		return nil, err
+	}
+	var requirements []corev1.NodeSelectorRequirement
+	if cfg.Profile != "" {
+		profile, ok := hardwareProfiles[cfg.Profile]
+		if !ok {
+			return nil, fmt.Errorf("unknown hardware profile %s", cfg.Profile)
+		}
+		requirements = append(requirements, profile...)
+	}
+	requirements = append(requirements, cfg.MatchExpressions...)
+	if len(requirements) == 0 {
+		return nil, fmt.Errorf("profile or matchExpressions is required")
+	}
+	if cfg.Weight == 0 {
+		cfg.Weight = 100
+	}
+	if cfg.Weight < 1 || cfg.Weight > 100 {
+		return nil, fmt.Errorf("weight must be in the range 1-100, got %d", cfg.Weight)
+	}
+	return &HardwareProfilePlugin{name: spec.Name, cfg: cfg, requirements: requirements}, nil
+}
+
+func (p *HardwareProfilePlugin) Name() string { return p.name }
+
+// OnPodCreate adds the requirements of the profile to the node affinity of the pod. Required requirements are
+// added to every required node selector term, as the terms are ORed.
+func (p *HardwareProfilePlugin) OnPodCreate(_ context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	spec := &req.Pod.Spec
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := spec.Affinity.NodeAffinity
+	if !p.cfg.Required {
+		nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			corev1.PreferredSchedulingTerm{
+				Weight:     p.cfg.Weight,
+				Preference: corev1.NodeSelectorTerm{MatchExpressions: slices.Clone(p.requirements)},
+			})
+		return nil
+	}
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{}
+	}
+	selector := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(selector.NodeSelectorTerms) == 0 {
+		selector.NodeSelectorTerms = []corev1.NodeSelectorTerm{{}}
+	}
+	for i := range selector.NodeSelectorTerms {
+		selector.NodeSelectorTerms[i].MatchExpressions = append(selector.NodeSelectorTerms[i].MatchExpressions, p.requirements...)
+	}
+	return nil
+}
+
+// OnPodReady is a no-op for the hardware profile plugin.
+func (p *HardwareProfilePlugin) OnPodReady(_ context.Context, _ *HookRequest) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestHardwareProfilePluginOnPodCreate(t *testing.T) {
+	newPlugin := func(raw string) Plugin {
+		plugin, err := NewHardwareProfilePlugin(workloadv1alpha1.PluginSpec{
+			Name:   HardwareProfilePluginName,
+			Config: &apiextensionsv1.JSON{Raw: []byte(raw)},
+		})
+		if err != nil {
+			t.Fatalf("new plugin: %v", err)
+		}
+		return plugin
+	}
+
+	// Preferred by default.
+	pod := &corev1.Pod{}
+	if err := newPlugin(`{"profile":"nvidia-h100","weight":30}`).OnPodCreate(context.Background(), &HookRequest{Pod: pod}); err != nil {
+		t.Fatalf("on create: %v", err)
+	}
+	preferred := pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(preferred) != 1 || preferred[0].Weight != 30 || preferred[0].Preference.MatchExpressions[0].Key != gpuProductLabel {
+		t.Fatalf("unexpected preferred terms: %+v", preferred)
+	}
+
+	// Required requirements are ANDed into every existing term.
+	pod = &corev1.Pod{Spec: corev1.PodSpec{Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}}},
+			{MatchExpressions: []corev1.NodeSelectorRequirement{{Key: "zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"b"}}}},
+		}},
+	}}}}
+	plugin := newPlugin(`{"profile":"ascend-910b","required":true,"matchExpressions":[{"key":"pool","operator":"Exists"}]}`)
+	if err := plugin.OnPodCreate(context.Background(), &HookRequest{Pod: pod}); err != nil {
+		t.Fatalf("on create: %v", err)
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if len(term.MatchExpressions) != 3 || term.MatchExpressions[1].Key != acceleratorLabel || term.MatchExpressions[2].Key != "pool" {
+			t.Fatalf("unexpected required term: %+v", term)
+		}
+	}
+}
+
+func TestNewHardwareProfilePluginInvalidConfig(t *testing.T) {
+	for _, raw := range []string{`{}`, `{"profile":"tpu-v5"}`, `{"profile":"nvidia-a100","weight":200}`} {
+		spec := workloadv1alpha1.PluginSpec{
+			Name:   HardwareProfilePluginName,
+			Config: &apiextensionsv1.JSON{Raw: []byte(raw)},
+		}
+		if _, err := NewHardwareProfilePlugin(spec); err == nil {
+			t.Fatalf("expected error for config %s", raw)
+		}
+	}
+}
@@ -63,13 +63,19 @@ func NewChain(registry *Registry, specs []workloadv1alpha1.PluginSpec) (*Chain,
 	}
 	var entries []entry
 	for _, spec := range specs {
-		if spec.Type != workloadv1alpha1.PluginTypeBuiltIn {
+		var factory Factory
+		switch spec.Type {
+		case workloadv1alpha1.PluginTypeBuiltIn:
+			f, ok := registry.factories[spec.Name]
+			if !ok {
+				return nil, fmt.Errorf("plugin %s not registered", spec.Name)
+			}
+			factory = f
+		case workloadv1alpha1.PluginTypeWebhook:
+			factory = NewWebhookPlugin
+		default:
 			return nil, fmt.Errorf("plugin %s has unsupported type %s", spec.Name, spec.Type)
 		}
-		factory, ok := registry.factories[spec.Name]
-		if !ok {
-			return nil, fmt.Errorf("plugin %s not registered", spec.Name)
-		}
 		p, err := factory(spec)
 		if err != nil {
 			return nil, fmt.Errorf("build plugin %s: %w", spec.Name, err)
@@ -0,0 +1,106 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+const ModelCacheAffinityPluginName = "model-cache-affinity"
+
+// ModelCacheAffinityConfig is the config of the model cache affinity plugin.
+type ModelCacheAffinityConfig struct {
+	// NodeLabelKey is the label set on the nodes holding the model in their cache.
+	NodeLabelKey string `json:"nodeLabelKey"`
+	// Weight is the weight of the preferred node affinity, in the range 1-100. Defaults to 100.
+	Weight int32 `json:"weight,omitempty"`
+}
+
+// ModelCacheAffinityPlugin makes the serving pods prefer the nodes which already hold the model in their cache,
+// so that they skip the model download.
+type ModelCacheAffinityPlugin struct {
+	name string
+	cfg  ModelCacheAffinityConfig
+}
+
+func init() {
+	DefaultRegistry.Register(ModelCacheAffinityPluginName, NewModelCacheAffinityPlugin)
+}
+
+// NewModelCacheAffinityPlugin constructs the model cache affinity plugin from PluginSpec config.
+func NewModelCacheAffinityPlugin(spec workloadv1alpha1.PluginSpec) (Plugin, error) {
+	cfg := ModelCacheAffinityConfig{}
+	if err := DecodeJSON(spec.Config, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.NodeLabelKey == "" {
+		return nil, fmt.Errorf("nodeLabelKey is required")
+	}
+	if cfg.Weight == 0 {
+		cfg.Weight = 100
+	}
+	if cfg.Weight < 1 || cfg.Weight > 100 {
+		return nil, fmt.Errorf("weight must be in the range 1-100, got %d", cfg.Weight)
+	}
+	return &ModelCacheAffinityPlugin{name: spec.Name, cfg: cfg}, nil
+}
+
+func (p *ModelCacheAffinityPlugin) Name() string { return p.name }
+
+// OnPodCreate adds a preferred node affinity to the nodes labelled with the cache label of the model.
+func (p *ModelCacheAffinityPlugin) OnPodCreate(_ context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	spec := &req.Pod.Spec
+	if spec.Affinity == nil {
+		spec.Affinity = &corev1.Affinity{}
+	}
+	if spec.Affinity.NodeAffinity == nil {
+		spec.Affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	nodeAffinity := spec.Affinity.NodeAffinity
+	for _, term := range nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution {
+		for _, expr := range term.Preference.MatchExpressions {
+			if expr.Key == p.cfg.NodeLabelKey {
+				// Already set by the user or the pod template.
+				return nil
+			}
+		}
+	}
+	nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{
+			Weight: p.cfg.Weight,
+			Preference: corev1.NodeSelectorTerm{
+				MatchExpressions: []corev1.NodeSelectorRequirement{{
+					Key:      p.cfg.NodeLabelKey,
+					Operator: corev1.NodeSelectorOpExists,
+				}},
+			},
+		})
+	return nil
+}
+
+// OnPodReady is a no-op for the model cache affinity plugin.
+func (p *ModelCacheAffinityPlugin) OnPodReady(_ context.Context, _ *HookRequest) error {
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestModelCacheAffinityPluginOnPodCreate(t *testing.T) {
+	spec := workloadv1alpha1.PluginSpec{
+		Name:   ModelCacheAffinityPluginName,
+		Type:   workloadv1alpha1.PluginTypeBuiltIn,
+		Config: &apiextensionsv1.JSON{Raw: []byte(`{"nodeLabelKey":"workload.serving.volcano.sh/model-cache-abc"}`)},
+	}
+	plugin, err := NewModelCacheAffinityPlugin(spec)
+	if err != nil {
+		t.Fatalf("new plugin: %v", err)
+	}
+
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Affinity: &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{},
+	}}}
+	req := &HookRequest{Pod: pod}
+	for i := 0; i < 2; i++ {
+		if err := plugin.OnPodCreate(context.Background(), req); err != nil {
+			t.Fatalf("on create: %v", err)
+		}
+	}
+
+	if pod.Spec.Affinity.PodAntiAffinity == nil {
+		t.Fatalf("existing affinity was dropped")
+	}
+	terms := pod.Spec.Affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	if len(terms) != 1 {
+		t.Fatalf("expected 1 preferred term, got %d", len(terms))
+	}
+	if terms[0].Weight != 100 {
+		t.Fatalf("expected default weight 100, got %d", terms[0].Weight)
+	}
+	expr := terms[0].Preference.MatchExpressions[0]
+	if expr.Key != "workload.serving.volcano.sh/model-cache-abc" || expr.Operator != corev1.NodeSelectorOpExists {
+		t.Fatalf("unexpected match expression: %+v", expr)
+	}
+}
+
+func TestNewModelCacheAffinityPluginInvalidConfig(t *testing.T) {
+	for _, raw := range []string{`{}`, `{"nodeLabelKey":"a","weight":101}`} {
+		spec := workloadv1alpha1.PluginSpec{
+			Name:   ModelCacheAffinityPluginName,
+			Config: &apiextensionsv1.JSON{Raw: []byte(raw)},
+		}
+		if _, err := NewModelCacheAffinityPlugin(spec); err == nil {
+			t.Fatalf("expected error for config %s", raw)
+		}
+	}
+}
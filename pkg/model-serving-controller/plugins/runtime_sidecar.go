@@ -0,0 +1,145 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+const (
+	RuntimeSidecarPluginName = "runtime-sidecar"
+
+	// RuntimeSidecarContainerName is the name of the injected container, the same as the runtime container of the
+	// ModelServings generated from ModelBoosters, so those are left untouched.
+	RuntimeSidecarContainerName = "runtime"
+
+	defaultRuntimeSidecarImage = "kthena/runtime:latest"
+	defaultRuntimeSidecarPort  = 8100
+)
+
+// RuntimeSidecarConfig is the config of the runtime sidecar plugin.
+type RuntimeSidecarConfig struct {
+	// Image is the image of the runtime. Defaults to kthena/runtime:latest.
+	Image string `json:"image,omitempty"`
+	// Port is the port the runtime serves on. Defaults to 8100.
+	Port int32 `json:"port,omitempty"`
+	// Engine is the inference engine of the pod, e.g. vllm or sglang.
+	Engine string `json:"engine"`
+	// EngineBaseURL is the URL of the engine. Defaults to http://localhost:8000.
+	EngineBaseURL string `json:"engineBaseURL,omitempty"`
+	// MetricsPath is the metrics path of the engine. Defaults to /metrics.
+	MetricsPath string `json:"metricsPath,omitempty"`
+	// Model is the name of the served model. Defaults to the name of the ModelServing.
+	Model string `json:"model,omitempty"`
+}
+
+// RuntimeSidecarPlugin injects the kthena runtime, which exposes the standardized metrics of the engine, into
+// the entry pods of ModelServings which do not run it yet.
+type RuntimeSidecarPlugin struct {
+	name string
+	cfg  RuntimeSidecarConfig
+}
+
+func init() {
+	DefaultRegistry.Register(RuntimeSidecarPluginName, NewRuntimeSidecarPlugin)
+}
+
+// NewRuntimeSidecarPlugin constructs the runtime sidecar plugin from PluginSpec config.
+func NewRuntimeSidecarPlugin(spec workloadv1alpha1.PluginSpec) (Plugin, error) {
+	cfg := RuntimeSidecarConfig{}
+	if err := DecodeJSON(spec.Config, &cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Engine == "" {
+		return nil, fmt.Errorf("engine is required")
+	}
+	if cfg.Image == "" {
+		cfg.Image = defaultRuntimeSidecarImage
+	}
+	if cfg.Port == 0 {
+		cfg.Port = defaultRuntimeSidecarPort
+	}
+	if cfg.EngineBaseURL == "" {
+		cfg.EngineBaseURL = "http://localhost:8000"
+	}
+	if cfg.MetricsPath == "" {
+		cfg.MetricsPath = "/metrics"
+	}
+	return &RuntimeSidecarPlugin{name: spec.Name, cfg: cfg}, nil
+}
+
+func (p *RuntimeSidecarPlugin) Name() string { return p.name }
+
+// OnPodCreate appends the runtime container to the pod, unless it already has one.
+func (p *RuntimeSidecarPlugin) OnPodCreate(_ context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	for _, container := range req.Pod.Spec.Containers {
+		if container.Name == RuntimeSidecarContainerName {
+			return nil
+		}
+	}
+	model := p.cfg.Model
+	if model == "" && req.ModelServing != nil {
+		model = req.ModelServing.Name
+	}
+	port := strconv.Itoa(int(p.cfg.Port))
+	req.Pod.Spec.Containers = append(req.Pod.Spec.Containers, corev1.Container{
+		Name:  RuntimeSidecarContainerName,
+		Image: p.cfg.Image,
+		Ports: []corev1.ContainerPort{{ContainerPort: p.cfg.Port}},
+		Env: []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+			{
+				Name:      "NAMESPACE",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+			},
+		},
+		Args: []string{
+			"--port", port,
+			"--engine", p.cfg.Engine,
+			"--engine-base-url", p.cfg.EngineBaseURL,
+			"--engine-metrics-path", p.cfg.MetricsPath,
+			"--pod", "$(POD_NAME).$(NAMESPACE)",
+			"--model", model,
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(p.cfg.Port)},
+			},
+			InitialDelaySeconds: 5,
+			PeriodSeconds:       10,
+		},
+	})
+	return nil
+}
+
+// OnPodReady is a no-op for the runtime sidecar plugin.
+func (p *RuntimeSidecarPlugin) OnPodReady(_ context.Context, _ *HookRequest) error {
+	return nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestRuntimeSidecarPluginOnPodCreate(t *testing.T) {
+	plugin, err := NewRuntimeSidecarPlugin(workloadv1alpha1.PluginSpec{
+		Name:   RuntimeSidecarPluginName,
+		Config: &apiextensionsv1.JSON{Raw: []byte(`{"engine":"sglang","engineBaseURL":"http://localhost:30000"}`)},
+	})
+	if err != nil {
+		t.Fatalf("new plugin: %v", err)
+	}
+
+	ms := &workloadv1alpha1.ModelServing{ObjectMeta: metav1.ObjectMeta{Name: "qwen"}}
+	pod := &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "engine"}}}}
+	for i := 0; i < 2; i++ {
+		if err := plugin.OnPodCreate(context.Background(), &HookRequest{ModelServing: ms, Pod: pod}); err != nil {
+			t.Fatalf("on create: %v", err)
+		}
+	}
+
+	if len(pod.Spec.Containers) != 2 {
+		t.Fatalf("expected the runtime to be injected once, got %d containers", len(pod.Spec.Containers))
+	}
+	runtime := pod.Spec.Containers[1]
+	if runtime.Name != RuntimeSidecarContainerName || runtime.Image != defaultRuntimeSidecarImage {
+		t.Fatalf("unexpected runtime container: %+v", runtime)
+	}
+	want := []string{
+		"--port", "8100",
+		"--engine", "sglang",
+		"--engine-base-url", "http://localhost:30000",
+		"--engine-metrics-path", "/metrics",
+		"--pod", "$(POD_NAME).$(NAMESPACE)",
+		"--model", "qwen",
+	}
+	if !slices.Equal(runtime.Args, want) {
+		t.Fatalf("unexpected args: %v", runtime.Args)
+	}
+	if runtime.ReadinessProbe == nil || runtime.ReadinessProbe.HTTPGet.Port.IntValue() != 8100 {
+		t.Fatalf("unexpected readiness probe: %+v", runtime.ReadinessProbe)
+	}
+}
+
+func TestNewRuntimeSidecarPluginRequiresEngine(t *testing.T) {
+	if _, err := NewRuntimeSidecarPlugin(workloadv1alpha1.PluginSpec{Name: RuntimeSidecarPluginName}); err == nil {
+		t.Fatalf("expected error without engine")
+	}
+}
@@ -25,13 +25,14 @@ import (
 )
 
 // HookRequest carries the context for plugin hook invocations.
+// It is also the body of the requests sent to Webhook plugins.
 type HookRequest struct {
-	ModelServing *workloadv1alpha1.ModelServing
-	ServingGroup string
-	RoleName     string
-	RoleID       string
-	IsEntry      bool
-	Pod          *corev1.Pod
+	ModelServing *workloadv1alpha1.ModelServing `json:"modelServing,omitempty"`
+	ServingGroup string                         `json:"servingGroup"`
+	RoleName     string                         `json:"roleName"`
+	RoleID       string                         `json:"roleID"`
+	IsEntry      bool                           `json:"isEntry"`
+	Pod          *corev1.Pod                    `json:"pod,omitempty"`
 }
 
 // Plugin defines the lifecycle hooks a plugin may implement.
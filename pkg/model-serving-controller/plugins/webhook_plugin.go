@@ -0,0 +1,235 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+const (
+	HookOnPodCreate = "OnPodCreate"
+	HookOnPodReady  = "OnPodReady"
+
+	defaultWebhookTimeout = 10 * time.Second
+	// maxWebhookResponseSize bounds the response read from the endpoint.
+	maxWebhookResponseSize = 4 << 20
+)
+
+// webhookTransports shares the transports of the webhook plugins, so that the plugins built for each generation of
+// a ModelServing reuse the connections. key: CA bundle, value: *http.Transport
+var webhookTransports sync.Map
+
+// webhookTransport returns the shared transport trusting the CA bundle, or the system roots if it is empty.
+func webhookTransport(caBundle []byte) (*http.Transport, error) {
+	if value, ok := webhookTransports.Load(string(caBundle)); ok {
+		return value.(*http.Transport), nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("invalid caBundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	value, _ := webhookTransports.LoadOrStore(string(caBundle), transport)
+	return value.(*http.Transport), nil
+}
+
+// WebhookRequest is the body POSTed to the endpoint of a Webhook plugin.
+type WebhookRequest struct {
+	// Hook is the name of the invoked hook, OnPodCreate or OnPodReady.
+	Hook string `json:"hook"`
+	// Config is the config of the plugin in the ModelServing.
+	Config json.RawMessage `json:"config,omitempty"`
+	// Request is the context of the hook.
+	Request *HookRequest `json:"request"`
+}
+
+// WebhookResponse is the body returned by the endpoint of a Webhook plugin.
+type WebhookResponse struct {
+	// Patch is a RFC 6902 JSON patch applied to the pod. It is only applied on OnPodCreate.
+	Patch json.RawMessage `json:"patch,omitempty"`
+}
+
+// WebhookPlugin delegates the hooks to an external HTTP endpoint.
+type WebhookPlugin struct {
+	name   string
+	config json.RawMessage
+	url    string
+	policy workloadv1alpha1.WebhookFailurePolicy
+	client *http.Client
+}
+
+// NewWebhookPlugin constructs a webhook plugin from PluginSpec.
+func NewWebhookPlugin(spec workloadv1alpha1.PluginSpec) (Plugin, error) {
+	if spec.Webhook == nil || spec.Webhook.URL == "" {
+		return nil, fmt.Errorf("webhook url is required")
+	}
+	timeout := defaultWebhookTimeout
+	if spec.Webhook.TimeoutSeconds > 0 {
+		timeout = time.Duration(spec.Webhook.TimeoutSeconds) * time.Second
+	}
+	transport, err := webhookTransport(spec.Webhook.CABundle)
+	if err != nil {
+		return nil, err
+	}
+	policy := spec.Webhook.FailurePolicy
+	if policy == "" {
+		policy = workloadv1alpha1.WebhookFailurePolicyFail
+	}
+	var config json.RawMessage
+	if spec.Config != nil {
+		config = spec.Config.Raw
+	}
+	return &WebhookPlugin{
+		name:   spec.Name,
+		config: config,
+		url:    spec.Webhook.URL,
+		policy: policy,
+		client: &http.Client{Timeout: timeout, Transport: transport},
+	}, nil
+}
+
+func (p *WebhookPlugin) Name() string { return p.name }
+
+// OnPodCreate sends the pod to the endpoint, and applies the returned patch to req.Pod.
+func (p *WebhookPlugin) OnPodCreate(ctx context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	return p.handleError(HookOnPodCreate, req, func() error {
+		resp, err := p.call(ctx, HookOnPodCreate, req)
+		if err != nil {
+			return err
+		}
+		if len(resp.Patch) == 0 {
+			return nil
+		}
+		return applyPodPatch(req.Pod, resp.Patch)
+	})
+}
+
+// OnPodReady notifies the endpoint that the pod is ready. The returned patch is ignored.
+func (p *WebhookPlugin) OnPodReady(ctx context.Context, req *HookRequest) error {
+	if req == nil || req.Pod == nil {
+		return nil
+	}
+	return p.handleError(HookOnPodReady, req, func() error {
+		_, err := p.call(ctx, HookOnPodReady, req)
+		return err
+	})
+}
+
+func (p *WebhookPlugin) handleError(hook string, req *HookRequest, fn func() error) error {
+	err := fn()
+	if err == nil {
+		return nil
+	}
+	if p.policy == workloadv1alpha1.WebhookFailurePolicyIgnore {
+		klog.Warningf("webhook plugin %s %s for pod %s failed, ignored: %v", p.name, hook, req.Pod.Name, err)
+		return nil
+	}
+	return err
+}
+
+func (p *WebhookPlugin) call(ctx context.Context, hook string, req *HookRequest) (*WebhookResponse, error) {
+	body, err := json.Marshal(WebhookRequest{Hook: hook, Config: p.config, Request: req})
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(httpResp.Body, maxWebhookResponseSize))
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return nil, fmt.Errorf("webhook returned status %d: %s", httpResp.StatusCode, string(data))
+	}
+	resp := &WebhookResponse{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return resp, nil
+	}
+	if err := json.Unmarshal(data, resp); err != nil {
+		return nil, fmt.Errorf("invalid webhook response: %w", err)
+	}
+	return resp, nil
+}
+
+// isControllerOwnedLabel reports whether the pod label is set by the ModelServing controller, which relies on it
+// to track the pod.
+func isControllerOwnedLabel(key string) bool {
+	return strings.HasPrefix(key, "modelserving.volcano.sh/")
+}
+
+// applyPodPatch applies the JSON patch to the pod in place. The patch must not change the labels owned by the
+// controller.
+func applyPodPatch(pod *corev1.Pod, patch []byte) error {
+	decoded, err := jsonpatch.DecodePatch(patch)
+	if err != nil {
+		return fmt.Errorf("invalid patch: %w", err)
+	}
+	original, err := json.Marshal(pod)
+	if err != nil {
+		return err
+	}
+	patched, err := decoded.Apply(original)
+	if err != nil {
+		return fmt.Errorf("apply patch: %w", err)
+	}
+	updated := corev1.Pod{}
+	if err := json.Unmarshal(patched, &updated); err != nil {
+		return fmt.Errorf("patched pod is invalid: %w", err)
+	}
+	for key, value := range pod.Labels {
+		if newValue, ok := updated.Labels[key]; isControllerOwnedLabel(key) && (!ok || newValue != value) {
+			return fmt.Errorf("patch must not change label %s", key)
+		}
+	}
+	for key := range updated.Labels {
+		if _, ok := pod.Labels[key]; isControllerOwnedLabel(key) && !ok {
+			return fmt.Errorf("patch must not set label %s", key)
+		}
+	}
+	*pod = updated
+	return nil
+}
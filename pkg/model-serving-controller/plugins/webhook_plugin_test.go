@@ -0,0 +1,129 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func TestWebhookPluginOnPodCreate(t *testing.T) {
+	var received WebhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"patch":[{"op":"add","path":"/metadata/labels/tuned","value":"true"}]}`))
+	}))
+	defer server.Close()
+
+	chain, err := NewChain(DefaultRegistry, []workloadv1alpha1.PluginSpec{{
+		Name:    "tuner",
+		Type:    workloadv1alpha1.PluginTypeWebhook,
+		Config:  &apiextensionsv1.JSON{Raw: []byte(`{"profile":"fast"}`)},
+		Webhook: &workloadv1alpha1.WebhookPluginConfig{URL: server.URL},
+	}})
+	if err != nil {
+		t.Fatalf("new chain: %v", err)
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod-0", Labels: map[string]string{"app": "llm"}},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "engine"}}},
+	}
+	if err := chain.OnPodCreate(context.Background(), &HookRequest{RoleName: "prefill", IsEntry: true, Pod: pod}); err != nil {
+		t.Fatalf("on create: %v", err)
+	}
+
+	if received.Hook != HookOnPodCreate || received.Request.RoleName != "prefill" || received.Request.Pod.Name != "pod-0" {
+		t.Fatalf("unexpected request: %+v", received)
+	}
+	if string(received.Config) != `{"profile":"fast"}` {
+		t.Fatalf("unexpected config: %s", received.Config)
+	}
+	if pod.Labels["tuned"] != "true" || pod.Labels["app"] != "llm" {
+		t.Fatalf("patch not applied: %v", pod.Labels)
+	}
+}
+
+func TestApplyPodPatchControllerOwnedLabels(t *testing.T) {
+	for _, patch := range []string{
+		`[{"op":"replace","path":"/metadata/labels/modelserving.volcano.sh~1group-name","value":"other"}]`,
+		`[{"op":"remove","path":"/metadata/labels/modelserving.volcano.sh~1group-name"}]`,
+		`[{"op":"add","path":"/metadata/labels/modelserving.volcano.sh~1entry","value":"true"}]`,
+	} {
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{workloadv1alpha1.GroupNameLabelKey: "ms-0"}}}
+		if err := applyPodPatch(pod, []byte(patch)); err == nil {
+			t.Fatalf("expected error for patch %s", patch)
+		}
+		if pod.Labels[workloadv1alpha1.GroupNameLabelKey] != "ms-0" || len(pod.Labels) != 1 {
+			t.Fatalf("pod changed by rejected patch %s: %v", patch, pod.Labels)
+		}
+	}
+}
+
+func TestWebhookPluginFailurePolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	for _, tc := range []struct {
+		policy  workloadv1alpha1.WebhookFailurePolicy
+		wantErr bool
+	}{
+		{policy: "", wantErr: true},
+		{policy: workloadv1alpha1.WebhookFailurePolicyFail, wantErr: true},
+		{policy: workloadv1alpha1.WebhookFailurePolicyIgnore, wantErr: false},
+	} {
+		plugin, err := NewWebhookPlugin(workloadv1alpha1.PluginSpec{
+			Name:    "tuner",
+			Type:    workloadv1alpha1.PluginTypeWebhook,
+			Webhook: &workloadv1alpha1.WebhookPluginConfig{URL: server.URL, FailurePolicy: tc.policy},
+		})
+		if err != nil {
+			t.Fatalf("new plugin: %v", err)
+		}
+		req := &HookRequest{Pod: &corev1.Pod{}}
+		if err := plugin.OnPodCreate(context.Background(), req); (err != nil) != tc.wantErr {
+			t.Fatalf("policy %q: expected error %v, got %v", tc.policy, tc.wantErr, err)
+		}
+		if err := plugin.OnPodReady(context.Background(), req); (err != nil) != tc.wantErr {
+			t.Fatalf("policy %q: expected ready error %v, got %v", tc.policy, tc.wantErr, err)
+		}
+	}
+}
+
+func TestNewWebhookPluginInvalidConfig(t *testing.T) {
+	for _, webhook := range []*workloadv1alpha1.WebhookPluginConfig{
+		nil,
+		{URL: "https://tuner", CABundle: []byte("not a cert")},
+	} {
+		spec := workloadv1alpha1.PluginSpec{Name: "tuner", Type: workloadv1alpha1.PluginTypeWebhook, Webhook: webhook}
+		if _, err := NewWebhookPlugin(spec); err == nil {
+			t.Fatalf("expected error for webhook %+v", webhook)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/klog/v2"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+// InPlaceUpdateState is the state of the last in-place update of a pod, recorded in the
+// InPlaceUpdateStateAnnotationKey annotation.
+type InPlaceUpdateState struct {
+	// Revision is the modelServing revision the pod is updated to.
+	Revision string `json:"revision"`
+	// LastImageIDs are the image IDs of the updated containers before the update, by container name.
+	LastImageIDs map[string]string `json:"lastImageIDs,omitempty"`
+	// RestartBaseline is the total restart count of the pod containers expected after the update.
+	// Only the restarts above it are failures.
+	RestartBaseline int32 `json:"restartBaseline"`
+}
+
+// CanUpdateRoleInPlace checks whether the pods created from the old role template can be updated in place to
+// the new role template, that is whether only the container images or the pod template labels and annotations changed.
+func CanUpdateRoleInPlace(oldRole, newRole workloadv1alpha1.Role) bool {
+	if oldRole.Name != newRole.Name || oldRole.WorkerReplicas != newRole.WorkerReplicas {
+		return false
+	}
+	if !canUpdateTemplateInPlace(oldRole.EntryTemplate, newRole.EntryTemplate) {
+		return false
+	}
+	if oldRole.WorkerTemplate == nil || newRole.WorkerTemplate == nil {
+		return oldRole.WorkerTemplate == nil && newRole.WorkerTemplate == nil
+	}
+	return canUpdateTemplateInPlace(*oldRole.WorkerTemplate, *newRole.WorkerTemplate)
+}
+
+func canUpdateTemplateInPlace(oldTemplate, newTemplate workloadv1alpha1.PodTemplateSpec) bool {
+	updated := oldTemplate.DeepCopy()
+	updated.Metadata = newTemplate.Metadata
+	for i := range updated.Spec.Containers {
+		for _, container := range newTemplate.Spec.Containers {
+			if container.Name == updated.Spec.Containers[i].Name {
+				updated.Spec.Containers[i].Image = container.Image
+			}
+		}
+	}
+	return equality.Semantic.DeepEqual(*updated, newTemplate)
+}
+
+// GenerateInPlaceUpdatedPod returns a copy of the pod created from the old template, updated in place to the new
+// template and labeled with the new revisions. The in-place update state is recorded on the returned pod.
+func GenerateInPlaceUpdatedPod(pod *corev1.Pod, oldTemplate, newTemplate workloadv1alpha1.PodTemplateSpec, revision, roleRevision string) *corev1.Pod {
+	updated := pod.DeepCopy()
+	if updated.Labels == nil {
+		updated.Labels = map[string]string{}
+	}
+	if updated.Annotations == nil {
+		updated.Annotations = map[string]string{}
+	}
+
+	if oldTemplate.Metadata != nil {
+		for k := range oldTemplate.Metadata.Labels {
+			delete(updated.Labels, k)
+		}
+		for k := range oldTemplate.Metadata.Annotations {
+			delete(updated.Annotations, k)
+		}
+	}
+	addPodLabelAndAnnotation(updated, newTemplate.Metadata)
+	updated.Labels[workloadv1alpha1.RevisionLabelKey] = revision
+	updated.Labels[workloadv1alpha1.RoleRevisionLabelKey] = roleRevision
+
+	state := InPlaceUpdateState{
+		Revision:        revision,
+		RestartBaseline: totalRestartCount(pod),
+	}
+	for i := range updated.Spec.Containers {
+		container := &updated.Spec.Containers[i]
+		for _, newContainer := range newTemplate.Spec.Containers {
+			if newContainer.Name != container.Name || newContainer.Image == container.Image {
+				continue
+			}
+			container.Image = newContainer.Image
+			// The container is restarted by kubelet with the new image.
+			state.RestartBaseline++
+			if state.LastImageIDs == nil {
+				state.LastImageIDs = map[string]string{}
+			}
+			state.LastImageIDs[container.Name] = containerImageID(pod, container.Name)
+		}
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		klog.Errorf("failed to marshal in-place update state of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+	} else {
+		updated.Annotations[workloadv1alpha1.InPlaceUpdateStateAnnotationKey] = string(data)
+	}
+	return updated
+}
+
+// GetInPlaceUpdateState returns the state of the last in-place update of the pod, if any.
+func GetInPlaceUpdateState(pod *corev1.Pod) (*InPlaceUpdateState, bool) {
+	value, ok := pod.Annotations[workloadv1alpha1.InPlaceUpdateStateAnnotationKey]
+	if !ok {
+		return nil, false
+	}
+	state := &InPlaceUpdateState{}
+	if err := json.Unmarshal([]byte(value), state); err != nil {
+		klog.Warningf("Invalid in-place update state of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		return nil, false
+	}
+	return state, true
+}
+
+// IsInPlaceUpdateCompleted checks whether all the containers updated in place run their new image.
+// It returns true for pods that have never been updated in place.
+func IsInPlaceUpdateCompleted(pod *corev1.Pod) bool {
+	state, ok := GetInPlaceUpdateState(pod)
+	if !ok {
+		return true
+	}
+	for name, lastImageID := range state.LastImageIDs {
+		imageID := containerImageID(pod, name)
+		if imageID == "" || imageID == lastImageID {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainerRestartedByFailure checks whether containers of the pod restarted, not counting the restarts caused
+// by the last in-place update.
+func ContainerRestartedByFailure(pod *corev1.Pod) bool {
+	if !ContainerRestarted(pod) {
+		return false
+	}
+	state, ok := GetInPlaceUpdateState(pod)
+	if !ok {
+		return true
+	}
+	return totalRestartCount(pod) > state.RestartBaseline
+}
+
+func totalRestartCount(pod *corev1.Pod) int32 {
+	var count int32
+	for _, status := range pod.Status.InitContainerStatuses {
+		count += status.RestartCount
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		count += status.RestartCount
+	}
+	return count
+}
+
+func containerImageID(pod *corev1.Pod, name string) string {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == name {
+			return status.ImageID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,160 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	workloadv1alpha1 "github.com/volcano-sh/kthena/pkg/apis/workload/v1alpha1"
+)
+
+func newInPlaceTestRole(image string) workloadv1alpha1.Role {
+	return workloadv1alpha1.Role{
+		Name:     "prefill",
+		Replicas: ptr.To[int32](1),
+		EntryTemplate: workloadv1alpha1.PodTemplateSpec{
+			Metadata: &workloadv1alpha1.Metadata{Labels: map[string]string{"app": "v1"}},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "engine", Image: image}},
+			},
+		},
+	}
+}
+
+func TestCanUpdateRoleInPlace(t *testing.T) {
+	oldRole := newInPlaceTestRole("engine:v1")
+
+	tests := []struct {
+		name   string
+		modify func(role *workloadv1alpha1.Role)
+		want   bool
+	}{
+		{
+			name:   "image changed",
+			modify: func(role *workloadv1alpha1.Role) { role.EntryTemplate.Spec.Containers[0].Image = "engine:v2" },
+			want:   true,
+		},
+		{
+			name: "labels changed",
+			modify: func(role *workloadv1alpha1.Role) {
+				role.EntryTemplate.Metadata = &workloadv1alpha1.Metadata{Labels: map[string]string{"app": "v2"}}
+			},
+			want: true,
+		},
+		{
+			name:   "replicas changed",
+			modify: func(role *workloadv1alpha1.Role) { role.Replicas = ptr.To[int32](3) },
+			want:   true,
+		},
+		{
+			name: "resources changed",
+			modify: func(role *workloadv1alpha1.Role) {
+				role.EntryTemplate.Spec.Containers[0].Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}
+			},
+			want: false,
+		},
+		{
+			name: "container added",
+			modify: func(role *workloadv1alpha1.Role) {
+				role.EntryTemplate.Spec.Containers = append(role.EntryTemplate.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar"})
+			},
+			want: false,
+		},
+		{
+			name: "worker template added",
+			modify: func(role *workloadv1alpha1.Role) {
+				role.WorkerReplicas = 1
+				role.WorkerTemplate = role.EntryTemplate.DeepCopy()
+			},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			newRole := *oldRole.DeepCopy()
+			tt.modify(&newRole)
+			assert.Equal(t, tt.want, CanUpdateRoleInPlace(oldRole, newRole))
+		})
+	}
+}
+
+func TestGenerateInPlaceUpdatedPod(t *testing.T) {
+	oldRole := newInPlaceTestRole("engine:v1")
+	newRole := newInPlaceTestRole("engine:v2")
+	newRole.EntryTemplate.Metadata = &workloadv1alpha1.Metadata{Labels: map[string]string{"version": "v2"}}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod",
+			Labels: map[string]string{
+				"app":                             "v1",
+				workloadv1alpha1.RevisionLabelKey: "old",
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "engine", Image: "engine:v1"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "engine", ImageID: "sha256:v1", RestartCount: 2}},
+		},
+	}
+
+	updated := GenerateInPlaceUpdatedPod(pod, oldRole.EntryTemplate, newRole.EntryTemplate, "new", RoleRevision(newRole))
+	assert.Equal(t, "engine:v1", pod.Spec.Containers[0].Image, "the original pod should not be modified")
+	assert.Equal(t, "engine:v2", updated.Spec.Containers[0].Image)
+	assert.NotContains(t, updated.Labels, "app")
+	assert.Equal(t, "v2", updated.Labels["version"])
+	assert.Equal(t, "new", updated.Labels[workloadv1alpha1.RevisionLabelKey])
+	assert.Equal(t, RoleRevision(newRole), updated.Labels[workloadv1alpha1.RoleRevisionLabelKey])
+
+	state, ok := GetInPlaceUpdateState(updated)
+	assert.True(t, ok)
+	assert.Equal(t, "new", state.Revision)
+	assert.Equal(t, int32(3), state.RestartBaseline)
+	assert.Equal(t, map[string]string{"engine": "sha256:v1"}, state.LastImageIDs)
+
+	// The container still runs the old image.
+	assert.False(t, IsInPlaceUpdateCompleted(updated))
+	updated.Status.ContainerStatuses[0].ImageID = "sha256:v2"
+	updated.Status.ContainerStatuses[0].RestartCount = 3
+	assert.True(t, IsInPlaceUpdateCompleted(updated))
+	assert.False(t, ContainerRestartedByFailure(updated), "restart caused by the in-place update is not a failure")
+
+	updated.Status.ContainerStatuses[0].RestartCount = 4
+	assert.True(t, ContainerRestartedByFailure(updated))
+}
+
+func TestContainerRestartedByFailure(t *testing.T) {
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase:             corev1.PodRunning,
+			ContainerStatuses: []corev1.ContainerStatus{{Name: "engine", RestartCount: 1}},
+		},
+	}
+	assert.True(t, ContainerRestartedByFailure(pod))
+
+	pod.Status.ContainerStatuses[0].RestartCount = 0
+	assert.False(t, ContainerRestartedByFailure(pod))
+	assert.True(t, IsInPlaceUpdateCompleted(pod), "a pod never updated in place is completed")
+}
@@ -39,11 +39,20 @@ func DeepHashObject(hasher hash.Hash, objectToWrite interface{}) {
 	fmt.Fprintf(hasher, "%v", dump.ForHash(objectToWrite))
 }
 
-// RemoveRoleReplicasForRevision remove role.replicas when calculating modelServing revision hash
+// RemoveRoleReplicasForRevision remove role.replicas and role.recoveryPolicy when calculating modelServing revision hash
 func RemoveRoleReplicasForRevision(ms *workloadv1alpha1.ModelServing) *workloadv1alpha1.ModelServing {
 	Copy := ms.DeepCopy()
 	for i := range Copy.Spec.Template.Roles {
 		Copy.Spec.Template.Roles[i].Replicas = nil
+		Copy.Spec.Template.Roles[i].RecoveryPolicy = nil
 	}
 	return Copy
 }
+
+// RoleRevision calculates the revision of a role template. Like the modelServing revision, it ignores role.replicas
+// and role.recoveryPolicy.
+func RoleRevision(role workloadv1alpha1.Role) string {
+	role.Replicas = nil
+	role.RecoveryPolicy = nil
+	return Revision(role)
+}
@@ -133,6 +133,7 @@ func createBasePod(role workloadv1alpha1.Role, ms *workloadv1alpha1.ModelServing
 				workloadv1alpha1.RoleLabelKey:             role.Name,
 				workloadv1alpha1.RoleIDKey:                GenerateRoleID(role.Name, roleIndex),
 				workloadv1alpha1.RevisionLabelKey:         revision,
+				workloadv1alpha1.RoleRevisionLabelKey:     RoleRevision(role),
 			},
 			OwnerReferences: []metav1.OwnerReference{
 				newModelServingOwnerRef(ms),
@@ -151,6 +152,9 @@ func addPodLabelAndAnnotation(pod *corev1.Pod, metadata *workloadv1alpha1.Metada
 		}
 	}
 	if metadata.Annotations != nil {
+		if pod.Annotations == nil {
+			pod.Annotations = make(map[string]string, len(metadata.Annotations))
+		}
 		for k, v := range metadata.Annotations {
 			pod.Annotations[k] = v
 		}
@@ -571,3 +575,13 @@ func GetMaxUnavailable(mi *workloadv1alpha1.ModelServing) (int, error) {
 	// Calculate maxUnavailable as absolute numbers
 	return intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, replicas, false)
 }
+
+// GetMaxSurge returns the absolute number of ServingGroups that can be created above the desired replicas
+// during a rolling update. Percentages are rounded up, following the Deployment behavior.
+func GetMaxSurge(ms *workloadv1alpha1.ModelServing) (int, error) {
+	if ms.Spec.RolloutStrategy == nil || ms.Spec.RolloutStrategy.RollingUpdateConfiguration == nil ||
+		ms.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge == nil {
+		return 0, nil
+	}
+	return intstr.GetScaledValueFromIntOrPercent(ms.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge, int(*ms.Spec.Replicas), true)
+}
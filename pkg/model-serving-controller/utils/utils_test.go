@@ -241,3 +241,59 @@ func TestGetMaxUnavailable(t *testing.T) {
 		})
 	}
 }
+
+func TestGetMaxSurge(t *testing.T) {
+	tests := []struct {
+		name           string
+		modelServing   *workloadv1alpha1.ModelServing
+		expectedResult int
+	}{
+		{
+			name: "Default case - no rollout strategy",
+			modelServing: &workloadv1alpha1.ModelServing{
+				Spec: workloadv1alpha1.ModelServingSpec{
+					Replicas: ptr.To[int32](5),
+				},
+			},
+			expectedResult: 0,
+		},
+		{
+			name: "MaxSurge as integer - value 2",
+			modelServing: &workloadv1alpha1.ModelServing{
+				Spec: workloadv1alpha1.ModelServingSpec{
+					Replicas: ptr.To[int32](10),
+					RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+						Type: "ServingGroupRollingUpdate",
+						RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+							MaxSurge: ptr.To(intstr.FromInt(2)),
+						},
+					},
+				},
+			},
+			expectedResult: 2,
+		},
+		{
+			name: "MaxSurge as percentage - 25% rounds up",
+			modelServing: &workloadv1alpha1.ModelServing{
+				Spec: workloadv1alpha1.ModelServingSpec{
+					Replicas: ptr.To[int32](3),
+					RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+						Type: "ServingGroupRollingUpdate",
+						RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+							MaxSurge: ptr.To(intstr.FromString("25%")),
+						},
+					},
+				},
+			},
+			expectedResult: 1, // 25% of 3 is 0.75, rounded up to 1
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := GetMaxSurge(tt.modelServing)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -163,10 +163,25 @@ func validateRollingUpdateConfiguration(ms *workloadv1alpha1.ModelServing) field
 		}
 	}
 
+	maxSurgeValue := 0
+	if maxSurge := ms.Spec.RolloutStrategy.RollingUpdateConfiguration.MaxSurge; maxSurge != nil {
+		maxSurgePath := field.NewPath("spec").Child("rolloutStrategy").Child("rollingUpdateConfiguration").Child("maxSurge")
+		allErrs = append(allErrs, validateIntOrPercent(maxSurge, maxSurgePath)...)
+		value, err := intstr.GetScaledValueFromIntOrPercent(maxSurge, int(*ms.Spec.Replicas), true)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge, "invalidate maxSurge"))
+		} else {
+			maxSurgeValue = value
+		}
+		if maxSurgeValue > 0 && ms.Spec.RolloutStrategy.Type == workloadv1alpha1.RoleRollingUpdate {
+			allErrs = append(allErrs, field.Invalid(maxSurgePath, maxSurge, "maxSurge is not supported by RoleRollingUpdate"))
+		}
+	}
+
 	maxUnavailableValue, err := intstr.GetScaledValueFromIntOrPercent(maxUnavailable, int(*ms.Spec.Replicas), false)
 	if err != nil {
 		allErrs = append(allErrs, field.Invalid(maxUnavailablePath, maxUnavailable, "invalidate maxUnavailable"))
-	} else if maxUnavailableValue == 0 {
+	} else if maxUnavailableValue == 0 && maxSurgeValue == 0 {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("rolloutStrategy").Child("rollingUpdateConfiguration"),
 			"",
 			"maxUnavailable cannot be 0"))
@@ -182,6 +182,62 @@ func TestValidateRollingUpdateConfiguration(t *testing.T) {
 				),
 			},
 		},
+		{
+			name: "maxUnavailable is zero with maxSurge",
+			args: args{
+				ms: &workloadv1alpha1.ModelServing{
+					Spec: workloadv1alpha1.ModelServingSpec{
+						Replicas: &replicas,
+						RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+							RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+								MaxUnavailable: &intstr.IntOrString{
+									Type:   intstr.Int,
+									IntVal: 0,
+								},
+								MaxSurge: &intstr.IntOrString{
+									Type:   intstr.Int,
+									IntVal: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: field.ErrorList(nil),
+		},
+		{
+			name: "maxSurge with RoleRollingUpdate",
+			args: args{
+				ms: &workloadv1alpha1.ModelServing{
+					Spec: workloadv1alpha1.ModelServingSpec{
+						Replicas: &replicas,
+						RolloutStrategy: &workloadv1alpha1.RolloutStrategy{
+							Type: workloadv1alpha1.RoleRollingUpdate,
+							RollingUpdateConfiguration: &workloadv1alpha1.RollingUpdateConfiguration{
+								MaxUnavailable: &intstr.IntOrString{
+									Type:   intstr.Int,
+									IntVal: 1,
+								},
+								MaxSurge: &intstr.IntOrString{
+									Type:   intstr.Int,
+									IntVal: 1,
+								},
+							},
+						},
+					},
+				},
+			},
+			want: field.ErrorList{
+				field.Invalid(
+					field.NewPath("spec").Child("rolloutStrategy").Child("rollingUpdateConfiguration").Child("maxSurge"),
+					&intstr.IntOrString{
+						Type:   intstr.Int,
+						IntVal: 1,
+					},
+					"maxSurge is not supported by RoleRollingUpdate",
+				),
+			},
+		},
 		{
 			name: "valid partition - within range",
 			args: args{
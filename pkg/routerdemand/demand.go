@@ -0,0 +1,94 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package routerdemand defines the demand the routers serve on their management port, which the autoscaler pulls
+// to scale the ModelServers to and from zero and to scale on the RouterDemand metric source.
+package routerdemand
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Path is the path of the router serving the demand of the models and the ModelServers.
+	Path = "/demand"
+	// PortName is the name of the container port of the router pods serving the demand.
+	PortName = "management"
+	// DefaultPort is the port serving the demand if the router pods don't name it.
+	DefaultPort = 15001
+)
+
+// Demand is the demand a router observed for the models and the ModelServers.
+type Demand struct {
+	Models       []ModelDemand       `json:"models"`
+	ModelServers []ModelServerDemand `json:"modelServers"`
+}
+
+// ModelDemand is the demand a router observed for a model, i.e. the model of the requests.
+type ModelDemand struct {
+	Name string `json:"name"`
+	// QueueLength is the number of requests waiting in the fairness queue of the model.
+	QueueLength int32 `json:"queueLength"`
+	// RateLimitedPerSecond is the rate of the requests rejected by the rate limits of the model over the last minute.
+	RateLimitedPerSecond float64 `json:"rateLimitedPerSecond"`
+}
+
+// ModelServerDemand is the demand a router observed for a ModelServer.
+type ModelServerDemand struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Requests is the number of requests received since the router started, or since the ModelServer was last
+	// pruned from the demand after being idle.
+	Requests int64 `json:"requests"`
+	// Waiting is the number of requests held until a pod of the ModelServer is ready.
+	Waiting int32 `json:"waiting"`
+	// LastRequestTime is the time the last request was received.
+	LastRequestTime metav1.Time `json:"lastRequestTime"`
+	// ActiveRequests is the number of requests being proxied to the pods of the ModelServer.
+	ActiveRequests int32 `json:"activeRequests"`
+	// NotFoundPerSecond is the rate of the requests rejected over the last minute because the ModelServer had no ready pods.
+	NotFoundPerSecond float64 `json:"notFoundPerSecond"`
+	// InputTokensPerSecond is the rate of the input tokens of the requests over the last minute.
+	InputTokensPerSecond float64 `json:"inputTokensPerSecond"`
+	// OutputTokensPerSecond is the rate of the output tokens of the responses over the last minute.
+	OutputTokensPerSecond float64 `json:"outputTokensPerSecond"`
+}
+
+// Fetch gets the demand of the models and the ModelServers from a router, e.g. http://<router>:15001/demand.
+func Fetch(ctx context.Context, client *http.Client, url string) (*Demand, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, url)
+	}
+	demand := &Demand{}
+	if err := json.NewDecoder(resp.Body).Decode(demand); err != nil {
+		return nil, fmt.Errorf("failed to decode demand from %s: %v", url, err)
+	}
+	return demand, nil
+}
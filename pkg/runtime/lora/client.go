@@ -0,0 +1,98 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lora loads and unloads LoRA adapters through the runtime sidecar of the serving pods.
+package lora
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultRuntimePort is the default port of the runtime sidecar.
+	DefaultRuntimePort = 8100
+	// LoadPath is the path of the API loading an adapter.
+	LoadPath = "/v1/load_lora_adapter"
+	// UnloadPath is the path of the API unloading an adapter.
+	UnloadPath = "/v1/unload_lora_adapter"
+)
+
+// Client calls the LoRA adapter APIs of the runtime sidecar.
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient returns a client sending its requests with httpClient.
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{httpClient: httpClient}
+}
+
+// Load loads the adapter from source into outputDir, on the runtime sidecar listening on the port of the pod IP.
+// Loading an adapter which is already loaded succeeds.
+func (c *Client) Load(ctx context.Context, podIP string, port int32, name, source, outputDir string) error {
+	_, err := c.post(ctx, podIP, port, LoadPath, map[string]any{
+		"lora_name":  name,
+		"source":     source,
+		"output_dir": outputDir,
+	})
+	// vLLM rejects loading an adapter twice, which happens when the caller didn't observe the last load.
+	if err != nil && !strings.Contains(err.Error(), "already been loaded") {
+		return err
+	}
+	return nil
+}
+
+// Unload unloads the adapter from the runtime sidecar listening on the port of the pod IP.
+// Unloading an adapter which is not loaded succeeds.
+func (c *Client) Unload(ctx context.Context, podIP string, port int32, name string) error {
+	code, err := c.post(ctx, podIP, port, UnloadPath, map[string]any{"lora_name": name})
+	if err != nil && code != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// post posts the request to the runtime sidecar. It returns the HTTP status code, and an error if the request failed.
+func (c *Client) post(ctx context.Context, podIP string, port int32, path string, body map[string]any) (int, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return 0, err
+	}
+	url := "http://" + net.JoinHostPort(podIP, strconv.Itoa(int(port))) + path
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return resp.StatusCode, fmt.Errorf("HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return resp.StatusCode, nil
+}
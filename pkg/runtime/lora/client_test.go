@@ -0,0 +1,67 @@
+/*
+Copyright The Volcano Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lora
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	var bodies []map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		switch body["lora_name"] {
+		case "loaded-lora":
+			http.Error(w, "The lora adapter 'loaded-lora' has already been loaded.", http.StatusBadRequest)
+		case "missing-lora":
+			http.Error(w, "not found", http.StatusNotFound)
+		case "bad-lora":
+			http.Error(w, "failed to download", http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	host, portStr, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	assert.NoError(t, err)
+	client := NewClient(server.Client())
+	ctx := context.Background()
+
+	assert.NoError(t, client.Load(ctx, host, int32(port), "sql-lora", "hf://yard1/llama-2-7b-sql-lora-test", "/cache/sql-lora"))
+	assert.Equal(t, map[string]any{
+		"lora_name":  "sql-lora",
+		"source":     "hf://yard1/llama-2-7b-sql-lora-test",
+		"output_dir": "/cache/sql-lora",
+	}, bodies[0])
+	assert.NoError(t, client.Load(ctx, host, int32(port), "loaded-lora", "s3://bucket/loaded-lora", "/cache/loaded-lora"))
+	assert.ErrorContains(t, client.Load(ctx, host, int32(port), "bad-lora", "s3://bucket/bad-lora", "/cache/bad-lora"), "HTTP 500: failed to download")
+
+	assert.NoError(t, client.Unload(ctx, host, int32(port), "sql-lora"))
+	assert.Equal(t, map[string]any{"lora_name": "sql-lora"}, bodies[3])
+	assert.NoError(t, client.Unload(ctx, host, int32(port), "missing-lora"))
+	assert.Error(t, client.Unload(ctx, host, int32(port), "bad-lora"))
+}
@@ -19,9 +19,13 @@ from pathlib import Path
 
 from kthena.downloader.downloader import download_model
 from kthena.downloader.logger import setup_logger
+from kthena.downloader.verify import verify_sha256_manifest
 
 logger = setup_logger()
 
+# The message is reported in the pod status when the container fails, and surfaced by the ModelBooster controller.
+TERMINATION_LOG_PATH = "/dev/termination-log"
+
 
 def load_config(config_str: str = None) -> dict:
     config = {}
@@ -57,7 +61,6 @@ def parse_arguments() -> argparse.Namespace:
     parser.add_argument(
         "-s", "--source",
         type=str,
-        required=True,
         help="Model source URI or identifier. Supports multiple sources including: "
              "Hugging Face repositories (format: '<namespace>/<repo_name>'), "
              "S3 buckets (s3://bucket/path), Object Storage (obs://bucket/path) and PVC storage (pvc://path)"
@@ -88,24 +91,52 @@ def parse_arguments() -> argparse.Namespace:
              "\"hf_revision\": \"main\", \"access_key\": \"your_access_key\", \"secret_key\": \"your_secret_key\", "
              "\"endpoint\": \"your_endpoint_url\"}'"
     )
+    parser.add_argument(
+        "--sha256-manifest",
+        type=str,
+        default=None,
+        help="Path of a manifest in the output format of sha256sum. The files listed in it are verified after download."
+    )
+    parser.add_argument(
+        "--verify-only",
+        action="store_true",
+        help="Only verify the files in the output directory against --sha256-manifest, without downloading."
+    )
     args = parser.parse_args()
+    if args.verify_only and not args.sha256_manifest:
+        parser.error("--verify-only requires --sha256-manifest")
+    if not args.verify_only and not args.source:
+        parser.error("--source is required unless --verify-only is set")
     args.output_dir = str(Path(args.output_dir).expanduser().resolve())
     logger.info(f"Resolved output directory: {args.output_dir}")
     return args
 
 
+def write_termination_message(message: str):
+    try:
+        with open(TERMINATION_LOG_PATH, "w") as f:
+            # The kubelet truncates the message to 4096 bytes.
+            f.write(message[:4096])
+    except OSError:
+        pass
+
+
 def main():
     try:
         args = parse_arguments()
-        config = load_config(args.config)
-        download_model(
-            source=args.source,
-            output_dir=args.output_dir,
-            config=config,
-            max_workers=args.max_workers,
-        )
+        if not args.verify_only:
+            config = load_config(args.config)
+            download_model(
+                source=args.source,
+                output_dir=args.output_dir,
+                config=config,
+                max_workers=args.max_workers,
+            )
+        if args.sha256_manifest:
+            verify_sha256_manifest(args.output_dir, args.sha256_manifest)
     except Exception as e:
         logger.error(f"An error occurred: {e}")
+        write_termination_message(str(e))
         exit(1)
 
 
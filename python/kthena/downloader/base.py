@@ -24,6 +24,9 @@ from kthena.downloader.logger import setup_logger
 
 logger = setup_logger()
 
+# COMPLETE_MARKER is written into the output directory once the model is downloaded.
+COMPLETE_MARKER = ".complete"
+
 
 def parse_bucket_from_model_url(url: str, scheme: str) -> Tuple[str, str]:
     result = urlparse(url, scheme=scheme)
@@ -44,15 +47,22 @@ class ModelDownloader(ABC):
     def download_model(self, output_dir: str):
         os.makedirs(output_dir, exist_ok=True)
         lock_path = os.path.join(output_dir, ".lock")
+        complete_path = os.path.join(output_dir, COMPLETE_MARKER)
         self.lock_manager = LockManager(lock_path, timeout=15)
         while True:
             try:
                 if self.lock_manager.try_acquire():
                     try:
+                        if os.path.exists(complete_path):
+                            # The model was already downloaded, e.g. by the cache warmer.
+                            logger.info(f"Model already downloaded to {output_dir}")
+                            break
                         logger.info(
                             f"Acquired lock successfully. Starting download to {output_dir}"
                         )
                         self.download(output_dir)
+                        with open(complete_path, "w"):
+                            pass
                         break
                     except Exception as e:
                         logger.error(f"Error during model download: {e}")
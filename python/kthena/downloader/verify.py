@@ -0,0 +1,72 @@
+# Copyright The Volcano Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import hashlib
+import os
+from typing import Dict
+
+from kthena.downloader.logger import setup_logger
+
+logger = setup_logger()
+
+CHUNK_SIZE = 8 * 1024 * 1024
+
+
+class VerificationError(Exception):
+    pass
+
+
+def parse_sha256_manifest(manifest_path: str) -> Dict[str, str]:
+    """Parse a manifest in the output format of sha256sum: "<sha256>  <path>" per line."""
+    checksums = {}
+    with open(manifest_path, "r") as f:
+        for line_number, line in enumerate(f, start=1):
+            line = line.strip()
+            if not line or line.startswith("#"):
+                continue
+            parts = line.split(maxsplit=1)
+            if len(parts) != 2 or len(parts[0]) != 64:
+                raise VerificationError(f"invalid line {line_number} in SHA256 manifest: {line}")
+            # sha256sum marks the files read in binary mode with a leading "*".
+            path = parts[1].strip().lstrip("*")
+            checksums[os.path.normpath(path)] = parts[0].lower()
+    if not checksums:
+        raise VerificationError("SHA256 manifest is empty")
+    return checksums
+
+
+def sha256_file(path: str) -> str:
+    h = hashlib.sha256()
+    with open(path, "rb") as f:
+        for chunk in iter(lambda: f.read(CHUNK_SIZE), b""):
+            h.update(chunk)
+    return h.hexdigest()
+
+
+def verify_sha256_manifest(model_dir: str, manifest_path: str):
+    """Verify the files of the model directory against the manifest. Files not listed in the manifest are ignored."""
+    checksums = parse_sha256_manifest(manifest_path)
+    root = os.path.realpath(model_dir)
+    logger.info(f"Verifying {len(checksums)} files in {model_dir}")
+    for index, (path, expected) in enumerate(sorted(checksums.items()), start=1):
+        file_path = os.path.realpath(os.path.join(root, path))
+        if os.path.commonpath([root, file_path]) != root:
+            raise VerificationError(f"path outside of the model directory: {path}")
+        if not os.path.isfile(file_path):
+            raise VerificationError(f"missing file: {path}")
+        actual = sha256_file(file_path)
+        if actual != expected:
+            raise VerificationError(f"checksum mismatch: {path}, expected {expected}, got {actual}")
+        logger.info(f"Verified {index}/{len(checksums)}: {path}")
+    logger.info("All files verified successfully.")
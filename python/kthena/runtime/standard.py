@@ -22,6 +22,7 @@ from kthena.runtime.metric import MetricOperator, RenameMetric
 class EngineType(Enum):
     VLLM = "vllm"
     SGLANG = "sglang"
+    MINDIE = "mindie"
     VLLM_DISAGGREGATED = "vllmdisaggregated"
 
 
@@ -74,6 +75,25 @@ STANDARD_RULES: Dict[str, List[MetricOperator]] = {
             StandardMetricNames.E2E_REQUEST_LATENCY_SECONDS,
         ),
     ],
+    EngineType.MINDIE.value: [
+        RenameMetric(
+            "generation_tokens_total",
+            StandardMetricNames.GENERATION_TOKENS_TOTAL,
+        ),
+        RenameMetric("num_requests_waiting", StandardMetricNames.NUM_REQUESTS_WAITING),
+        RenameMetric(
+            "time_to_first_token_seconds",
+            StandardMetricNames.TIME_TO_FIRST_TOKEN_SECONDS,
+        ),
+        RenameMetric(
+            "time_per_output_token_seconds",
+            StandardMetricNames.TIME_PER_OUTPUT_TOKEN_SECONDS,
+        ),
+        RenameMetric(
+            "e2e_request_latency_seconds",
+            StandardMetricNames.E2E_REQUEST_LATENCY_SECONDS,
+        ),
+    ],
 }
 
 
@@ -95,6 +115,8 @@ class MetricStandard:
             return EngineType.VLLM
         if engine.lower() == EngineType.SGLANG.value:
             return EngineType.SGLANG
+        if engine.lower() == EngineType.MINDIE.value:
+            return EngineType.MINDIE
         supported_engines = list(STANDARD_RULES.keys())
         raise UnsupportedEngineError(
             f"Unsupported engine: {engine}. "
@@ -0,0 +1,44 @@
+# Copyright The Volcano Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import os
+import tempfile
+import unittest
+
+from kthena.downloader.base import COMPLETE_MARKER, ModelDownloader
+
+
+class CountingDownloader(ModelDownloader):
+    def __init__(self):
+        super().__init__()
+        self.downloads = 0
+
+    def download(self, output_dir: str):
+        self.downloads += 1
+
+
+class TestModelDownloader(unittest.TestCase):
+    def test_download_model_once(self):
+        with tempfile.TemporaryDirectory() as output_dir:
+            downloader = CountingDownloader()
+            downloader.download_model(output_dir)
+            self.assertTrue(os.path.exists(os.path.join(output_dir, COMPLETE_MARKER)))
+
+            # The model downloaded by the cache warmer is not downloaded again.
+            downloader.download_model(output_dir)
+            self.assertEqual(downloader.downloads, 1)
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -44,6 +44,12 @@ def test_build_operators_dict_with_valid_engine():
     )
 
 
+def test_build_operators_dict_with_mindie_engine():
+    metric_standard = MetricStandard("MindIE")
+    assert metric_standard.is_supported_metric("num_requests_waiting")
+    assert metric_standard.is_supported_metric("time_to_first_token_seconds")
+
+
 def test_build_operators_dict_with_invalid_engine():
     invalid_engine_name = "invalid_engine"
 
@@ -0,0 +1,78 @@
+# Copyright The Volcano Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import hashlib
+import os
+import tempfile
+import unittest
+
+from kthena.downloader.verify import VerificationError, parse_sha256_manifest, verify_sha256_manifest
+
+
+class TestVerifySHA256Manifest(unittest.TestCase):
+    def setUp(self):
+        self.tmp = tempfile.TemporaryDirectory()
+        self.model_dir = os.path.join(self.tmp.name, "model")
+        os.makedirs(os.path.join(self.model_dir, "weights"))
+        self.files = {
+            "config.json": b'{"model_type": "llama"}',
+            "weights/model.safetensors": b"weights",
+        }
+        for path, content in self.files.items():
+            with open(os.path.join(self.model_dir, path), "wb") as f:
+                f.write(content)
+        self.manifest = os.path.join(self.tmp.name, "SHA256SUMS")
+
+    def tearDown(self):
+        self.tmp.cleanup()
+
+    def write_manifest(self, files: dict):
+        with open(self.manifest, "w") as f:
+            for path, content in files.items():
+                f.write(f"{hashlib.sha256(content).hexdigest()}  {path}\n")
+
+    def test_verify_success(self):
+        self.write_manifest(self.files)
+        verify_sha256_manifest(self.model_dir, self.manifest)
+
+    def test_verify_checksum_mismatch(self):
+        self.write_manifest({"config.json": b"tampered"})
+        with self.assertRaisesRegex(VerificationError, "checksum mismatch: config.json"):
+            verify_sha256_manifest(self.model_dir, self.manifest)
+
+    def test_verify_missing_file(self):
+        self.write_manifest({"tokenizer.json": b"{}"})
+        with self.assertRaisesRegex(VerificationError, "missing file: tokenizer.json"):
+            verify_sha256_manifest(self.model_dir, self.manifest)
+
+    def test_verify_path_outside_model_dir(self):
+        self.write_manifest({"../SHA256SUMS": b""})
+        with self.assertRaisesRegex(VerificationError, "path outside of the model directory"):
+            verify_sha256_manifest(self.model_dir, self.manifest)
+
+    def test_parse_binary_mode_and_comments(self):
+        digest = "a" * 64
+        with open(self.manifest, "w") as f:
+            f.write(f"# generated by sha256sum\n{digest} *config.json\n\n")
+        self.assertEqual({"config.json": digest}, parse_sha256_manifest(self.manifest))
+
+    def test_parse_invalid_manifest(self):
+        with open(self.manifest, "w") as f:
+            f.write("not-a-checksum config.json\n")
+        with self.assertRaises(VerificationError):
+            parse_sha256_manifest(self.manifest)
+
+
+if __name__ == "__main__":
+    unittest.main()
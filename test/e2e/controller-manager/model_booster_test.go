@@ -85,7 +85,7 @@ func createTestModel() *workload.ModelBooster {
 		},
 		Spec: workload.ModelBoosterSpec{
 			Name: "test-model",
-			Backend: workload.ModelBackend{
+			Backend: &workload.ModelBackend{
 				Name:        "backend1",
 				Type:        workload.ModelBackendTypeVLLM,
 				ModelURI:    "hf://Qwen/Qwen2.5-0.5B-Instruct",
@@ -126,7 +126,7 @@ func createInvalidModel() *workload.ModelBooster {
 		},
 		Spec: workload.ModelBoosterSpec{
 			Name: "invalid-model",
-			Backend: workload.ModelBackend{
+			Backend: &workload.ModelBackend{
 				Name:        "backend1",
 				Type:        workload.ModelBackendTypeVLLM,
 				ModelURI:    "hf://Qwen/Qwen2.5-0.5B-Instruct",